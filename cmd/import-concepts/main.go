@@ -0,0 +1,80 @@
+// Command import-concepts imports the concepts of a terminology source from an
+// OpenConceptLab JSON export or a CSV export into the directory of the offline
+// terminology service. Importing a source again refreshes it, and a running
+// server reads the new concepts on its next lookup.
+//
+//	go run ./cmd/import-concepts -directory ./terminology -org CIEL -source CIEL ./ciel.json
+//	go run ./cmd/import-concepts -directory ./terminology -org WHO -source ICD-10-WHO ./icd10.csv
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab"
+)
+
+func main() {
+	directory := flag.String("directory", os.Getenv(openconceptlab.OCLOfflineDirectoryEnvVarName), "the directory of the imported concepts")
+	organisation := flag.String("org", "", "the organisation that owns the source e.g CIEL or WHO. It defaults to the owner of the exported concepts")
+	source := flag.String("source", "", "the terminology source e.g CIEL or ICD-10-WHO. It defaults to the source of the exported concepts")
+	format := flag.String("format", "", "the format of the export, json or csv. It defaults to the extension of the file")
+	flag.Parse()
+
+	if *directory == "" {
+		log.Fatalf("the terminology directory is required, set -directory or %s", openconceptlab.OCLOfflineDirectoryEnvVarName)
+	}
+
+	if flag.NArg() != 1 {
+		log.Fatalf("usage: import-concepts [flags] <export file>")
+	}
+
+	path := flag.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	concepts, err := openconceptlab.ReadConceptExport(file, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(concepts) == 0 {
+		log.Fatalf("%s has no concepts", path)
+	}
+
+	if *organisation == "" {
+		*organisation = concepts[0].Owner
+	}
+
+	if *source == "" {
+		*source = concepts[0].Source
+	}
+
+	if *organisation == "" || *source == "" {
+		log.Fatalf("the export does not name its organisation and source, set -org and -source")
+	}
+
+	err = os.MkdirAll(*directory, 0o755)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = openconceptlab.ImportConcepts(*directory, *organisation, *source, concepts, time.Now())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("imported %d %s concepts of %s", len(concepts), *source, *organisation)
+}
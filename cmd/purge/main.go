@@ -0,0 +1,67 @@
+// Command purge applies the retention policies once and prints the purge
+// report, e.g. from a scheduled job or while trying policies out. It uses the
+// FHIR dataset selected by the same environment variables as the server, so
+// that policies can be tried against a local in-memory dataset.
+//
+//	FHIR_DATASET_BACKEND=memory FHIR_MEMORY_DATASET_PATH=./dataset.json \
+//		PURGE_DIRECTORY=./purges go run ./cmd/purge -policies ./retention.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/extensions"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore"
+	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/httpclient"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+)
+
+func main() {
+	path := flag.String("policies", os.Getenv(common.RetentionPoliciesPathEnvVar), "the JSON file that lists the retention policies")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatalf("the retention policies file is required, set -policies or %s", common.RetentionPoliciesPathEnvVar)
+	}
+
+	ctx := context.Background()
+
+	policies, err := clinicalUsecase.ReadRetentionPolicies(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	transportConfig, err := httpclient.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("unable to configure the HTTP transport: %s", err)
+	}
+
+	repo, err := datastore.NewFHIRDataset(ctx, httpclient.NewTransport(transportConfig, nil))
+	if err != nil {
+		log.Fatalf("unable to initialize the FHIR dataset: %s", err)
+	}
+
+	// purging only reads and deletes FHIR resources, so the other services
+	// are not configured
+	infrastructure := infrastructure.NewInfrastructureInteractor(extensions.NewBaseExtensionImpl(), fhir.NewFHIRStoreImpl(repo), nil, nil)
+
+	report, err := clinicalUsecase.NewUseCasesClinicalImpl(infrastructure).PurgeExpiredResources(ctx, policies)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(report)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
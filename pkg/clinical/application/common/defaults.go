@@ -1,6 +1,9 @@
 package common
 
 import (
+	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/rs/xid"
@@ -13,7 +16,6 @@ const (
 
 	// CenturyHours is the number of hours in a (fictional) century of leap years
 	CenturyHours                  = 878400
-	timeFormatStr                 = "2006-01-02T15:04:05+03:00"
 	healthCloudIdentifiers        = "healthcloud.identifiers"
 	healthCloudIdentifiersVersion = "0.0.1"
 
@@ -42,7 +44,7 @@ const (
 	TenantTopicName = "mycarehub.tenant.create"
 
 	// MedicalDataCount is the count of medical records
-	MedicalDataCount = "3"
+	MedicalDataCount = 3
 
 	// WeightCIELTerminologyCode is the terminology code for weight
 	WeightCIELTerminologyCode = "5089"
@@ -82,11 +84,131 @@ const (
 
 	// TopicVersion defines the topic version. That standard one is `v1`
 	TopicVersion = "v1"
+
+	// BulkExportDirectoryEnvVar is the directory where the NDJSON files of bulk
+	// exports are written. It defaults to a directory in the system's temporary directory
+	BulkExportDirectoryEnvVar = "BULK_EXPORT_DIRECTORY"
+
+	// BulkExportTTLEnvVar is how long the files of a finished bulk export are
+	// kept e.g `6h`. It defaults to a day
+	BulkExportTTLEnvVar = "BULK_EXPORT_TTL"
+
+	// RetentionPoliciesPathEnvVar is the JSON file that lists the tenants'
+	// retention policies. The server only purges expired resources when it is set
+	RetentionPoliciesPathEnvVar = "RETENTION_POLICIES_PATH"
+
+	// PurgeIntervalEnvVar is how often the server purges expired resources e.g `24h`.
+	// It defaults to once a day
+	PurgeIntervalEnvVar = "PURGE_INTERVAL"
+
+	// PurgeDirectoryEnvVar is the directory where the reports and archives of
+	// purges are written. Policies that archive require it, since archives must
+	// outlast the resources they hold; reports default to the system's
+	// temporary directory
+	PurgeDirectoryEnvVar = "PURGE_DIRECTORY"
+
+	// OrganizationCacheTTLEnvVar is how long organizations are cached e.g `5m`.
+	// It defaults to five minutes and `0` turns the cache off
+	OrganizationCacheTTLEnvVar = "ORGANIZATION_CACHE_TTL"
+
+	// OCLCacheTTLEnvVar is how long OpenConceptLab concepts are cached e.g `24h`.
+	// It defaults to a day and `0` turns the cache off
+	OCLCacheTTLEnvVar = "OCL_CACHE_TTL"
+
+	// OCLCacheNegativeTTLEnvVar is how long concepts that OpenConceptLab does not
+	// have are remembered as missing e.g `10m`. It defaults to ten minutes
+	OCLCacheNegativeTTLEnvVar = "OCL_CACHE_NEGATIVE_TTL"
+
+	// OCLCacheSizeEnvVar is the most OpenConceptLab concepts that are cached. It
+	// defaults to 10000
+	OCLCacheSizeEnvVar = "OCL_CACHE_SIZE"
+
+	// OCLCachePathEnvVar is an optional file that the OpenConceptLab concepts are
+	// kept in so that they survive restarts
+	OCLCachePathEnvVar = "OCL_CACHE_PATH"
+
+	// OCLCacheSaveIntervalEnvVar is how often the OpenConceptLab concepts are
+	// written to their file e.g `1m`. It defaults to a minute
+	OCLCacheSaveIntervalEnvVar = "OCL_CACHE_SAVE_INTERVAL"
+
+	// DefaultTimeZoneEnvVar is the IANA time zone of the times recorded for
+	// facilities that do not have a time zone of their own
+	DefaultTimeZoneEnvVar = "DEFAULT_TIME_ZONE"
+
+	// defaultTimeZone is used when no default time zone is configured
+	defaultTimeZone = "Africa/Nairobi"
 )
 
+// defaultLocation is the location of the configured default time zone. It is
+// loaded once, at startup
+var defaultLocation struct {
+	once     sync.Once
+	location *time.Location
+	err      error
+}
+
+// LoadDefaultLocation loads the location of the configured default time zone.
+// It is called at startup so that an unknown time zone stops the service
+// instead of being reported on each use
+func LoadDefaultLocation() (*time.Location, error) {
+	defaultLocation.once.Do(func() {
+		defaultLocation.location, defaultLocation.err = readDefaultLocation()
+	})
+
+	return defaultLocation.location, defaultLocation.err
+}
+
+// readDefaultLocation reads the default time zone from the environment. It is
+// `Africa/Nairobi` when none is configured
+func readDefaultLocation() (*time.Location, error) {
+	timeZone := os.Getenv(DefaultTimeZoneEnvVar)
+	if timeZone == "" {
+		timeZone = defaultTimeZone
+	}
+
+	location, err := domain.LoadTimeZone(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DefaultTimeZoneEnvVar, err)
+	}
+
+	return location, nil
+}
+
+// DefaultLocation returns the location of the default time zone. It is
+// `Africa/Nairobi` if the configured time zone could not be loaded, which
+// stops the service at startup
+func DefaultLocation() *time.Location {
+	location, err := LoadDefaultLocation()
+	if err == nil {
+		return location
+	}
+
+	location, err = time.LoadLocation(defaultTimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// OrganizationLocation returns the location of an organization's time zone,
+// or the default location if the organization has no usable time zone
+func OrganizationLocation(organization *domain.FHIROrganization) *time.Location {
+	if organization == nil || organization.TimeZone() == "" {
+		return DefaultLocation()
+	}
+
+	location, err := domain.LoadTimeZone(organization.TimeZone())
+	if err != nil {
+		return DefaultLocation()
+	}
+
+	return location
+}
+
 // DefaultIdentifier assigns a patient a code to function as their
 // medical record number.
-func DefaultIdentifier() *domain.FHIRIdentifierInput {
+func DefaultIdentifier(clock domain.Clock) *domain.FHIRIdentifierInput {
 	xid := xid.New().String()
 	system := scalarutils.URI(healthCloudIdentifiers)
 	version := healthCloudIdentifiersVersion
@@ -108,28 +230,30 @@ func DefaultIdentifier() *domain.FHIRIdentifierInput {
 			},
 		},
 		System: &system,
-		Period: DefaultPeriodInput(),
+		Period: DefaultPeriodInput(clock),
 	}
 }
 
-// DefaultPeriodInput sets up a period input covering roughly a century from when it's run
-func DefaultPeriodInput() *domain.FHIRPeriodInput {
-	now := time.Now()
-	farFuture := time.Now().Add(time.Hour * CenturyHours)
+// DefaultPeriodInput sets up a period input covering roughly a century from
+// the time told by the clock
+func DefaultPeriodInput(clock domain.Clock) *domain.FHIRPeriodInput {
+	now := clock.Now().In(DefaultLocation())
+	farFuture := now.Add(time.Hour * CenturyHours)
 
 	return &domain.FHIRPeriodInput{
-		Start: scalarutils.DateTime(now.Format(timeFormatStr)),
-		End:   scalarutils.DateTime(farFuture.Format(timeFormatStr)),
+		Start: domain.FormatDateTime(now),
+		End:   domain.FormatDateTime(farFuture),
 	}
 }
 
-// DefaultPeriod sets up a period input covering roughly a century from when it's run
-func DefaultPeriod() *domain.FHIRPeriod {
-	now := time.Now()
-	farFuture := time.Now().Add(time.Hour * CenturyHours)
+// DefaultPeriod sets up a period covering roughly a century from the time told
+// by the clock
+func DefaultPeriod(clock domain.Clock) *domain.FHIRPeriod {
+	now := clock.Now().In(DefaultLocation())
+	farFuture := now.Add(time.Hour * CenturyHours)
 
 	return &domain.FHIRPeriod{
-		Start: scalarutils.DateTime(now.Format(timeFormatStr)),
-		End:   scalarutils.DateTime(farFuture.Format(timeFormatStr)),
+		Start: domain.FormatDateTime(now),
+		End:   domain.FormatDateTime(farFuture),
 	}
 }
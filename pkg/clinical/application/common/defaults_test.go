@@ -2,7 +2,9 @@ package common
 
 import (
 	"testing"
+	"time"
 
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +20,7 @@ func TestDefaultIdentifier(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := DefaultIdentifier()
+			got := DefaultIdentifier(domain.SystemClock{})
 			if !tt.wantEmpty {
 				assert.NotEmpty(t, got, "DefaultIdentifier() = empty struct, want non-empty struct")
 				assert.NotEmpty(t, got.Period, "DefaultIdentifier() = %v, Period field is empty", got)
@@ -43,9 +45,12 @@ func TestDefaultPeriod(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := DefaultPeriod()
+			now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			got := DefaultPeriod(domain.FixedClock{Time: now})
 			if !tt.wantEmpty {
 				assert.NotEmpty(t, got, "DefaultPeriod() = empty struct, want non-empty struct")
+				assert.Equal(t, domain.FormatDateTime(now.In(DefaultLocation())), got.Start, "DefaultPeriod() does not start at the time of the clock")
 			}
 			if got != nil && tt.wantEmpty {
 				t.Errorf("DefaultPeriod() = %v, want %v", got == nil, tt.wantEmpty)
@@ -53,3 +58,41 @@ func TestDefaultPeriod(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadDefaultLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "Happy case: the default time zone",
+			want: "Africa/Nairobi",
+		},
+		{
+			name:     "Happy case: a configured time zone",
+			timeZone: "Africa/Lagos",
+			want:     "Africa/Lagos",
+		},
+		{
+			name:     "Sad case: an unknown time zone",
+			timeZone: "Africa/Atlantis",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(DefaultTimeZoneEnvVar, tt.timeZone)
+
+			got, err := readDefaultLocation()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readDefaultLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("readDefaultLocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/savannahghi/converterandformatter"
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/scalarutils"
-	log "github.com/sirupsen/logrus"
 )
 
 const (
@@ -22,11 +21,6 @@ const (
 	CenturyHours       = 878400
 	fullAccessLevel    = "FULL_ACCESS"
 	partialAccessLevel = "PROFILE_AND_RECENT_VISITS_ACCESS"
-	timeFormatStr      = "2006-01-02T15:04:05+03:00"
-	// StringTimeParseMonthNameLayout ...
-	StringTimeParseMonthNameLayout = "2006-Jan-02"
-	// StringTimeParseMonthNumberLayout ...
-	StringTimeParseMonthNumberLayout = "2006-01-02"
 )
 
 // simple patient registration defaults
@@ -40,7 +34,7 @@ const (
 
 // ComposeOneHealthEpisodeOfCare is used to create an episode of care
 func ComposeOneHealthEpisodeOfCare(
-	validPhone string, fullAccess bool, organizationID, providerCode, patientID string,
+	clock domain.Clock, validPhone string, fullAccess bool, organizationID, providerCode, patientID string,
 ) domain.FHIREpisodeOfCare {
 	accessLevel := ""
 	if fullAccess {
@@ -49,8 +43,8 @@ func ComposeOneHealthEpisodeOfCare(
 		accessLevel = partialAccessLevel
 	}
 
-	now := time.Now()
-	farFuture := time.Now().Add(time.Hour * CenturyHours)
+	now := clock.Now().In(common.DefaultLocation())
+	farFuture := now.Add(time.Hour * CenturyHours)
 	orgIdentifier := &domain.FHIRIdentifier{
 		Use:   "official",
 		Value: providerCode,
@@ -64,8 +58,8 @@ func ComposeOneHealthEpisodeOfCare(
 	return domain.FHIREpisodeOfCare{
 		Status: &active,
 		Period: &domain.FHIRPeriod{
-			Start: scalarutils.DateTime(now.Format(timeFormatStr)),
-			End:   scalarutils.DateTime(farFuture.Format(timeFormatStr)),
+			Start: domain.FormatDateTime(now),
+			End:   domain.FormatDateTime(farFuture),
 		},
 		ManagingOrganization: &domain.FHIRReference{
 			Reference:  &orgRef,
@@ -86,37 +80,10 @@ func ComposeOneHealthEpisodeOfCare(
 	}
 }
 
-// ParseDate parses a formatted string and returns the time value it represents
-// Try different layout due to Inconsistent date formats
-// They include "2018-01-01", "2020-09-24T18:02:38.661033Z", "2018-Jan-01"
-func ParseDate(date string) time.Time {
-	// parses "2020-09-24T18:02:38.661033Z"
-	timeValue, err := time.Parse(time.RFC3339, date)
-	if err != nil {
-		// parses when month is a number e.g "2018-01-01"
-		timeValue, err := time.Parse(StringTimeParseMonthNameLayout, date)
-
-		if err != nil {
-			// parses when month is a name e.g "2018-Jan-01"
-			timeValue, err := time.Parse(StringTimeParseMonthNumberLayout, date)
-
-			if err != nil {
-				log.Errorf("cannot parse date %v with error %v", date, err)
-			}
-
-			return timeValue
-		}
-
-		return timeValue
-	}
-
-	return timeValue
-}
-
 // IDToIdentifier translates simple identification
 // document details to FHIR identifiers
 func IDToIdentifier(
-	ids []*domain.IdentificationDocument, phones []*domain.PhoneNumberInput) ([]*domain.FHIRIdentifierInput, error) {
+	clock domain.Clock, ids []*domain.IdentificationDocument, phones []*domain.PhoneNumberInput) ([]*domain.FHIRIdentifierInput, error) {
 	if ids == nil || phones == nil {
 		return nil, nil
 	}
@@ -145,7 +112,7 @@ func IDToIdentifier(
 			},
 			System: &idSystem,
 			Value:  id.DocumentNumber,
-			Period: common.DefaultPeriodInput(),
+			Period: common.DefaultPeriodInput(clock),
 		}
 		output = append(output, identifier)
 	}
@@ -174,7 +141,7 @@ func IDToIdentifier(
 			},
 			System: &msisdnIdentifierSystem,
 			Value:  *normalized,
-			Period: common.DefaultPeriodInput(),
+			Period: common.DefaultPeriodInput(clock),
 		}
 		output = append(output, identifier)
 	}
@@ -184,7 +151,7 @@ func IDToIdentifier(
 
 // NameToHumanName translates the simple name input of simple
 // patient registration to FHIR human names
-func NameToHumanName(names []*domain.NameInput) []*domain.FHIRHumanNameInput {
+func NameToHumanName(clock domain.Clock, names []*domain.NameInput) []*domain.FHIRHumanNameInput {
 	if names == nil {
 		return nil
 	}
@@ -204,7 +171,7 @@ func NameToHumanName(names []*domain.NameInput) []*domain.FHIRHumanNameInput {
 			Given:  []string{name.FirstName},
 			Family: name.LastName,
 			Use:    use,
-			Period: common.DefaultPeriodInput(),
+			Period: common.DefaultPeriodInput(clock),
 			Text:   fullName,
 		}
 		output = append(output, humanName)
@@ -215,7 +182,7 @@ func NameToHumanName(names []*domain.NameInput) []*domain.FHIRHumanNameInput {
 
 // PhysicalPostalAddressesToFHIRAddresses translates address inputs to FHIR addresses
 func PhysicalPostalAddressesToFHIRAddresses(
-	physical []*domain.PhysicalAddress, postal []*domain.PostalAddress) []*domain.FHIRAddressInput {
+	clock domain.Clock, physical []*domain.PhysicalAddress, postal []*domain.PostalAddress) []*domain.FHIRAddressInput {
 	if physical == nil && postal == nil {
 		return nil
 	}
@@ -233,7 +200,7 @@ func PhysicalPostalAddressesToFHIRAddresses(
 			Use:        &addrUse,
 			Type:       &postalAddrType,
 			Country:    &country,
-			Period:     common.DefaultPeriodInput(),
+			Period:     common.DefaultPeriodInput(clock),
 			PostalCode: &postalCode,
 			Line:       []*string{&postal.PostalAddress},
 			Text:       text,
@@ -249,7 +216,7 @@ func PhysicalPostalAddressesToFHIRAddresses(
 			Use:        &addrUse,
 			Type:       &physicalAddrType,
 			Country:    &country,
-			Period:     common.DefaultPeriodInput(),
+			Period:     common.DefaultPeriodInput(clock),
 			PostalCode: &mapsCode,
 			Line:       []*string{&physical.PhysicalAddress},
 			Text:       text,
@@ -316,6 +283,7 @@ func LanguagesToCommunicationInputs(languages []enumutils.Language) []*domain.FH
 // It is used for patient contacts (e.g next of kin) where the spec has only
 // one address per next of kin.
 func PhysicalPostalAddressesToCombinedFHIRAddress(
+	clock domain.Clock,
 	physical []*domain.PhysicalAddress,
 	postal []*domain.PostalAddress,
 ) *domain.FHIRAddressInput {
@@ -331,7 +299,7 @@ func PhysicalPostalAddressesToCombinedFHIRAddress(
 		Use:     &addressUse,
 		Type:    &postalAddrType,
 		Country: &country,
-		Period:  common.DefaultPeriodInput(),
+		Period:  common.DefaultPeriodInput(clock),
 		Line:    nil, // will be replaced below
 		Text:    "",  // will be replaced below
 	}
@@ -366,6 +334,7 @@ func PhysicalPostalAddressesToCombinedFHIRAddress(
 // FHIR contact points
 func ContactsToContactPoint(
 	_ context.Context,
+	clock domain.Clock,
 	phones []*domain.PhoneNumberInput,
 	emails []*domain.EmailInput,
 	firestoreClient *firestore.Client,
@@ -390,7 +359,7 @@ func ContactsToContactPoint(
 			System: &phoneSystem,
 			Use:    &contactUse,
 			Rank:   &rank,
-			Period: common.DefaultPeriod(),
+			Period: common.DefaultPeriod(clock),
 			Value:  normalized,
 		}
 		output = append(output, phoneContact)
@@ -408,7 +377,7 @@ func ContactsToContactPoint(
 			System: &emailSystem,
 			Use:    &contactUse,
 			Rank:   &rank,
-			Period: common.DefaultPeriod(),
+			Period: common.DefaultPeriod(clock),
 			Value:  &email.Email,
 		}
 		output = append(output, emailContact)
@@ -3,9 +3,7 @@ package helpers
 import (
 	"context"
 	"encoding/base64"
-	"reflect"
 	"testing"
-	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/brianvoe/gofakeit"
@@ -52,7 +50,7 @@ func TestComposeOneHealthEpisodeOfCare(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ComposeOneHealthEpisodeOfCare(tt.args.validPhone, tt.args.fullAccess, tt.args.organizationID, tt.args.providerCode, tt.args.patientID)
+			got := ComposeOneHealthEpisodeOfCare(domain.SystemClock{}, tt.args.validPhone, tt.args.fullAccess, tt.args.organizationID, tt.args.providerCode, tt.args.patientID)
 			if !tt.wantEmpty {
 				assert.NotEmpty(t, got, "ComposeOneHealthEpisodeOfCare() = empty struct, want non-empty struct")
 				assert.NotEmpty(t, got.Patient.Reference, "ComposeOneHealthEpisodeOfCare() = %v, PatientReference field is empty", got)
@@ -61,39 +59,6 @@ func TestComposeOneHealthEpisodeOfCare(t *testing.T) {
 	}
 }
 
-func TestParseDate(t *testing.T) {
-	type args struct {
-		date string
-	}
-	tests := []struct {
-		name string
-		args args
-		want time.Time
-	}{
-		{
-			name: "Happy case: valid date",
-			args: args{
-				date: "2006-01-02T00:00:00",
-			},
-			want: time.Time{},
-		},
-		{
-			name: "Sad case: invalid date",
-			args: args{
-				date: "invalid",
-			},
-			want: time.Time{},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := ParseDate(tt.args.date); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("ParseDate() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestIDToIdentifier(t *testing.T) {
 	dummyString := gofakeit.BS()
 	base64String := base64.StdEncoding.EncodeToString([]byte(dummyString))
@@ -160,7 +125,7 @@ func TestIDToIdentifier(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := IDToIdentifier(tt.args.ids, tt.args.phones)
+			_, err := IDToIdentifier(domain.SystemClock{}, tt.args.ids, tt.args.phones)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IDToIdentifier() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -198,7 +163,7 @@ func TestNameToHumanName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NameToHumanName(tt.args.names)
+			got := NameToHumanName(domain.SystemClock{}, tt.args.names)
 			if got != nil && tt.wantNil {
 				t.Errorf("NameToHumanName() = '%v', want '%v'", got == nil, tt.wantNil)
 				return
@@ -239,7 +204,7 @@ func TestPhysicalPostalAddressesToFHIRAddresses(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := PhysicalPostalAddressesToFHIRAddresses(tt.args.physical, tt.args.postal); got != nil && tt.wantNil {
+			if got := PhysicalPostalAddressesToFHIRAddresses(domain.SystemClock{}, tt.args.physical, tt.args.postal); got != nil && tt.wantNil {
 				t.Errorf("PhysicalPostalAddressesToFHIRAddresses() = %v, want %v", got == nil, tt.wantNil)
 			}
 		})
@@ -330,7 +295,7 @@ func TestPhysicalPostalAddressesToCombinedFHIRAddress(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := PhysicalPostalAddressesToCombinedFHIRAddress(tt.args.physical, tt.args.postal)
+			got := PhysicalPostalAddressesToCombinedFHIRAddress(domain.SystemClock{}, tt.args.physical, tt.args.postal)
 			if !tt.wantEmpty {
 				assert.NotEmpty(t, got, "PhysicalPostalAddressesToCombinedFHIRAddress() = empty struct, want non-empty struct")
 				assert.NotEmpty(t, got.Period, "PhysicalPostalAddressesToCombinedFHIRAddress() = %v, Period field is empty", got)
@@ -423,7 +388,7 @@ func TestContactsToContactPoint(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ContactsToContactPoint(tt.args.ctx, tt.args.phones, tt.args.emails, tt.args.firestoreClient)
+			got, err := ContactsToContactPoint(tt.args.ctx, domain.SystemClock{}, tt.args.phones, tt.args.emails, tt.args.firestoreClient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ContactsToContactPoint() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"context"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	log "github.com/sirupsen/logrus"
+)
+
+// OrganizationReader reads organizations e.g from the FHIR store
+type OrganizationReader interface {
+	GetFHIROrganization(ctx context.Context, organizationID string) (*domain.FHIROrganizationRelayPayload, error)
+}
+
+// FacilityLocation returns the location of the time zone of the facility in
+// the context. A facility without a time zone uses the time zone of its
+// organisation, and the default location is used when neither has one
+func FacilityLocation(ctx context.Context, organizations OrganizationReader) *time.Location {
+	if ctx == nil {
+		return common.DefaultLocation()
+	}
+
+	for _, key := range []utils.ContextKey{utils.FacilityIDContextKey, utils.OrganizationIDContextKey} {
+		organizationID, _ := ctx.Value(key).(string)
+		if organizationID == "" {
+			continue
+		}
+
+		organization, err := organizations.GetFHIROrganization(ctx, organizationID)
+		if err != nil || organization == nil || organization.Resource == nil {
+			log.Errorf("unable to read the time zone of organization %s: %v", organizationID, err)
+
+			continue
+		}
+
+		if organization.Resource.TimeZone() != "" {
+			return common.OrganizationLocation(organization.Resource)
+		}
+	}
+
+	return common.DefaultLocation()
+}
@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// fakeOrganizations reads organizations from a map, keyed by ID to time zone
+type fakeOrganizations map[string]string
+
+func (f fakeOrganizations) GetFHIROrganization(ctx context.Context, organizationID string) (*domain.FHIROrganizationRelayPayload, error) {
+	timeZone, ok := f[organizationID]
+	if !ok {
+		return nil, fmt.Errorf("organization %s not found", organizationID)
+	}
+
+	organization := &domain.FHIROrganization{ID: &organizationID}
+	if timeZone != "" {
+		organization.Extension = []*domain.Extension{{URL: domain.TimeZoneExtensionURL, ValueCode: timeZone}}
+	}
+
+	return &domain.FHIROrganizationRelayPayload{Resource: organization}, nil
+}
+
+func TestFacilityLocation(t *testing.T) {
+	organizations := fakeOrganizations{
+		"kampala":      "Africa/Kampala",
+		"lagos":        "Africa/Lagos",
+		"no-time-zone": "",
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "Happy case: the facility's time zone",
+			ctx:  utils.WithTenant(context.Background(), "lagos", "kampala"),
+			want: "Africa/Kampala",
+		},
+		{
+			name: "Happy case: the organisation's time zone when the facility has none",
+			ctx:  utils.WithTenant(context.Background(), "lagos", "no-time-zone"),
+			want: "Africa/Lagos",
+		},
+		{
+			name: "Happy case: the default time zone without a tenant",
+			ctx:  context.Background(),
+			want: common.DefaultLocation().String(),
+		},
+		{
+			name: "Sad case: the default time zone when the facility cannot be read",
+			ctx:  utils.WithTenant(context.Background(), "", "missing"),
+			want: common.DefaultLocation().String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FacilityLocation(tt.ctx, organizations).String(); got != tt.want {
+				t.Errorf("FacilityLocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/savannahghi/scalarutils"
+import (
+	"time"
+
+	"github.com/savannahghi/scalarutils"
+)
 
 // Allergy represents an allergy containing minimal FHIR resources
 type Allergy struct {
@@ -53,3 +57,44 @@ func CreateAllergyConnection(allergies []*Allergy, pageInfo PageInfo, total int)
 
 	return connection
 }
+
+// AllergyVersion is a version of an allergy from the allergy's history
+type AllergyVersion struct {
+	VersionID string    `json:"versionID"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    string    `json:"author"`
+	Allergy   Allergy   `json:"allergy"`
+}
+
+// AllergyVersionEdge is an allergy version edge
+type AllergyVersionEdge struct {
+	Node   AllergyVersion
+	Cursor string
+}
+
+// AllergyHistoryConnection is a connection of an allergy's versions, newest first
+type AllergyHistoryConnection struct {
+	TotalCount int
+	Edges      []AllergyVersionEdge
+	PageInfo   PageInfo
+}
+
+// CreateAllergyHistoryConnection creates a connection that follows the GraphQl Cursor Connection Specification
+func CreateAllergyHistoryConnection(versions []AllergyVersion, pageInfo PageInfo, total int) AllergyHistoryConnection {
+	connection := AllergyHistoryConnection{
+		TotalCount: total,
+		Edges:      []AllergyVersionEdge{},
+		PageInfo:   pageInfo,
+	}
+
+	for _, version := range versions {
+		edge := AllergyVersionEdge{
+			Node:   version,
+			Cursor: version.VersionID,
+		}
+
+		connection.Edges = append(connection.Edges, edge)
+	}
+
+	return connection
+}
@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/savannahghi/scalarutils"
+import (
+	"time"
+
+	"github.com/savannahghi/scalarutils"
+)
 
 // Condition represents a FHIR condition
 type Condition struct {
@@ -51,3 +55,44 @@ func CreateConditionConnection(conditions []Condition, pageInfo PageInfo, total
 
 	return connection
 }
+
+// ConditionVersion is a version of a condition from the condition's history
+type ConditionVersion struct {
+	VersionID string    `json:"versionID"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    string    `json:"author"`
+	Condition Condition `json:"condition"`
+}
+
+// ConditionVersionEdge is a condition version edge
+type ConditionVersionEdge struct {
+	Node   ConditionVersion
+	Cursor string
+}
+
+// ConditionHistoryConnection is a connection of a condition's versions, newest first
+type ConditionHistoryConnection struct {
+	TotalCount int
+	Edges      []ConditionVersionEdge
+	PageInfo   PageInfo
+}
+
+// CreateConditionHistoryConnection creates a connection that follows the GraphQl Cursor Connection Specification
+func CreateConditionHistoryConnection(versions []ConditionVersion, pageInfo PageInfo, total int) ConditionHistoryConnection {
+	connection := ConditionHistoryConnection{
+		TotalCount: total,
+		Edges:      []ConditionVersionEdge{},
+		PageInfo:   pageInfo,
+	}
+
+	for _, version := range versions {
+		edge := ConditionVersionEdge{
+			Node:   version,
+			Cursor: version.VersionID,
+		}
+
+		connection.Edges = append(connection.Edges, edge)
+	}
+
+	return connection
+}
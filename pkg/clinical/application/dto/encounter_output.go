@@ -7,6 +7,8 @@ type Encounter struct {
 	Class           EncounterClass      `json:"class,omitempty"`
 	PatientID       string              `json:"patientID,omitempty"`
 	EpisodeOfCareID string              `json:"episodeOfCareID,omitempty"`
+	// Duration is how long a finished encounter lasted, in seconds
+	Duration *int `json:"duration,omitempty"`
 }
 
 // EncounterConnection is the encounter connection type
@@ -120,3 +120,16 @@ const (
 	TerminologySourceSNOMEDCT TerminologySource = "SNOMED_CT"
 	TerminologySourceLOINC    TerminologySource = "LOINC"
 )
+
+// ConceptEquivalence is how a translated concept relates to the concept it was
+// translated from
+type ConceptEquivalence string
+
+const (
+	// ConceptEquivalenceEquivalent means the concepts have the same meaning
+	ConceptEquivalenceEquivalent ConceptEquivalence = "EQUIVALENT"
+	// ConceptEquivalenceWider means the translated concept is broader in meaning
+	ConceptEquivalenceWider ConceptEquivalence = "WIDER"
+	// ConceptEquivalenceNarrower means the translated concept is narrower in meaning
+	ConceptEquivalenceNarrower ConceptEquivalence = "NARROWER"
+)
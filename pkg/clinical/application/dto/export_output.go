@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// BulkExportStatus is the status of a bulk export job
+type BulkExportStatus string
+
+const (
+	BulkExportStatusInProgress BulkExportStatus = "IN_PROGRESS"
+	BulkExportStatusCompleted  BulkExportStatus = "COMPLETED"
+	BulkExportStatusFailed     BulkExportStatus = "FAILED"
+)
+
+// BulkExportJob is an asynchronous export of a tenant's resources to one
+// NDJSON file per resource type
+type BulkExportJob struct {
+	ID             string           `json:"id"`
+	OrganizationID string           `json:"organizationID"`
+	Status         BulkExportStatus `json:"status"`
+	Types          []string         `json:"types"`
+
+	// TransactionTime is when the export started. Resources changed after it
+	// may or may not be exported
+	TransactionTime time.Time  `json:"transactionTime"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty"`
+
+	// Output has a file for each resource type that has been exported so far
+	Output []BulkExportOutput `json:"output"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// BulkExportOutput is the NDJSON file of a resource type in a bulk export
+type BulkExportOutput struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// BulkImportReport is the outcome of importing NDJSON files of FHIR resources
+type BulkImportReport struct {
+	Total    int `json:"total"`
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+
+	// Errors has an entry for each line that was not imported
+	Errors []BulkImportLineError `json:"errors"`
+}
+
+// BulkImportLineError explains why a line of an imported file was not imported
+type BulkImportLineError struct {
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	ResourceType string `json:"resourceType,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Error        string `json:"error"`
+}
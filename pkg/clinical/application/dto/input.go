@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"io"
+
 	"github.com/go-playground/validator"
 	"github.com/savannahghi/scalarutils"
 )
@@ -14,6 +16,8 @@ type OrganizationInput struct {
 	Name        string                   `json:"name,omitempty"`
 	PhoneNumber string                   `json:"phoneNumber,omitempty"`
 	Identifiers []OrganizationIdentifier `json:"identifiers,omitempty"`
+	// TimeZone is the IANA time zone, e.g `Africa/Nairobi`, that the organization's times are recorded in
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 type EpisodeOfCareInput struct {
@@ -88,3 +92,9 @@ type ReactionInput struct {
 	System   string                                 `json:"system"`
 	Severity AllergyIntoleranceReactionSeverityEnum `json:"severity"`
 }
+
+// BulkImportFile is a NDJSON file of FHIR resources to import
+type BulkImportFile struct {
+	Name    string
+	Content io.Reader
+}
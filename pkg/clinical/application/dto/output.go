@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/savannahghi/scalarutils"
 )
 
@@ -10,12 +12,43 @@ type TenantIdentifiers struct {
 	FacilityID     string `json:"facilityID,omitempty"`
 }
 
+// the systems of the tags that record the tenant of a resource
+const (
+	OrganisationTagSystem = "http://mycarehub/tenant-identification/organisation"
+	FacilityTagSystem     = "http://mycarehub/tenant-identification/facility"
+)
+
+// Tagged reports whether a resource carries the tags of the tenant. Like a
+// search, an identifier that is not set matches any tag
+func (t TenantIdentifiers) Tagged(resource map[string]interface{}) bool {
+	meta, _ := resource["meta"].(map[string]interface{})
+	tags, _ := meta["tag"].([]interface{})
+
+	tagged := func(system, code string) bool {
+		if code == "" {
+			return true
+		}
+
+		for _, tag := range tags {
+			tag, _ := tag.(map[string]interface{})
+			if tag["system"] == system && tag["code"] == code {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return tagged(OrganisationTagSystem, t.OrganizationID) && tagged(FacilityTagSystem, t.FacilityID)
+}
+
 type Organization struct {
 	ID           string                   `json:"id"`
 	Active       bool                     `json:"active"`
 	Name         string                   `json:"name"`
 	Identifiers  []OrganizationIdentifier `json:"identifiers"`
 	PhoneNumbers []string                 `json:"phoneNumbers"`
+	TimeZone     string                   `json:"timeZone,omitempty"`
 }
 
 type EpisodeOfCare struct {
@@ -88,6 +121,26 @@ type Patient struct {
 	PhoneNumber []string         `json:"phoneNumber"`
 	Gender      Gender           `json:"gender"`
 	BirthDate   scalarutils.Date `json:"birthDate"`
+
+	// Retirement is set when the patient has been retired
+	Retirement *PatientRetirement `json:"retirement,omitempty"`
+
+	// MergedInto is the ID of the patient that replaced this one when it was
+	// merged as a duplicate
+	MergedInto *string `json:"mergedInto,omitempty"`
+}
+
+// PatientRetirement records why and when a patient was retired
+type PatientRetirement struct {
+	Reason    string    `json:"reason"`
+	RetiredAt time.Time `json:"retiredAt"`
+}
+
+// PatientDeletion reports the resources that deleting a patient removed, or
+// would remove when it is a dry run
+type PatientDeletion struct {
+	DryRun  bool     `json:"dryRun"`
+	Deleted []string `json:"deleted"`
 }
 
 // Terminology models the OCL terminology output
@@ -96,3 +149,12 @@ type Terminology struct {
 	System TerminologySource `json:"system"`
 	Name   string            `json:"name"`
 }
+
+// ConceptTranslation is a concept of another terminology source that a concept
+// is mapped to
+type ConceptTranslation struct {
+	Code        string             `json:"code"`
+	System      TerminologySource  `json:"system"`
+	Name        string             `json:"name"`
+	Equivalence ConceptEquivalence `json:"equivalence"`
+}
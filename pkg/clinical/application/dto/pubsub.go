@@ -37,6 +37,10 @@ type FacilityPubSubMessage struct {
 
 // VitalSignPubSubMessage models the details that will be posted to the vitals pub/sub topic
 type VitalSignPubSubMessage struct {
+	// ID identifies the event in the source system. It is optional, when it
+	// is missing one is derived from the contents of the message.
+	ID string `json:"id,omitempty"`
+
 	Name      string    `json:"name"`
 	ConceptID *string   `json:"conceptId"`
 	Value     string    `json:"value"`
@@ -50,6 +54,10 @@ type VitalSignPubSubMessage struct {
 
 // PatientAllergyPubSubMessage contains allergy details for a patient
 type PatientAllergyPubSubMessage struct {
+	// ID identifies the event in the source system. It is optional, when it
+	// is missing one is derived from the contents of the message.
+	ID string `json:"id,omitempty"`
+
 	Name      string          `json:"name"`
 	ConceptID *string         `json:"conceptID"`
 	Date      time.Time       `json:"date"`
@@ -76,6 +84,10 @@ type AllergySeverity struct {
 
 // MedicationPubSubMessage contains details for medication that a patient/client is prescribed or using
 type MedicationPubSubMessage struct {
+	// ID identifies the event in the source system. It is optional, when it
+	// is missing one is derived from the contents of the message.
+	ID string `json:"id,omitempty"`
+
 	Name      string          `json:"medication"`
 	ConceptID *string         `json:"conceptId"`
 	Date      time.Time       `json:"date"`
@@ -95,6 +107,10 @@ type MedicationDrug struct {
 
 // PatientTestResultPubSubMessage models details that are published to the test results topic
 type PatientTestResultPubSubMessage struct {
+	// ID identifies the event in the source system. It is optional, when it
+	// is missing one is derived from the contents of the message.
+	ID string `json:"id,omitempty"`
+
 	Name      string     `json:"name"`
 	ConceptID *string    `json:"conceptId"`
 	Date      time.Time  `json:"date"`
@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// RetentionAction is what a purge does with a resource once its retention
+// period has passed
+type RetentionAction string
+
+const (
+	// RetentionActionDelete deletes expired resources
+	RetentionActionDelete RetentionAction = "delete"
+
+	// RetentionActionArchive writes expired resources to a NDJSON file before
+	// deleting them
+	RetentionActionArchive RetentionAction = "archive"
+)
+
+// RetentionPolicy is how long a tenant keeps a type of resource after it was
+// last updated
+type RetentionPolicy struct {
+	OrganizationID string `json:"organizationID"`
+
+	// FacilityID limits the policy to one of the organisation's facilities.
+	// The policy applies to every facility when it is empty
+	FacilityID string `json:"facilityID,omitempty"`
+
+	ResourceType  string          `json:"resourceType"`
+	RetentionDays int             `json:"retentionDays"`
+	Action        RetentionAction `json:"action"`
+}
+
+// PurgeReport is the outcome of applying the retention policies once
+type PurgeReport struct {
+	ID          string    `json:"id"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+
+	// Directory holds the report and the archives written by the purge
+	Directory string `json:"directory"`
+
+	Results []PurgeResult `json:"results"`
+}
+
+// PurgeResult is the outcome of applying one retention policy
+type PurgeResult struct {
+	Policy RetentionPolicy `json:"policy"`
+
+	// Cutoff is the last update time before which resources had expired
+	Cutoff time.Time `json:"cutoff"`
+
+	Expired int `json:"expired"`
+	Deleted int `json:"deleted"`
+
+	// Archive is the NDJSON file the expired resources were written to
+	Archive string `json:"archive,omitempty"`
+
+	// Failures has an entry for each expired resource that was not deleted
+	Failures []PurgeFailure `json:"failures"`
+
+	// Error is set when the policy could not be applied at all
+	Error string `json:"error,omitempty"`
+}
+
+// PurgeFailure explains why an expired resource was not deleted
+type PurgeFailure struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/savannahghi/authutils"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
 	"github.com/savannahghi/converterandformatter"
-	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/pubsubtools"
@@ -48,9 +48,9 @@ func (b *BaseExtensionImpl) GetLoggedInUser(ctx context.Context) (*profileutils.
 	return profileutils.GetLoggedInUser(ctx)
 }
 
-// GetLoggedInUserUID get the logged in user uid
+// GetLoggedInUserUID get the logged in user uid from the token added to the context by the authentication middleware
 func (b *BaseExtensionImpl) GetLoggedInUserUID(ctx context.Context) (string, error) {
-	return firebasetools.GetLoggedInUserUID(ctx)
+	return authutils.GetLoggedInUserUID(ctx)
 }
 
 // NormalizeMSISDN validates the input phone number.
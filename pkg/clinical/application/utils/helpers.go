@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -27,6 +28,22 @@ const (
 	FacilityIDContextKey = ContextKey("FacilityID")
 )
 
+// WithTenant returns a copy of the context that carries a tenant's identifiers, as
+// the tenant identification middleware does for API requests. Work that does not
+// start from an API request uses it so that its FHIR requests reach the tenant's store.
+// Empty identifiers are not added
+func WithTenant(ctx context.Context, organizationID, facilityID string) context.Context {
+	if organizationID != "" {
+		ctx = context.WithValue(ctx, OrganizationIDContextKey, organizationID)
+	}
+
+	if facilityID != "" {
+		ctx = context.WithValue(ctx, FacilityIDContextKey, facilityID)
+	}
+
+	return ctx
+}
+
 // ValidateEmail returns an error if the supplied string does not have a
 // valid format or resolvable host
 func ValidateEmail(email string) error {
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/savannahghi/scalarutils"
 )
 
@@ -219,3 +221,21 @@ type PagedFHIRAllergy struct {
 	PreviousCursor  string
 	TotalCount      int
 }
+
+// FHIRAllergyIntoleranceVersion is a version of an allergy intolerance from its history
+type FHIRAllergyIntoleranceVersion struct {
+	Resource FHIRAllergyIntolerance
+
+	// The time at which this version was recorded
+	LastUpdated time.Time
+}
+
+// PagedFHIRAllergyHistory is a page of an allergy intolerance's versions, newest first
+type PagedFHIRAllergyHistory struct {
+	Versions        []FHIRAllergyIntoleranceVersion
+	HasNextPage     bool
+	NextCursor      string
+	HasPreviousPage bool
+	PreviousCursor  string
+	TotalCount      int
+}
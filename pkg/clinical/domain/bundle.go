@@ -0,0 +1,80 @@
+package domain
+
+// FHIRBundleTypeEnum indicates the purpose of a bundle
+type FHIRBundleTypeEnum string
+
+const (
+	// FHIRBundleTypeEnumTransaction is a set of actions that must be processed as a single atomic unit
+	FHIRBundleTypeEnumTransaction FHIRBundleTypeEnum = "transaction"
+	// FHIRBundleTypeEnumTransactionResponse is the response to a transaction
+	FHIRBundleTypeEnumTransactionResponse FHIRBundleTypeEnum = "transaction-response"
+	// FHIRBundleTypeEnumBatch is a set of actions that are processed independently of each other
+	FHIRBundleTypeEnumBatch FHIRBundleTypeEnum = "batch"
+	// FHIRBundleTypeEnumBatchResponse is the response to a batch
+	FHIRBundleTypeEnumBatchResponse FHIRBundleTypeEnum = "batch-response"
+	// FHIRBundleTypeEnumCollection is a set of resources collected into a single package for ease of distribution
+	FHIRBundleTypeEnumCollection FHIRBundleTypeEnum = "collection"
+)
+
+// FHIRBundle is a container for a collection of resources.
+//
+// It is used to execute several interactions as a transaction or batch, and
+// to hand over a collection of resources.
+type FHIRBundle struct {
+	// The type of the bundle. Always `Bundle`
+	ResourceType string `json:"resourceType"`
+
+	// Indicates the purpose of this bundle - how it is intended to be used.
+	Type FHIRBundleTypeEnum `json:"type"`
+
+	// An entry in a bundle resource - will either contain a resource or information about a resource (transactions and history only).
+	Entry []FHIRBundleEntry `json:"entry,omitempty"`
+}
+
+// FHIRBundleEntry is an entry in a bundle
+type FHIRBundleEntry struct {
+	// The Absolute URL for the resource. Placeholders such as `urn:uuid:...` can be referenced by other entries of a transaction.
+	FullURL string `json:"fullUrl,omitempty"`
+
+	// The Resource for the entry.
+	Resource map[string]interface{} `json:"resource,omitempty"`
+
+	// Additional information about how this entry should be processed as part of a transaction or batch.
+	Request *FHIRBundleEntryRequest `json:"request,omitempty"`
+
+	// Indicates the results of processing the corresponding 'request' entry in the batch or transaction being responded to.
+	Response *FHIRBundleEntryResponse `json:"response,omitempty"`
+}
+
+// FHIRBundleEntryRequest describes the interaction that an entry is executed with
+type FHIRBundleEntryRequest struct {
+	// In a transaction or batch, this is the HTTP action to be executed for this entry.
+	Method string `json:"method"`
+
+	// The URL for this entry, relative to the root (the address to which the request is posted) e.g `Patient/123`.
+	URL string `json:"url"`
+
+	// Only perform the operation if the Etag value matches.
+	IfMatch string `json:"ifMatch,omitempty"`
+
+	// Instruct the server not to perform the create if a specified resource already exists.
+	IfNoneExist string `json:"ifNoneExist,omitempty"`
+}
+
+// FHIRBundleEntryResponse is the result of executing an entry
+type FHIRBundleEntryResponse struct {
+	// The status code returned by processing this entry e.g `201 Created`.
+	Status string `json:"status"`
+
+	// The location header created by processing this operation, populated if the operation returns a location.
+	Location string `json:"location,omitempty"`
+
+	// The Etag for the resource, if the operation for the entry produced a versioned resource.
+	Etag string `json:"etag,omitempty"`
+
+	// The date/time that the resource was modified on the server.
+	LastModified string `json:"lastModified,omitempty"`
+
+	// An OperationOutcome containing hints and warnings produced as part of processing this entry.
+	Outcome map[string]interface{} `json:"outcome,omitempty"`
+}
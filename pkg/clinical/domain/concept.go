@@ -24,4 +24,24 @@ type Concept struct {
 	VersionCreatedOn string  `mapstructure:"version_created_on" json:"version_created_on"`
 	VersionURL       string  `mapstructure:"version_url" json:"version_url"`
 	VersionsURL      string  `mapstructure:"versions_url" json:"versions_url"`
+
+	// Mappings are returned when mappings or inverse mappings are asked for
+	Mappings []*ConceptMapping `mapstructure:"mappings" json:"mappings,omitempty"`
+}
+
+// ConceptMapping relates two concepts of the same or of different sources e.g
+// a CIEL concept that is the `SAME-AS` an ICD-10 concept
+type ConceptMapping struct {
+	MapType         string `mapstructure:"map_type" json:"map_type"`
+	Retired         bool   `mapstructure:"retired" json:"retired"`
+	FromSourceOwner string `mapstructure:"from_source_owner" json:"from_source_owner"`
+	FromSourceName  string `mapstructure:"from_source_name" json:"from_source_name"`
+	FromConceptCode string `mapstructure:"from_concept_code" json:"from_concept_code"`
+	FromConceptName string `mapstructure:"from_concept_name" json:"from_concept_name"`
+	FromConceptURL  string `mapstructure:"from_concept_url" json:"from_concept_url"`
+	ToSourceOwner   string `mapstructure:"to_source_owner" json:"to_source_owner"`
+	ToSourceName    string `mapstructure:"to_source_name" json:"to_source_name"`
+	ToConceptCode   string `mapstructure:"to_concept_code" json:"to_concept_code"`
+	ToConceptName   string `mapstructure:"to_concept_name" json:"to_concept_name"`
+	ToConceptURL    string `mapstructure:"to_concept_url" json:"to_concept_url"`
 }
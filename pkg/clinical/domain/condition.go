@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/savannahghi/scalarutils"
 )
 
@@ -245,6 +247,24 @@ type PagedFHIRCondition struct {
 	TotalCount      int
 }
 
+// FHIRConditionVersion is a version of a condition from the condition's history
+type FHIRConditionVersion struct {
+	Resource FHIRCondition
+
+	// The time at which this version was recorded
+	LastUpdated time.Time
+}
+
+// PagedFHIRConditionHistory is a page of a condition's versions, newest first
+type PagedFHIRConditionHistory struct {
+	Versions        []FHIRConditionVersion
+	HasNextPage     bool
+	NextCursor      string
+	HasPreviousPage bool
+	PreviousCursor  string
+	TotalCount      int
+}
+
 // FHIRConditionRelayPayload is used to return single instances of Condition
 type FHIRConditionRelayPayload struct {
 	Resource *FHIRCondition `json:"resource,omitempty"`
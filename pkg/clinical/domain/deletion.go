@@ -0,0 +1,12 @@
+package domain
+
+// FHIRDeletion reports the resources removed by a cascading delete
+type FHIRDeletion struct {
+	// DryRun is set when the resources were only listed and not deleted
+	DryRun bool `json:"dryRun"`
+
+	// Deleted lists the resources, such as `Encounter/123`, in the order that
+	// they are deleted. Resources are deleted before the resources they
+	// reference
+	Deleted []string `json:"deleted"`
+}
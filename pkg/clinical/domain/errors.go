@@ -0,0 +1,15 @@
+package domain
+
+import "errors"
+
+// ErrFHIRResourceNotFound is returned when a resource does not exist or does
+// not belong to the tenant
+var ErrFHIRResourceNotFound = errors.New("resource not found")
+
+// ErrConceptNotFound is returned when a terminology service does not have a
+// concept
+var ErrConceptNotFound = errors.New("concept not found")
+
+// ErrFHIRTransactionTooLarge is returned when a transaction has more writes
+// than the FHIR store accepts in a single transaction Bundle
+var ErrFHIRTransactionTooLarge = errors.New("too many writes for a single transaction")
@@ -204,7 +204,8 @@ type PatientPayload struct {
 
 // RetirePatientInput is used to retire patient records.
 type RetirePatientInput struct {
-	ID string `json:"id"`
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
 }
 
 // PatientExtraInformationInput is used to update patient records metadata.
@@ -511,4 +512,8 @@ type PagedFHIRResource struct {
 	HasPreviousPage bool
 	PreviousCursor  string
 	TotalCount      int
+
+	// Included holds the resources returned by `_include` and `_revinclude`,
+	// keyed by their reference e.g `Patient/123`
+	Included map[string]map[string]interface{}
 }
@@ -24,6 +24,9 @@ type FHIROrganization struct {
 
 	// An address for the organization.
 	Address []*FHIRAddress `json:"address,omitempty"`
+
+	// Additional content defined by implementations, such as the organization's time zone
+	Extension []*Extension `json:"extension,omitempty"`
 }
 
 // FHIROrganizationInput definition: The organization (facility) responsible for this organization
@@ -46,6 +49,21 @@ type FHIROrganizationInput struct {
 
 	// An address for the organization.
 	Address []*FHIRAddressInput `json:"address,omitempty"`
+
+	// Additional content defined by implementations, such as the organization's time zone
+	Extension []*Extension `json:"extension,omitempty"`
+}
+
+// TimeZone returns the IANA time zone of the organization, or an empty string
+// if it has none
+func (o FHIROrganization) TimeZone() string {
+	for _, extension := range o.Extension {
+		if extension != nil && extension.URL == TimeZoneExtensionURL {
+			return extension.ValueCode
+		}
+	}
+
+	return ""
 }
 
 // FHIROrganizationRelayPayload is used to return single instances of Organization
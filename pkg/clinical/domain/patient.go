@@ -275,6 +275,13 @@ type FHIRPatientRelayPayload struct {
 	Resource *FHIRPatient `json:"resource,omitempty"`
 }
 
+// FHIRPatientTimeline holds the records that make up a patient's timeline
+type FHIRPatientTimeline struct {
+	AllergyIntolerances  []FHIRAllergyIntolerance
+	Observations         []FHIRObservation
+	MedicationStatements []FHIRMedicationStatement
+}
+
 // PatientEdge is a Relay style edge for listings of FHIR patient records.
 type PatientEdge struct {
 	Cursor string       `json:"cursor"`
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PatientMergeExtensionURL identifies the extension, on a patient that was
+// merged into another, that records when it was merged and which resources
+// were moved to the patient that replaced it
+const PatientMergeExtensionURL = "http://mycarehub/patient-merge"
+
+// the elements of the patient merge extension
+const (
+	patientMergeMergedAtURL = "mergedAt"
+	patientMergeResourceURL = "resource"
+)
+
+// the link types are written with their FHIR codes
+var (
+	patientLinkReplacedBy = PatientLinkTypeEnum(PatientLinkTypeEnumReplacedBy.String())
+	patientLinkReplaces   = PatientLinkTypeEnum(PatientLinkTypeEnumReplaces.String())
+)
+
+// MergeInto marks the patient as a duplicate that is replaced by the target.
+//
+// The patient is no longer in active use and records the resources, such as
+// "Encounter/123", that were moved to the target so that the merge can be
+// reversed with `Unmerge`. The target is linked back to the patient
+func (p *FHIRPatient) MergeInto(target *FHIRPatient, moved []string, at time.Time) {
+	active := false
+	p.Active = &active
+
+	p.Link = append(p.Link, newPatientLink(*target.ID, patientLinkReplacedBy))
+	target.Link = append(target.Link, newPatientLink(*p.ID, patientLinkReplaces))
+
+	record := &FHIRExtension{
+		URL: PatientMergeExtensionURL,
+		Extension: []Extension{
+			{URL: patientMergeMergedAtURL, ValueDateTime: at.UTC().Format(time.RFC3339)},
+		},
+	}
+
+	for _, reference := range moved {
+		reference := reference
+		record.Extension = append(record.Extension, Extension{
+			URL:            patientMergeResourceURL,
+			ValueReference: &FHIRReference{Reference: &reference},
+		})
+	}
+
+	p.Extension = append(p.Extension, record)
+}
+
+// Merge returns the ID of the patient that replaced this one, the resources
+// that were moved to it and when. It reports false if the patient has not
+// been merged
+func (p FHIRPatient) Merge() (targetID string, moved []string, mergedAt time.Time, merged bool) {
+	for _, link := range p.Link {
+		if link == nil || link.Type == nil || link.Type.String() != patientLinkReplacedBy.String() {
+			continue
+		}
+
+		if link.Other != nil && link.Other.Reference != nil {
+			targetID = strings.TrimPrefix(*link.Other.Reference, "Patient/")
+			merged = true
+		}
+	}
+
+	if !merged {
+		return "", nil, time.Time{}, false
+	}
+
+	moved = []string{}
+
+	for _, extension := range p.Extension {
+		if extension == nil || extension.URL != PatientMergeExtensionURL {
+			continue
+		}
+
+		for _, element := range extension.Extension {
+			switch element.URL {
+			case patientMergeMergedAtURL:
+				mergedAt, _ = time.Parse(time.RFC3339, element.ValueDateTime)
+			case patientMergeResourceURL:
+				if element.ValueReference != nil && element.ValueReference.Reference != nil {
+					moved = append(moved, *element.ValueReference.Reference)
+				}
+			}
+		}
+	}
+
+	return targetID, moved, mergedAt, true
+}
+
+// Unmerge reverses `MergeInto`. The patient is put back into active use,
+// unless it is retired, and the links between it and the target are removed
+func (p *FHIRPatient) Unmerge(target *FHIRPatient) {
+	_, _, retired := p.Retirement()
+
+	active := !retired
+	p.Active = &active
+
+	p.Link = removePatientLink(p.Link, *target.ID, patientLinkReplacedBy)
+	target.Link = removePatientLink(target.Link, *p.ID, patientLinkReplaces)
+
+	extensions := []*FHIRExtension{}
+
+	for _, extension := range p.Extension {
+		if extension != nil && extension.URL == PatientMergeExtensionURL {
+			continue
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	p.Extension = extensions
+}
+
+// newPatientLink links to another patient
+func newPatientLink(patientID string, linkType PatientLinkTypeEnum) *FHIRPatientLink {
+	reference := fmt.Sprintf("Patient/%s", patientID)
+
+	return &FHIRPatientLink{
+		Other: &FHIRReference{Reference: &reference},
+		Type:  &linkType,
+	}
+}
+
+// removePatientLink removes the links of the given type to another patient
+func removePatientLink(links []*FHIRPatientLink, patientID string, linkType PatientLinkTypeEnum) []*FHIRPatientLink {
+	reference := fmt.Sprintf("Patient/%s", patientID)
+	kept := []*FHIRPatientLink{}
+
+	for _, link := range links {
+		if link != nil && link.Type != nil && link.Type.String() == linkType.String() &&
+			link.Other != nil && link.Other.Reference != nil && *link.Other.Reference == reference {
+			continue
+		}
+
+		kept = append(kept, link)
+	}
+
+	return kept
+}
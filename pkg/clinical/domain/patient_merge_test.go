@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFHIRPatient_MergeInto(t *testing.T) {
+	sourceID, targetID := "source", "target"
+	at := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	source := &FHIRPatient{ID: &sourceID}
+	target := &FHIRPatient{ID: &targetID}
+
+	if _, _, _, merged := source.Merge(); merged {
+		t.Fatalf("expected a new patient not to be merged")
+	}
+
+	source.MergeInto(target, []string{"Encounter/1", "Observation/2"}, at)
+
+	// the merge is read back from the stored resource
+	data, err := json.Marshal(source)
+	if err != nil {
+		t.Fatalf("unable to marshal patient: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"type":"replaced-by"`) {
+		t.Errorf("expected a replaced-by link, got %s", data)
+	}
+
+	stored := &FHIRPatient{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		t.Fatalf("unable to unmarshal patient: %v", err)
+	}
+
+	mergedInto, moved, mergedAt, merged := stored.Merge()
+	if !merged || mergedInto != targetID || len(moved) != 2 || moved[1] != "Observation/2" || !mergedAt.Equal(at) {
+		t.Errorf("expected the merge into %s to be recorded, got %v %s %v %v", targetID, merged, mergedInto, moved, mergedAt)
+	}
+
+	if stored.Active == nil || *stored.Active {
+		t.Errorf("expected a merged patient to be inactive")
+	}
+
+	if len(target.Link) != 1 || *target.Link[0].Other.Reference != "Patient/source" || target.Link[0].Type.String() != "replaces" {
+		t.Errorf("expected the target to replace the source, got %v", target.Link)
+	}
+
+	stored.Unmerge(target)
+
+	if _, _, _, merged := stored.Merge(); merged {
+		t.Errorf("expected an unmerged patient not to be merged")
+	}
+
+	if stored.Active == nil || !*stored.Active || len(stored.Extension) != 0 || len(target.Link) != 0 {
+		t.Errorf("expected the merge to be removed, got %#v and %#v", stored, target)
+	}
+
+	retired := &FHIRPatient{ID: &sourceID}
+	retired.Retire("Duplicate record", at)
+	retired.MergeInto(target, nil, at)
+	retired.Unmerge(target)
+
+	if retired.Active == nil || *retired.Active {
+		t.Errorf("expected a retired patient to stay inactive when unmerged")
+	}
+}
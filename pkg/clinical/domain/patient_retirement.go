@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// PatientRetirementExtensionURL identifies the extension that records why and
+// when a patient was retired
+const PatientRetirementExtensionURL = "http://mycarehub/patient-retirement"
+
+// the elements of the patient retirement extension
+const (
+	patientRetirementReasonURL    = "reason"
+	patientRetirementRetiredAtURL = "retiredAt"
+)
+
+// Retire marks the patient record as no longer in active use and records why
+// and when. A retired patient keeps all of their data and can be reinstated
+func (p *FHIRPatient) Retire(reason string, at time.Time) {
+	active := false
+	p.Active = &active
+
+	p.removeRetirement()
+	p.Extension = append(p.Extension, &FHIRExtension{
+		URL: PatientRetirementExtensionURL,
+		Extension: []Extension{
+			{URL: patientRetirementReasonURL, ValueString: reason},
+			{URL: patientRetirementRetiredAtURL, ValueDateTime: at.UTC().Format(time.RFC3339)},
+		},
+	})
+}
+
+// Reinstate puts a retired patient record back into active use. A patient
+// that has been merged stays inactive until it is unmerged
+func (p *FHIRPatient) Reinstate() {
+	_, _, _, merged := p.Merge()
+
+	active := !merged
+	p.Active = &active
+
+	p.removeRetirement()
+}
+
+// Retirement returns why and when the patient was retired. It reports false
+// if the patient is not retired
+func (p FHIRPatient) Retirement() (reason string, retiredAt time.Time, retired bool) {
+	for _, extension := range p.Extension {
+		if extension == nil || extension.URL != PatientRetirementExtensionURL {
+			continue
+		}
+
+		for _, element := range extension.Extension {
+			switch element.URL {
+			case patientRetirementReasonURL:
+				reason = element.ValueString
+			case patientRetirementRetiredAtURL:
+				retiredAt, _ = time.Parse(time.RFC3339, element.ValueDateTime)
+			}
+		}
+
+		return reason, retiredAt, true
+	}
+
+	return "", time.Time{}, false
+}
+
+// removeRetirement removes the retirement extension
+func (p *FHIRPatient) removeRetirement() {
+	extensions := []*FHIRExtension{}
+
+	for _, extension := range p.Extension {
+		if extension != nil && extension.URL == PatientRetirementExtensionURL {
+			continue
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	p.Extension = extensions
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFHIRPatient_Retire(t *testing.T) {
+	at := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.FixedZone("EAT", 3*60*60))
+
+	patient := FHIRPatient{
+		Extension: []*FHIRExtension{{URL: "http://example.com/other"}},
+	}
+
+	if _, _, retired := patient.Retirement(); retired {
+		t.Fatalf("expected a new patient not to be retired")
+	}
+
+	patient.Retire("Duplicate record", at)
+	patient.Retire("Deceased", at)
+
+	reason, retiredAt, retired := patient.Retirement()
+	if !retired || reason != "Deceased" || !retiredAt.Equal(at) {
+		t.Errorf("expected the patient to be retired as deceased at %v, got %v %q %v", at, retired, reason, retiredAt)
+	}
+
+	if patient.Active == nil || *patient.Active {
+		t.Errorf("expected a retired patient to be inactive")
+	}
+
+	if len(patient.Extension) != 2 {
+		t.Errorf("expected one retirement extension and the other extension, got %d extensions", len(patient.Extension))
+	}
+
+	patient.Reinstate()
+
+	if _, _, retired := patient.Retirement(); retired {
+		t.Errorf("expected a reinstated patient not to be retired")
+	}
+
+	if patient.Active == nil || !*patient.Active {
+		t.Errorf("expected a reinstated patient to be active")
+	}
+
+	if len(patient.Extension) != 1 || patient.Extension[0].URL != "http://example.com/other" {
+		t.Errorf("expected the other extension to be kept, got %v", patient.Extension)
+	}
+}
+
+func TestFHIRPatient_Reinstate_Merged(t *testing.T) {
+	patientID, targetID := "1", "2"
+	patient := FHIRPatient{ID: &patientID}
+	target := FHIRPatient{ID: &targetID}
+
+	patient.Retire("Duplicate record", time.Now())
+	patient.MergeInto(&target, []string{}, time.Now())
+
+	patient.Reinstate()
+
+	if _, _, retired := patient.Retirement(); retired {
+		t.Errorf("expected a reinstated patient not to be retired")
+	}
+
+	if patient.Active == nil || *patient.Active {
+		t.Errorf("expected a merged patient to stay inactive")
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+// ProvenanceActivitySystem is the code system of the lifecycle events that a
+// Provenance records
+const ProvenanceActivitySystem = "http://terminology.hl7.org/CodeSystem/iso-21089-lifecycle"
+
+// the recorded lifecycle events
+const (
+	ProvenanceActivityMerge   = "merge"
+	ProvenanceActivityUnmerge = "unmerge"
+)
+
+// FHIRProvenance definition: provenance of a resource is a record that describes entities and processes involved in producing and delivering or otherwise influencing that resource.
+type FHIRProvenance struct {
+	// The logical id of the resource, as used in the URL for the resource. Once assigned, this value never changes.
+	ID *string `json:"id,omitempty"`
+
+	// Meta stores more information about the resource
+	Meta *FHIRMeta `json:"meta,omitempty"`
+
+	// The Reference(s) that were generated or updated by the activity described in this resource.
+	Target []*FHIRReference `json:"target,omitempty"`
+
+	// The instant of time at which the activity was recorded.
+	Recorded string `json:"recorded,omitempty"`
+
+	// An activity is something that occurs over a period of time and acts upon or with entities.
+	Activity *FHIRCodeableConcept `json:"activity,omitempty"`
+
+	// An actor taking a role in an activity for which it can be assigned some degree of responsibility for the activity taking place.
+	Agent []*FHIRProvenanceAgent `json:"agent,omitempty"`
+}
+
+// FHIRProvenanceAgent definition: an actor taking a role in an activity for which it can be assigned some degree of responsibility for the activity taking place.
+type FHIRProvenanceAgent struct {
+	// The individual, device or organization that participated in the event.
+	Who *FHIRReference `json:"who,omitempty"`
+}
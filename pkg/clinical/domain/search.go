@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SearchPrefix compares a date search parameter with the resource's value
+// e.g `ge` matches the resources on or after the date
+type SearchPrefix string
+
+// the FHIR search prefixes
+const (
+	SearchPrefixEqual          SearchPrefix = "eq"
+	SearchPrefixNotEqual       SearchPrefix = "ne"
+	SearchPrefixGreaterThan    SearchPrefix = "gt"
+	SearchPrefixLessThan       SearchPrefix = "lt"
+	SearchPrefixGreaterOrEqual SearchPrefix = "ge"
+	SearchPrefixLessOrEqual    SearchPrefix = "le"
+	SearchPrefixStartsAfter    SearchPrefix = "sa"
+	SearchPrefixEndsBefore     SearchPrefix = "eb"
+)
+
+// SearchModifier changes how a search parameter is matched e.g `:exact`
+type SearchModifier string
+
+// the FHIR search modifiers
+const (
+	// SearchModifierExact matches the whole value, including its case
+	SearchModifierExact SearchModifier = "exact"
+	// SearchModifierNot matches the resources that do not match the token,
+	// including those without the element
+	SearchModifierNot SearchModifier = "not"
+)
+
+// searchParameter is a single `name[:modifier]=[prefix]value` search parameter
+type searchParameter struct {
+	name     string
+	modifier SearchModifier
+	value    string
+}
+
+// SearchParams is a FHIR search query. It is built with the methods below,
+// each of which returns a new query and leaves the receiver unchanged, so a
+// query can be shared and extended by several searches e.g
+//
+//	byPatient := NewSearchParams().Reference("patient", "Patient/123")
+//	weights := byPatient.Token("code", "", weightCode)
+//	heights := byPatient.Token("code", "", heightCode)
+type SearchParams struct {
+	parameters  []searchParameter
+	sort        []string
+	includes    []string
+	revincludes []string
+	count       int
+}
+
+// NewSearchParams returns an empty search query, which matches every resource
+func NewSearchParams() SearchParams {
+	return SearchParams{}
+}
+
+// with returns a copy of the query with the parameter added
+func (s SearchParams) with(parameter searchParameter) SearchParams {
+	parameters := make([]searchParameter, 0, len(s.parameters)+1)
+	parameters = append(parameters, s.parameters...)
+	s.parameters = append(parameters, parameter)
+
+	return s
+}
+
+// Exact matches a parameter with the `:exact` modifier e.g `status:exact=active`
+func (s SearchParams) Exact(name, value string) SearchParams {
+	return s.with(searchParameter{name: name, modifier: SearchModifierExact, value: value})
+}
+
+// Token matches a code, Coding, CodeableConcept or Identifier e.g
+// `code=http://loinc.org|1234-5`. An empty system matches a code in any system
+func (s SearchParams) Token(name, system, code string) SearchParams {
+	return s.with(searchParameter{name: name, value: token(system, code)})
+}
+
+// NotToken excludes the resources that match a token e.g `active:not=false`.
+// The resources that do not have the element are not excluded
+func (s SearchParams) NotToken(name, system, code string) SearchParams {
+	return s.with(searchParameter{name: name, modifier: SearchModifierNot, value: token(system, code)})
+}
+
+// Reference matches a reference to a resource e.g `patient=Patient/123`
+func (s SearchParams) Reference(name, reference string) SearchParams {
+	return s.with(searchParameter{name: name, value: reference})
+}
+
+// ID matches the resource with the given ID
+func (s SearchParams) ID(id string) SearchParams {
+	return s.with(searchParameter{name: "_id", value: id})
+}
+
+// Content matches the resources that contain every one of the terms in the
+// text
+func (s SearchParams) Content(text string) SearchParams {
+	return s.with(searchParameter{name: "_content", value: text})
+}
+
+// Date compares a date parameter with an instant e.g
+// `date=ge2023-01-01T00:00:00Z`. A range is searched by adding the parameter
+// twice e.g with `ge` and `lt`
+func (s SearchParams) Date(name string, prefix SearchPrefix, value time.Time) SearchParams {
+	return s.with(searchParameter{name: name, value: string(prefix) + value.Format(time.RFC3339)})
+}
+
+// Sort orders the results by the parameter, in ascending order. Later sort
+// parameters break the ties of earlier ones
+func (s SearchParams) Sort(name string) SearchParams {
+	s.sort = append(append([]string{}, s.sort...), name)
+
+	return s
+}
+
+// SortDescending orders the results by the parameter, in descending order
+func (s SearchParams) SortDescending(name string) SearchParams {
+	return s.Sort("-" + name)
+}
+
+// Include also returns the resources that the matches refer to through the
+// reference parameter e.g `_include=Observation:patient`
+func (s SearchParams) Include(resourceType, name string) SearchParams {
+	s.includes = append(append([]string{}, s.includes...), fmt.Sprintf("%s:%s", resourceType, name))
+
+	return s
+}
+
+// RevInclude also returns the resources of the type that refer to the matches
+// through the reference parameter e.g `_revinclude=Observation:patient`
+func (s SearchParams) RevInclude(resourceType, name string) SearchParams {
+	s.revincludes = append(append([]string{}, s.revincludes...), fmt.Sprintf("%s:%s", resourceType, name))
+
+	return s
+}
+
+// Count limits the number of results. It is only used when the search is not
+// paginated
+func (s SearchParams) Count(count int) SearchParams {
+	s.count = count
+
+	return s
+}
+
+// Values returns the query parameters of the search. The returned values are
+// a copy that the caller can change e.g to add pagination parameters
+func (s SearchParams) Values() url.Values {
+	values := url.Values{}
+
+	for _, parameter := range s.parameters {
+		name := parameter.name
+		if parameter.modifier != "" {
+			name = fmt.Sprintf("%s:%s", name, parameter.modifier)
+		}
+
+		values.Add(name, parameter.value)
+	}
+
+	if len(s.sort) > 0 {
+		values.Set("_sort", strings.Join(s.sort, ","))
+	}
+
+	for _, include := range s.includes {
+		values.Add("_include", include)
+	}
+
+	for _, revinclude := range s.revincludes {
+		values.Add("_revinclude", revinclude)
+	}
+
+	if s.count > 0 {
+		values.Set("_count", strconv.Itoa(s.count))
+	}
+
+	return values
+}
+
+// Encode returns the search as a URL query string e.g `patient=Patient%2F123`
+func (s SearchParams) Encode() string {
+	return s.Values().Encode()
+}
+
+// token formats a token search value
+func token(system, code string) string {
+	if system == "" {
+		return code
+	}
+
+	return fmt.Sprintf("%s|%s", system, code)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchParams_Encode(t *testing.T) {
+	date := time.Date(2023, time.February, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		params SearchParams
+		want   string
+	}{
+		{
+			name:   "happy case: empty search",
+			params: NewSearchParams(),
+			want:   "",
+		},
+		{
+			name: "happy case: token and reference",
+			params: NewSearchParams().
+				Reference("patient", "Patient/123").
+				Token("code", "http://loinc.org", "1234-5"),
+			want: "code=http%3A%2F%2Floinc.org%7C1234-5&patient=Patient%2F123",
+		},
+		{
+			name: "happy case: date range",
+			params: NewSearchParams().
+				Date("date", SearchPrefixGreaterOrEqual, date).
+				Date("date", SearchPrefixLessThan, date.AddDate(0, 1, 0)),
+			want: "date=ge2023-02-01T08%3A00%3A00Z&date=lt2023-03-01T08%3A00%3A00Z",
+		},
+		{
+			name: "happy case: include and revinclude",
+			params: NewSearchParams().
+				ID("123").
+				Include("Encounter", "patient").
+				RevInclude("Observation", "encounter"),
+			want: "_id=123&_include=Encounter%3Apatient&_revinclude=Observation%3Aencounter",
+		},
+		{
+			name: "happy case: modifier, sort, include and count",
+			params: NewSearchParams().
+				Exact("status", "active").
+				SortDescending("date").
+				Sort("_id").
+				Include("Observation", "patient").
+				Count(3),
+			want: "_count=3&_include=Observation%3Apatient&_sort=-date%2C_id&status%3Aexact=active",
+		},
+		{
+			name:   "happy case: not modifier",
+			params: NewSearchParams().NotToken("active", "", "false"),
+			want:   "active%3Anot=false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.Encode(); got != tt.want {
+				t.Errorf("SearchParams.Encode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchParams_SharedQuery(t *testing.T) {
+	byPatient := NewSearchParams().Reference("patient", "Patient/123").Sort("date")
+
+	weights := byPatient.Token("code", "", "5089")
+	heights := byPatient.Token("code", "", "5090").SortDescending("_lastUpdated")
+
+	if got := byPatient.Encode(); got != "_sort=date&patient=Patient%2F123" {
+		t.Errorf("expected the shared query to be unchanged, got %v", got)
+	}
+
+	if got := weights.Values()["code"]; len(got) != 1 || got[0] != "5089" {
+		t.Errorf("expected a single weight code, got %v", got)
+	}
+
+	if got := heights.Values(); len(got["code"]) != 1 || got.Get("code") != "5090" || got.Get("_sort") != "date,-_lastUpdated" {
+		t.Errorf("unexpected height search, got %v", got)
+	}
+
+	if got := weights.Values().Get("_sort"); got != "date" {
+		t.Errorf("expected the weight sort to be unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SourceIdentifierSystem is the identifier system for the ID of the event that
+// a resource was ingested from e.g a pub/sub message. A resource is created at
+// most once for each source identifier.
+const SourceIdentifierSystem = "http://mycarehub/source-identification"
+
+// FHIRSourceIdentifierQuery returns the search query, as used in the
+// `If-None-Exist` header, that finds the resources with the same source
+// identifier as the payload. It is empty if the payload has no source
+// identifier.
+func FHIRSourceIdentifierQuery(payload map[string]interface{}) string {
+	identifiers, _ := payload["identifier"].([]interface{})
+
+	for _, item := range identifiers {
+		identifier, _ := item.(map[string]interface{})
+		if identifier["system"] != SourceIdentifierSystem {
+			continue
+		}
+
+		value, _ := identifier["value"].(string)
+		if value == "" {
+			continue
+		}
+
+		return url.Values{"identifier": {fmt.Sprintf("%s|%s", SourceIdentifierSystem, value)}}.Encode()
+	}
+
+	return ""
+}
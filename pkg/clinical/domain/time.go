@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	// the time zone database is embedded for hosts that do not have one
+	_ "time/tzdata"
+
+	"github.com/savannahghi/scalarutils"
+)
+
+// TimeZoneExtensionURL is the standard FHIR extension that records the IANA
+// time zone, e.g `Africa/Nairobi`, of an organization
+const TimeZoneExtensionURL = "http://hl7.org/fhir/StructureDefinition/timezone"
+
+// dateTimeLayouts are the layouts of a FHIR dateTime, from the most to the
+// least precise. A dateTime with a time always has an offset
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// Clock tells the time. Code that records times takes a clock so that tests
+// can fix the time
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the clock of the host
+type SystemClock struct{}
+
+// Now returns the current time of the host
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a clock that is stopped at a time
+type FixedClock struct {
+	Time time.Time
+}
+
+// Now returns the time that the clock is stopped at
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// FormatDateTime formats a time as a FHIR dateTime with the offset of the
+// time's location
+func FormatDateTime(t time.Time) scalarutils.DateTime {
+	return scalarutils.DateTime(t.Format(time.RFC3339))
+}
+
+// ParseDateTime parses a FHIR date or dateTime. A date without a time is
+// taken to be the start of the day in UTC
+func ParseDateTime(value string) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a FHIR date or dateTime", value)
+}
+
+// LoadTimeZone returns the location of an IANA time zone
+func LoadTimeZone(timeZone string) (*time.Location, error) {
+	// `Local` is the host's time zone, which is what the time zones are configured to avoid
+	if timeZone == "" || timeZone == "Local" {
+		return nil, fmt.Errorf("an IANA time zone is required, got %q", timeZone)
+	}
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %w", timeZone, err)
+	}
+
+	return location, nil
+}
+
+// Duration returns how long a period lasted. It reports false if the period
+// has not ended or its bounds cannot be read
+func (p FHIRPeriod) Duration() (time.Duration, bool) {
+	if p.Start == "" || p.End == "" {
+		return 0, false
+	}
+
+	start, err := ParseDateTime(string(p.Start))
+	if err != nil {
+		return 0, false
+	}
+
+	end, err := ParseDateTime(string(p.End))
+	if err != nil {
+		return 0, false
+	}
+
+	return end.Sub(start), true
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "Happy case: date time with an offset",
+			value: "2023-03-01T08:00:00+03:00",
+			want:  time.Date(2023, time.March, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Happy case: date time in UTC with fractional seconds",
+			value: "2020-09-24T18:02:38.661033Z",
+			want:  time.Date(2020, time.September, 24, 18, 2, 38, 661033000, time.UTC),
+		},
+		{
+			name:  "Happy case: date",
+			value: "2018-01-02",
+			want:  time.Date(2018, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Happy case: year and month",
+			value: "2018-01",
+			want:  time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Happy case: year",
+			value: "2018",
+			want:  time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Sad case: date time without an offset",
+			value:   "2006-01-02T00:00:00",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: month name",
+			value:   "2018-Jan-01",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: not a date",
+			value:   "invalid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseDateTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	nairobi, err := LoadTimeZone("Africa/Nairobi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2023, time.March, 1, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{
+			name: "Happy case: UTC",
+			time: at,
+			want: "2023-03-01T05:00:00Z",
+		},
+		{
+			name: "Happy case: the offset of the time zone",
+			time: at.In(nairobi),
+			want: "2023-03-01T08:00:00+03:00",
+		},
+		{
+			name: "Happy case: a negative offset",
+			time: at.In(time.FixedZone("", -5*60*60)),
+			want: "2023-03-01T00:00:00-05:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatDateTime(tt.time)
+			if string(got) != tt.want {
+				t.Errorf("FormatDateTime() = %v, want %v", got, tt.want)
+			}
+
+			parsed, err := ParseDateTime(string(got))
+			if err != nil || !parsed.Equal(tt.time) {
+				t.Errorf("expected %v to parse back to %v, got %v %v", got, tt.time, parsed, err)
+			}
+		})
+	}
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		wantErr  bool
+	}{
+		{
+			name:     "Happy case: IANA time zone",
+			timeZone: "Africa/Kampala",
+		},
+		{
+			name:     "Sad case: no time zone",
+			timeZone: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: the host's time zone",
+			timeZone: "Local",
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: unknown time zone",
+			timeZone: "Africa/Atlantis",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTimeZone(tt.timeZone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadTimeZone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFHIROrganization_TimeZone(t *testing.T) {
+	organization := FHIROrganization{
+		Extension: []*Extension{
+			nil,
+			{URL: "http://example.com/other", ValueCode: "other"},
+			{URL: TimeZoneExtensionURL, ValueCode: "Africa/Kigali"},
+		},
+	}
+
+	if got := organization.TimeZone(); got != "Africa/Kigali" {
+		t.Errorf("FHIROrganization.TimeZone() = %v, want Africa/Kigali", got)
+	}
+
+	if got := (FHIROrganization{}).TimeZone(); got != "" {
+		t.Errorf("expected an organization without a time zone, got %v", got)
+	}
+}
+
+func TestFHIRPeriod_Duration(t *testing.T) {
+	tests := []struct {
+		name   string
+		period FHIRPeriod
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "Happy case: a finished period",
+			period: FHIRPeriod{Start: "2023-03-01T08:00:00+03:00", End: "2023-03-01T06:30:00Z"},
+			want:   90 * time.Minute,
+			wantOK: true,
+		},
+		{
+			name:   "Sad case: a period that has not ended",
+			period: FHIRPeriod{Start: "2023-03-01T08:00:00+03:00"},
+		},
+		{
+			name:   "Sad case: a period that cannot be read",
+			period: FHIRPeriod{Start: "February", End: "March"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.period.Duration()
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("FHIRPeriod.Duration() = %v %v, want %v %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFHIRVersionConflict is returned when a resource is written with a
+// version that is no longer the current version of the resource i.e. someone
+// else changed it after it was read. The caller can read the resource again
+// and retry.
+var ErrFHIRVersionConflict = errors.New("the resource has been changed since it was read")
+
+// fhirVersionPath is the JSON pointer to a resource's version
+const fhirVersionPath = "/meta/versionId"
+
+// FHIRVersionETag returns the weak ETag of a resource version e.g `W/"2"`, as
+// used in the `If-Match` header
+func FHIRVersionETag(versionID string) string {
+	return fmt.Sprintf(`W/"%s"`, versionID)
+}
+
+// FHIRVersionFromETag returns the version in an ETag e.g `2` from `W/"2"`
+func FHIRVersionFromETag(etag string) string {
+	return strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+}
+
+// FHIRPayloadVersion returns the `meta.versionId` of a resource payload or an
+// empty string if it has none
+func FHIRPayloadVersion(payload map[string]interface{}) string {
+	meta, _ := payload["meta"].(map[string]interface{})
+	versionID, _ := meta["versionId"].(string)
+
+	return versionID
+}
+
+// FHIRPatchVersion returns the version that a JSON patch document expects the
+// resource to be at, through a `test` operation on `/meta/versionId`, or an
+// empty string if it has none
+func FHIRPatchVersion(patch []map[string]interface{}) string {
+	for _, operation := range patch {
+		if operation["op"] != "test" || operation["path"] != fhirVersionPath {
+			continue
+		}
+
+		versionID, _ := operation["value"].(string)
+
+		return versionID
+	}
+
+	return ""
+}
+
+// FHIRVersionTestOperation returns a JSON patch `test` operation that makes a
+// patch only apply to the given version of a resource
+func FHIRVersionTestOperation(versionID string) map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "test",
+		"path":  fhirVersionPath,
+		"value": versionID,
+	}
+}
@@ -0,0 +1,228 @@
+package fhir
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// CascadeDeleteFHIRPatient deletes a patient together with every resource in
+// the patient's compartment and reports what was deleted.
+//
+// The references between the resources are read from the compartment so that
+// each resource is deleted before the resources that it references. Resources
+// that reference each other in a cycle are deleted in a stable order, which
+// the transaction allows since none of them remain once it is committed.
+//
+// Everything is deleted in a single transaction. With `dryRun` the resources
+// that would be deleted are reported and nothing is deleted. The patient must
+// belong to the tenant.
+func (fh StoreImpl) CascadeDeleteFHIRPatient(
+	ctx context.Context, id string, tenant dto.TenantIdentifiers, dryRun bool,
+) (*domain.FHIRDeletion, error) {
+	_, err := fh.getTenantFHIRPatient(ctx, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return fh.cascadeDeleteFHIRPatient(ctx, id, dryRun)
+}
+
+// cascadeDeleteFHIRPatient deletes a patient's compartment, or reports what
+// would be deleted with `dryRun`, without checking the patient's tenant
+func (fh StoreImpl) cascadeDeleteFHIRPatient(ctx context.Context, id string, dryRun bool) (*domain.FHIRDeletion, error) {
+	everythingBs, err := fh.Dataset.GetFHIRPatientAllData(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get patient's compartment: %w", err)
+	}
+
+	everything := struct {
+		Entry []domain.FHIRBundleEntry `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(everythingBs, &everything)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal patient everything: %w", err)
+	}
+
+	graph := newReferenceGraph()
+
+	for _, entry := range everything.Entry {
+		if entry.Resource == nil {
+			return nil, fmt.Errorf("server error: patient everything entry %q has no resource", entry.FullURL)
+		}
+
+		err := graph.add(entry.Resource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deletion := &domain.FHIRDeletion{
+		DryRun:  dryRun,
+		Deleted: graph.deletionOrder(),
+	}
+
+	if dryRun || len(deletion.Deleted) == 0 {
+		return deletion, nil
+	}
+
+	store, tx := fh.beginTransaction()
+
+	resources := []map[string]string{}
+
+	for _, reference := range deletion.Deleted {
+		resourceType, resourceID, _ := strings.Cut(reference, "/")
+
+		resources = append(resources, map[string]string{
+			"resourceType": resourceType,
+			"resourceID":   resourceID,
+		})
+	}
+
+	err = store.DeleteFHIRResourceType(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fh.commitTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete patient %s: %w", id, err)
+	}
+
+	return deletion, nil
+}
+
+// referenceGraph records which resources, such as `Encounter/123`, reference
+// which other resources in a set of resources
+type referenceGraph struct {
+	// references maps each resource to the resources in the set that it
+	// references
+	references map[string]map[string]bool
+}
+
+// newReferenceGraph initializes an empty reference graph
+func newReferenceGraph() *referenceGraph {
+	return &referenceGraph{references: map[string]map[string]bool{}}
+}
+
+// add adds a resource and the references it makes to the graph
+func (g *referenceGraph) add(resource map[string]interface{}) error {
+	resourceType, _ := resource["resourceType"].(string)
+	id, _ := resource["id"].(string)
+
+	if resourceType == "" || id == "" {
+		return fmt.Errorf("server error: resource %v has no resource type or ID", resource)
+	}
+
+	node := fmt.Sprintf("%s/%s", resourceType, id)
+	if g.references[node] == nil {
+		g.references[node] = map[string]bool{}
+	}
+
+	collectReferences(resource, func(reference string) {
+		if reference != node {
+			g.references[node][reference] = true
+		}
+	})
+
+	return nil
+}
+
+// deletionOrder orders the resources so that every resource comes before the
+// resources that it references. Only references to resources in the graph
+// are considered. When the remaining resources reference each other in a
+// cycle, the cycle is broken at the first of them in lexical order
+func (g *referenceGraph) deletionOrder() []string {
+	// referencedBy counts the remaining resources that reference each resource
+	referencedBy := map[string]int{}
+	remaining := map[string]bool{}
+
+	for node, references := range g.references {
+		remaining[node] = true
+
+		for reference := range references {
+			if _, ok := g.references[reference]; ok {
+				referencedBy[reference]++
+			}
+		}
+	}
+
+	order := []string{}
+
+	for len(remaining) > 0 {
+		ready := []string{}
+		for node := range remaining {
+			if referencedBy[node] == 0 {
+				ready = append(ready, node)
+			}
+		}
+
+		if len(ready) == 0 {
+			for node := range remaining {
+				ready = append(ready, node)
+			}
+		}
+
+		sort.Strings(ready)
+
+		next := ready[0]
+		order = append(order, next)
+		delete(remaining, next)
+
+		for reference := range g.references[next] {
+			if remaining[reference] {
+				referencedBy[reference]--
+			}
+		}
+	}
+
+	return order
+}
+
+// collectReferences passes every relative reference, such as `Patient/123`,
+// found anywhere within a resource to fn. Absolute and versioned references
+// are reduced to the referenced resource
+func collectReferences(value interface{}, fn func(reference string)) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if reference, ok := item.(string); ok && key == "reference" {
+				if resource := referencedResource(reference); resource != "" {
+					fn(resource)
+				}
+
+				continue
+			}
+
+			collectReferences(item, fn)
+		}
+
+	case []interface{}:
+		for _, item := range value {
+			collectReferences(item, fn)
+		}
+	}
+}
+
+// referencedResource returns the `Type/id` of the resource that a reference
+// points to, or an empty string for contained and placeholder references
+func referencedResource(reference string) string {
+	if strings.HasPrefix(reference, "#") || strings.HasPrefix(reference, "urn:") {
+		return ""
+	}
+
+	reference, _, _ = strings.Cut(reference, "/_history/")
+
+	parts := strings.Split(strings.TrimSuffix(reference, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return strings.Join(parts[len(parts)-2:], "/")
+}
@@ -0,0 +1,61 @@
+package fhir
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// bulkPageSize is the number of resources read per page when paging through
+// all of a tenant's resources
+const bulkPageSize = 500
+
+// ExportFHIRResources pages through every resource of the given type that
+// belongs to the tenant, in creation order, and passes each one to write.
+//
+// A tenant without a facility ID exports the resources of every facility in
+// the organisation, since an empty tag code matches any code in its system.
+// Paging stops at the first error returned by write.
+func (fh StoreImpl) ExportFHIRResources(
+	ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error,
+) error {
+	err := fh.eachFHIRResource(ctx, resourceType, domain.NewSearchParams(), tenant, write)
+	if err != nil {
+		return fmt.Errorf("unable to export %s: %w", resourceType, err)
+	}
+
+	return nil
+}
+
+// eachFHIRResource pages through every resource of the given type that
+// belongs to the tenant and matches the search, and passes each one to fn.
+// Paging stops at the first error returned by fn
+func (fh StoreImpl) eachFHIRResource(
+	ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers,
+	fn func(resource map[string]interface{}) error,
+) error {
+	size := bulkPageSize
+	pagination := dto.Pagination{First: &size}
+
+	for {
+		page, err := fh.Dataset.SearchFHIRResource(ctx, resourceType, params, tenant, pagination)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range page.Resources {
+			err := fn(resource)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !page.HasNextPage {
+			return nil
+		}
+
+		pagination.After = page.NextCursor
+	}
+}
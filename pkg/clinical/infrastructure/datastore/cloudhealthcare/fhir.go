@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -17,7 +18,6 @@ import (
 // constants and defaults
 const (
 	internalError = "an error occurred on our end. Please try again later"
-	timeFormatStr = "2006-01-02T15:04:05+03:00"
 )
 
 // resource types
@@ -34,23 +34,37 @@ const (
 	compositionResourceType         = "Composition"
 	medicationStatementResourceType = "MedicationStatement"
 	medicationResourceType          = "Medication"
+	provenanceResourceType          = "Provenance"
 )
 
 // Dataset ...
 type Dataset interface {
-	GetFHIRResource(resourceType, fhirResourceID string, resource interface{}) error
-	CreateFHIRResource(resourceType string, payload map[string]interface{}, resource interface{}) error
-	DeleteFHIRResource(resourceType, fhirResourceID string) error
-	PatchFHIRResource(resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error
-	UpdateFHIRResource(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error
-	SearchFHIRResource(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error)
-
-	GetFHIRPatientAllData(fhirResourceID string) ([]byte, error)
+	GetFHIRResource(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error
+	CreateFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error
+	DeleteFHIRResource(ctx context.Context, resourceType, fhirResourceID string) error
+	PatchFHIRResource(ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error
+	UpdateFHIRResource(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error
+	SearchFHIRResource(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error)
+
+	GetFHIRPatientAllData(ctx context.Context, fhirResourceID string) ([]byte, error)
+	ExecuteBundle(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error)
+
+	GetFHIRResourceHistory(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error)
+	GetFHIRResourceVersion(ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error
 }
 
 // StoreImpl represents the FHIR infrastructure implementation
 type StoreImpl struct {
 	Dataset Dataset
+
+	// Clock tells the time that is recorded on resources. The host's clock is
+	// used when it is nil
+	Clock domain.Clock
+
+	// Organizations reads the organizations whose time zones the times are
+	// recorded in e.g through the organization cache. The store reads them
+	// itself when it is nil
+	Organizations helpers.OrganizationReader
 }
 
 // NewFHIRStoreImpl initializes the new FHIR implementation
@@ -62,20 +76,42 @@ func NewFHIRStoreImpl(
 	}
 }
 
+// clock returns the clock of the store
+func (fh StoreImpl) clock() domain.Clock {
+	if fh.Clock == nil {
+		return domain.SystemClock{}
+	}
+
+	return fh.Clock
+}
+
+// organizations returns the reader of the organizations' time zones
+func (fh StoreImpl) organizations() helpers.OrganizationReader {
+	if fh.Organizations == nil {
+		return fh
+	}
+
+	return fh.Organizations
+}
+
+// now returns the current time in the time zone of the facility in the context
+func (fh StoreImpl) now(ctx context.Context) time.Time {
+	return fh.clock().Now().In(helpers.FacilityLocation(ctx, fh.organizations()))
+}
+
 // SearchPatientObservations fetches all observations that belong to a specific patient
 func (fh StoreImpl) SearchPatientObservations(
-	_ context.Context,
+	ctx context.Context,
 	patientReference string,
 	observationCode string,
 	tenant dto.TenantIdentifiers,
 	pagination dto.Pagination,
 ) ([]*domain.FHIRObservation, error) {
-	params := map[string]interface{}{
-		"patient": patientReference,
-		"code":    observationCode,
-	}
+	params := domain.NewSearchParams().
+		Reference("patient", patientReference).
+		Token("code", "", observationCode)
 
-	observations, err := fh.Dataset.SearchFHIRResource(observationResourceType, params, tenant, pagination)
+	observations, err := fh.Dataset.SearchFHIRResource(ctx, observationResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -105,20 +141,18 @@ func (fh StoreImpl) SearchPatientObservations(
 //
 // The patientReference should be a [string] in the format "Patient/<patient resource ID>".
 func (fh StoreImpl) SearchPatientEncounters(
-	_ context.Context,
+	ctx context.Context,
 	patientReference string,
 	status *domain.EncounterStatusEnum,
 	tenant dto.TenantIdentifiers,
 	pagination dto.Pagination,
 ) (*domain.PagedFHIREncounter, error) {
-	params := map[string]interface{}{
-		"patient": patientReference,
-	}
+	params := domain.NewSearchParams().Reference("patient", patientReference)
 	if status != nil {
-		params["status:exact"] = status.String()
+		params = params.Exact("status", status.String())
 	}
 
-	resources, err := fh.Dataset.SearchFHIRResource(encounterResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, encounterResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -152,10 +186,10 @@ func (fh StoreImpl) SearchPatientEncounters(
 }
 
 // SearchFHIREpisodeOfCare provides a search API for FHIREpisodeOfCare
-func (fh StoreImpl) SearchFHIREpisodeOfCare(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
+func (fh StoreImpl) SearchFHIREpisodeOfCare(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
 	output := domain.FHIREpisodeOfCareRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(episodeOfCareResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, episodeOfCareResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +218,7 @@ func (fh StoreImpl) SearchFHIREpisodeOfCare(_ context.Context, params map[string
 
 // CreateEpisodeOfCare is the final common pathway for creation of episodes of
 // care.
-func (fh StoreImpl) CreateEpisodeOfCare(_ context.Context, episode domain.FHIREpisodeOfCareInput) (*domain.EpisodeOfCarePayload, error) {
+func (fh StoreImpl) CreateEpisodeOfCare(ctx context.Context, episode domain.FHIREpisodeOfCareInput) (*domain.EpisodeOfCarePayload, error) {
 	payload, err := converterandformatter.StructToMap(episode)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn episode of care input into a map: %w", err)
@@ -193,7 +227,7 @@ func (fh StoreImpl) CreateEpisodeOfCare(_ context.Context, episode domain.FHIREp
 	fhirEpisode := &domain.FHIREpisodeOfCare{}
 	// create a new episode if none has been found
 
-	err = fh.Dataset.CreateFHIRResource(episodeOfCareResourceType, payload, fhirEpisode)
+	err = fh.Dataset.CreateFHIRResource(ctx, episodeOfCareResourceType, payload, fhirEpisode)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"unable to create episode of care resource: %w", err)
@@ -207,7 +241,7 @@ func (fh StoreImpl) CreateEpisodeOfCare(_ context.Context, episode domain.FHIREp
 }
 
 // CreateFHIRCondition creates a FHIRCondition instance
-func (fh StoreImpl) CreateFHIRCondition(_ context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRCondition(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", conditionResourceType, err)
@@ -215,7 +249,7 @@ func (fh StoreImpl) CreateFHIRCondition(_ context.Context, input domain.FHIRCond
 
 	resource := &domain.FHIRCondition{}
 
-	err = fh.Dataset.CreateFHIRResource(conditionResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, conditionResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create %s resource: %w", conditionResourceType, err)
 	}
@@ -228,7 +262,7 @@ func (fh StoreImpl) CreateFHIRCondition(_ context.Context, input domain.FHIRCond
 }
 
 // CreateFHIROrganization creates a FHIROrganization instance
-func (fh StoreImpl) CreateFHIROrganization(_ context.Context, input domain.FHIROrganizationInput) (*domain.FHIROrganizationRelayPayload, error) {
+func (fh StoreImpl) CreateFHIROrganization(ctx context.Context, input domain.FHIROrganizationInput) (*domain.FHIROrganizationRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", organizationResource, err)
@@ -236,7 +270,7 @@ func (fh StoreImpl) CreateFHIROrganization(_ context.Context, input domain.FHIRO
 
 	resource := &domain.FHIROrganization{}
 
-	err = fh.Dataset.CreateFHIRResource(organizationResource, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, organizationResource, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create %s resource: %w", organizationResource, err)
 	}
@@ -249,10 +283,10 @@ func (fh StoreImpl) CreateFHIROrganization(_ context.Context, input domain.FHIRO
 }
 
 // SearchFHIROrganization provides a search API for FHIROrganization
-func (fh StoreImpl) SearchFHIROrganization(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error) {
+func (fh StoreImpl) SearchFHIROrganization(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error) {
 	output := domain.FHIROrganizationRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(organizationResource, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, organizationResource, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -280,14 +314,14 @@ func (fh StoreImpl) SearchFHIROrganization(_ context.Context, params map[string]
 }
 
 // GetFHIROrganization finds and retrieves organization details using the specified organization ID
-func (fh StoreImpl) GetFHIROrganization(_ context.Context, organizationID string) (*domain.FHIROrganizationRelayPayload, error) {
+func (fh StoreImpl) GetFHIROrganization(ctx context.Context, organizationID string) (*domain.FHIROrganizationRelayPayload, error) {
 	if organizationID == "" {
 		return nil, fmt.Errorf("organization ID is required")
 	}
 
 	organization := &domain.FHIROrganization{}
 
-	err := fh.Dataset.GetFHIRResource(organizationResource, organizationID, organization)
+	err := fh.Dataset.GetFHIRResource(ctx, organizationResource, organizationID, organization)
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve organization: %w", err)
 	}
@@ -298,10 +332,10 @@ func (fh StoreImpl) GetFHIROrganization(_ context.Context, organizationID string
 }
 
 // GetFHIRAllergyIntolerance fetches the allergy from FHIR repository using its id
-func (fh StoreImpl) GetFHIRAllergyIntolerance(_ context.Context, id string) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
+func (fh StoreImpl) GetFHIRAllergyIntolerance(ctx context.Context, id string) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
 	allergyIntoleranace := &domain.FHIRAllergyIntolerance{}
 
-	err := fh.Dataset.GetFHIRResource(allergyIntoleranceResourceType, id, allergyIntoleranace)
+	err := fh.Dataset.GetFHIRResource(ctx, allergyIntoleranceResourceType, id, allergyIntoleranace)
 	if err != nil {
 		return nil, err
 	}
@@ -312,8 +346,8 @@ func (fh StoreImpl) GetFHIRAllergyIntolerance(_ context.Context, id string) (*do
 }
 
 // SearchEpisodesByParam search episodes by params
-func (fh StoreImpl) SearchEpisodesByParam(_ context.Context, searchParams map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
-	resources, err := fh.Dataset.SearchFHIRResource(episodeOfCareResourceType, searchParams, tenant, pagination)
+func (fh StoreImpl) SearchEpisodesByParam(ctx context.Context, searchParams domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, episodeOfCareResourceType, searchParams, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -321,26 +355,9 @@ func (fh StoreImpl) SearchEpisodesByParam(_ context.Context, searchParams map[st
 	output := []*domain.FHIREpisodeOfCare{}
 
 	for _, resource := range resources.Resources {
-		period := resource["period"].(map[string]interface{})
-
-		// parse period->start as map[string]interface{}
-		resStart := helpers.ParseDate(period["start"].(string))
-		startDateAsMap := make(map[string]int)
-		startDateAsMap["year"] = resStart.Year()
-		startDateAsMap["month"] = int(resStart.Month())
-		startDateAsMap["day"] = resStart.Day()
-		period["start"] = scalarutils.DateTime(resStart.Format(timeFormatStr))
-
-		// parse period->end as map[string]interface{}
-		resEnd := helpers.ParseDate(period["end"].(string))
-		endDateAsMap := make(map[string]int)
-		endDateAsMap["year"] = resEnd.Year()
-		endDateAsMap["month"] = int(resEnd.Month())
-		endDateAsMap["day"] = resEnd.Day()
-		period["end"] = scalarutils.DateTime(resEnd.Format(timeFormatStr))
-
-		// update the original period resource
-		resource["period"] = period
+		if period, ok := resource["period"].(map[string]interface{}); ok {
+			resource["period"] = periodMapper(period)
+		}
 
 		var episode domain.FHIREpisodeOfCare
 
@@ -357,10 +374,9 @@ func (fh StoreImpl) SearchEpisodesByParam(_ context.Context, searchParams map[st
 
 // OpenEpisodes returns the IDs of a patient's open episodes
 func (fh StoreImpl) OpenEpisodes(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
-	params := map[string]interface{}{
-		"status:exact": domain.EpisodeOfCareStatusEnumActive.String(),
-		"patient":      patientReference,
-	}
+	params := domain.NewSearchParams().
+		Exact("status", domain.EpisodeOfCareStatusEnumActive.String()).
+		Reference("patient", patientReference)
 
 	return fh.SearchEpisodesByParam(ctx, params, tenant, pagination)
 }
@@ -383,7 +399,7 @@ func (fh StoreImpl) HasOpenEpisode(
 }
 
 // CreateFHIREncounter creates a FHIREncounter instance
-func (fh StoreImpl) CreateFHIREncounter(_ context.Context, input domain.FHIREncounterInput) (*domain.FHIREncounterRelayPayload, error) {
+func (fh StoreImpl) CreateFHIREncounter(ctx context.Context, input domain.FHIREncounterInput) (*domain.FHIREncounterRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", encounterResourceType, err)
@@ -391,7 +407,7 @@ func (fh StoreImpl) CreateFHIREncounter(_ context.Context, input domain.FHIREnco
 
 	resource := &domain.FHIREncounter{}
 
-	err = fh.Dataset.CreateFHIRResource(encounterResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, encounterResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", encounterResourceType, err)
 	}
@@ -404,10 +420,10 @@ func (fh StoreImpl) CreateFHIREncounter(_ context.Context, input domain.FHIREnco
 }
 
 // GetFHIREpisodeOfCare retrieves instances of FHIREpisodeOfCare by ID
-func (fh StoreImpl) GetFHIREpisodeOfCare(_ context.Context, id string) (*domain.FHIREpisodeOfCareRelayPayload, error) {
+func (fh StoreImpl) GetFHIREpisodeOfCare(ctx context.Context, id string) (*domain.FHIREpisodeOfCareRelayPayload, error) {
 	resource := &domain.FHIREpisodeOfCare{}
 
-	err := fh.Dataset.GetFHIRResource(episodeOfCareResourceType, id, resource)
+	err := fh.Dataset.GetFHIRResource(ctx, episodeOfCareResourceType, id, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get %s with ID %s, err: %w", episodeOfCareResourceType, id, err)
 	}
@@ -419,6 +435,78 @@ func (fh StoreImpl) GetFHIREpisodeOfCare(_ context.Context, id string) (*domain.
 	return payload, nil
 }
 
+// GetFHIREpisodeOfCareWithEncounters retrieves an episode of care by ID
+// together with its in-progress encounters, which are reverse included in the
+// search for the episode
+func (fh StoreImpl) GetFHIREpisodeOfCareWithEncounters(
+	ctx context.Context, id string, tenant dto.TenantIdentifiers,
+) (*domain.FHIREpisodeOfCareRelayPayload, []domain.FHIREncounter, error) {
+	params := domain.NewSearchParams().ID(id).RevInclude(encounterResourceType, "episode-of-care")
+
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, episodeOfCareResourceType, params, tenant, dto.Pagination{Skip: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get %s with ID %s, err: %w", episodeOfCareResourceType, id, err)
+	}
+
+	if len(resources.Resources) == 0 {
+		return nil, nil, fmt.Errorf("%s with ID %s not found", episodeOfCareResourceType, id)
+	}
+
+	episode := &domain.FHIREpisodeOfCare{}
+
+	err = decodeResource(resources.Resources[0], episode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("server error: Unable to unmarshal %s: %w", episodeOfCareResourceType, err)
+	}
+
+	encounters := []domain.FHIREncounter{}
+
+	for _, result := range includedResources(resources, encounterResourceType, tenant) {
+		var encounter domain.FHIREncounter
+
+		err := decodeResource(result, &encounter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("server error: Unable to unmarshal %s: %w", encounterResourceType, err)
+		}
+
+		if encounter.Status != domain.EncounterStatusEnumInProgress {
+			continue
+		}
+
+		encounters = append(encounters, encounter)
+	}
+
+	payload := &domain.FHIREpisodeOfCareRelayPayload{
+		Resource: episode,
+	}
+
+	return payload, encounters, nil
+}
+
+// includedResources returns the resources of a type that were included in a
+// search and that carry the tenant tags, ordered by their reference. FHIR
+// servers do not filter included resources by `_tag`, so the records of other
+// facilities are left out here
+func includedResources(resources *domain.PagedFHIRResource, resourceType string, tenant dto.TenantIdentifiers) []map[string]interface{} {
+	references := []string{}
+
+	for reference, resource := range resources.Included {
+		if resource["resourceType"] == resourceType && tenant.Tagged(resource) {
+			references = append(references, reference)
+		}
+	}
+
+	sort.Strings(references)
+
+	included := []map[string]interface{}{}
+
+	for _, reference := range references {
+		included = append(included, resources.Included[reference])
+	}
+
+	return included
+}
+
 // StartEncounter starts an encounter within an episode of care
 func (fh StoreImpl) StartEncounter(
 	ctx context.Context, episodeID string) (string, error) {
@@ -435,8 +523,7 @@ func (fh StoreImpl) StartEncounter(
 	}
 
 	episodeRef := fmt.Sprintf("EpisodeOfCare/%s", *episodePayload.Resource.ID)
-	now := time.Now()
-	startTime := scalarutils.DateTime(now.Format("2006-01-02T15:04:05+03:00"))
+	startTime := domain.FormatDateTime(fh.now(ctx))
 
 	encounterClassCode := scalarutils.Code("AMB")
 	encounterClassSystem := scalarutils.URI("http://terminology.hl7.org/CodeSystem/v3-ActCode")
@@ -488,10 +575,9 @@ func (fh StoreImpl) SearchEpisodeEncounter(
 	pagination dto.Pagination,
 ) (*domain.PagedFHIREncounter, error) {
 	episodeRef := fmt.Sprintf("Episode/%s", episodeReference)
-	encounterFilterParams := map[string]interface{}{
-		"episodeOfCare": episodeRef,
-		"status":        "in_progress",
-	}
+	encounterFilterParams := domain.NewSearchParams().
+		Reference("episodeOfCare", episodeRef).
+		Token("status", "", "in_progress")
 	encounterConn, err := fh.SearchFHIREncounter(ctx, encounterFilterParams, tenant, pagination)
 
 	if err != nil {
@@ -501,25 +587,26 @@ func (fh StoreImpl) SearchEpisodeEncounter(
 	return encounterConn, nil
 }
 
-// EndEncounter ends an encounter
+// EndEncounter ends an encounter at the given time, which is recorded in the
+// time zone of the facility. The end cannot be before the encounter started.
+//
+// The encounter is only updated if it has not changed since it was read,
+// otherwise an error wrapping `domain.ErrFHIRVersionConflict` is returned.
 func (fh StoreImpl) EndEncounter(
-	ctx context.Context, encounterID string) (bool, error) {
+	ctx context.Context, encounterID string, endTime time.Time) (bool, error) {
 	encounterPayload, err := fh.GetFHIREncounter(ctx, encounterID)
 	if err != nil {
 		return false, err
 	}
 
+	period, err := fh.endPeriod(ctx, encounterPayload.Resource.Period, endTime)
+	if err != nil {
+		return false, fmt.Errorf("unable to end encounter %s: %w", encounterID, err)
+	}
+
 	updatedStatus := domain.EncounterStatusEnumFinished
 	encounterPayload.Resource.Status = updatedStatus
-
-	// workaround for odd date comparison behavior on the Google Cloud Healthcare API
-	// the end time must be at least 24 hours after the start time
-	// so: if the time now is less than 24 hours after start, set the end to be
-	// 24 hours after the start of the visit. If the time now is more than 24 hours
-	// after the start, use the current time as the end of the visit
-	end := time.Now().Add(time.Hour * 24)
-	endTime := scalarutils.DateTime(end.Format(timeFormatStr))
-	encounterPayload.Resource.Period.End = endTime
+	encounterPayload.Resource.Period = period
 
 	payload, err := converterandformatter.StructToMap(encounterPayload.Resource)
 	if err != nil {
@@ -528,7 +615,7 @@ func (fh StoreImpl) EndEncounter(
 
 	encounter := &domain.FHIREncounter{}
 
-	err = fh.Dataset.UpdateFHIRResource(encounterResourceType, encounterID, payload, encounter)
+	err = fh.Dataset.UpdateFHIRResource(ctx, encounterResourceType, encounterID, payload, encounter)
 	if err != nil {
 		return false, fmt.Errorf("unable to create/update %s resource: %w", encounterResourceType, err)
 	}
@@ -536,31 +623,27 @@ func (fh StoreImpl) EndEncounter(
 	return true, nil
 }
 
-// EndEpisode ends an episode of care by patching its status to "finished"
+// EndEpisode ends an episode of care at the given time by updating its status
+// to "finished". The end is recorded in the time zone of the facility and
+// cannot be before the episode started.
+//
+// The episode is only updated if it has not changed since it was read,
+// otherwise an error wrapping `domain.ErrFHIRVersionConflict` is returned.
 func (fh StoreImpl) EndEpisode(
-	ctx context.Context, episodeID string) (bool, error) {
+	ctx context.Context, episodeID string, endTime time.Time) (bool, error) {
 	episodePayload, err := fh.GetFHIREpisodeOfCare(ctx, episodeID)
 	if err != nil {
 		return false, fmt.Errorf("unable to get episode with ID %s: %w", episodeID, err)
 	}
 
-	startTime := scalarutils.DateTime(time.Now().Format(timeFormatStr))
-	if episodePayload.Resource.Period != nil {
-		startTime = episodePayload.Resource.Period.Start
+	period, err := fh.endPeriod(ctx, episodePayload.Resource.Period, endTime)
+	if err != nil {
+		return false, fmt.Errorf("unable to end episode %s: %w", episodeID, err)
 	}
 
-	// // workaround for odd date comparison behavior on the Google Cloud Healthcare API
-	// the end time must be at least 24 hours after the start time
-	// so: if the time now is less than 24 hours after start, set the end to be
-	// 24 hours after the start of the visit. If the time now is more than 24 hours
-	// after the start, use the current time as the end of the visit
-	end := time.Now().Add(time.Hour * 24)
-	endTime := scalarutils.DateTime(end.Format(timeFormatStr))
-
 	updatedStatus := domain.EpisodeOfCareStatusEnumFinished
 	episodePayload.Resource.Status = &updatedStatus
-	episodePayload.Resource.Period.Start = startTime
-	episodePayload.Resource.Period.End = endTime
+	episodePayload.Resource.Period = period
 
 	payload, err := converterandformatter.StructToMap(episodePayload.Resource)
 	if err != nil {
@@ -569,7 +652,7 @@ func (fh StoreImpl) EndEpisode(
 
 	episode := &domain.FHIREpisodeOfCare{}
 
-	err = fh.Dataset.UpdateFHIRResource(episodeOfCareResourceType, episodeID, payload, episode)
+	err = fh.Dataset.UpdateFHIRResource(ctx, episodeOfCareResourceType, episodeID, payload, episode)
 	if err != nil {
 		return false, fmt.Errorf("unable to create/update %s resource: %w", episodeOfCareResourceType, err)
 	}
@@ -577,14 +660,36 @@ func (fh StoreImpl) EndEpisode(
 	return true, nil
 }
 
-// GetActiveEpisode returns any ACTIVE episode that has to the indicated ID
-func (fh StoreImpl) GetActiveEpisode(_ context.Context, episodeID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCare, error) {
-	params := map[string]interface{}{
-		"status:exact": domain.EpisodeOfCareStatusEnumActive.String(),
-		"_id":          episodeID,
+// endPeriod sets the end of a period, in the time zone of the facility. A
+// period that has no start cannot be ended since its length is unknown
+func (fh StoreImpl) endPeriod(ctx context.Context, period *domain.FHIRPeriod, endTime time.Time) (*domain.FHIRPeriod, error) {
+	if period == nil || period.Start == "" {
+		return nil, fmt.Errorf("the period has no start")
+	}
+
+	end := domain.FormatDateTime(endTime.In(helpers.FacilityLocation(ctx, fh.organizations())))
+
+	start, err := domain.ParseDateTime(string(period.Start))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the start of the period: %w", err)
 	}
 
-	resources, err := fh.Dataset.SearchFHIRResource(episodeOfCareResourceType, params, tenant, pagination)
+	if endTime.Before(start) {
+		return nil, fmt.Errorf("the end %s is before the start %s", end, period.Start)
+	}
+
+	period.End = end
+
+	return period, nil
+}
+
+// GetActiveEpisode returns any ACTIVE episode that has to the indicated ID
+func (fh StoreImpl) GetActiveEpisode(ctx context.Context, episodeID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCare, error) {
+	params := domain.NewSearchParams().
+		Exact("status", domain.EpisodeOfCareStatusEnumActive.String()).
+		ID(episodeID)
+
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, episodeOfCareResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -610,10 +715,10 @@ func (fh StoreImpl) GetActiveEpisode(_ context.Context, episodeID string, tenant
 }
 
 // SearchFHIRServiceRequest provides a search API for FHIRServiceRequest
-func (fh StoreImpl) SearchFHIRServiceRequest(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error) {
+func (fh StoreImpl) SearchFHIRServiceRequest(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error) {
 	output := domain.FHIRServiceRequestRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(serviceRequestResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, serviceRequestResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -641,7 +746,7 @@ func (fh StoreImpl) SearchFHIRServiceRequest(_ context.Context, params map[strin
 }
 
 // CreateFHIRServiceRequest creates a FHIRServiceRequest instance
-func (fh StoreImpl) CreateFHIRServiceRequest(_ context.Context, input domain.FHIRServiceRequestInput) (*domain.FHIRServiceRequestRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRServiceRequest(ctx context.Context, input domain.FHIRServiceRequestInput) (*domain.FHIRServiceRequestRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", serviceRequestResourceType, err)
@@ -649,7 +754,7 @@ func (fh StoreImpl) CreateFHIRServiceRequest(_ context.Context, input domain.FHI
 
 	resource := &domain.FHIRServiceRequest{}
 
-	err = fh.Dataset.CreateFHIRResource(serviceRequestResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, serviceRequestResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", serviceRequestResourceType, err)
 	}
@@ -662,8 +767,8 @@ func (fh StoreImpl) CreateFHIRServiceRequest(_ context.Context, input domain.FHI
 }
 
 // SearchFHIRAllergyIntolerance provides a search API for FHIRAllergyIntolerance
-func (fh StoreImpl) SearchFHIRAllergyIntolerance(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
-	resources, err := fh.Dataset.SearchFHIRResource(allergyIntoleranceResourceType, params, tenant, pagination)
+func (fh StoreImpl) SearchFHIRAllergyIntolerance(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, allergyIntoleranceResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -698,7 +803,7 @@ func (fh StoreImpl) SearchFHIRAllergyIntolerance(_ context.Context, params map[s
 }
 
 // CreateFHIRAllergyIntolerance creates a FHIRAllergyIntolerance instance
-func (fh StoreImpl) CreateFHIRAllergyIntolerance(_ context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRAllergyIntolerance(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", allergyIntoleranceResourceType, err)
@@ -706,7 +811,7 @@ func (fh StoreImpl) CreateFHIRAllergyIntolerance(_ context.Context, input domain
 
 	resource := &domain.FHIRAllergyIntolerance{}
 
-	err = fh.Dataset.CreateFHIRResource(allergyIntoleranceResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, allergyIntoleranceResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", allergyIntoleranceResourceType, err)
 	}
@@ -720,7 +825,7 @@ func (fh StoreImpl) CreateFHIRAllergyIntolerance(_ context.Context, input domain
 
 // UpdateFHIRAllergyIntolerance updates a FHIRAllergyIntolerance instance
 // The resource must have its ID set.
-func (fh StoreImpl) UpdateFHIRAllergyIntolerance(_ context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
+func (fh StoreImpl) UpdateFHIRAllergyIntolerance(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
 	if input.ID == nil {
 		return nil, fmt.Errorf("can't update with a nil ID")
 	}
@@ -732,7 +837,7 @@ func (fh StoreImpl) UpdateFHIRAllergyIntolerance(_ context.Context, input domain
 
 	resource := &domain.FHIRAllergyIntolerance{}
 
-	err = fh.Dataset.UpdateFHIRResource(allergyIntoleranceResourceType, *input.ID, payload, resource)
+	err = fh.Dataset.UpdateFHIRResource(ctx, allergyIntoleranceResourceType, *input.ID, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", allergyIntoleranceResourceType, err)
 	}
@@ -745,10 +850,10 @@ func (fh StoreImpl) UpdateFHIRAllergyIntolerance(_ context.Context, input domain
 }
 
 // SearchFHIRComposition provides a search API for FHIRComposition
-func (fh StoreImpl) SearchFHIRComposition(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error) {
+func (fh StoreImpl) SearchFHIRComposition(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error) {
 	output := domain.FHIRCompositionRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(compositionResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, compositionResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -776,7 +881,7 @@ func (fh StoreImpl) SearchFHIRComposition(_ context.Context, params map[string]i
 }
 
 // CreateFHIRComposition creates a FHIRComposition instance
-func (fh StoreImpl) CreateFHIRComposition(_ context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRComposition(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", compositionResourceType, err)
@@ -784,7 +889,7 @@ func (fh StoreImpl) CreateFHIRComposition(_ context.Context, input domain.FHIRCo
 
 	resource := &domain.FHIRComposition{}
 
-	err = fh.Dataset.CreateFHIRResource(compositionResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, compositionResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", compositionResourceType, err)
 	}
@@ -798,7 +903,7 @@ func (fh StoreImpl) CreateFHIRComposition(_ context.Context, input domain.FHIRCo
 
 // UpdateFHIRComposition updates a FHIRComposition instance
 // The resource must have its ID set.
-func (fh StoreImpl) UpdateFHIRComposition(_ context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error) {
+func (fh StoreImpl) UpdateFHIRComposition(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error) {
 	if input.ID == nil {
 		return nil, fmt.Errorf("can't update with a nil ID")
 	}
@@ -810,7 +915,7 @@ func (fh StoreImpl) UpdateFHIRComposition(_ context.Context, input domain.FHIRCo
 
 	resource := &domain.FHIRComposition{}
 
-	err = fh.Dataset.UpdateFHIRResource(compositionResourceType, *input.ID, payload, resource)
+	err = fh.Dataset.UpdateFHIRResource(ctx, compositionResourceType, *input.ID, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", compositionResourceType, err)
 	}
@@ -823,8 +928,8 @@ func (fh StoreImpl) UpdateFHIRComposition(_ context.Context, input domain.FHIRCo
 }
 
 // DeleteFHIRComposition deletes the FHIRComposition identified by the supplied ID
-func (fh StoreImpl) DeleteFHIRComposition(_ context.Context, id string) (bool, error) {
-	err := fh.Dataset.DeleteFHIRResource(compositionResourceType, id)
+func (fh StoreImpl) DeleteFHIRComposition(ctx context.Context, id string) (bool, error) {
+	err := fh.Dataset.DeleteFHIRResource(ctx, compositionResourceType, id)
 	if err != nil {
 		return false, fmt.Errorf(
 			"unable to delete %s, error: %w",
@@ -836,8 +941,8 @@ func (fh StoreImpl) DeleteFHIRComposition(_ context.Context, id string) (bool, e
 }
 
 // SearchFHIRCondition provides a search API for FHIRCondition
-func (fh StoreImpl) SearchFHIRCondition(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
-	resources, err := fh.Dataset.SearchFHIRResource(conditionResourceType, params, tenant, pagination)
+func (fh StoreImpl) SearchFHIRCondition(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, conditionResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -872,12 +977,10 @@ func (fh StoreImpl) SearchFHIRCondition(_ context.Context, params map[string]int
 }
 
 // SearchPatientAllergyIntolerance searches for a patient's FHIR allergy intolerance using patient ID
-func (fh StoreImpl) SearchPatientAllergyIntolerance(_ context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
-	params := map[string]interface{}{
-		"patient": patientReference,
-	}
+func (fh StoreImpl) SearchPatientAllergyIntolerance(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+	params := domain.NewSearchParams().Reference("patient", patientReference)
 
-	resources, err := fh.Dataset.SearchFHIRResource(allergyIntoleranceResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, allergyIntoleranceResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -913,7 +1016,7 @@ func (fh StoreImpl) SearchPatientAllergyIntolerance(_ context.Context, patientRe
 
 // UpdateFHIRCondition updates a FHIRCondition instance
 // The resource must have its ID set.
-func (fh StoreImpl) UpdateFHIRCondition(_ context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error) {
+func (fh StoreImpl) UpdateFHIRCondition(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error) {
 	if input.ID == nil {
 		return nil, fmt.Errorf("can't update with a nil ID")
 	}
@@ -925,7 +1028,7 @@ func (fh StoreImpl) UpdateFHIRCondition(_ context.Context, input domain.FHIRCond
 
 	resource := &domain.FHIRCondition{}
 
-	err = fh.Dataset.UpdateFHIRResource(conditionResourceType, *input.ID, payload, resource)
+	err = fh.Dataset.UpdateFHIRResource(ctx, conditionResourceType, *input.ID, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", conditionResourceType, err)
 	}
@@ -938,10 +1041,10 @@ func (fh StoreImpl) UpdateFHIRCondition(_ context.Context, input domain.FHIRCond
 }
 
 // GetFHIREncounter retrieves instances of FHIREncounter by ID
-func (fh StoreImpl) GetFHIREncounter(_ context.Context, id string) (*domain.FHIREncounterRelayPayload, error) {
+func (fh StoreImpl) GetFHIREncounter(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error) {
 	resource := &domain.FHIREncounter{}
 
-	err := fh.Dataset.GetFHIRResource(encounterResourceType, id, resource)
+	err := fh.Dataset.GetFHIRResource(ctx, encounterResourceType, id, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get %s with ID %s, err: %w", encounterResourceType, id, err)
 	}
@@ -953,9 +1056,53 @@ func (fh StoreImpl) GetFHIREncounter(_ context.Context, id string) (*domain.FHIR
 	return payload, nil
 }
 
+// GetFHIREncounterWithPatient retrieves an encounter by ID together with the
+// patient that it is for, which is included in the search for the encounter
+func (fh StoreImpl) GetFHIREncounterWithPatient(
+	ctx context.Context, id string, tenant dto.TenantIdentifiers,
+) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
+	params := domain.NewSearchParams().ID(id).Include(encounterResourceType, "patient")
+
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, encounterResourceType, params, tenant, dto.Pagination{Skip: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get %s with ID %s, err: %w", encounterResourceType, id, err)
+	}
+
+	if len(resources.Resources) == 0 {
+		return nil, nil, fmt.Errorf("%s with ID %s not found", encounterResourceType, id)
+	}
+
+	encounter := &domain.FHIREncounter{}
+
+	err = decodeResource(resources.Resources[0], encounter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("server error: Unable to unmarshal %s: %w", encounterResourceType, err)
+	}
+
+	if encounter.Subject == nil || encounter.Subject.ID == nil {
+		return nil, nil, fmt.Errorf("%s with ID %s has no subject", encounterResourceType, id)
+	}
+
+	reference := fmt.Sprintf("%s/%s", patientResourceType, *encounter.Subject.ID)
+
+	result, ok := resources.Included[reference]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s of %s with ID %s not found", reference, encounterResourceType, id)
+	}
+
+	patient := &domain.FHIRPatient{}
+
+	err = decodeResource(result, patient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("server error: Unable to unmarshal %s: %w", patientResourceType, err)
+	}
+
+	return &domain.FHIREncounterRelayPayload{Resource: encounter}, &domain.FHIRPatientRelayPayload{Resource: patient}, nil
+}
+
 // SearchFHIREncounter provides a search API for FHIREncounter
-func (fh StoreImpl) SearchFHIREncounter(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
-	resources, err := fh.Dataset.SearchFHIRResource(encounterResourceType, params, tenant, pagination)
+func (fh StoreImpl) SearchFHIREncounter(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, encounterResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -990,10 +1137,10 @@ func (fh StoreImpl) SearchFHIREncounter(_ context.Context, params map[string]int
 }
 
 // SearchFHIRMedicationRequest provides a search API for FHIRMedicationRequest
-func (fh StoreImpl) SearchFHIRMedicationRequest(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error) {
+func (fh StoreImpl) SearchFHIRMedicationRequest(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error) {
 	output := domain.FHIRMedicationRequestRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(medicationRequestResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, medicationRequestResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -1021,7 +1168,7 @@ func (fh StoreImpl) SearchFHIRMedicationRequest(_ context.Context, params map[st
 }
 
 // CreateFHIRMedicationRequest creates a FHIRMedicationRequest instance
-func (fh StoreImpl) CreateFHIRMedicationRequest(_ context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRMedicationRequest(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", medicationRequestResourceType, err)
@@ -1029,7 +1176,7 @@ func (fh StoreImpl) CreateFHIRMedicationRequest(_ context.Context, input domain.
 
 	resource := &domain.FHIRMedicationRequest{}
 
-	err = fh.Dataset.CreateFHIRResource(medicationRequestResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, medicationRequestResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", medicationRequestResourceType, err)
 	}
@@ -1043,7 +1190,7 @@ func (fh StoreImpl) CreateFHIRMedicationRequest(_ context.Context, input domain.
 
 // UpdateFHIRMedicationRequest updates a FHIRMedicationRequest instance
 // The resource must have its ID set.
-func (fh StoreImpl) UpdateFHIRMedicationRequest(_ context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error) {
+func (fh StoreImpl) UpdateFHIRMedicationRequest(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error) {
 	if input.ID == nil {
 		return nil, fmt.Errorf("can't update with a nil ID")
 	}
@@ -1055,7 +1202,7 @@ func (fh StoreImpl) UpdateFHIRMedicationRequest(_ context.Context, input domain.
 
 	resource := &domain.FHIRMedicationRequest{}
 
-	err = fh.Dataset.UpdateFHIRResource(medicationRequestResourceType, *input.ID, payload, resource)
+	err = fh.Dataset.UpdateFHIRResource(ctx, medicationRequestResourceType, *input.ID, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", medicationRequestResourceType, err)
 	}
@@ -1068,8 +1215,8 @@ func (fh StoreImpl) UpdateFHIRMedicationRequest(_ context.Context, input domain.
 }
 
 // DeleteFHIRMedicationRequest deletes the FHIRMedicationRequest identified by the supplied ID
-func (fh StoreImpl) DeleteFHIRMedicationRequest(_ context.Context, id string) (bool, error) {
-	err := fh.Dataset.DeleteFHIRResource(medicationRequestResourceType, id)
+func (fh StoreImpl) DeleteFHIRMedicationRequest(ctx context.Context, id string) (bool, error) {
+	err := fh.Dataset.DeleteFHIRResource(ctx, medicationRequestResourceType, id)
 	if err != nil {
 		return false, fmt.Errorf(
 			"unable to delete %s, error: %w",
@@ -1081,10 +1228,10 @@ func (fh StoreImpl) DeleteFHIRMedicationRequest(_ context.Context, id string) (b
 }
 
 // SearchFHIRObservation provides a search API for FHIRObservation
-func (fh StoreImpl) SearchFHIRObservation(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+func (fh StoreImpl) SearchFHIRObservation(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 	output := domain.FHIRObservationRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(observationResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, observationResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -1112,7 +1259,7 @@ func (fh StoreImpl) SearchFHIRObservation(_ context.Context, params map[string]i
 }
 
 // CreateFHIRObservation creates a FHIRObservation instance
-func (fh StoreImpl) CreateFHIRObservation(_ context.Context, input domain.FHIRObservationInput) (*domain.FHIRObservationRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRObservation(ctx context.Context, input domain.FHIRObservationInput) (*domain.FHIRObservationRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", observationResourceType, err)
@@ -1120,7 +1267,7 @@ func (fh StoreImpl) CreateFHIRObservation(_ context.Context, input domain.FHIROb
 
 	resource := &domain.FHIRObservation{}
 
-	err = fh.Dataset.CreateFHIRResource(observationResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, observationResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", observationResourceType, err)
 	}
@@ -1133,8 +1280,8 @@ func (fh StoreImpl) CreateFHIRObservation(_ context.Context, input domain.FHIROb
 }
 
 // DeleteFHIRObservation deletes the FHIRObservation identified by the passed ID
-func (fh StoreImpl) DeleteFHIRObservation(_ context.Context, id string) (bool, error) {
-	err := fh.Dataset.DeleteFHIRResource(observationResourceType, id)
+func (fh StoreImpl) DeleteFHIRObservation(ctx context.Context, id string) (bool, error) {
+	err := fh.Dataset.DeleteFHIRResource(ctx, observationResourceType, id)
 	if err != nil {
 		return false, fmt.Errorf(
 			"unable to delete %s, error: %w",
@@ -1146,10 +1293,10 @@ func (fh StoreImpl) DeleteFHIRObservation(_ context.Context, id string) (bool, e
 }
 
 // GetFHIRPatient retrieves instances of FHIRPatient by ID
-func (fh StoreImpl) GetFHIRPatient(_ context.Context, id string) (*domain.FHIRPatientRelayPayload, error) {
+func (fh StoreImpl) GetFHIRPatient(ctx context.Context, id string) (*domain.FHIRPatientRelayPayload, error) {
 	resource := &domain.FHIRPatient{}
 
-	err := fh.Dataset.GetFHIRResource(patientResourceType, id, resource)
+	err := fh.Dataset.GetFHIRResource(ctx, patientResourceType, id, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get %s with ID %s, err: %w", patientResourceType, id, err)
 	}
@@ -1161,170 +1308,234 @@ func (fh StoreImpl) GetFHIRPatient(_ context.Context, id string) (*domain.FHIRPa
 	return payload, nil
 }
 
-// DeleteFHIRPatient deletes the FHIRPatient identified by the supplied ID
-func (fh StoreImpl) DeleteFHIRPatient(_ context.Context, id string) (bool, error) {
-	patientEverythingBs, err := fh.Dataset.GetFHIRPatientAllData(id)
+// GetFHIRPatientEverything returns every resource in a patient's compartment,
+// the patient and the resources that reference it, as a `collection` Bundle.
+// The patient has to belong to the tenant. `$everything` cannot be filtered by
+// tags, so resources that are not tagged with the tenant's organisation are
+// left out
+func (fh StoreImpl) GetFHIRPatientEverything(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRBundle, error) {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, patientResourceType, domain.NewSearchParams().ID(id), tenant, dto.Pagination{Skip: true})
 	if err != nil {
-		return false, fmt.Errorf("unable to get patient's compartment: %w", err)
+		return nil, fmt.Errorf("unable to get %s with ID %s, err: %w", patientResourceType, id, err)
 	}
 
-	var patientEverything map[string]interface{}
+	if len(resources.Resources) == 0 {
+		return nil, fmt.Errorf("%s with ID %s: %w", patientResourceType, id, domain.ErrFHIRResourceNotFound)
+	}
 
-	err = json.Unmarshal(patientEverythingBs, &patientEverything)
+	everythingBs, err := fh.Dataset.GetFHIRPatientAllData(ctx, id)
 	if err != nil {
-		return false, fmt.Errorf("unable to unmarshal patient everything")
+		return nil, fmt.Errorf("unable to get patient's compartment: %w", err)
 	}
 
-	respEntries := patientEverything["entry"]
-	if respEntries == nil {
-		return false, nil
+	everything := struct {
+		Entry []domain.FHIRBundleEntry `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(everythingBs, &everything)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal patient everything: %w", err)
 	}
 
-	entries, ok := respEntries.([]interface{})
-	if !ok {
-		return false, fmt.Errorf(
-			"server error: entries is not a list of maps, it is: %T", respEntries)
+	bundle := &domain.FHIRBundle{
+		ResourceType: "Bundle",
+		Type:         domain.FHIRBundleTypeEnumCollection,
+		Entry:        []domain.FHIRBundleEntry{},
 	}
 
-	// This list stores assorted ResourceTypes and ResourceIDs found in an Encounter
-	// i.e. Observations, Medication Request etc
-	assortedResourceTypes := []map[string]string{}
+	// the patient's records from every facility of the organisation are kept
+	organisation := dto.TenantIdentifiers{OrganizationID: tenant.OrganizationID}
 
-	// This list stores all the encounters ResourceType and ResourceID in a patient's compartment
-	encounters := []map[string]string{}
+	for _, entry := range everything.Entry {
+		if entry.Resource == nil || !organisation.Tagged(entry.Resource) {
+			continue
+		}
 
-	// This list stores all the Episodesofcare ResourceType and ResourceIDs in a patient's compartment
-	episodesOfCare := []map[string]string{}
+		bundle.Entry = append(bundle.Entry, domain.FHIRBundleEntry{
+			FullURL:  entry.FullURL,
+			Resource: entry.Resource,
+		})
+	}
 
-	// This list stores the patient ResourceType and ResourceID
-	patient := []map[string]string{}
+	return bundle, nil
+}
 
-	// This list stores all the observations resource types
-	observations := []map[string]string{}
+// RetireFHIRPatient marks one of the tenant's patients as no longer in active
+// use, recording the reason and time. The patient is hidden from patient
+// searches but none of their data is removed.
+//
+// The patient is only updated if it has not changed since it was read,
+// otherwise an error wrapping `domain.ErrFHIRVersionConflict` is returned.
+func (fh StoreImpl) RetireFHIRPatient(ctx context.Context, id, reason string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	return fh.changeFHIRPatient(ctx, id, tenant, func(patient *domain.FHIRPatient) error {
+		if _, _, retired := patient.Retirement(); retired {
+			return fmt.Errorf("%s with ID %s is already retired", patientResourceType, id)
+		}
 
-	medicationRequests := []map[string]string{}
+		patient.Retire(reason, fh.clock().Now())
 
-	for _, en := range entries {
-		entry, ok := en.(map[string]interface{})
-		if !ok {
-			return false, fmt.Errorf(
-				"server error: expected each entry to be map, they are %T instead",
-				en,
-			)
-		}
+		return nil
+	})
+}
 
-		resource, ok := entry["resource"]
-		if !ok {
-			{
-				return false, fmt.Errorf(
-					"server error: result entry %#v is not a map",
-					entry["resource"],
-				)
-			}
+// ReinstateFHIRPatient puts a retired patient back into active use.
+//
+// The patient is only updated if it has not changed since it was read,
+// otherwise an error wrapping `domain.ErrFHIRVersionConflict` is returned.
+func (fh StoreImpl) ReinstateFHIRPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	return fh.changeFHIRPatient(ctx, id, tenant, func(patient *domain.FHIRPatient) error {
+		if _, _, retired := patient.Retirement(); !retired {
+			return fmt.Errorf("%s with ID %s is not retired", patientResourceType, id)
 		}
 
-		resourceMap, ok := resource.(map[string]interface{})
-		if !ok {
-			return false, fmt.Errorf(
-				"server error: expected each entry to be map, they are %T instead",
-				resource,
-			)
+		if targetID, _, _, merged := patient.Merge(); merged {
+			return fmt.Errorf("%s with ID %s is merged into %s/%s and has to be unmerged first", patientResourceType, id, patientResourceType, targetID)
 		}
 
-		resourceType := resourceMap["resourceType"].(string)
+		patient.Reinstate()
 
-		resourceTypeIDMap := map[string]string{
-			"resourceType": resourceType,
-			"resourceID":   resourceMap["id"].(string),
-		}
-
-		switch resourceType {
-		case encounterResourceType:
-			encounters = append(
-				encounters,
-				resourceTypeIDMap,
-			)
+		return nil
+	})
+}
 
-			continue
+// changeFHIRPatient reads one of the tenant's patients, applies the change and
+// writes it back at the version that was read
+func (fh StoreImpl) changeFHIRPatient(
+	ctx context.Context, id string, tenant dto.TenantIdentifiers, change func(patient *domain.FHIRPatient) error,
+) (*domain.FHIRPatient, error) {
+	patient, err := fh.getTenantFHIRPatient(ctx, id, tenant)
+	if err != nil {
+		return nil, err
+	}
 
-		case episodeOfCareResourceType:
-			episodesOfCare = append(
-				episodesOfCare,
-				resourceTypeIDMap,
-			)
+	err = change(patient)
+	if err != nil {
+		return nil, err
+	}
 
-			continue
+	payload, err := converterandformatter.StructToMap(patient)
+	if err != nil {
+		return nil, fmt.Errorf("unable to turn the updated %s into a map: %w", patientResourceType, err)
+	}
 
-		case patientResourceType:
-			patient = append(
-				patient,
-				resourceTypeIDMap,
-			)
+	updated := &domain.FHIRPatient{}
 
-			continue
+	err = fh.Dataset.UpdateFHIRResource(ctx, patientResourceType, id, payload, updated)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update %s resource: %w", patientResourceType, err)
+	}
 
-		case observationResourceType:
-			observations = append(
-				observations,
-				resourceTypeIDMap,
-			)
+	return updated, nil
+}
 
-			continue
+// getTenantFHIRPatient reads a patient, including its version, if it belongs
+// to the tenant. It fails with `domain.ErrFHIRResourceNotFound` otherwise
+func (fh StoreImpl) getTenantFHIRPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, patientResourceType, domain.NewSearchParams().ID(id), tenant, dto.Pagination{Skip: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %s with ID %s, err: %w", patientResourceType, id, err)
+	}
 
-		case medicationRequestResourceType:
-			medicationRequests = append(
-				medicationRequests,
-				resourceTypeIDMap,
-			)
+	if len(resources.Resources) == 0 {
+		return nil, fmt.Errorf("%s with ID %s: %w", patientResourceType, id, domain.ErrFHIRResourceNotFound)
+	}
 
-			continue
-		}
+	patient := &domain.FHIRPatient{}
 
-		assortedResourceTypes = append(assortedResourceTypes, resourceTypeIDMap)
+	err = decodeResource(resources.Resources[0], patient)
+	if err != nil {
+		return nil, fmt.Errorf("server error: Unable to unmarshal %s: %w", patientResourceType, err)
 	}
 
-	// Special case, a medication request causes the failure for deleting a FHIR Condition
-	if err = fh.DeleteFHIRResourceType(medicationRequests); err != nil {
-		return false, err
+	return patient, nil
+}
+
+// GetFHIRPatientTimeline retrieves the allergies, observations and medication
+// statements of a patient. They are reverse included in a search for the
+// patient within the organisation, and the records of other facilities are
+// left out
+func (fh StoreImpl) GetFHIRPatientTimeline(ctx context.Context, patientID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatientTimeline, error) {
+	timeline := &domain.FHIRPatientTimeline{
+		AllergyIntolerances:  []domain.FHIRAllergyIntolerance{},
+		Observations:         []domain.FHIRObservation{},
+		MedicationStatements: []domain.FHIRMedicationStatement{},
 	}
 
-	// Order of deletion matters to avoid conflicts
-	// First delete the ResourceTypes found in an encounter
-	if err = fh.DeleteFHIRResourceType(assortedResourceTypes); err != nil {
-		return false, err
+	records := map[string]func(result map[string]interface{}) error{
+		allergyIntoleranceResourceType: func(result map[string]interface{}) error {
+			var resource domain.FHIRAllergyIntolerance
+
+			err := decodeResource(result, &resource)
+			timeline.AllergyIntolerances = append(timeline.AllergyIntolerances, resource)
+
+			return err
+		},
+		observationResourceType: func(result map[string]interface{}) error {
+			var resource domain.FHIRObservation
+
+			err := decodeResource(result, &resource)
+			timeline.Observations = append(timeline.Observations, resource)
+
+			return err
+		},
+		medicationStatementResourceType: func(result map[string]interface{}) error {
+			var resource domain.FHIRMedicationStatement
+
+			err := decodeResource(result, &resource)
+			timeline.MedicationStatements = append(timeline.MedicationStatements, resource)
+
+			return err
+		},
 	}
 
-	// Secondly, delete the encounters. This will bring no conflict
-	// as it ensures ResourceType that refers to the encounter is not found
-	if err = fh.DeleteFHIRResourceType(encounters); err != nil {
-		return false, err
+	resourceTypes := []string{allergyIntoleranceResourceType, observationResourceType, medicationStatementResourceType}
+	params := domain.NewSearchParams().ID(patientID)
+
+	for _, resourceType := range resourceTypes {
+		params = params.RevInclude(resourceType, "patient")
 	}
 
-	// Thirdly, delete the episodes of care. This will bring no conflict
-	// as it ensures Encounter that refers to the EpisodeOfCare is not found
-	if err = fh.DeleteFHIRResourceType(episodesOfCare); err != nil {
-		return false, err
+	// the patient may have been registered at another facility of the organisation
+	organisation := dto.TenantIdentifiers{OrganizationID: tenant.OrganizationID}
+
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, patientResourceType, params, organisation, dto.Pagination{Skip: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to search the timeline of %s with ID %s: %w", patientResourceType, patientID, err)
 	}
 
-	if err = fh.DeleteFHIRResourceType(observations); err != nil {
-		return false, err
+	for _, resourceType := range resourceTypes {
+		record := records[resourceType]
+
+		for _, result := range includedResources(resources, resourceType, tenant) {
+			err := record(result)
+			if err != nil {
+				return nil, fmt.Errorf("server error: Unable to unmarshal %s: %w", resourceType, err)
+			}
+		}
 	}
 
-	// Finally delete the patient ResourceType
-	if err = fh.DeleteFHIRResourceType(patient); err != nil {
+	return timeline, nil
+}
+
+// DeleteFHIRPatient deletes the FHIRPatient identified by the supplied ID
+// together with the resources in the patient's compartment. It reports false
+// if there was nothing to delete
+func (fh StoreImpl) DeleteFHIRPatient(ctx context.Context, id string) (bool, error) {
+	deletion, err := fh.cascadeDeleteFHIRPatient(ctx, id, false)
+	if err != nil {
 		return false, err
 	}
 
-	return true, nil
+	return len(deletion.Deleted) > 0, nil
 }
 
 // DeleteFHIRResourceType takes a ResourceType and ID and deletes them from FHIR
-func (fh StoreImpl) DeleteFHIRResourceType(results []map[string]string) error {
+func (fh StoreImpl) DeleteFHIRResourceType(ctx context.Context, results []map[string]string) error {
 	for _, result := range results {
 		resourceType := result["resourceType"]
 		resourceID := result["resourceID"]
 
-		err := fh.Dataset.DeleteFHIRResource(
+		err := fh.Dataset.DeleteFHIRResource(ctx,
 			resourceType,
 			resourceID,
 		)
@@ -1340,8 +1551,8 @@ func (fh StoreImpl) DeleteFHIRResourceType(results []map[string]string) error {
 }
 
 // DeleteFHIRServiceRequest deletes the FHIRServiceRequest identified by the supplied ID
-func (fh StoreImpl) DeleteFHIRServiceRequest(_ context.Context, id string) (bool, error) {
-	err := fh.Dataset.DeleteFHIRResource(serviceRequestResourceType, id)
+func (fh StoreImpl) DeleteFHIRServiceRequest(ctx context.Context, id string) (bool, error) {
+	err := fh.Dataset.DeleteFHIRResource(ctx, serviceRequestResourceType, id)
 	if err != nil {
 		return false, fmt.Errorf(
 			"unable to delete %s, error: %w",
@@ -1353,7 +1564,7 @@ func (fh StoreImpl) DeleteFHIRServiceRequest(_ context.Context, id string) (bool
 }
 
 // CreateFHIRMedicationStatement creates a new FHIR Medication statement instance
-func (fh StoreImpl) CreateFHIRMedicationStatement(_ context.Context, input domain.FHIRMedicationStatementInput) (*domain.FHIRMedicationStatementRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRMedicationStatement(ctx context.Context, input domain.FHIRMedicationStatementInput) (*domain.FHIRMedicationStatementRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", medicationStatementResourceType, err)
@@ -1361,7 +1572,7 @@ func (fh StoreImpl) CreateFHIRMedicationStatement(_ context.Context, input domai
 
 	resource := &domain.FHIRMedicationStatement{}
 
-	err = fh.Dataset.CreateFHIRResource(medicationStatementResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, medicationStatementResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", medicationStatementResourceType, err)
 	}
@@ -1374,7 +1585,7 @@ func (fh StoreImpl) CreateFHIRMedicationStatement(_ context.Context, input domai
 }
 
 // CreateFHIRMedication creates a new FHIR Medication instance
-func (fh StoreImpl) CreateFHIRMedication(_ context.Context, input domain.FHIRMedicationInput) (*domain.FHIRMedicationRelayPayload, error) {
+func (fh StoreImpl) CreateFHIRMedication(ctx context.Context, input domain.FHIRMedicationInput) (*domain.FHIRMedicationRelayPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", medicationResourceType, err)
@@ -1382,7 +1593,7 @@ func (fh StoreImpl) CreateFHIRMedication(_ context.Context, input domain.FHIRMed
 
 	resource := &domain.FHIRMedication{}
 
-	err = fh.Dataset.CreateFHIRResource(medicationResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, medicationResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create/update %s resource: %w", medicationResourceType, err)
 	}
@@ -1395,10 +1606,10 @@ func (fh StoreImpl) CreateFHIRMedication(_ context.Context, input domain.FHIRMed
 }
 
 // SearchFHIRMedicationStatement used to search for a fhir medication statement
-func (fh StoreImpl) SearchFHIRMedicationStatement(_ context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+func (fh StoreImpl) SearchFHIRMedicationStatement(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 	output := domain.FHIRMedicationStatementRelayConnection{}
 
-	resources, err := fh.Dataset.SearchFHIRResource(medicationStatementResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, medicationStatementResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -1426,7 +1637,7 @@ func (fh StoreImpl) SearchFHIRMedicationStatement(_ context.Context, params map[
 }
 
 // CreateFHIRPatient creates a patient on FHIR
-func (fh StoreImpl) CreateFHIRPatient(_ context.Context, input domain.FHIRPatientInput) (*domain.PatientPayload, error) {
+func (fh StoreImpl) CreateFHIRPatient(ctx context.Context, input domain.FHIRPatientInput) (*domain.PatientPayload, error) {
 	payload, err := converterandformatter.StructToMap(input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to turn %s input into a map: %w", patientResourceType, err)
@@ -1434,7 +1645,7 @@ func (fh StoreImpl) CreateFHIRPatient(_ context.Context, input domain.FHIRPatien
 
 	resource := &domain.FHIRPatient{}
 
-	err = fh.Dataset.CreateFHIRResource(patientResourceType, payload, resource)
+	err = fh.Dataset.CreateFHIRResource(ctx, patientResourceType, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create %s resource: %w", patientResourceType, err)
 	}
@@ -1447,10 +1658,10 @@ func (fh StoreImpl) CreateFHIRPatient(_ context.Context, input domain.FHIRPatien
 }
 
 // PatchFHIRPatient is used to patch a patient resource
-func (fh StoreImpl) PatchFHIRPatient(_ context.Context, id string, params []map[string]interface{}) (*domain.FHIRPatient, error) {
+func (fh StoreImpl) PatchFHIRPatient(ctx context.Context, id string, params []map[string]interface{}) (*domain.FHIRPatient, error) {
 	resource := &domain.FHIRPatient{}
 
-	err := fh.Dataset.PatchFHIRResource(patientResourceType, id, params, resource)
+	err := fh.Dataset.PatchFHIRResource(ctx, patientResourceType, id, params, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to patch %s resource: %w", patientResourceType, err)
 	}
@@ -1458,15 +1669,18 @@ func (fh StoreImpl) PatchFHIRPatient(_ context.Context, id string, params []map[
 	return resource, nil
 }
 
-// UpdateFHIREpisodeOfCare updates a fhir episode of care
-func (fh StoreImpl) UpdateFHIREpisodeOfCare(_ context.Context, fhirResourceID string, payload map[string]interface{}) (*domain.FHIREpisodeOfCare, error) {
+// UpdateFHIREpisodeOfCare updates a fhir episode of care.
+//
+// If the payload has a `meta.versionId` the episode is only updated if it is
+// still at that version.
+func (fh StoreImpl) UpdateFHIREpisodeOfCare(ctx context.Context, fhirResourceID string, payload map[string]interface{}) (*domain.FHIREpisodeOfCare, error) {
 	if fhirResourceID == "" {
 		return nil, fmt.Errorf("can't update with a nil ID")
 	}
 
 	resource := &domain.FHIREpisodeOfCare{}
 
-	err := fh.Dataset.UpdateFHIRResource(episodeOfCareResourceType, fhirResourceID, payload, resource)
+	err := fh.Dataset.UpdateFHIRResource(ctx, episodeOfCareResourceType, fhirResourceID, payload, resource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to update %s resource: %w", episodeOfCareResourceType, err)
 	}
@@ -1474,13 +1688,13 @@ func (fh StoreImpl) UpdateFHIREpisodeOfCare(_ context.Context, fhirResourceID st
 	return resource, nil
 }
 
-// SearchFHIRPatient searches for a FHIR patient
-func (fh StoreImpl) SearchFHIRPatient(_ context.Context, searchParams string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PatientConnection, error) {
-	params := map[string]interface{}{
-		"_content": searchParams,
-	}
+// SearchFHIRPatient searches for a FHIR patient. Retired patients are not
+// returned
+func (fh StoreImpl) SearchFHIRPatient(ctx context.Context, searchParams string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PatientConnection, error) {
+	// retired patients are hidden
+	params := domain.NewSearchParams().Content(searchParams).NotToken("active", "", "false")
 
-	resources, err := fh.Dataset.SearchFHIRResource(patientResourceType, params, tenant, pagination)
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, patientResourceType, params, tenant, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -1510,3 +1724,128 @@ func (fh StoreImpl) SearchFHIRPatient(_ context.Context, searchParams string, te
 
 	return &output, nil
 }
+
+// GetFHIRConditionHistory lists the versions of a condition, newest first
+func (fh StoreImpl) GetFHIRConditionHistory(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRConditionHistory, error) {
+	err := fh.checkTenantFHIRResource(ctx, conditionResourceType, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := fh.Dataset.GetFHIRResourceHistory(ctx, conditionResourceType, id, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %s history: %w", conditionResourceType, err)
+	}
+
+	output := domain.PagedFHIRConditionHistory{
+		Versions:        []domain.FHIRConditionVersion{},
+		HasNextPage:     versions.HasNextPage,
+		NextCursor:      versions.NextCursor,
+		HasPreviousPage: versions.HasPreviousPage,
+		PreviousCursor:  versions.PreviousCursor,
+		TotalCount:      versions.TotalCount,
+	}
+
+	for _, version := range versions.Resources {
+		var resource domain.FHIRCondition
+
+		lastUpdated, err := decodeVersion(version, &resource)
+		if err != nil {
+			return nil, fmt.Errorf("server error: Unable to decode %s version: %w", conditionResourceType, err)
+		}
+
+		output.Versions = append(output.Versions, domain.FHIRConditionVersion{
+			Resource:    resource,
+			LastUpdated: lastUpdated,
+		})
+	}
+
+	return &output, nil
+}
+
+// GetFHIRAllergyIntoleranceHistory lists the versions of an allergy intolerance, newest first
+func (fh StoreImpl) GetFHIRAllergyIntoleranceHistory(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergyHistory, error) {
+	err := fh.checkTenantFHIRResource(ctx, allergyIntoleranceResourceType, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := fh.Dataset.GetFHIRResourceHistory(ctx, allergyIntoleranceResourceType, id, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %s history: %w", allergyIntoleranceResourceType, err)
+	}
+
+	output := domain.PagedFHIRAllergyHistory{
+		Versions:        []domain.FHIRAllergyIntoleranceVersion{},
+		HasNextPage:     versions.HasNextPage,
+		NextCursor:      versions.NextCursor,
+		HasPreviousPage: versions.HasPreviousPage,
+		PreviousCursor:  versions.PreviousCursor,
+		TotalCount:      versions.TotalCount,
+	}
+
+	for _, version := range versions.Resources {
+		var resource domain.FHIRAllergyIntolerance
+
+		lastUpdated, err := decodeVersion(version, &resource)
+		if err != nil {
+			return nil, fmt.Errorf("server error: Unable to decode %s version: %w", allergyIntoleranceResourceType, err)
+		}
+
+		output.Versions = append(output.Versions, domain.FHIRAllergyIntoleranceVersion{
+			Resource:    resource,
+			LastUpdated: lastUpdated,
+		})
+	}
+
+	return &output, nil
+}
+
+// checkTenantFHIRResource makes sure that a resource belongs to the tenant.
+// History is read by ID alone and cannot be filtered by tags, so the resource
+// is looked up within the tenant first
+func (fh StoreImpl) checkTenantFHIRResource(ctx context.Context, resourceType, id string, tenant dto.TenantIdentifiers) error {
+	resources, err := fh.Dataset.SearchFHIRResource(ctx, resourceType, domain.NewSearchParams().ID(id), tenant, dto.Pagination{Skip: true})
+	if err != nil {
+		return fmt.Errorf("unable to get %s with ID %s, err: %w", resourceType, id, err)
+	}
+
+	if len(resources.Resources) == 0 {
+		return fmt.Errorf("%s with ID %s: %w", resourceType, id, domain.ErrFHIRResourceNotFound)
+	}
+
+	return nil
+}
+
+// decodeResource unmarshals a resource returned by a search
+func decodeResource(result map[string]interface{}, resource interface{}) error {
+	resourceBs, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("unable to marshal map to JSON: %w", err)
+	}
+
+	return json.Unmarshal(resourceBs, resource)
+}
+
+// decodeVersion unmarshals a version of a resource and returns the time at
+// which the version was recorded, from its `meta.lastUpdated`
+func decodeVersion(version map[string]interface{}, resource interface{}) (time.Time, error) {
+	resourceBs, err := json.Marshal(version)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to marshal map to JSON: %w", err)
+	}
+
+	err = json.Unmarshal(resourceBs, resource)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	meta, _ := version["meta"].(map[string]interface{})
+	lastUpdated, _ := meta["lastUpdated"].(string)
+
+	if lastUpdated == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339Nano, lastUpdated)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,20 +15,24 @@ import (
 	"github.com/savannahghi/clinical/pkg/clinical/application/common"
 	"github.com/savannahghi/clinical/pkg/clinical/application/common/helpers"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	FHIR "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
 	"github.com/savannahghi/converterandformatter"
 	"github.com/savannahghi/scalarutils"
 	"github.com/segmentio/ksuid"
 
 	fakeDataset "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/fhirdataset/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/memorydataset"
 )
 
 func TestStoreImpl_SearchFHIRObservation(t *testing.T) {
 
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -38,20 +44,16 @@ func TestStoreImpl_SearchFHIRObservation(t *testing.T) {
 		{
 			name: "happy case: search observation",
 			args: args{
-				ctx: context.Background(),
-				params: map[string]interface{}{
-					"_sort": "-date",
-				},
+				ctx:    context.Background(),
+				params: domain.NewSearchParams().SortDescending("date"),
 			},
 			wantErr: false,
 		},
 		{
 			name: "sad case: search resource error",
 			args: args{
-				ctx: context.Background(),
-				params: map[string]interface{}{
-					"_sort": "-date",
-				},
+				ctx:    context.Background(),
+				params: domain.NewSearchParams().SortDescending("date"),
 			},
 			wantErr: true,
 		},
@@ -62,7 +64,7 @@ func TestStoreImpl_SearchFHIRObservation(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: search resource error" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search fhir resource")
 				}
 			}
@@ -117,7 +119,7 @@ func TestStoreImpl_DeleteFHIRObservation(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error deleting resource" {
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
+				dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
 					return fmt.Errorf("failed to delete resource")
 				}
 			}
@@ -176,7 +178,7 @@ func TestStoreImpl_CreateFHIRObservation(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error creating resource" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create resource")
 				}
 			}
@@ -228,7 +230,7 @@ func TestStoreImpl_GetFHIRPatient(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "happy case: get patient" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					id := gofakeit.UUID()
 					patient := &domain.FHIRPatient{
 						ID: &id,
@@ -247,7 +249,7 @@ func TestStoreImpl_GetFHIRPatient(t *testing.T) {
 					return nil
 				}
 
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					episode := domain.FHIREpisodeOfCare{
 						Period: &domain.FHIRPeriod{
 							Start: "2020-09-24T18:02:38.661033Z",
@@ -269,7 +271,7 @@ func TestStoreImpl_GetFHIRPatient(t *testing.T) {
 			}
 
 			if tt.name == "sad case: error retrieving fhir resource" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					return fmt.Errorf("failed to get resource")
 				}
 			}
@@ -322,12 +324,12 @@ func TestStoreImpl_DeleteFHIRResourceType(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: delete resource error" {
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
+				dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
 					return fmt.Errorf("failed to delete resource")
 				}
 			}
 
-			if err := fh.DeleteFHIRResourceType(tt.args.results); (err != nil) != tt.wantErr {
+			if err := fh.DeleteFHIRResourceType(context.Background(), tt.args.results); (err != nil) != tt.wantErr {
 				t.Errorf("StoreImpl.DeleteFHIRResourceType() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -371,7 +373,7 @@ func TestStoreImpl_DeleteFHIRServiceRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: delete resource error" {
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
+				dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
 					return fmt.Errorf("failed to delete resource")
 				}
 			}
@@ -430,7 +432,7 @@ func TestStoreImpl_CreateFHIRMedicationStatement(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error creating resource" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create resource")
 				}
 			}
@@ -490,7 +492,7 @@ func TestStoreImpl_CreateFHIRMedication(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error creating resource" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create resource")
 				}
 			}
@@ -550,7 +552,7 @@ func TestStoreImpl_CreateFHIRPatient(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error creating resource" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create resource")
 				}
 			}
@@ -609,7 +611,7 @@ func TestStoreImpl_PatchFHIRPatient(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error patching resource" {
-				dataset.MockPatchFHIRResourceFn = func(resourceType string, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
+				dataset.MockPatchFHIRResourceFn = func(ctx context.Context, resourceType string, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to patch resource")
 				}
 			}
@@ -668,7 +670,7 @@ func TestStoreImpl_UpdateFHIREpisodeOfCare(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: error updating resource" {
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed ro update resource")
 				}
 			}
@@ -689,7 +691,7 @@ func TestStoreImpl_UpdateFHIREpisodeOfCare(t *testing.T) {
 func TestStoreImpl_SearchFHIRMedicationStatement(t *testing.T) {
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -701,20 +703,16 @@ func TestStoreImpl_SearchFHIRMedicationStatement(t *testing.T) {
 		{
 			name: "happy case: search medication statement",
 			args: args{
-				ctx: context.Background(),
-				params: map[string]interface{}{
-					"name": "ARVs",
-				},
+				ctx:    context.Background(),
+				params: domain.NewSearchParams().Token("code", "", "ARVs"),
 			},
 			wantErr: false,
 		},
 		{
 			name: "sad case: search resource error",
 			args: args{
-				ctx: context.Background(),
-				params: map[string]interface{}{
-					"name": "ARVs",
-				},
+				ctx:    context.Background(),
+				params: domain.NewSearchParams().Token("code", "", "ARVs"),
 			},
 			wantErr: true,
 		},
@@ -725,7 +723,7 @@ func TestStoreImpl_SearchFHIRMedicationStatement(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "sad case: search resource error" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search")
 				}
 			}
@@ -788,7 +786,7 @@ func fakePatient() (*domain.FHIRPatient, error) {
 				},
 				System:   &system,
 				Value:    id,
-				Period:   common.DefaultPeriod(),
+				Period:   common.DefaultPeriod(domain.SystemClock{}),
 				Assigner: &domain.FHIRReference{},
 			},
 		},
@@ -798,7 +796,7 @@ func fakePatient() (*domain.FHIRPatient, error) {
 				Given:  []*string{&name},
 				Family: &name,
 				Use:    nameUse,
-				Period: common.DefaultPeriod(),
+				Period: common.DefaultPeriod(domain.SystemClock{}),
 				Text:   name,
 			},
 		},
@@ -807,7 +805,7 @@ func fakePatient() (*domain.FHIRPatient, error) {
 				System: &phoneSystem,
 				Use:    &use,
 				Rank:   &rank,
-				Period: common.DefaultPeriod(),
+				Period: common.DefaultPeriod(domain.SystemClock{}),
 				Value:  &phone,
 			},
 		},
@@ -818,7 +816,7 @@ func fakePatient() (*domain.FHIRPatient, error) {
 				Use:     &addrUse,
 				Type:    &postalAddrType,
 				Country: &country,
-				Period:  common.DefaultPeriod(),
+				Period:  common.DefaultPeriod(domain.SystemClock{}),
 				Line:    []*string{&address.Address},
 				Text:    address.Address,
 			},
@@ -837,7 +835,7 @@ func fakePatient() (*domain.FHIRPatient, error) {
 					Given:  []*string{&name},
 					Family: &name,
 					Use:    nameUse,
-					Period: common.DefaultPeriod(),
+					Period: common.DefaultPeriod(domain.SystemClock{}),
 					Text:   name,
 				},
 				Telecom: []*domain.FHIRContactPoint{
@@ -845,7 +843,7 @@ func fakePatient() (*domain.FHIRPatient, error) {
 						System: &phoneSystem,
 						Use:    &use,
 						Rank:   &rank,
-						Period: common.DefaultPeriod(),
+						Period: common.DefaultPeriod(domain.SystemClock{}),
 						Value:  &phone,
 					},
 				},
@@ -854,12 +852,12 @@ func fakePatient() (*domain.FHIRPatient, error) {
 					Use:     &addrUse,
 					Type:    &postalAddrType,
 					Country: &country,
-					Period:  common.DefaultPeriod(),
+					Period:  common.DefaultPeriod(domain.SystemClock{}),
 					Line:    []*string{&address.Address},
 					Text:    address.Address,
 				},
 				Gender: &maleContact,
-				Period: common.DefaultPeriod(),
+				Period: common.DefaultPeriod(domain.SystemClock{}),
 			},
 		},
 		MaritalStatus: &domain.FHIRCodeableConcept{
@@ -913,7 +911,7 @@ func TestStoreImpl_SearchFHIRPatient(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "happy case: search patient" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					var payload map[string]interface{}
 
 					switch resourceType {
@@ -953,7 +951,7 @@ func TestStoreImpl_SearchFHIRPatient(t *testing.T) {
 			}
 
 			if tt.name == "sad case: search patient error" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					var payload map[string]interface{}
 
 					switch resourceType {
@@ -1109,7 +1107,7 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "happy case: delete all patient data" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 							{
@@ -1191,13 +1189,13 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 			}
 
 			if tt.name == "sad case: all patient data error" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					return nil, fmt.Errorf("failed to get data")
 				}
 			}
 
 			if tt.name == "sad case: all patient data invalid entry" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": "invalid",
 					}
@@ -1212,7 +1210,7 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 			}
 
 			if tt.name == "sad case: all patient data invalid entry type" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[int]string{
 							{
@@ -1231,7 +1229,7 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 			}
 
 			if tt.name == "sad case: all patient data entry invalid resource type" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 
@@ -1251,7 +1249,7 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 			}
 
 			if tt.name == "sad case: error deleting medication request" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 							{
@@ -1271,16 +1269,18 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 					return bs, err
 				}
 
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
-					if resourceType == "MedicationRequest" {
-						return fmt.Errorf("failed")
+				dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+					for _, entry := range bundle.Entry {
+						if strings.HasPrefix(entry.Request.URL, "MedicationRequest/") {
+							return nil, fmt.Errorf("failed")
+						}
 					}
-					return nil
+					return &domain.FHIRBundle{}, nil
 				}
 			}
 
 			if tt.name == "sad case: error deleting other types" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 							{
@@ -1300,16 +1300,18 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 					return bs, err
 				}
 
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
-					if resourceType == "Composition" {
-						return fmt.Errorf("failed")
+				dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+					for _, entry := range bundle.Entry {
+						if strings.HasPrefix(entry.Request.URL, "Composition/") {
+							return nil, fmt.Errorf("failed")
+						}
 					}
-					return nil
+					return &domain.FHIRBundle{}, nil
 				}
 			}
 
 			if tt.name == "sad case: error deleting patient" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 							{
@@ -1329,16 +1331,18 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 					return bs, err
 				}
 
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
-					if resourceType == "Patient" {
-						return fmt.Errorf("failed")
+				dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+					for _, entry := range bundle.Entry {
+						if strings.HasPrefix(entry.Request.URL, "Patient/") {
+							return nil, fmt.Errorf("failed")
+						}
 					}
-					return nil
+					return &domain.FHIRBundle{}, nil
 				}
 			}
 
 			if tt.name == "sad case: error deleting observation" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 							{
@@ -1358,16 +1362,18 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 					return bs, err
 				}
 
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
-					if resourceType == "Observation" {
-						return fmt.Errorf("failed")
+				dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+					for _, entry := range bundle.Entry {
+						if strings.HasPrefix(entry.Request.URL, "Observation/") {
+							return nil, fmt.Errorf("failed")
+						}
 					}
-					return nil
+					return &domain.FHIRBundle{}, nil
 				}
 			}
 
 			if tt.name == "sad case: error deleting encounters" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 
@@ -1388,16 +1394,18 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 					return bs, err
 				}
 
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
-					if resourceType == "Encounter" {
-						return fmt.Errorf("failed")
+				dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+					for _, entry := range bundle.Entry {
+						if strings.HasPrefix(entry.Request.URL, "Encounter/") {
+							return nil, fmt.Errorf("failed")
+						}
 					}
-					return nil
+					return &domain.FHIRBundle{}, nil
 				}
 			}
 
 			if tt.name == "sad case: error deleting episode of care" {
-				dataset.MockGetFHIRPatientAllDataFn = func(fhirResourceID string) ([]byte, error) {
+				dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 					data := map[string]interface{}{
 						"entry": []map[string]interface{}{
 							{
@@ -1417,11 +1425,13 @@ func TestStoreImpl_DeleteFHIRPatient(t *testing.T) {
 					return bs, err
 				}
 
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
-					if resourceType == "EpisodeOfCare" {
-						return fmt.Errorf("failed")
+				dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+					for _, entry := range bundle.Entry {
+						if strings.HasPrefix(entry.Request.URL, "EpisodeOfCare/") {
+							return nil, fmt.Errorf("failed")
+						}
 					}
-					return nil
+					return &domain.FHIRBundle{}, nil
 				}
 			}
 
@@ -1552,7 +1562,7 @@ func TestStoreImpl_CreateFHIRCondition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to create FHIR condition" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -1615,7 +1625,7 @@ func TestStoreImpl_CreateFHIROrganization_Unittest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to create FHIR organization" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -1673,7 +1683,7 @@ func TestStoreImpl_GetFHIROrganization_Unittest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to find organization by ID" {
-				dataset.MockGetFHIRResourceFn = func(resourceType string, id string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType string, id string, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -1731,7 +1741,7 @@ func TestStoreImpl_CreateFHIREncounter(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - Fail to create FHIR resource" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create fhir service request")
 				}
 			}
@@ -1783,7 +1793,7 @@ func TestStoreImpl_GetFHIREpisodeOfCare(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to get FHIR resource" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -1832,7 +1842,7 @@ func TestStoreImpl_GetFHIREncounter(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -1882,7 +1892,7 @@ func TestStoreImpl_CreateFHIRServiceRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - Fail to create FHIR service request" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create fhir service request")
 				}
 			}
@@ -1933,7 +1943,7 @@ func TestStoreImpl_CreateFHIRAllergyIntolerance(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - Fail to create FHIR allergy intolerance" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create fhir service request")
 				}
 			}
@@ -1996,7 +2006,7 @@ func TestStoreImpl_UpdateFHIRAllergyIntolerance(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case" {
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -2042,7 +2052,7 @@ func TestStoreImpl_CreateFHIRComposition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - Fail to create FHIR composition" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create FHIR composition")
 				}
 			}
@@ -2106,7 +2116,7 @@ func TestStoreImpl_UpdateFHIRComposition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case" {
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -2159,7 +2169,7 @@ func TestStoreImpl_DeleteFHIRComposition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case" {
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
+				dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -2214,7 +2224,7 @@ func TestStoreImpl_CreateFHIRMedicationRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to create medication request" {
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to create fhir service request")
 				}
 			}
@@ -2237,7 +2247,7 @@ func TestStoreImpl_SearchFHIRServiceRequest(t *testing.T) {
 
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2250,10 +2260,8 @@ func TestStoreImpl_SearchFHIRServiceRequest(t *testing.T) {
 		{
 			name: "Happy Case - successfully search fhir service request",
 			args: args{
-				ctx: ctx,
-				params: map[string]interface{}{
-					"id": "1234",
-				},
+				ctx:    ctx,
+				params: domain.NewSearchParams().ID("1234"),
 			},
 			wantErr: false,
 		},
@@ -2271,7 +2279,7 @@ func TestStoreImpl_SearchFHIRServiceRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search a service request" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search resource")
 				}
 			}
@@ -2293,7 +2301,7 @@ func TestStoreImpl_SearchFHIRAllergyIntolerance(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2306,10 +2314,8 @@ func TestStoreImpl_SearchFHIRAllergyIntolerance(t *testing.T) {
 		{
 			name: "Happy Case - successfully search fhir allergy intolerance",
 			args: args{
-				ctx: ctx,
-				params: map[string]interface{}{
-					"id": "1234",
-				},
+				ctx:    ctx,
+				params: domain.NewSearchParams().ID("1234"),
 			},
 			wantErr: false,
 		},
@@ -2327,7 +2333,7 @@ func TestStoreImpl_SearchFHIRAllergyIntolerance(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search an allergy intolerance" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search resource")
 				}
 			}
@@ -2349,7 +2355,7 @@ func TestStoreImpl_SearchFHIRComposition(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2362,10 +2368,8 @@ func TestStoreImpl_SearchFHIRComposition(t *testing.T) {
 		{
 			name: "Happy Case - successfully search fhir composition",
 			args: args{
-				ctx: ctx,
-				params: map[string]interface{}{
-					"id": "1234",
-				},
+				ctx:    ctx,
+				params: domain.NewSearchParams().ID("1234"),
 			},
 			wantErr: false,
 		},
@@ -2383,7 +2387,7 @@ func TestStoreImpl_SearchFHIRComposition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search a composition" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search resource")
 				}
 			}
@@ -2405,7 +2409,7 @@ func TestStoreImpl_SearchFHIRCondition(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2418,10 +2422,8 @@ func TestStoreImpl_SearchFHIRCondition(t *testing.T) {
 		{
 			name: "Happy Case - successfully search fhir condition",
 			args: args{
-				ctx: ctx,
-				params: map[string]interface{}{
-					"id": "1234",
-				},
+				ctx:        ctx,
+				params:     domain.NewSearchParams().ID("1234"),
 				pagination: dto.Pagination{},
 			},
 			wantErr: false,
@@ -2441,7 +2443,7 @@ func TestStoreImpl_SearchFHIRCondition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search a condition" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search resource")
 				}
 			}
@@ -2463,7 +2465,7 @@ func TestStoreImpl_SearchFHIREncounter(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2476,10 +2478,8 @@ func TestStoreImpl_SearchFHIREncounter(t *testing.T) {
 		{
 			name: "Happy Case - successfully search fhir encounter",
 			args: args{
-				ctx: ctx,
-				params: map[string]interface{}{
-					"id": "1234",
-				},
+				ctx:    ctx,
+				params: domain.NewSearchParams().ID("1234"),
 			},
 			wantErr: false,
 		},
@@ -2497,7 +2497,7 @@ func TestStoreImpl_SearchFHIREncounter(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search an encounter" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search resource")
 				}
 			}
@@ -2519,7 +2519,7 @@ func TestStoreImpl_SearchFHIRMedicationRequest(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2532,10 +2532,8 @@ func TestStoreImpl_SearchFHIRMedicationRequest(t *testing.T) {
 		{
 			name: "Happy Case - successfully search fhir medication request",
 			args: args{
-				ctx: ctx,
-				params: map[string]interface{}{
-					"id": "1234",
-				},
+				ctx:    ctx,
+				params: domain.NewSearchParams().ID("1234"),
 			},
 			wantErr: false,
 		},
@@ -2553,7 +2551,7 @@ func TestStoreImpl_SearchFHIRMedicationRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search a medication request" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search resource")
 				}
 			}
@@ -2610,7 +2608,7 @@ func TestStoreImpl_UpdateFHIRCondition(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to update fhir condition" {
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to update condition")
 				}
 			}
@@ -2667,7 +2665,7 @@ func TestStoreImpl_UpdateFHIRMedicationRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to update fhir medication request" {
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("failed to update medication request")
 				}
 			}
@@ -2722,7 +2720,7 @@ func TestStoreImpl_DeleteFHIRMedicationRequest(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to delete a medication request" {
-				dataset.MockDeleteFHIRResourceFn = func(resourceType, fhirResourceID string) error {
+				dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
 					return fmt.Errorf("failed to update resource")
 				}
 			}
@@ -2786,7 +2784,7 @@ func TestStoreImpl_SearchPatientEncounters(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -2808,7 +2806,7 @@ func TestStoreImpl_SearchPatientEncounters(t *testing.T) {
 func TestStoreImpl_SearchFHIREpisodeOfCare(t *testing.T) {
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2820,20 +2818,16 @@ func TestStoreImpl_SearchFHIREpisodeOfCare(t *testing.T) {
 		{
 			name: "Happy Case: search FHIR episode of care",
 			args: args{
-				ctx: context.Background(),
-				params: map[string]interface{}{
-					"test": "search",
-				},
+				ctx:    context.Background(),
+				params: domain.NewSearchParams().Reference("patient", "Patient/1234"),
 			},
 			wantErr: false,
 		},
 		{
 			name: "Sad case: failed to search FHIR resource",
 			args: args{
-				ctx: context.Background(),
-				params: map[string]interface{}{
-					"test": "search",
-				},
+				ctx:    context.Background(),
+				params: domain.NewSearchParams().Reference("patient", "Patient/1234"),
 			},
 			wantErr: true,
 		},
@@ -2844,7 +2838,7 @@ func TestStoreImpl_SearchFHIREpisodeOfCare(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -2934,16 +2928,16 @@ func TestStoreImpl_CreateEpisodeOfCare(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Happy case: create episode of care, episode does not exist" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, nil
 				}
 			}
 
 			if tt.name == "Sad case: failed to create FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, nil
 				}
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
@@ -2964,7 +2958,7 @@ func TestStoreImpl_CreateEpisodeOfCare(t *testing.T) {
 func TestStoreImpl_SearchFHIROrganization(t *testing.T) {
 	type args struct {
 		ctx        context.Context
-		params     map[string]interface{}
+		params     domain.SearchParams
 		tenant     dto.TenantIdentifiers
 		pagination dto.Pagination
 	}
@@ -2976,20 +2970,16 @@ func TestStoreImpl_SearchFHIROrganization(t *testing.T) {
 		{
 			name: "Happy case: search FHIR organisation",
 			args: args{
-				ctx: nil,
-				params: map[string]interface{}{
-					"test": "params",
-				},
+				ctx:    nil,
+				params: domain.NewSearchParams().Token("identifier", "", "1234"),
 			},
 			wantErr: false,
 		},
 		{
 			name: "Sad case: failed to search FHIR organisation",
 			args: args{
-				ctx: nil,
-				params: map[string]interface{}{
-					"test": "params",
-				},
+				ctx:    nil,
+				params: domain.NewSearchParams().Token("identifier", "", "1234"),
 			},
 			wantErr: true,
 		},
@@ -3000,7 +2990,7 @@ func TestStoreImpl_SearchFHIROrganization(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR organisation" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -3020,7 +3010,7 @@ func TestStoreImpl_SearchFHIROrganization(t *testing.T) {
 func TestStoreImpl_SearchEpisodesByParam(t *testing.T) {
 	type args struct {
 		ctx          context.Context
-		searchParams map[string]interface{}
+		searchParams domain.SearchParams
 		tenant       dto.TenantIdentifiers
 		pagination   dto.Pagination
 	}
@@ -3033,12 +3023,9 @@ func TestStoreImpl_SearchEpisodesByParam(t *testing.T) {
 			name: "Happy case: search episode by param",
 			args: args{
 				ctx: context.Background(),
-				searchParams: map[string]interface{}{
-					"period": map[string]interface{}{
-						"start": time.February.String(),
-						"end":   time.February.String(),
-					},
-				},
+				searchParams: domain.NewSearchParams().
+					Date("date", domain.SearchPrefixGreaterOrEqual, time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)).
+					Date("date", domain.SearchPrefixLessThan, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)),
 			},
 			wantErr: false,
 		},
@@ -3046,12 +3033,9 @@ func TestStoreImpl_SearchEpisodesByParam(t *testing.T) {
 			name: "Sad case: failed to search FHIR resource",
 			args: args{
 				ctx: context.Background(),
-				searchParams: map[string]interface{}{
-					"period": map[string]interface{}{
-						"start": time.February.String(),
-						"end":   time.February.String(),
-					},
-				},
+				searchParams: domain.NewSearchParams().
+					Date("date", domain.SearchPrefixGreaterOrEqual, time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)).
+					Date("date", domain.SearchPrefixLessThan, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)),
 			},
 			wantErr: true,
 		},
@@ -3062,7 +3046,7 @@ func TestStoreImpl_SearchEpisodesByParam(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -3115,7 +3099,7 @@ func TestStoreImpl_OpenEpisodes(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -3176,7 +3160,7 @@ func TestStoreImpl_HasOpenEpisode(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -3228,7 +3212,7 @@ func TestStoreImpl_SearchEpisodeEncounter(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
@@ -3288,13 +3272,13 @@ func TestStoreImpl_GetActiveEpisode(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad case: failed to search FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
 
 			if tt.name == "Sad case: empty FHIR resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return &domain.PagedFHIRResource{
 						Resources: []map[string]interface{}{},
 					}, nil
@@ -3367,7 +3351,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Happy case: start encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
@@ -3396,7 +3380,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 					return nil
 				}
 
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID: &UUID,
 					}
@@ -3415,7 +3399,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: failed to get FHIR episode of care" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
@@ -3447,7 +3431,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: episode  not active" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &finishedStatus,
@@ -3476,7 +3460,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 					return nil
 				}
 
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID: &UUID,
 					}
@@ -3495,7 +3479,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: failed to create encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
@@ -3524,7 +3508,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 					return nil
 				}
 
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID: &UUID,
 					}
@@ -3543,7 +3527,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 			}
 
 			if tt.name == "Happy case: start encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
@@ -3572,7 +3556,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 					return nil
 				}
 
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID: &UUID,
 					}
@@ -3591,7 +3575,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 			}
 
 			if tt.name == "Happy case: start encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
@@ -3620,7 +3604,7 @@ func TestStoreImpl_StartEncounter(t *testing.T) {
 					return nil
 				}
 
-				dataset.MockCreateFHIRResourceFn = func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID: &UUID,
 					}
@@ -3657,6 +3641,7 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 	type args struct {
 		ctx         context.Context
 		encounterID string
+		endTime     time.Time
 	}
 	tests := []struct {
 		name    string
@@ -3669,6 +3654,7 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 			args: args{
 				ctx:         context.Background(),
 				encounterID: gofakeit.UUID(),
+				endTime:     time.Now(),
 			},
 			want:    true,
 			wantErr: false,
@@ -3678,6 +3664,7 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 			args: args{
 				ctx:         context.Background(),
 				encounterID: gofakeit.UUID(),
+				endTime:     time.Now(),
 			},
 			want:    false,
 			wantErr: true,
@@ -3687,6 +3674,7 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 			args: args{
 				ctx:         context.Background(),
 				encounterID: gofakeit.UUID(),
+				endTime:     time.Now(),
 			},
 			want:    false,
 			wantErr: true,
@@ -3698,14 +3686,14 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Happy case: end encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
 						Period: &domain.FHIRPeriod{
 							ID:    &UUID,
-							Start: scalarutils.DateTime(time.February.String()),
-							End:   scalarutils.DateTime(time.March.String()),
+							Start: "2023-02-01T08:00:00+03:00",
+							End:   "2023-03-01T08:00:00+03:00",
 						},
 					}
 					bs, err := json.Marshal(episode)
@@ -3722,14 +3710,14 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: failed to get encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
 						Period: &domain.FHIRPeriod{
 							ID:    &UUID,
-							Start: scalarutils.DateTime(time.February.String()),
-							End:   scalarutils.DateTime(time.March.String()),
+							Start: "2023-02-01T08:00:00+03:00",
+							End:   "2023-03-01T08:00:00+03:00",
 						},
 					}
 					bs, err := json.Marshal(episode)
@@ -3745,14 +3733,14 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad case: failed to get update resource" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
 						Period: &domain.FHIRPeriod{
 							ID:    &UUID,
-							Start: scalarutils.DateTime(time.February.String()),
-							End:   scalarutils.DateTime(time.March.String()),
+							Start: "2023-02-01T08:00:00+03:00",
+							End:   "2023-03-01T08:00:00+03:00",
 						},
 					}
 					bs, err := json.Marshal(episode)
@@ -3766,12 +3754,12 @@ func TestStoreImpl_EndEncounter(t *testing.T) {
 					}
 					return nil
 				}
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
 
-			got, err := fh.EndEncounter(tt.args.ctx, tt.args.encounterID)
+			got, err := fh.EndEncounter(tt.args.ctx, tt.args.encounterID, tt.args.endTime)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("StoreImpl.EndEncounter() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -3789,6 +3777,7 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 	type args struct {
 		ctx       context.Context
 		episodeID string
+		endTime   time.Time
 	}
 	tests := []struct {
 		name    string
@@ -3801,6 +3790,7 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 			args: args{
 				ctx:       context.Background(),
 				episodeID: UUID,
+				endTime:   time.Now(),
 			},
 			want:    true,
 			wantErr: false,
@@ -3810,6 +3800,7 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 			args: args{
 				ctx:       context.Background(),
 				episodeID: UUID,
+				endTime:   time.Now(),
 			},
 			want:    false,
 			wantErr: true,
@@ -3819,6 +3810,7 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 			args: args{
 				ctx:       context.Background(),
 				episodeID: UUID,
+				endTime:   time.Now(),
 			},
 			want:    false,
 			wantErr: true,
@@ -3830,14 +3822,14 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Happy case: end episode" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
 						Period: &domain.FHIRPeriod{
 							ID:    &UUID,
-							Start: scalarutils.DateTime(time.February.String()),
-							End:   scalarutils.DateTime(time.March.String()),
+							Start: "2023-02-01T08:00:00+03:00",
+							End:   "2023-03-01T08:00:00+03:00",
 						},
 					}
 					bs, err := json.Marshal(episode)
@@ -3854,14 +3846,14 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: failed to get encounter" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
 						Period: &domain.FHIRPeriod{
 							ID:    &UUID,
-							Start: scalarutils.DateTime(time.February.String()),
-							End:   scalarutils.DateTime(time.March.String()),
+							Start: "2023-02-01T08:00:00+03:00",
+							End:   "2023-03-01T08:00:00+03:00",
 						},
 					}
 					bs, err := json.Marshal(episode)
@@ -3878,14 +3870,14 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: failed to get update resource" {
-				dataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					episode := domain.FHIREpisodeOfCare{
 						ID:     &UUID,
 						Status: &status,
 						Period: &domain.FHIRPeriod{
 							ID:    &UUID,
-							Start: scalarutils.DateTime(time.February.String()),
-							End:   scalarutils.DateTime(time.March.String()),
+							Start: "2023-02-01T08:00:00+03:00",
+							End:   "2023-03-01T08:00:00+03:00",
 						},
 					}
 					bs, err := json.Marshal(episode)
@@ -3899,12 +3891,12 @@ func TestStoreImpl_EndEpisode(t *testing.T) {
 					}
 					return nil
 				}
-				dataset.MockUpdateFHIRResourceFn = func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 					return fmt.Errorf("an error occurred")
 				}
 			}
 
-			got, err := fh.EndEpisode(tt.args.ctx, tt.args.episodeID)
+			got, err := fh.EndEpisode(tt.args.ctx, tt.args.episodeID, tt.args.endTime)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("StoreImpl.EndEpisode() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -3955,7 +3947,7 @@ func TestStoreImpl_SearchPatientObservations(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(dataset)
 
 			if tt.name == "Sad Case - fail to search fhir resource" {
-				dataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, fmt.Errorf("failed to search observation resource")
 				}
 			}
@@ -4006,7 +3998,7 @@ func TestStoreImpl_GetFHIRAllergyIntolerance(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(fakeDataset)
 
 			if tt.name == "Sad case: unable to get allergy intolerance by ID" {
-				fakeDataset.MockGetFHIRResourceFn = func(resourceType, fhirResourceID string, resource interface{}) error {
+				fakeDataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 					return fmt.Errorf("error")
 				}
 			}
@@ -4066,7 +4058,7 @@ func TestStoreImpl_SearchPatientAllergyIntolerance(t *testing.T) {
 			fh := FHIR.NewFHIRStoreImpl(fakeDataset)
 
 			if tt.name == "Sad case: unable to search allergy intolerance" {
-				fakeDataset.MockSearchFHIRResourceFn = func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				fakeDataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 					return nil, errors.New("some error")
 				}
 			}
@@ -4079,3 +4071,1621 @@ func TestStoreImpl_SearchPatientAllergyIntolerance(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreImpl_RunInTransaction(t *testing.T) {
+	tests := []struct {
+		name        string
+		work        func(fhir repository.FHIR) error
+		wantEntries int
+		wantErr     bool
+	}{
+		{
+			name: "happy case: writes are committed together",
+			work: func(fhir repository.FHIR) error {
+				if _, err := fhir.EndEncounter(context.Background(), gofakeit.UUID(), time.Now()); err != nil {
+					return err
+				}
+
+				_, err := fhir.EndEpisode(context.Background(), gofakeit.UUID(), time.Now())
+				return err
+			},
+			wantEntries: 2,
+			wantErr:     false,
+		},
+		{
+			name: "happy case: nothing to commit",
+			work: func(fhir repository.FHIR) error {
+				return nil
+			},
+			wantEntries: 0,
+			wantErr:     false,
+		},
+		{
+			name: "sad case: work fails",
+			work: func(fhir repository.FHIR) error {
+				if _, err := fhir.EndEncounter(context.Background(), gofakeit.UUID(), time.Now()); err != nil {
+					return err
+				}
+
+				return fmt.Errorf("failed")
+			},
+			wantEntries: 0,
+			wantErr:     true,
+		},
+		{
+			name: "sad case: patching within a transaction",
+			work: func(fhir repository.FHIR) error {
+				_, err := fhir.PatchFHIRPatient(context.Background(), gofakeit.UUID(), []map[string]interface{}{})
+				return err
+			},
+			wantEntries: 0,
+			wantErr:     true,
+		},
+		{
+			name: "sad case: error committing transaction",
+			work: func(fhir repository.FHIR) error {
+				_, err := fhir.EndEncounter(context.Background(), gofakeit.UUID(), time.Now())
+				return err
+			},
+			wantEntries: 1,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			committed := []domain.FHIRBundleEntry{}
+
+			dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+				committed = append(committed, bundle.Entry...)
+
+				if tt.name == "sad case: error committing transaction" {
+					return nil, fmt.Errorf("failed")
+				}
+
+				return &domain.FHIRBundle{Type: domain.FHIRBundleTypeEnumTransactionResponse}, nil
+			}
+
+			dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+				bs, err := json.Marshal(map[string]interface{}{
+					"resourceType": resourceType,
+					"id":           fhirResourceID,
+					"period": map[string]interface{}{
+						"start": time.Now().Format(time.RFC3339),
+					},
+				})
+				if err != nil {
+					return err
+				}
+
+				return json.Unmarshal(bs, resource)
+			}
+
+			dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				return fmt.Errorf("writes should not reach the dataset within a transaction")
+			}
+
+			err := fh.RunInTransaction(context.Background(), tt.work)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.RunInTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(committed) != tt.wantEntries {
+				t.Errorf("expected %d committed entries, got %d", tt.wantEntries, len(committed))
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIRConditionHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "happy case: get condition history",
+			wantErr: false,
+		},
+		{
+			name:    "sad case: error getting history",
+			wantErr: true,
+		},
+		{
+			name:    "sad case: condition not found in the tenant",
+			wantErr: true,
+		},
+		{
+			name:    "sad case: invalid last updated time",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			if tt.name == "sad case: condition not found in the tenant" {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+					return &domain.PagedFHIRResource{}, nil
+				}
+			}
+
+			if tt.name == "sad case: error getting history" {
+				dataset.MockGetFHIRResourceHistoryFn = func(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+					return nil, fmt.Errorf("failed")
+				}
+			}
+
+			if tt.name == "sad case: invalid last updated time" {
+				dataset.MockGetFHIRResourceHistoryFn = func(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+					return &domain.PagedFHIRResource{
+						Resources: []map[string]interface{}{
+							{
+								"resourceType": resourceType,
+								"id":           fhirResourceID,
+								"meta":         map[string]interface{}{"versionId": "1", "lastUpdated": "yesterday"},
+							},
+						},
+					}, nil
+				}
+			}
+
+			got, err := fh.GetFHIRConditionHistory(context.Background(), gofakeit.UUID(), dto.TenantIdentifiers{OrganizationID: gofakeit.UUID()}, dto.Pagination{Skip: true})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.GetFHIRConditionHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (len(got.Versions) != 2 || got.Versions[0].LastUpdated.IsZero()) {
+				t.Errorf("expected 2 timestamped versions, got: %v", got)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIRAllergyIntoleranceHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "happy case: get allergy intolerance history",
+			wantErr: false,
+		},
+		{
+			name:    "sad case: error getting history",
+			wantErr: true,
+		},
+		{
+			name:    "sad case: allergy intolerance not found in the tenant",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			if tt.name == "sad case: allergy intolerance not found in the tenant" {
+				dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+					return &domain.PagedFHIRResource{}, nil
+				}
+			}
+
+			if tt.name == "sad case: error getting history" {
+				dataset.MockGetFHIRResourceHistoryFn = func(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+					return nil, fmt.Errorf("failed")
+				}
+			}
+
+			got, err := fh.GetFHIRAllergyIntoleranceHistory(context.Background(), gofakeit.UUID(), dto.TenantIdentifiers{OrganizationID: gofakeit.UUID()}, dto.Pagination{Skip: true})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.GetFHIRAllergyIntoleranceHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && len(got.Versions) != 2 {
+				t.Errorf("expected 2 versions, got: %v", got)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIREncounterWithPatient(t *testing.T) {
+	encounterID := uuid.New().String()
+	patientID := uuid.New().String()
+
+	encounter := map[string]interface{}{
+		"resourceType": "Encounter",
+		"id":           encounterID,
+		"status":       domain.EncounterStatusEnumInProgress,
+		"subject":      map[string]interface{}{"id": patientID, "reference": "Patient/" + patientID},
+	}
+	patient := map[string]interface{}{
+		"resourceType": "Patient",
+		"id":           patientID,
+	}
+
+	tests := []struct {
+		name     string
+		resource *domain.PagedFHIRResource
+		err      error
+		wantErr  bool
+	}{
+		{
+			name: "Happy case: encounter with its patient",
+			resource: &domain.PagedFHIRResource{
+				Resources: []map[string]interface{}{encounter},
+				Included:  map[string]map[string]interface{}{"Patient/" + patientID: patient},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Sad case: encounter not found",
+			resource: &domain.PagedFHIRResource{},
+			wantErr:  true,
+		},
+		{
+			name: "Sad case: patient not included",
+			resource: &domain.PagedFHIRResource{
+				Resources: []map[string]interface{}{encounter},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: search error",
+			err:     fmt.Errorf("an error occurred"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if got := params.Values().Get("_include"); got != "Encounter:patient" {
+					t.Errorf("expected the patient to be included, got %q", got)
+				}
+
+				return tt.resource, tt.err
+			}
+
+			gotEncounter, gotPatient, err := fh.GetFHIREncounterWithPatient(context.Background(), encounterID, dto.TenantIdentifiers{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.GetFHIREncounterWithPatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (*gotEncounter.Resource.ID != encounterID || *gotPatient.Resource.ID != patientID) {
+				t.Errorf("expected encounter %s and patient %s, got %v and %v", encounterID, patientID, gotEncounter, gotPatient)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIREpisodeOfCareWithEncounters(t *testing.T) {
+	episodeID := uuid.New().String()
+	tenant := dto.TenantIdentifiers{OrganizationID: "org-1", FacilityID: "facility-1"}
+
+	meta := func(facilityID string) map[string]interface{} {
+		return map[string]interface{}{
+			"tag": []interface{}{
+				map[string]interface{}{"system": dto.OrganisationTagSystem, "code": "org-1"},
+				map[string]interface{}{"system": dto.FacilityTagSystem, "code": facilityID},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		episodes      []map[string]interface{}
+		included      map[string]map[string]interface{}
+		episodeErr    error
+		wantEncounter int
+		wantErr       bool
+	}{
+		{
+			name:     "Happy case: episode with its in progress encounters",
+			episodes: []map[string]interface{}{{"resourceType": "EpisodeOfCare", "id": episodeID, "status": "active"}},
+			included: map[string]map[string]interface{}{
+				"Encounter/1": {"resourceType": "Encounter", "id": "1", "status": domain.EncounterStatusEnumInProgress, "meta": meta("facility-1")},
+				"Encounter/2": {"resourceType": "Encounter", "id": "2", "status": domain.EncounterStatusEnumFinished, "meta": meta("facility-1")},
+			},
+			wantEncounter: 1,
+			wantErr:       false,
+		},
+		{
+			name:     "Happy case: encounters of other facilities are left out",
+			episodes: []map[string]interface{}{{"resourceType": "EpisodeOfCare", "id": episodeID, "status": "active"}},
+			included: map[string]map[string]interface{}{
+				"Encounter/1": {"resourceType": "Encounter", "id": "1", "status": domain.EncounterStatusEnumInProgress, "meta": meta("facility-1")},
+				"Encounter/2": {"resourceType": "Encounter", "id": "2", "status": domain.EncounterStatusEnumInProgress, "meta": meta("facility-2")},
+				"Encounter/3": {"resourceType": "Encounter", "id": "3", "status": domain.EncounterStatusEnumInProgress},
+			},
+			wantEncounter: 1,
+			wantErr:       false,
+		},
+		{
+			name:    "Sad case: episode not found",
+			wantErr: true,
+		},
+		{
+			name:       "Sad case: search error",
+			episodeErr: fmt.Errorf("an error occurred"),
+			wantErr:    true,
+		},
+		{
+			name:     "Sad case: invalid encounter",
+			episodes: []map[string]interface{}{{"resourceType": "EpisodeOfCare", "id": episodeID, "status": "active"}},
+			included: map[string]map[string]interface{}{
+				"Encounter/1": {"resourceType": "Encounter", "id": 1, "meta": meta("facility-1")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if params.Values().Get("_revinclude") != "Encounter:episode-of-care" {
+					t.Errorf("expected the encounters of the episode to be reverse included, got %v", params.Encode())
+				}
+
+				return &domain.PagedFHIRResource{Resources: tt.episodes, Included: tt.included}, tt.episodeErr
+			}
+
+			_, encounters, err := fh.GetFHIREpisodeOfCareWithEncounters(context.Background(), episodeID, tenant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.GetFHIREpisodeOfCareWithEncounters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(encounters) != tt.wantEncounter {
+				t.Errorf("expected %d encounters, got %d", tt.wantEncounter, len(encounters))
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIRPatientTimeline(t *testing.T) {
+	records := map[string]map[string]interface{}{
+		"AllergyIntolerance/1":  {"resourceType": "AllergyIntolerance", "id": "1"},
+		"Observation/1":         {"resourceType": "Observation", "id": "1", "status": "final"},
+		"MedicationStatement/1": {"resourceType": "MedicationStatement", "id": "1", "status": "active"},
+	}
+
+	tests := []struct {
+		name    string
+		records map[string]map[string]interface{}
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: patient timeline",
+			records: records,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid resource",
+			records: map[string]map[string]interface{}{
+				"Observation/1": {"resourceType": "Observation", "id": 1},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: search error",
+			err:     fmt.Errorf("an error occurred"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if resourceType != "Patient" || len(params.Values()["_revinclude"]) != 3 {
+					t.Errorf("expected the records to be reverse included in the search for the patient, got %s %v", resourceType, params.Encode())
+				}
+
+				patients := []map[string]interface{}{{"resourceType": "Patient", "id": "1"}}
+
+				return &domain.PagedFHIRResource{Resources: patients, Included: tt.records}, tt.err
+			}
+
+			got, err := fh.GetFHIRPatientTimeline(context.Background(), "1", dto.TenantIdentifiers{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.GetFHIRPatientTimeline() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (len(got.AllergyIntolerances) != 1 || len(got.Observations) != 1 || len(got.MedicationStatements) != 1) {
+				t.Errorf("expected a record of each type, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIRPatientTimeline_OtherFacility(t *testing.T) {
+	dataset, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize the dataset: %v", err)
+	}
+
+	fh := FHIR.NewFHIRStoreImpl(dataset)
+
+	tenant := dto.TenantIdentifiers{OrganizationID: "org-1", FacilityID: "facility-1"}
+	otherFacility := dto.TenantIdentifiers{OrganizationID: "org-1", FacilityID: "facility-2"}
+
+	meta := func(identifiers dto.TenantIdentifiers) map[string]interface{} {
+		return map[string]interface{}{
+			"tag": []map[string]interface{}{
+				{"system": "http://mycarehub/tenant-identification/organisation", "code": identifiers.OrganizationID},
+				{"system": "http://mycarehub/tenant-identification/facility", "code": identifiers.FacilityID},
+			},
+		}
+	}
+
+	patient := map[string]interface{}{}
+
+	err = dataset.CreateFHIRResource(context.Background(), "Patient", map[string]interface{}{"meta": meta(tenant)}, &patient)
+	if err != nil {
+		t.Fatalf("unable to create the patient: %v", err)
+	}
+
+	patientID := patient["id"].(string)
+
+	for _, identifiers := range []dto.TenantIdentifiers{tenant, otherFacility} {
+		allergy := map[string]interface{}{
+			"patient": map[string]interface{}{"reference": "Patient/" + patientID},
+			"meta":    meta(identifiers),
+		}
+
+		err = dataset.CreateFHIRResource(context.Background(), "AllergyIntolerance", allergy, &map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("unable to create the allergy: %v", err)
+		}
+	}
+
+	timeline, err := fh.GetFHIRPatientTimeline(context.Background(), patientID, tenant)
+	if err != nil {
+		t.Fatalf("StoreImpl.GetFHIRPatientTimeline() error = %v", err)
+	}
+
+	if len(timeline.AllergyIntolerances) != 1 {
+		t.Errorf("expected only the allergy recorded at the facility, got %d allergies", len(timeline.AllergyIntolerances))
+	}
+}
+
+func TestStoreImpl_ExportFHIRResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		searchErr error
+		writeErr  error
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: export every page",
+			wantCount: 3,
+			wantErr:   false,
+		},
+		{
+			name:      "Sad case: search error",
+			searchErr: fmt.Errorf("an error occurred"),
+			wantErr:   true,
+		},
+		{
+			name:     "Sad case: write error",
+			writeErr: fmt.Errorf("an error occurred"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if tt.searchErr != nil {
+					return nil, tt.searchErr
+				}
+
+				if pagination.After == "" {
+					return &domain.PagedFHIRResource{
+						Resources: []map[string]interface{}{
+							{"resourceType": resourceType, "id": "1"},
+							{"resourceType": resourceType, "id": "2"},
+						},
+						HasNextPage: true,
+						NextCursor:  "2",
+					}, nil
+				}
+
+				return &domain.PagedFHIRResource{
+					Resources: []map[string]interface{}{{"resourceType": resourceType, "id": "3"}},
+				}, nil
+			}
+
+			count := 0
+
+			err := fh.ExportFHIRResources(context.Background(), "Patient", dto.TenantIdentifiers{}, func(resource map[string]interface{}) error {
+				count++
+
+				return tt.writeErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.ExportFHIRResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && count != tt.wantCount {
+				t.Errorf("expected %d resources, got %d", tt.wantCount, count)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_ImportFHIRResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		created map[string]interface{}
+		err     error
+		wantID  string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: import resource",
+			created: map[string]interface{}{"resourceType": "Patient", "id": "1"},
+			wantID:  "1",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: created resource has no ID",
+			created: map[string]interface{}{"resourceType": "Patient"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: create error",
+			err:     fmt.Errorf("an error occurred"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+				if tt.err != nil {
+					return tt.err
+				}
+
+				bs, err := json.Marshal(tt.created)
+				if err != nil {
+					return err
+				}
+
+				return json.Unmarshal(bs, resource)
+			}
+
+			got, err := fh.ImportFHIRResource(context.Background(), "Patient", map[string]interface{}{"resourceType": "Patient"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.ImportFHIRResource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.wantID {
+				t.Errorf("StoreImpl.ImportFHIRResource() = %v, want %v", got, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_GetFHIRPatientEverything(t *testing.T) {
+	tests := []struct {
+		name        string
+		patients    []map[string]interface{}
+		searchErr   error
+		everything  []byte
+		tenant      dto.TenantIdentifiers
+		allDataErr  error
+		wantEntries int
+		wantErr     error
+	}{
+		{
+			name:        "Happy case: patient everything",
+			patients:    []map[string]interface{}{{"resourceType": "Patient", "id": "1"}},
+			everything:  []byte(`{"resourceType":"Bundle","type":"searchset","entry":[{"fullUrl":"Patient/1","resource":{"resourceType":"Patient","id":"1"},"search":{"mode":"match"}},{"fullUrl":"Encounter/1","resource":{"resourceType":"Encounter","id":"1"}},{"fullUrl":"Encounter/2"}]}`),
+			wantEntries: 2,
+		},
+		{
+			name:        "Happy case: resources of other organisations are left out",
+			patients:    []map[string]interface{}{{"resourceType": "Patient", "id": "1"}},
+			everything:  []byte(`{"resourceType":"Bundle","type":"searchset","entry":[{"fullUrl":"Patient/1","resource":{"resourceType":"Patient","id":"1","meta":{"tag":[{"system":"http://mycarehub/tenant-identification/organisation","code":"org-1"}]}}},{"fullUrl":"Encounter/1","resource":{"resourceType":"Encounter","id":"1","meta":{"tag":[{"system":"http://mycarehub/tenant-identification/organisation","code":"org-2"}]}}},{"fullUrl":"Encounter/2","resource":{"resourceType":"Encounter","id":"2"}}]}`),
+			tenant:      dto.TenantIdentifiers{OrganizationID: "org-1", FacilityID: "facility-1"},
+			wantEntries: 1,
+		},
+		{
+			name:     "Sad case: patient not in tenant",
+			patients: []map[string]interface{}{},
+			wantErr:  domain.ErrFHIRResourceNotFound,
+		},
+		{
+			name:      "Sad case: search error",
+			searchErr: fmt.Errorf("an error occurred"),
+			wantErr:   fmt.Errorf("an error occurred"),
+		},
+		{
+			name:       "Sad case: patient everything error",
+			patients:   []map[string]interface{}{{"resourceType": "Patient", "id": "1"}},
+			allDataErr: fmt.Errorf("an error occurred"),
+			wantErr:    fmt.Errorf("an error occurred"),
+		},
+		{
+			name:       "Sad case: invalid bundle",
+			patients:   []map[string]interface{}{{"resourceType": "Patient", "id": "1"}},
+			everything: []byte(`{"entry":1}`),
+			wantErr:    fmt.Errorf("an error occurred"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if tt.searchErr != nil {
+					return nil, tt.searchErr
+				}
+
+				return &domain.PagedFHIRResource{Resources: tt.patients}, nil
+			}
+
+			dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
+				return tt.everything, tt.allDataErr
+			}
+
+			got, err := fh.GetFHIRPatientEverything(context.Background(), "1", tt.tenant)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("StoreImpl.GetFHIRPatientEverything() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if errors.Is(tt.wantErr, domain.ErrFHIRResourceNotFound) && !errors.Is(err, domain.ErrFHIRResourceNotFound) {
+				t.Errorf("expected a not found error, got %v", err)
+			}
+
+			if tt.wantErr == nil && (got.Type != domain.FHIRBundleTypeEnumCollection || len(got.Entry) != tt.wantEntries) {
+				t.Errorf("expected a collection Bundle with %d entries, got %#v", tt.wantEntries, got)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_RetireFHIRPatient(t *testing.T) {
+	retired := domain.FHIRPatient{}
+	retired.Retire("Duplicate record", time.Now())
+
+	retiredPatient, _ := converterandformatter.StructToMap(retired)
+
+	tests := []struct {
+		name      string
+		patients  []map[string]interface{}
+		searchErr error
+		updateErr error
+		wantErr   error
+	}{
+		{
+			name:     "Happy case: retire patient",
+			patients: []map[string]interface{}{{"resourceType": "Patient", "id": "1", "active": true}},
+		},
+		{
+			name:     "Sad case: patient already retired",
+			patients: []map[string]interface{}{retiredPatient},
+			wantErr:  fmt.Errorf("an error occurred"),
+		},
+		{
+			name:     "Sad case: patient not in tenant",
+			patients: []map[string]interface{}{},
+			wantErr:  domain.ErrFHIRResourceNotFound,
+		},
+		{
+			name:      "Sad case: search error",
+			searchErr: fmt.Errorf("an error occurred"),
+			wantErr:   fmt.Errorf("an error occurred"),
+		},
+		{
+			name:      "Sad case: update error",
+			patients:  []map[string]interface{}{{"resourceType": "Patient", "id": "1", "active": true}},
+			updateErr: domain.ErrFHIRVersionConflict,
+			wantErr:   domain.ErrFHIRVersionConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if tt.searchErr != nil {
+					return nil, tt.searchErr
+				}
+
+				return &domain.PagedFHIRResource{Resources: tt.patients}, nil
+			}
+
+			dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				if tt.updateErr != nil {
+					return tt.updateErr
+				}
+
+				data, err := json.Marshal(payload)
+				if err != nil {
+					return err
+				}
+
+				return json.Unmarshal(data, resource)
+			}
+
+			got, err := fh.RetireFHIRPatient(context.Background(), "1", "Duplicate record", dto.TenantIdentifiers{})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("StoreImpl.RetireFHIRPatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr != nil {
+				if errors.Is(tt.wantErr, domain.ErrFHIRResourceNotFound) || errors.Is(tt.wantErr, domain.ErrFHIRVersionConflict) {
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("expected %v, got %v", tt.wantErr, err)
+					}
+				}
+
+				return
+			}
+
+			reason, _, ok := got.Retirement()
+			if !ok || reason != "Duplicate record" || got.Active == nil || *got.Active {
+				t.Errorf("expected an inactive patient retired as a duplicate, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_ReinstateFHIRPatient(t *testing.T) {
+	retired := domain.FHIRPatient{}
+	retired.Retire("Duplicate record", time.Now())
+
+	retiredPatient, _ := converterandformatter.StructToMap(retired)
+	retiredPatient["resourceType"], retiredPatient["id"] = "Patient", "1"
+
+	mergedID, targetID := "1", "2"
+	merged := domain.FHIRPatient{ID: &mergedID}
+	merged.Retire("Duplicate record", time.Now())
+	merged.MergeInto(&domain.FHIRPatient{ID: &targetID}, []string{}, time.Now())
+
+	mergedPatient, _ := converterandformatter.StructToMap(merged)
+	mergedPatient["resourceType"] = "Patient"
+
+	tests := []struct {
+		name      string
+		patients  []map[string]interface{}
+		searchErr error
+		updateErr error
+		wantErr   error
+	}{
+		{
+			name:     "Happy case: reinstate patient",
+			patients: []map[string]interface{}{retiredPatient},
+		},
+		{
+			name:     "Sad case: patient not retired",
+			patients: []map[string]interface{}{{"resourceType": "Patient", "id": "1", "active": true}},
+			wantErr:  fmt.Errorf("an error occurred"),
+		},
+		{
+			name:     "Sad case: patient merged into another patient",
+			patients: []map[string]interface{}{mergedPatient},
+			wantErr:  fmt.Errorf("an error occurred"),
+		},
+		{
+			name:     "Sad case: patient not in tenant",
+			patients: []map[string]interface{}{},
+			wantErr:  domain.ErrFHIRResourceNotFound,
+		},
+		{
+			name:      "Sad case: search error",
+			searchErr: fmt.Errorf("an error occurred"),
+			wantErr:   fmt.Errorf("an error occurred"),
+		},
+		{
+			name:      "Sad case: update error",
+			patients:  []map[string]interface{}{retiredPatient},
+			updateErr: fmt.Errorf("an error occurred"),
+			wantErr:   fmt.Errorf("an error occurred"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if tt.searchErr != nil {
+					return nil, tt.searchErr
+				}
+
+				return &domain.PagedFHIRResource{Resources: tt.patients}, nil
+			}
+
+			dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				if tt.updateErr != nil {
+					return tt.updateErr
+				}
+
+				data, err := json.Marshal(payload)
+				if err != nil {
+					return err
+				}
+
+				return json.Unmarshal(data, resource)
+			}
+
+			got, err := fh.ReinstateFHIRPatient(context.Background(), "1", dto.TenantIdentifiers{})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("StoreImpl.ReinstateFHIRPatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if errors.Is(tt.wantErr, domain.ErrFHIRResourceNotFound) && !errors.Is(err, domain.ErrFHIRResourceNotFound) {
+				t.Errorf("expected a not found error, got %v", err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if _, _, ok := got.Retirement(); ok || got.Active == nil || !*got.Active {
+				t.Errorf("expected an active patient, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_MergeFHIRPatients(t *testing.T) {
+	sourceID, targetID := gofakeit.UUID(), gofakeit.UUID()
+
+	tests := []struct {
+		name     string
+		sourceID string
+		wantErr  bool
+	}{
+		{
+			name:     "Happy case: merge patients",
+			sourceID: sourceID,
+		},
+		{
+			name:     "Sad case: merge patient into itself",
+			sourceID: targetID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: source already merged",
+			sourceID: sourceID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: target merged into another patient",
+			sourceID: sourceID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: source not in tenant",
+			sourceID: sourceID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: search error",
+			sourceID: sourceID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: commit error",
+			sourceID: sourceID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: too many resources to merge",
+			sourceID: sourceID,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			patients := map[string]map[string]interface{}{
+				sourceID: {"resourceType": "Patient", "id": sourceID, "active": true, "meta": map[string]interface{}{"versionId": "1"}},
+				targetID: {"resourceType": "Patient", "id": targetID, "active": true, "meta": map[string]interface{}{"versionId": "1"}},
+			}
+
+			if tt.name == "Sad case: source already merged" || tt.name == "Sad case: target merged into another patient" {
+				id := sourceID
+				if tt.name == "Sad case: target merged into another patient" {
+					id = targetID
+				}
+
+				other := gofakeit.UUID()
+				merged := domain.FHIRPatient{ID: &id}
+				merged.MergeInto(&domain.FHIRPatient{ID: &other}, nil, time.Now())
+
+				patients[id], _ = converterandformatter.StructToMap(merged)
+			}
+
+			if tt.name == "Sad case: source not in tenant" {
+				delete(patients, sourceID)
+			}
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if resourceType == "Patient" {
+					resources := []map[string]interface{}{}
+					if patient, ok := patients[params.Values().Get("_id")]; ok {
+						resources = append(resources, patient)
+					}
+
+					return &domain.PagedFHIRResource{Resources: resources}, nil
+				}
+
+				if tt.name == "Sad case: search error" {
+					return nil, fmt.Errorf("an error occurred")
+				}
+
+				// the patient's records are moved from every facility
+				if tenant.OrganizationID != "organisation" || tenant.FacilityID != "" {
+					t.Errorf("expected the resources of the organisation to be searched, got %v", tenant)
+				}
+
+				if params.Values().Get("patient") != "Patient/"+sourceID || (resourceType != "Encounter" && resourceType != "Observation") {
+					return &domain.PagedFHIRResource{}, nil
+				}
+
+				if tt.name == "Sad case: too many resources to merge" {
+					resources := []map[string]interface{}{}
+
+					for i := 0; i < 5000; i++ {
+						resources = append(resources, map[string]interface{}{
+							"resourceType": resourceType,
+							"id":           strconv.Itoa(i),
+							"subject":      map[string]interface{}{"reference": "Patient/" + sourceID},
+						})
+					}
+
+					return &domain.PagedFHIRResource{Resources: resources}, nil
+				}
+
+				return &domain.PagedFHIRResource{Resources: []map[string]interface{}{{
+					"resourceType": resourceType,
+					"id":           "1",
+					"subject":      map[string]interface{}{"reference": "Patient/" + sourceID},
+					"performer":    []interface{}{map[string]interface{}{"reference": "Patient/" + sourceID}},
+				}}}, nil
+			}
+
+			var committed domain.FHIRBundle
+
+			dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+				if tt.name == "Sad case: commit error" {
+					return nil, domain.ErrFHIRVersionConflict
+				}
+
+				committed = bundle
+
+				return &domain.FHIRBundle{}, nil
+			}
+
+			tenant := dto.TenantIdentifiers{OrganizationID: "organisation", FacilityID: "facility"}
+
+			got, err := fh.MergeFHIRPatients(context.Background(), tt.sourceID, targetID, tenant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.MergeFHIRPatients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				if tt.name == "Sad case: commit error" && !errors.Is(err, domain.ErrFHIRVersionConflict) {
+					t.Errorf("expected a version conflict, got %v", err)
+				}
+
+				if tt.name == "Sad case: too many resources to merge" && (!errors.Is(err, domain.ErrFHIRTransactionTooLarge) || committed.Entry != nil) {
+					t.Errorf("expected the merge to fail before anything is written, got %v", err)
+				}
+
+				return
+			}
+
+			if *got.ID != targetID || len(got.Link) != 1 || *got.Link[0].Other.Reference != "Patient/"+sourceID {
+				t.Errorf("expected the target to be linked to the source, got %#v", got)
+			}
+
+			entries := map[string]map[string]interface{}{}
+			for _, entry := range committed.Entry {
+				entries[entry.Request.URL] = entry.Resource
+			}
+
+			if len(entries) != 5 {
+				t.Fatalf("expected the resources, patients and a provenance to be written, got %v", committed.Entry)
+			}
+
+			for _, reference := range []string{"Encounter/1", "Observation/1"} {
+				data, _ := json.Marshal(entries[reference])
+				if strings.Contains(string(data), sourceID) || strings.Count(string(data), targetID) != 2 {
+					t.Errorf("expected %s to reference the target, got %s", reference, data)
+				}
+			}
+
+			source := domain.FHIRPatient{}
+			data, _ := json.Marshal(entries["Patient/"+sourceID])
+			_ = json.Unmarshal(data, &source)
+
+			mergedInto, moved, _, merged := source.Merge()
+			if !merged || mergedInto != targetID || len(moved) != 2 || source.Active == nil || *source.Active {
+				t.Errorf("expected an inactive source that records the merge, got %s", data)
+			}
+
+			if !strings.Contains(string(data), `"replaced-by"`) {
+				t.Errorf("expected a replaced-by link, got %s", data)
+			}
+
+			for url, resource := range entries {
+				if !strings.HasPrefix(url, "Provenance/") {
+					continue
+				}
+
+				provenance := domain.FHIRProvenance{}
+				data, _ := json.Marshal(resource)
+				_ = json.Unmarshal(data, &provenance)
+
+				if len(provenance.Target) != 4 || provenance.Activity.Coding[0].Code != domain.ProvenanceActivityMerge || *provenance.Agent[0].Who.Reference != "Organization/facility" {
+					t.Errorf("unexpected provenance %s", data)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreImpl_UnmergeFHIRPatient(t *testing.T) {
+	sourceID, targetID := gofakeit.UUID(), gofakeit.UUID()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "Happy case: unmerge patient",
+		},
+		{
+			name:    "Sad case: patient not merged",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: target not in tenant",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: commit error",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			source := domain.FHIRPatient{ID: &sourceID}
+			target := domain.FHIRPatient{ID: &targetID}
+
+			if tt.name != "Sad case: patient not merged" {
+				source.MergeInto(&target, []string{"Encounter/1"}, time.Now())
+			}
+
+			patients := map[string]map[string]interface{}{}
+			patients[sourceID], _ = converterandformatter.StructToMap(source)
+			patients[targetID], _ = converterandformatter.StructToMap(target)
+
+			if tt.name == "Sad case: target not in tenant" {
+				delete(patients, targetID)
+			}
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if resourceType == "Patient" {
+					resources := []map[string]interface{}{}
+					if patient, ok := patients[params.Values().Get("_id")]; ok {
+						resources = append(resources, patient)
+					}
+
+					return &domain.PagedFHIRResource{Resources: resources}, nil
+				}
+
+				if resourceType != "Encounter" {
+					return &domain.PagedFHIRResource{}, nil
+				}
+
+				// the second encounter was recorded against the target after the merge
+				return &domain.PagedFHIRResource{Resources: []map[string]interface{}{
+					{"resourceType": "Encounter", "id": "1", "subject": map[string]interface{}{"reference": "Patient/" + targetID}},
+					{"resourceType": "Encounter", "id": "2", "subject": map[string]interface{}{"reference": "Patient/" + targetID}},
+				}}, nil
+			}
+
+			var committed domain.FHIRBundle
+
+			dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+				if tt.name == "Sad case: commit error" {
+					return nil, fmt.Errorf("an error occurred")
+				}
+
+				committed = bundle
+
+				return &domain.FHIRBundle{}, nil
+			}
+
+			got, err := fh.UnmergeFHIRPatient(context.Background(), sourceID, dto.TenantIdentifiers{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.UnmergeFHIRPatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if _, _, _, merged := got.Merge(); merged || got.Active == nil || !*got.Active || len(got.Link) != 0 {
+				t.Errorf("expected an active patient that is not merged, got %#v", got)
+			}
+
+			entries := map[string]map[string]interface{}{}
+			for _, entry := range committed.Entry {
+				entries[entry.Request.URL] = entry.Resource
+			}
+
+			if _, ok := entries["Encounter/2"]; ok {
+				t.Errorf("expected the encounter recorded after the merge to be left with the target")
+			}
+
+			data, _ := json.Marshal(entries["Encounter/1"])
+			if !strings.Contains(string(data), "Patient/"+sourceID) {
+				t.Errorf("expected the moved encounter to be moved back, got %s", data)
+			}
+
+			data, _ = json.Marshal(entries["Patient/"+targetID])
+			if strings.Contains(string(data), sourceID) {
+				t.Errorf("expected the target's link to be removed, got %s", data)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_CascadeDeleteFHIRPatient(t *testing.T) {
+	everything := []byte(`{"resourceType":"Bundle","type":"searchset","entry":[
+		{"resource":{"resourceType":"Patient","id":"p1"}},
+		{"resource":{"resourceType":"EpisodeOfCare","id":"eoc1","patient":{"reference":"Patient/p1"}}},
+		{"resource":{"resourceType":"Encounter","id":"e1","subject":{"reference":"Patient/p1"},"episodeOfCare":[{"reference":"EpisodeOfCare/eoc1"}],"diagnosis":[{"condition":{"reference":"Condition/c1"}}]}},
+		{"resource":{"resourceType":"Condition","id":"c1","subject":{"reference":"https://example.com/fhir/Patient/p1/_history/2"},"encounter":{"reference":"Encounter/e1"}}},
+		{"resource":{"resourceType":"Observation","id":"o1","subject":{"reference":"Patient/p1"},"encounter":{"reference":"Encounter/e1"},"performer":[{"reference":"Practitioner/x"}],"hasMember":[{"reference":"#contained"}]}}
+	]}`)
+
+	tests := []struct {
+		name        string
+		everything  []byte
+		dryRun      bool
+		commitErr   error
+		wantDeleted int
+		wantErr     bool
+	}{
+		{
+			name:        "Happy case: delete in reference order",
+			everything:  everything,
+			wantDeleted: 5,
+		},
+		{
+			name:        "Happy case: dry run",
+			everything:  everything,
+			dryRun:      true,
+			wantDeleted: 5,
+		},
+		{
+			name:       "Happy case: nothing to delete",
+			everything: []byte(`{"resourceType":"Bundle","type":"searchset"}`),
+		},
+		{
+			name:       "Sad case: resource without an ID",
+			everything: []byte(`{"entry":[{"resource":{"resourceType":"Patient"}}]}`),
+			wantErr:    true,
+		},
+		{
+			name:       "Sad case: commit error",
+			everything: everything,
+			commitErr:  fmt.Errorf("an error occurred"),
+			wantErr:    true,
+		},
+		{
+			name:       "Sad case: patient not in tenant",
+			everything: everything,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if tt.name == "Sad case: patient not in tenant" {
+					return &domain.PagedFHIRResource{}, nil
+				}
+
+				return &domain.PagedFHIRResource{Resources: []map[string]interface{}{{"resourceType": "Patient", "id": "p1"}}}, nil
+			}
+
+			dataset.MockGetFHIRPatientAllDataFn = func(ctx context.Context, fhirResourceID string) ([]byte, error) {
+				return tt.everything, nil
+			}
+
+			executed := []string{}
+
+			dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+				if tt.commitErr != nil {
+					return nil, tt.commitErr
+				}
+
+				for _, entry := range bundle.Entry {
+					executed = append(executed, entry.Request.URL)
+				}
+
+				return &domain.FHIRBundle{}, nil
+			}
+
+			got, err := fh.CascadeDeleteFHIRPatient(context.Background(), "p1", dto.TenantIdentifiers{}, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.CascadeDeleteFHIRPatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.DryRun != tt.dryRun || len(got.Deleted) != tt.wantDeleted {
+				t.Fatalf("expected %d deleted resources, got %#v", tt.wantDeleted, got)
+			}
+
+			if tt.dryRun || tt.wantDeleted == 0 {
+				if len(executed) != 0 {
+					t.Errorf("expected nothing to be deleted, got %v", executed)
+				}
+
+				return
+			}
+
+			if strings.Join(executed, ",") != strings.Join(got.Deleted, ",") {
+				t.Errorf("expected the reported resources to be deleted, got %v and %v", executed, got.Deleted)
+			}
+
+			position := map[string]int{}
+			for i, reference := range got.Deleted {
+				position[reference] = i
+			}
+
+			// each resource is deleted before the resources it references,
+			// except within the encounter and condition cycle
+			for _, dependency := range [][2]string{
+				{"Observation/o1", "Encounter/e1"},
+				{"Encounter/e1", "EpisodeOfCare/eoc1"},
+				{"EpisodeOfCare/eoc1", "Patient/p1"},
+				{"Condition/c1", "Patient/p1"},
+			} {
+				if position[dependency[0]] > position[dependency[1]] {
+					t.Errorf("expected %s to be deleted before %s, got %v", dependency[0], dependency[1], got.Deleted)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreImpl_SearchExpiredFHIRResources(t *testing.T) {
+	cutoff := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		searchErr error
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: search expired resources",
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name:      "Sad case: search error",
+			searchErr: fmt.Errorf("an error occurred"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+				if tt.searchErr != nil {
+					return nil, tt.searchErr
+				}
+
+				if got := params.Values().Get("_lastUpdated"); got != "lt2023-01-01T00:00:00Z" {
+					t.Errorf("expected resources last updated before the cutoff, got %q", got)
+				}
+
+				if tenant.OrganizationID != "org" {
+					t.Errorf("expected the tenant's resources, got %v", tenant)
+				}
+
+				return &domain.PagedFHIRResource{
+					Resources: []map[string]interface{}{
+						{"resourceType": resourceType, "id": "1"},
+						{"resourceType": resourceType, "id": "2"},
+					},
+				}, nil
+			}
+
+			count := 0
+
+			err := fh.SearchExpiredFHIRResources(context.Background(), "Observation", cutoff, dto.TenantIdentifiers{OrganizationID: "org"}, func(resource map[string]interface{}) error {
+				count++
+
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.SearchExpiredFHIRResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && count != tt.wantCount {
+				t.Errorf("expected %d resources, got %d", tt.wantCount, count)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_PurgeFHIRResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: purge resource",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: delete error",
+			err:     fmt.Errorf("an error occurred"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
+				return tt.err
+			}
+
+			if err := fh.PurgeFHIRResource(context.Background(), "Observation", "1"); (err != nil) != tt.wantErr {
+				t.Errorf("StoreImpl.PurgeFHIRResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_StartEncounter_TimeZone(t *testing.T) {
+	at := time.Date(2023, time.March, 1, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timeZone string
+		// readerTimeZone is the time zone of the facility read through the
+		// store's organization reader e.g the organization cache
+		readerTimeZone string
+		// transaction starts the encounter within a transaction
+		transaction bool
+		want        string
+	}{
+		{
+			name:     "Happy case: the start is in the facility's time zone",
+			timeZone: "America/New_York",
+			want:     "2023-03-01T00:00:00-05:00",
+		},
+		{
+			name: "Happy case: the start is in the default time zone",
+			want: "2023-03-01T08:00:00+03:00",
+		},
+		{
+			name:           "Happy case: the facility is read through the organization reader",
+			readerTimeZone: "America/New_York",
+			want:           "2023-03-01T00:00:00-05:00",
+		},
+		{
+			name:           "Happy case: the organization reader is kept within a transaction",
+			readerTimeZone: "America/New_York",
+			transaction:    true,
+			want:           "2023-03-01T00:00:00-05:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(common.DefaultTimeZoneEnvVar, "")
+
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+			fh.Clock = domain.FixedClock{Time: at}
+
+			if tt.readerTimeZone != "" {
+				organizations := fakeFHIRMock.NewFHIRMock()
+				organizations.MockGetFHIROrganizationFn = func(ctx context.Context, organisationID string) (*domain.FHIROrganizationRelayPayload, error) {
+					return &domain.FHIROrganizationRelayPayload{Resource: &domain.FHIROrganization{
+						ID:        &organisationID,
+						Extension: []*domain.Extension{{URL: domain.TimeZoneExtensionURL, ValueCode: tt.readerTimeZone}},
+					}}, nil
+				}
+
+				fh.Organizations = organizations
+			}
+
+			id := gofakeit.UUID()
+			status := domain.EpisodeOfCareStatusEnumActive
+			reference := "Patient/" + id
+
+			dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+				var payload interface{} = domain.FHIREpisodeOfCare{
+					ID:                   &id,
+					Status:               &status,
+					Patient:              &domain.FHIRReference{Reference: &reference},
+					ManagingOrganization: &domain.FHIRReference{},
+				}
+
+				if resourceType == "Organization" {
+					payload = domain.FHIROrganization{
+						ID:        &fhirResourceID,
+						Extension: []*domain.Extension{{URL: domain.TimeZoneExtensionURL, ValueCode: tt.timeZone}},
+					}
+				}
+
+				data, err := json.Marshal(payload)
+				if err != nil {
+					return err
+				}
+
+				return json.Unmarshal(data, resource)
+			}
+
+			start := ""
+
+			dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+				period, _ := payload["period"].(map[string]interface{})
+				start, _ = period["start"].(string)
+
+				return json.Unmarshal([]byte(fmt.Sprintf(`{"id": %q}`, id)), resource)
+			}
+
+			dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+				for _, entry := range bundle.Entry {
+					period, _ := entry.Resource["period"].(map[string]interface{})
+					start, _ = period["start"].(string)
+				}
+
+				return &domain.FHIRBundle{}, nil
+			}
+
+			ctx := context.WithValue(context.Background(), utils.FacilityIDContextKey, gofakeit.UUID())
+
+			var err error
+			if tt.transaction {
+				err = fh.RunInTransaction(ctx, func(store repository.FHIR) error {
+					_, err := store.StartEncounter(ctx, id)
+					return err
+				})
+			} else {
+				_, err = fh.StartEncounter(ctx, id)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if start != tt.want {
+				t.Errorf("expected the encounter to start at %s, got %s", tt.want, start)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_EndEncounter_EndTime(t *testing.T) {
+	endTime := time.Date(2023, time.March, 1, 7, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		period    *domain.FHIRPeriod
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: the end is recorded as given",
+			period:    &domain.FHIRPeriod{Start: "2023-03-01T08:00:00+03:00"},
+			wantStart: "2023-03-01T08:00:00+03:00",
+			wantEnd:   "2023-03-01T10:30:00+03:00",
+		},
+		{
+			name:    "Sad case: the encounter has no start",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: the end is before the start",
+			period:  &domain.FHIRPeriod{Start: "2023-03-01T11:00:00+03:00"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: the start cannot be read",
+			period:  &domain.FHIRPeriod{Start: "February"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(common.DefaultTimeZoneEnvVar, "")
+
+			dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+			fh := FHIR.NewFHIRStoreImpl(dataset)
+
+			id := gofakeit.UUID()
+
+			dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+				data, err := json.Marshal(domain.FHIREncounter{ID: &id, Period: tt.period})
+				if err != nil {
+					return err
+				}
+
+				return json.Unmarshal(data, resource)
+			}
+
+			var period map[string]interface{}
+
+			dataset.MockUpdateFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+				period, _ = payload["period"].(map[string]interface{})
+
+				return nil
+			}
+
+			_, err := fh.EndEncounter(context.Background(), id, endTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StoreImpl.EndEncounter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if period["start"] != tt.wantStart || period["end"] != tt.wantEnd {
+				t.Errorf("expected the period %s - %s, got %v", tt.wantStart, tt.wantEnd, period)
+			}
+		})
+	}
+}
@@ -16,30 +16,70 @@ import (
 
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/serverutils"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/healthcare/v1"
+	"google.golang.org/api/option"
 )
 
 // constants used to configure the Google Cloud Healthcare API
 const (
 	baseFHIRURL           = "https://healthcare.googleapis.com/v1"
 	defaultTimeoutSeconds = 10
+	fhirContentType       = "application/fhir+json; charset=utf-8"
 )
 
 // Repository accesses and updates patient data that is stored on Healthcare
 // FHIR repository
 type Repository struct {
 	healthcareService                           *healthcare.Service
+	tokenSource                                 oauth2.TokenSource
+	httpClient                                  *http.Client
 	projectID, location, datasetID, fhirStoreID string
 	parent                                      string
 	datasetName                                 string
 	fhirStoreName                               string
 }
 
-// NewFHIRRepository initializes a FHIR repository
-func NewFHIRRepository(_ context.Context, hsv *healthcare.Service, projectID, datasetID, datasetLocation, fhirStoreID string) *Repository {
+// NewFHIRRepository initializes a FHIR repository.
+//
+// The repository holds on to a token source, which caches the access token
+// until it expires, and a single HTTP client that every request goes
+// through, so credentials are looked up once and connections are reused. A
+// nil token source uses the application default credentials. Requests are
+// sent through the given transport, or `http.DefaultTransport` if it is nil,
+// and their latency is recorded (see `Views`).
+func NewFHIRRepository(
+	ctx context.Context, tokenSource oauth2.TokenSource, transport http.RoundTripper,
+	projectID, datasetID, datasetLocation, fhirStoreID string,
+) (*Repository, error) {
+	if tokenSource == nil {
+		creds, err := google.FindDefaultCredentials(ctx, healthcare.CloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("default creds error: %w", err)
+		}
+
+		tokenSource = creds.TokenSource
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	tokenSource = oauth2.ReuseTokenSource(nil, tokenSource)
+	httpClient := &http.Client{
+		Transport: &oauth2.Transport{Source: tokenSource, Base: &metricsTransport{base: transport}},
+	}
+
+	hsv, err := healthcare.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize new Google Cloud Healthcare Service: %w", err)
+	}
+
 	return &Repository{
 		healthcareService: hsv,
+		tokenSource:       tokenSource,
+		httpClient:        httpClient,
 		projectID:         projectID,
 		location:          datasetLocation,
 		datasetID:         datasetID,
@@ -47,7 +87,7 @@ func NewFHIRRepository(_ context.Context, hsv *healthcare.Service, projectID, da
 		parent:            fmt.Sprintf("projects/%s/locations/%s", projectID, datasetLocation),
 		datasetName:       fmt.Sprintf("projects/%s/locations/%s/datasets/%s", projectID, datasetLocation, datasetID),
 		fhirStoreName:     fmt.Sprintf("projects/%s/locations/%s/datasets/%s/fhirStores/%s", projectID, datasetLocation, datasetID, fhirStoreID),
-	}
+	}, nil
 }
 
 // CreateDataset creates a dataset and returns it's name
@@ -139,8 +179,12 @@ func getErrorMessage(respBytes []byte) (errorText string, diagnostics string, er
 
 // CreateFHIRResource creates an FHIR resource.
 //
-// The payload should be the result of marshalling a resource to JSON
-func (fr Repository) CreateFHIRResource(resourceType string, payload map[string]interface{}, resource interface{}) error {
+// The payload should be the result of marshalling a resource to JSON.
+//
+// If the payload has a source identifier (see
+// `domain.SourceIdentifierSystem`) the resource is only created if no resource
+// with that identifier exists. Otherwise the existing resource is returned.
+func (fr Repository) CreateFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 	fr.checkPreconditions()
 
 	payload["resourceType"] = resourceType
@@ -157,7 +201,11 @@ func (fr Repository) CreateFHIRResource(resourceType string, payload map[string]
 	call := fhirService.Create(fr.fhirStoreName, resourceType, bytes.NewReader(jsonPayload))
 	call.Header().Set("Content-Type", "application/fhir+json;charset=utf-8")
 
-	resp, err := call.Do()
+	if query := domain.FHIRSourceIdentifierQuery(payload); query != "" {
+		call.Header().Set("If-None-Exist", query)
+	}
+
+	resp, err := call.Context(ctx).Do()
 	if err != nil {
 		return fmt.Errorf("create: %w", err)
 	}
@@ -189,13 +237,13 @@ func (fr Repository) CreateFHIRResource(resourceType string, payload map[string]
 }
 
 // DeleteFHIRResource deletes an FHIR resource.
-func (fr Repository) DeleteFHIRResource(resourceType, fhirResourceID string) error {
+func (fr Repository) DeleteFHIRResource(ctx context.Context, resourceType, fhirResourceID string) error {
 	fr.checkPreconditions()
 
 	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
 	fhirResource := fmt.Sprintf("%s/fhir/%s/%s", fr.fhirStoreName, resourceType, fhirResourceID)
 
-	resp, err := fhirService.Delete(fhirResource).Do()
+	resp, err := fhirService.Delete(fhirResource).Context(ctx).Do()
 	if err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
@@ -230,7 +278,7 @@ func (fr Repository) DeleteFHIRResource(resourceType, fhirResourceID string) err
 //
 // See: https://www.hl7.org/fhir/http.html#patch
 func (fr Repository) PatchFHIRResource(
-	resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
+	ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
 	fr.checkPreconditions()
 
 	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
@@ -248,7 +296,11 @@ func (fr Repository) PatchFHIRResource(
 	call := fhirService.Patch(fhirResource, bytes.NewReader(jsonPayload))
 	call.Header().Set("Content-Type", "application/json-patch+json")
 
-	resp, err := call.Do()
+	if versionID := domain.FHIRPatchVersion(payload); versionID != "" {
+		call.Header().Set("If-Match", domain.FHIRVersionETag(versionID))
+	}
+
+	resp, err := call.Context(ctx).Do()
 	if err != nil {
 		return fmt.Errorf("patch: %w", err)
 	}
@@ -266,6 +318,11 @@ func (fr Repository) PatchFHIRResource(
 		return fmt.Errorf("could not read response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return fmt.Errorf(
+			"patch: status %d %s: %s: %w", resp.StatusCode, resp.Status, respBytes, domain.ErrFHIRVersionConflict)
+	}
+
 	if resp.StatusCode > 299 {
 		return fmt.Errorf(
 			"patch: status %d %s: %s", resp.StatusCode, resp.Status, respBytes)
@@ -282,8 +339,40 @@ func (fr Repository) PatchFHIRResource(
 }
 
 // UpdateFHIRResource updates the entire contents of a resource.
+//
+// If the payload has a `meta.versionId` the update only succeeds if that is
+// still the current version of the resource. Otherwise an error wrapping
+// `domain.ErrFHIRVersionConflict` is returned.
+//
+// An encounter or episode of care whose period is rejected is updated again
+// with the end of its period padded to a day after its start (see
+// `paddedPeriodEnd`).
 func (fr Repository) UpdateFHIRResource(
-	resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+	ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+	statusCode, err := fr.updateFHIRResource(ctx, resourceType, fhirResourceID, payload, resource)
+	if err == nil || (statusCode != http.StatusBadRequest && statusCode != http.StatusUnprocessableEntity) {
+		return err
+	}
+
+	padded, ok := paddedPeriodEnd(resourceType, payload)
+	if !ok {
+		return err
+	}
+
+	log.Printf("the period of %s/%s was rejected, updating it with its end a day after its start: %v", resourceType, fhirResourceID, err)
+
+	_, paddedErr := fr.updateFHIRResource(ctx, resourceType, fhirResourceID, padded, resource)
+	if paddedErr != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateFHIRResource updates a resource and returns the status code of the
+// response
+func (fr Repository) updateFHIRResource(
+	ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) (int, error) {
 	fr.checkPreconditions()
 
 	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
@@ -292,7 +381,7 @@ func (fr Repository) UpdateFHIRResource(
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("json.Encode: %w", err)
+		return 0, fmt.Errorf("json.Encode: %w", err)
 	}
 
 	if serverutils.IsDebug() {
@@ -303,9 +392,13 @@ func (fr Repository) UpdateFHIRResource(
 	call := fhirService.Update(fhirResource, bytes.NewReader(jsonPayload))
 	call.Header().Set("Content-Type", "application/fhir+json;charset=utf-8")
 
-	resp, err := call.Do()
+	if versionID := domain.FHIRPayloadVersion(payload); versionID != "" {
+		call.Header().Set("If-Match", domain.FHIRVersionETag(versionID))
+	}
+
+	resp, err := call.Context(ctx).Do()
 	if err != nil {
-		return fmt.Errorf("update: %w", err)
+		return 0, fmt.Errorf("update: %w", err)
 	}
 
 	defer func() {
@@ -314,33 +407,128 @@ func (fr Repository) UpdateFHIRResource(
 
 	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("could not read response: %w", err)
+		return resp.StatusCode, fmt.Errorf("could not read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return resp.StatusCode, fmt.Errorf(
+			"update: status %d %s: %s: %w", resp.StatusCode, resp.Status, respBytes, domain.ErrFHIRVersionConflict)
 	}
 
 	if resp.StatusCode > 299 {
-		return fmt.Errorf(
+		return resp.StatusCode, fmt.Errorf(
 			"update: status %d %s: %s", resp.StatusCode, resp.Status, respBytes)
 	}
 
 	err = json.Unmarshal(respBytes, resource)
 	if err != nil {
-		return fmt.Errorf(
+		return resp.StatusCode, fmt.Errorf(
 			"unable to unmarshal %s response JSON: data: %v\n, error: %w",
 			resourceType, string(respBytes), err)
 	}
 
-	return nil
+	return resp.StatusCode, nil
 }
 
 // GetFHIRPatientAllData gets all resources associated with a particular
 // patient compartment.
-func (fr Repository) GetFHIRPatientAllData(fhirResourceID string) ([]byte, error) {
+//
+// The store pages the operation's results so every page is fetched and the
+// entries are returned as a single `searchset` Bundle.
+func (fr Repository) GetFHIRPatientAllData(ctx context.Context, fhirResourceID string) ([]byte, error) {
 	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
 	patientResource := fmt.Sprintf("%s/fhir/Patient/%s", fr.fhirStoreName, fhirResourceID)
 
-	resp, err := fhirService.PatientEverything(patientResource).Do()
+	entries := []interface{}{}
+	token := ""
+
+	for {
+		call := fhirService.PatientEverything(patientResource).Context(ctx)
+		if token != "" {
+			call = call.PageToken(token)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("PatientAllData: %w", err)
+		}
+
+		respBytes, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read response: %w", err)
+		}
+
+		if resp.StatusCode > 299 {
+			return nil, fmt.Errorf("PatientAllData: status %d %s: %s", resp.StatusCode, resp.Status, respBytes)
+		}
+
+		bundle := struct {
+			Link []struct {
+				Relation string `json:"relation"`
+				URL      string `json:"url"`
+			} `json:"link"`
+			Entry []interface{} `json:"entry"`
+		}{}
+
+		err = json.Unmarshal(respBytes, &bundle)
+		if err != nil {
+			return nil, fmt.Errorf("PatientAllData: unable to unmarshal bundle: %w", err)
+		}
+
+		entries = append(entries, bundle.Entry...)
+		token = ""
+
+		for _, link := range bundle.Link {
+			if link.Relation != "next" {
+				continue
+			}
+
+			token, err = pageToken(link.URL)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if token == "" {
+			break
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"resourceType": "Bundle",
+		"type":         "searchset",
+		"total":        len(entries),
+		"entry":        entries,
+	})
+}
+
+// ExecuteBundle executes the entries of a transaction or batch Bundle.
+//
+// A transaction is executed atomically by the FHIR store and fails as a whole
+// if any of its entries fail.
+func (fr Repository) ExecuteBundle(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+	fr.checkPreconditions()
+
+	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
+	bundle.ResourceType = "Bundle"
+
+	jsonPayload, err := json.Marshal(bundle)
 	if err != nil {
-		return nil, fmt.Errorf("PatientAllData: %w", err)
+		return nil, fmt.Errorf("json.Encode: %w", err)
+	}
+
+	if serverutils.IsDebug() {
+		log.Printf("FHIR Bundle payload: %s", string(jsonPayload))
+	}
+
+	call := fhirService.ExecuteBundle(fr.fhirStoreName, bytes.NewReader(jsonPayload))
+	call.Header().Set("Content-Type", "application/fhir+json;charset=utf-8")
+
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("execute bundle: %w", err)
 	}
 
 	defer func() {
@@ -353,21 +541,37 @@ func (fr Repository) GetFHIRPatientAllData(fhirResourceID string) ([]byte, error
 	}
 
 	if resp.StatusCode > 299 {
-		return nil, fmt.Errorf("PatientAllData: status %d %s: %s", resp.StatusCode, resp.Status, respBytes)
+		errorText, diagnostics, err := getErrorMessage(respBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusPreconditionFailed {
+			return nil, fmt.Errorf("%s: %s: %s: %w", resp.Status, errorText, diagnostics, domain.ErrFHIRVersionConflict)
+		}
+
+		return nil, fmt.Errorf("%s: %s: %s", resp.Status, errorText, diagnostics)
 	}
 
-	return respBytes, nil
+	response := &domain.FHIRBundle{}
+
+	err = json.Unmarshal(respBytes, response)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal bundle response JSON: data: %v\n, error: %w", string(respBytes), err)
+	}
+
+	return response, nil
 }
 
 // GetFHIRResource gets an FHIR resource.
-func (fr Repository) GetFHIRResource(resourceType, fhirResourceID string, resource interface{}) error {
+func (fr Repository) GetFHIRResource(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 	fr.checkPreconditions()
 	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
 	fhirResource := fmt.Sprintf("%s/fhir/%s/%s", fr.fhirStoreName, resourceType, fhirResourceID)
 	call := fhirService.Read(fhirResource)
 	call.Header().Set("Content-Type", "application/fhir+json;charset=utf-8")
 
-	resp, err := call.Do()
+	resp, err := call.Context(ctx).Do()
 	if err != nil {
 		return fmt.Errorf("read: %w", err)
 	}
@@ -400,33 +604,186 @@ func (fr Repository) GetFHIRResource(resourceType, fhirResourceID string, resour
 	return nil
 }
 
-// SearchFHIRResource ...
-func (fr Repository) SearchFHIRResource(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+// GetFHIRResourceVersion gets a specific version of a resource (vread)
+func (fr Repository) GetFHIRResourceVersion(ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error {
+	fr.checkPreconditions()
+	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
+	fhirResource := fmt.Sprintf("%s/fhir/%s/%s/_history/%s", fr.fhirStoreName, resourceType, fhirResourceID, versionID)
+	call := fhirService.Vread(fhirResource)
+	call.Header().Set("Content-Type", "application/fhir+json;charset=utf-8")
+
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("vread: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		_, diagnostics, err := getErrorMessage(respBytes)
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%s", diagnostics)
+	}
+
+	err = json.Unmarshal(respBytes, resource)
+	if err != nil {
+		return fmt.Errorf(
+			"unable to unmarshal %s , id:%s , version:%s ,response JSON: data: %v\n, error: %w",
+			resourceType, fhirResourceID, versionID, string(respBytes), err)
+	}
+
+	return nil
+}
+
+// GetFHIRResourceHistory lists the versions of a resource, newest first.
+//
+// Versions that record the deletion of the resource do not have a resource
+// and are left out.
+func (fr Repository) GetFHIRResourceHistory(
+	ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
 	err := pagination.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	if params == nil {
-		return nil, fmt.Errorf("can't search with nil params")
-	}
+	fr.checkPreconditions()
+	fhirService := fr.healthcareService.Projects.Locations.Datasets.FhirStores.Fhir
+	fhirResource := fmt.Sprintf("%s/fhir/%s/%s", fr.fhirStoreName, resourceType, fhirResourceID)
+	call := fhirService.History(fhirResource)
 
 	if !pagination.Skip {
-		params["_count"] = strconv.Itoa(*pagination.First)
+		call = call.Count(int64(*pagination.First))
 		if pagination.After != "" {
-			params["_page_token"] = pagination.After
+			call = call.PageToken(pagination.After)
 		}
 	}
 
-	urlParams := url.Values{}
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
 
-	for k, v := range params {
-		val, ok := v.(string)
-		if !ok {
-			return nil, fmt.Errorf("the search/filter param: %s should all be sent as strings", k)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		_, diagnostics, err := getErrorMessage(respBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%s", diagnostics)
+	}
+
+	bundle := struct {
+		Type  string `json:"type"`
+		Total int    `json:"total"`
+		Link  []struct {
+			Relation string `json:"relation"`
+			URL      string `json:"url"`
+		} `json:"link"`
+		Entry []struct {
+			Resource map[string]interface{} `json:"resource"`
+		} `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(respBytes, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %s history: %w", resourceType, err)
+	}
+
+	if bundle.Type != "history" {
+		return nil, fmt.Errorf("server error: the type value is not 'history' as expected")
+	}
+
+	response := domain.PagedFHIRResource{
+		Resources:  []map[string]interface{}{},
+		TotalCount: bundle.Total,
+	}
+
+	for _, entry := range bundle.Entry {
+		if entry.Resource == nil {
+			continue
+		}
+
+		response.Resources = append(response.Resources, entry.Resource)
+	}
+
+	for _, link := range bundle.Link {
+		if link.Relation != "next" {
+			continue
 		}
 
-		urlParams.Add(k, val)
+		u, err := url.Parse(link.URL)
+		if err != nil {
+			return nil, fmt.Errorf("server error: cannot parse url in link: %w", err)
+		}
+
+		response.HasNextPage = true
+		response.NextCursor = u.Query().Get("_page_token")
+	}
+
+	return &response, nil
+}
+
+// pageToken returns the `_page_token` of a search result's `next` or
+// `previous` link, which is the cursor of that page
+func pageToken(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("server error: cannot parse url in link: %w", err)
+	}
+
+	params, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return "", fmt.Errorf("server error: cannot parse url params in link: %w", err)
+	}
+
+	return params.Get("_page_token"), nil
+}
+
+// SearchFHIRResource searches for resources in the FHIR store. Pages are
+// requested forward with `First` and `After`, or backward with `Last` and
+// `Before`, using the page tokens of the `next` and `previous` links.
+func (fr Repository) SearchFHIRResource(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+	err := pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	urlParams := params.Values()
+
+	if !pagination.Skip {
+		size := pagination.First
+		if pagination.Last != nil {
+			size = pagination.Last
+		}
+
+		urlParams.Set("_count", strconv.Itoa(*size))
+
+		switch {
+		case pagination.Before != "":
+			urlParams.Set("_page_token", pagination.Before)
+		case pagination.After != "":
+			urlParams.Set("_page_token", pagination.After)
+		}
 	}
 
 	urlParams.Add("_tag", fmt.Sprintf("http://mycarehub/tenant-identification/organisation|%s", tenant.OrganizationID))
@@ -434,7 +791,7 @@ func (fr Repository) SearchFHIRResource(resourceType string, params map[string]i
 
 	path := "_search"
 
-	bs, err := fr.POSTRequest(resourceType, path, urlParams, nil)
+	bs, err := fr.POSTRequest(ctx, resourceType, path, urlParams, nil)
 	if err != nil {
 		return nil, fmt.Errorf("unable to search: %w", err)
 	}
@@ -444,7 +801,7 @@ func (fr Repository) SearchFHIRResource(resourceType string, params map[string]i
 	err = json.Unmarshal(bs, &respMap)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"%s could not be found with search params %v: %w", resourceType, params, err)
+			"%s could not be found with search params %s: %w", resourceType, params.Encode(), err)
 	}
 
 	mandatoryKeys := []string{"resourceType", "type", "total", "link"}
@@ -485,9 +842,10 @@ func (fr Repository) SearchFHIRResource(resourceType string, params map[string]i
 
 	response.TotalCount = int(respMap["total"].(float64))
 
+	// an empty page has no entries but can still link to the other pages
 	respEntries := respMap["entry"]
 	if respEntries == nil {
-		return &response, nil
+		respEntries = []interface{}{}
 	}
 
 	entries, ok := respEntries.([]interface{})
@@ -516,6 +874,18 @@ func (fr Repository) SearchFHIRResource(resourceType string, params map[string]i
 			return nil, fmt.Errorf("server error: result entry %#v is not a map", entry["resource"])
 		}
 
+		// resources returned by `_include` and `_revinclude` are not matches
+		search, _ := entry["search"].(map[string]interface{})
+		if search["mode"] == "include" {
+			if response.Included == nil {
+				response.Included = map[string]map[string]interface{}{}
+			}
+
+			response.Included[fmt.Sprintf("%s/%s", resource["resourceType"], resource["id"])] = resource
+
+			continue
+		}
+
 		response.Resources = append(response.Resources, resource)
 	}
 
@@ -537,21 +907,24 @@ func (fr Repository) SearchFHIRResource(resourceType string, params map[string]i
 				"server error: expected each link to be map, they are %T instead", en)
 		}
 
-		if link["relation"].(string) == "next" {
-			u, err := url.Parse(link["url"].(string))
-			if err != nil {
-				return nil, fmt.Errorf("server error: cannot parse url in link: %w", err)
-			}
+		relation, _ := link["relation"].(string)
+		if relation != "next" && relation != "previous" {
+			continue
+		}
 
-			params, err := url.ParseQuery(u.RawQuery)
-			if err != nil {
-				return nil, fmt.Errorf("server error: cannot parse url params in link: %w", err)
-			}
+		linkURL, _ := link["url"].(string)
 
-			cursor := params["_page_token"][0]
+		cursor, err := pageToken(linkURL)
+		if err != nil {
+			return nil, err
+		}
 
+		if relation == "next" {
 			response.HasNextPage = true
 			response.NextCursor = cursor
+		} else {
+			response.HasPreviousPage = true
+			response.PreviousCursor = cursor
 		}
 	}
 
@@ -564,29 +937,23 @@ func (fr Repository) SearchFHIRResource(resourceType string, params map[string]i
 // - `path` is a sub-path e.g `_search` under a resource
 // - `params` should be query params, sent as `url.Values`
 func (fr Repository) POSTRequest(
-	resourceName string, path string, params url.Values, body io.Reader) ([]byte, error) {
-	fhirHeaders, err := fr.FHIRHeaders()
-	if err != nil {
-		return nil, fmt.Errorf("unable to get FHIR headers: %w", err)
-	}
+	ctx context.Context, resourceName string, path string, params url.Values, body io.Reader) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*defaultTimeoutSeconds)
+	defer cancel()
 
 	url := fmt.Sprintf(
 		"%s/%s/%s?%s", fr.FHIRRestURL(), resourceName, path, params.Encode())
 
-	req, err := http.NewRequest("POST", url, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("unable to compose FHIR POST request: %w", err)
 	}
 
-	for k, v := range fhirHeaders {
-		for _, h := range v {
-			req.Header.Add(k, h)
-		}
-	}
+	// the client authenticates the request
+	req.Header.Set("Content-Type", fhirContentType)
+	req.Header.Set("Accept", fhirContentType)
 
-	httpClient := &http.Client{Timeout: time.Second * defaultTimeoutSeconds}
-
-	resp, err := httpClient.Do(req)
+	resp, err := fr.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP response error: %w", err)
 	}
@@ -612,41 +979,22 @@ func (fr Repository) POSTRequest(
 	return respBytes, nil
 }
 
-// GetBearerToken logs in and gets a Google bearer auth token.
-// The user referred to by `cloudhealthEmail` needs to have IAM permissions
-// that allow them to read and write from the project's Cloud Healthcare base.
-func GetBearerToken() (string, error) {
-	ctx := context.Background()
-	scopes := []string{
-		"https://www.googleapis.com/auth/cloud-platform",
-	}
-
-	creds, err := google.FindDefaultCredentials(ctx, scopes...)
-	if err != nil {
-		return "", fmt.Errorf("default creds error: %w", err)
-	}
-
-	token, err := creds.TokenSource.Token()
-	if err != nil {
-		return "", fmt.Errorf("oauth token error: %w", err)
-	}
-
-	return fmt.Sprintf("Bearer %s", token.AccessToken), nil
-}
-
 // FHIRHeaders composes suitable FHIR headers, with authentication and content
-// type already set
+// type already set. The access token is reused until it expires.
+//
+// The service account needs to have IAM permissions that allow it to read and
+// write from the project's Cloud Healthcare base.
 func (fr Repository) FHIRHeaders() (http.Header, error) {
 	headers := make(map[string][]string)
 
-	bearerHeader, err := GetBearerToken()
+	token, err := fr.tokenSource.Token()
 	if err != nil {
-		return nil, fmt.Errorf("can't get bearer token: %w", err)
+		return nil, fmt.Errorf("oauth token error: %w", err)
 	}
 
-	headers["Content-Type"] = []string{"application/fhir+json; charset=utf-8"}
-	headers["Accept"] = []string{"application/fhir+json; charset=utf-8"}
-	headers["Authorization"] = []string{bearerHeader}
+	headers["Content-Type"] = []string{fhirContentType}
+	headers["Accept"] = []string{fhirContentType}
+	headers["Authorization"] = []string{fmt.Sprintf("%s %s", token.Type(), token.AccessToken)}
 
 	return headers, nil
 }
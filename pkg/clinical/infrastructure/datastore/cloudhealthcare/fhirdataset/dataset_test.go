@@ -0,0 +1,257 @@
+package fhirdataset_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/fhirdataset"
+	"go.opencensus.io/stats/view"
+	"golang.org/x/oauth2"
+)
+
+var _ fhir.Dataset = (*fhirdataset.Repository)(nil)
+
+// countingTokenSource counts how many times a token is fetched
+type countingTokenSource struct {
+	calls int32
+}
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	atomic.AddInt32(&s.calls, 1)
+
+	return &oauth2.Token{AccessToken: "secret", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+// roundTripperFunc is a fake transport that answers every request
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func respond(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/fhir+json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestRepository_ReusesCredentials(t *testing.T) {
+	err := view.Register(fhirdataset.Views...)
+	if err != nil {
+		t.Fatalf("unable to register views: %v", err)
+	}
+	defer view.Unregister(fhirdataset.Views...)
+
+	tokens := &countingTokenSource{}
+	authorizations := []string{}
+
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+
+		if strings.HasSuffix(req.URL.Path, "/_search") {
+			return respond(http.StatusOK, `{"resourceType": "Bundle", "type": "searchset", "total": 0, "link": []}`), nil
+		}
+
+		return respond(http.StatusOK, `{"resourceType": "Patient", "id": "1"}`), nil
+	})
+
+	repo, err := fhirdataset.NewFHIRRepository(context.Background(), tokens, transport, "project", "dataset", "location", "store")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	first := 1
+	for i := 0; i < 3; i++ {
+		_, err = repo.SearchFHIRResource(
+			context.Background(), "Observation", domain.NewSearchParams(), dto.TenantIdentifiers{},
+			dto.Pagination{First: &first})
+		if err != nil {
+			t.Fatalf("SearchFHIRResource() error = %v", err)
+		}
+	}
+
+	err = repo.GetFHIRResource(context.Background(), "Patient", "1", &map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("GetFHIRResource() error = %v", err)
+	}
+
+	if calls := atomic.LoadInt32(&tokens.calls); calls != 1 {
+		t.Errorf("expected the token to be fetched once, got %d", calls)
+	}
+
+	for _, authorization := range authorizations {
+		if authorization != "Bearer secret" {
+			t.Errorf("expected every request to be authenticated, got %q", authorization)
+		}
+	}
+
+	rows, err := view.RetrieveData(fhirdataset.RequestCountView.Name)
+	if err != nil {
+		t.Fatalf("unable to retrieve metrics: %v", err)
+	}
+
+	counts := map[string]int64{}
+
+	for _, row := range rows {
+		interaction := ""
+
+		for _, tag := range row.Tags {
+			if tag.Key == fhirdataset.Interaction {
+				interaction = tag.Value
+			}
+		}
+
+		counts[interaction] += row.Data.(*view.CountData).Value
+	}
+
+	if counts["search"] != 3 || counts["read"] != 1 {
+		t.Errorf("expected the latency of 3 searches and 1 read to be recorded, got %v", counts)
+	}
+}
+
+func TestRepository_SearchFHIRResource_PageBackward(t *testing.T) {
+	var query url.Values
+
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		query = req.URL.Query()
+
+		return respond(http.StatusOK, `{
+			"resourceType": "Bundle",
+			"type": "searchset",
+			"total": 5,
+			"link": [
+				{"relation": "self", "url": "https://healthcare.googleapis.com/fhir/Condition/?_page_token=current"},
+				{"relation": "previous", "url": "https://healthcare.googleapis.com/fhir/Condition/?_count=2&_page_token=before"},
+				{"relation": "next", "url": "https://healthcare.googleapis.com/fhir/Condition/?_count=2&_page_token=after"}
+			]
+		}`), nil
+	})
+
+	repo, err := fhirdataset.NewFHIRRepository(context.Background(), &countingTokenSource{}, transport, "project", "dataset", "location", "store")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	last := 2
+
+	page, err := repo.SearchFHIRResource(
+		context.Background(), "Condition", domain.NewSearchParams(), dto.TenantIdentifiers{},
+		dto.Pagination{Last: &last, Before: "current"})
+	if err != nil {
+		t.Fatalf("SearchFHIRResource() error = %v", err)
+	}
+
+	if query.Get("_count") != "2" || query.Get("_page_token") != "current" {
+		t.Errorf("expected the page before the cursor to be requested, got %v", query)
+	}
+
+	if !page.HasPreviousPage || page.PreviousCursor != "before" || !page.HasNextPage || page.NextCursor != "after" {
+		t.Errorf("expected the previous and next page cursors to be set, got %#v", page)
+	}
+}
+
+func TestRepository_UpdateFHIRResource_PeriodEnd(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		period       map[string]interface{}
+		// rejected is the status of the response to the real end
+		rejected int
+		wantEnds []string
+		wantErr  bool
+	}{
+		{
+			name:         "Happy case: the real end is accepted",
+			resourceType: "Encounter",
+			period:       map[string]interface{}{"start": "2023-03-01T08:00:00+03:00", "end": "2023-03-01T10:30:00+03:00"},
+			wantEnds:     []string{"2023-03-01T10:30:00+03:00"},
+		},
+		{
+			name:         "Happy case: a rejected end is padded to a day after the start",
+			resourceType: "EpisodeOfCare",
+			period:       map[string]interface{}{"start": "2023-03-01T08:00:00+03:00", "end": "2023-03-01T10:30:00+03:00"},
+			rejected:     http.StatusBadRequest,
+			wantEnds:     []string{"2023-03-01T10:30:00+03:00", "2023-03-02T08:00:00+03:00"},
+		},
+		{
+			name:         "Sad case: a period that lasts a day is not padded",
+			resourceType: "Encounter",
+			period:       map[string]interface{}{"start": "2023-03-01T08:00:00+03:00", "end": "2023-03-03T10:30:00+03:00"},
+			rejected:     http.StatusBadRequest,
+			wantEnds:     []string{"2023-03-03T10:30:00+03:00"},
+			wantErr:      true,
+		},
+		{
+			name:         "Sad case: other resources are not padded",
+			resourceType: "Observation",
+			period:       map[string]interface{}{"start": "2023-03-01T08:00:00+03:00", "end": "2023-03-01T10:30:00+03:00"},
+			rejected:     http.StatusBadRequest,
+			wantEnds:     []string{"2023-03-01T10:30:00+03:00"},
+			wantErr:      true,
+		},
+		{
+			name:         "Sad case: a version conflict is not retried",
+			resourceType: "Encounter",
+			period:       map[string]interface{}{"start": "2023-03-01T08:00:00+03:00", "end": "2023-03-01T10:30:00+03:00"},
+			rejected:     http.StatusPreconditionFailed,
+			wantEnds:     []string{"2023-03-01T10:30:00+03:00"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ends := []string{}
+
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				body := map[string]interface{}{}
+
+				err := json.NewDecoder(req.Body).Decode(&body)
+				if err != nil {
+					return nil, err
+				}
+
+				period, _ := body["period"].(map[string]interface{})
+				end, _ := period["end"].(string)
+				ends = append(ends, end)
+
+				if tt.rejected != 0 && len(ends) == 1 {
+					return respond(tt.rejected, `{"resourceType": "OperationOutcome"}`), nil
+				}
+
+				return respond(http.StatusOK, `{"id": "1"}`), nil
+			})
+
+			repo, err := fhirdataset.NewFHIRRepository(context.Background(), &countingTokenSource{}, transport, "project", "dataset", "location", "store")
+			if err != nil {
+				t.Fatalf("unable to initialize repository: %v", err)
+			}
+
+			payload := map[string]interface{}{"id": "1", "period": tt.period}
+
+			err = repo.UpdateFHIRResource(context.Background(), tt.resourceType, "1", payload, &map[string]interface{}{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateFHIRResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if strings.Join(ends, ",") != strings.Join(tt.wantEnds, ",") {
+				t.Errorf("expected the ends %v to be sent, got %v", tt.wantEnds, ends)
+			}
+
+			if tt.period["end"] != tt.wantEnds[0] {
+				t.Errorf("expected the payload not to be modified, got the end %v", tt.period["end"])
+			}
+		})
+	}
+}
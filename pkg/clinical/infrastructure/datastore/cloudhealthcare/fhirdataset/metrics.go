@@ -0,0 +1,123 @@
+package fhirdataset
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/serverutils"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Measures and views of the requests made to the FHIR store
+var (
+	// RequestLatency is the time taken by a request to the FHIR store,
+	// including any retries
+	RequestLatency = stats.Float64(
+		"fhir_request_latency",
+		"The latency in milliseconds of requests to the FHIR store",
+		"ms",
+	)
+
+	// ResourceType is the FHIR resource type of the request e.g `Observation`
+	ResourceType = tag.MustNewKey("fhir.resource_type")
+
+	// Interaction is the FHIR interaction e.g `search`, `read` or `update`
+	Interaction = tag.MustNewKey("fhir.interaction")
+
+	// StatusCode is the HTTP status of the response, or `error` if there was none
+	StatusCode = tag.MustNewKey("http.status")
+
+	RequestLatencyView = &view.View{
+		Name:        "fhir_request_latency_distribution",
+		Description: "Time taken by requests to the FHIR store",
+		Measure:     RequestLatency,
+		Aggregation: view.Distribution(serverutils.LatencyBounds...),
+		TagKeys:     []tag.Key{ResourceType, Interaction, StatusCode},
+	}
+
+	RequestCountView = &view.View{
+		Name:        "fhir_request_count",
+		Description: "The number of requests to the FHIR store",
+		Measure:     RequestLatency,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{ResourceType, Interaction, StatusCode},
+	}
+
+	// Views should be registered for the FHIR store metrics to be exported
+	Views = []*view.View{RequestLatencyView, RequestCountView}
+)
+
+// metricsTransport records the latency of every request it sends
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends the request and records its latency
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	resourceType, interaction := describe(req)
+
+	ctx, _ := tag.New(req.Context(),
+		tag.Upsert(ResourceType, resourceType),
+		tag.Upsert(Interaction, interaction),
+		tag.Upsert(StatusCode, status),
+	)
+
+	stats.Record(ctx, RequestLatency.M(float64(time.Since(started))/float64(time.Millisecond)))
+
+	return resp, err
+}
+
+// describe works out the resource type and FHIR interaction of a request
+// from its path e.g `.../fhir/Observation/_search` is an Observation search
+func describe(req *http.Request) (resourceType string, interaction string) {
+	if strings.HasSuffix(req.URL.Path, "/fhir") {
+		return "", "transaction"
+	}
+
+	_, path, found := strings.Cut(req.URL.Path, "/fhir/")
+	if !found {
+		return "", "administration"
+	}
+
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return "", "transaction"
+	}
+
+	resourceType = segments[0]
+
+	switch last := segments[len(segments)-1]; {
+	case len(segments) == 4 && segments[2] == "_history":
+		return resourceType, "vread"
+
+	case strings.HasPrefix(last, "_") || strings.HasPrefix(last, "$"):
+		return resourceType, strings.TrimLeft(last, "_$")
+
+	case len(segments) == 1:
+		return resourceType, "create"
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		return resourceType, "update"
+	case http.MethodPatch:
+		return resourceType, "patch"
+	case http.MethodDelete:
+		return resourceType, "delete"
+	default:
+		return resourceType, "read"
+	}
+}
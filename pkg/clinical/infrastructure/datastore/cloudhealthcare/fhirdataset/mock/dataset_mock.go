@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
@@ -11,41 +12,44 @@ import (
 
 // FakeFHIRRepository is a mock FHIR repository
 type FakeFHIRRepository struct {
-	MockCreateFHIRResourceFn    func(resourceType string, payload map[string]interface{}, resource interface{}) error
-	MockDeleteFHIRResourceFn    func(resourceType, fhirResourceID string) error
-	MockPatchFHIRResourceFn     func(resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error
-	MockUpdateFHIRResourceFn    func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error
-	MockGetFHIRPatientAllDataFn func(fhirResourceID string) ([]byte, error)
-	MockGetFHIRResourceFn       func(resourceType, fhirResourceID string, resource interface{}) error
-	MockSearchFHIRResourceFn    func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error)
+	MockCreateFHIRResourceFn     func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error
+	MockDeleteFHIRResourceFn     func(ctx context.Context, resourceType, fhirResourceID string) error
+	MockPatchFHIRResourceFn      func(ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error
+	MockUpdateFHIRResourceFn     func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error
+	MockGetFHIRPatientAllDataFn  func(ctx context.Context, fhirResourceID string) ([]byte, error)
+	MockGetFHIRResourceFn        func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error
+	MockSearchFHIRResourceFn     func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error)
+	MockExecuteBundleFn          func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error)
+	MockGetFHIRResourceHistoryFn func(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error)
+	MockGetFHIRResourceVersionFn func(ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error
 }
 
 // NewFakeFHIRRepositoryMock initializes a new FakeFHIRRepositoryMock
 func NewFakeFHIRRepositoryMock() *FakeFHIRRepository {
 	return &FakeFHIRRepository{
-		MockCreateFHIRResourceFn: func(resourceType string, payload map[string]interface{}, resource interface{}) error {
+		MockCreateFHIRResourceFn: func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
 			return nil
 		},
-		MockDeleteFHIRResourceFn: func(resourceType, fhirResourceID string) error {
+		MockDeleteFHIRResourceFn: func(ctx context.Context, resourceType, fhirResourceID string) error {
 			return nil
 		},
-		MockPatchFHIRResourceFn: func(resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
+		MockPatchFHIRResourceFn: func(ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
 			return nil
 		},
-		MockUpdateFHIRResourceFn: func(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+		MockUpdateFHIRResourceFn: func(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
 			return nil
 		},
-		MockGetFHIRPatientAllDataFn: func(fhirResourceID string) ([]byte, error) {
+		MockGetFHIRPatientAllDataFn: func(ctx context.Context, fhirResourceID string) ([]byte, error) {
 			bs, err := json.Marshal("m")
 			if err != nil {
 				return nil, fmt.Errorf("unable to marshal map to JSON: %w", err)
 			}
 			return bs, nil
 		},
-		MockGetFHIRResourceFn: func(resourceType, fhirResourceID string, resource interface{}) error {
+		MockGetFHIRResourceFn: func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
 			return nil
 		},
-		MockSearchFHIRResourceFn: func(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+		MockSearchFHIRResourceFn: func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
 			n := map[string]interface{}{"given": []string{"John"}, "family": []string{"Doe"}}
 			p := map[string]interface{}{
 				"resourceType": "Patient/",
@@ -71,40 +75,87 @@ func NewFakeFHIRRepositoryMock() *FakeFHIRRepository {
 				Resources: m,
 			}, nil
 		},
+		MockExecuteBundleFn: func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+			return &domain.FHIRBundle{
+				ResourceType: "Bundle",
+				Type:         domain.FHIRBundleTypeEnumTransactionResponse,
+			}, nil
+		},
+		MockGetFHIRResourceHistoryFn: func(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+			return &domain.PagedFHIRResource{
+				Resources: []map[string]interface{}{
+					{
+						"resourceType": resourceType,
+						"id":           fhirResourceID,
+						"meta": map[string]interface{}{
+							"versionId":   "2",
+							"lastUpdated": time.Now().Format(time.RFC3339),
+						},
+					},
+					{
+						"resourceType": resourceType,
+						"id":           fhirResourceID,
+						"meta": map[string]interface{}{
+							"versionId":   "1",
+							"lastUpdated": time.Now().Add(-time.Hour).Format(time.RFC3339),
+						},
+					},
+				},
+				TotalCount: 2,
+			}, nil
+		},
+		MockGetFHIRResourceVersionFn: func(ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error {
+			return nil
+		},
 	}
 }
 
 // CreateFHIRResource ...
-func (f *FakeFHIRRepository) CreateFHIRResource(resourceType string, payload map[string]interface{}, resource interface{}) error {
-	return f.MockCreateFHIRResourceFn(resourceType, payload, resource)
+func (f *FakeFHIRRepository) CreateFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+	return f.MockCreateFHIRResourceFn(ctx, resourceType, payload, resource)
 }
 
 // DeleteFHIRResource ...
-func (f *FakeFHIRRepository) DeleteFHIRResource(resourceType, fhirResourceID string) error {
-	return f.MockDeleteFHIRResourceFn(resourceType, fhirResourceID)
+func (f *FakeFHIRRepository) DeleteFHIRResource(ctx context.Context, resourceType, fhirResourceID string) error {
+	return f.MockDeleteFHIRResourceFn(ctx, resourceType, fhirResourceID)
 }
 
 // PatchFHIRResource ...
-func (f *FakeFHIRRepository) PatchFHIRResource(resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
-	return f.MockPatchFHIRResourceFn(resourceType, fhirResourceID, payload, resource)
+func (f *FakeFHIRRepository) PatchFHIRResource(ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
+	return f.MockPatchFHIRResourceFn(ctx, resourceType, fhirResourceID, payload, resource)
 }
 
 // UpdateFHIRResource ...
-func (f *FakeFHIRRepository) UpdateFHIRResource(resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
-	return f.MockUpdateFHIRResourceFn(resourceType, fhirResourceID, payload, resource)
+func (f *FakeFHIRRepository) UpdateFHIRResource(ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+	return f.MockUpdateFHIRResourceFn(ctx, resourceType, fhirResourceID, payload, resource)
 }
 
 // GetFHIRPatientAllData ...
-func (f *FakeFHIRRepository) GetFHIRPatientAllData(fhirResourceID string) ([]byte, error) {
-	return f.MockGetFHIRPatientAllDataFn(fhirResourceID)
+func (f *FakeFHIRRepository) GetFHIRPatientAllData(ctx context.Context, fhirResourceID string) ([]byte, error) {
+	return f.MockGetFHIRPatientAllDataFn(ctx, fhirResourceID)
 }
 
 // GetFHIRResource ...
-func (f *FakeFHIRRepository) GetFHIRResource(resourceType, fhirResourceID string, resource interface{}) error {
-	return f.MockGetFHIRResourceFn(resourceType, fhirResourceID, resource)
+func (f *FakeFHIRRepository) GetFHIRResource(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+	return f.MockGetFHIRResourceFn(ctx, resourceType, fhirResourceID, resource)
 }
 
 // SearchFHIRResource ...
-func (f *FakeFHIRRepository) SearchFHIRResource(resourceType string, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
-	return f.MockSearchFHIRResourceFn(resourceType, params, tenant, pagination)
+func (f *FakeFHIRRepository) SearchFHIRResource(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+	return f.MockSearchFHIRResourceFn(ctx, resourceType, params, tenant, pagination)
+}
+
+// ExecuteBundle ...
+func (f *FakeFHIRRepository) ExecuteBundle(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+	return f.MockExecuteBundleFn(ctx, bundle)
+}
+
+// GetFHIRResourceHistory ...
+func (f *FakeFHIRRepository) GetFHIRResourceHistory(ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+	return f.MockGetFHIRResourceHistoryFn(ctx, resourceType, fhirResourceID, pagination)
+}
+
+// GetFHIRResourceVersion ...
+func (f *FakeFHIRRepository) GetFHIRResourceVersion(ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error {
+	return f.MockGetFHIRResourceVersionFn(ctx, resourceType, fhirResourceID, versionID, resource)
 }
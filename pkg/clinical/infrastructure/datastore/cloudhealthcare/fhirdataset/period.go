@@ -0,0 +1,68 @@
+package fhirdataset
+
+import (
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// minimumPeriodLength is how long after its start the Cloud Healthcare API has
+// been seen to require a period to end
+const minimumPeriodLength = 24 * time.Hour
+
+// periodResourceTypes are the resources whose periods are padded when the
+// Cloud Healthcare API rejects them
+var periodResourceTypes = map[string]bool{
+	"Encounter":     true,
+	"EpisodeOfCare": true,
+}
+
+// paddedPeriodEnd works around the Cloud Healthcare API rejecting periods that
+// end less than a day after they start. It returns a copy of the payload whose
+// period ends a day after its start. It reports false when the resource has no
+// such period, so that the update is not retried.
+//
+// The workaround is only applied after the API rejects the real end, and the
+// padded end makes the resource look longer than it was.
+func paddedPeriodEnd(resourceType string, payload map[string]interface{}) (map[string]interface{}, bool) {
+	if !periodResourceTypes[resourceType] {
+		return nil, false
+	}
+
+	period, ok := payload["period"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	start, startOK := period["start"].(string)
+	end, endOK := period["end"].(string)
+
+	if !startOK || !endOK {
+		return nil, false
+	}
+
+	startTime, err := domain.ParseDateTime(start)
+	if err != nil {
+		return nil, false
+	}
+
+	endTime, err := domain.ParseDateTime(end)
+	if err != nil || !endTime.Before(startTime.Add(minimumPeriodLength)) {
+		return nil, false
+	}
+
+	padded := map[string]interface{}{}
+	for key, value := range payload {
+		padded[key] = value
+	}
+
+	paddedPeriod := map[string]interface{}{}
+	for key, value := range period {
+		paddedPeriod[key] = value
+	}
+
+	paddedPeriod["end"] = string(domain.FormatDateTime(startTime.Add(minimumPeriodLength)))
+	padded["period"] = paddedPeriod
+
+	return padded, true
+}
@@ -1,14 +1,19 @@
 package fhir
 
 import (
-	"github.com/savannahghi/clinical/pkg/clinical/application/common/helpers"
-	"github.com/savannahghi/scalarutils"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	log "github.com/sirupsen/logrus"
 )
 
 func birthdateMapper(resource map[string]interface{}) map[string]interface{} {
 	resourceCopy := resource
 
-	parsedDate := helpers.ParseDate(resourceCopy["birthDate"].(string))
+	birthDate, _ := resourceCopy["birthDate"].(string)
+
+	parsedDate, err := domain.ParseDateTime(birthDate)
+	if err != nil {
+		log.Errorf("cannot parse the birth date: %v", err)
+	}
 
 	dateMap := make(map[string]interface{})
 
@@ -21,16 +26,32 @@ func birthdateMapper(resource map[string]interface{}) map[string]interface{} {
 	return resourceCopy
 }
 
-func periodMapper(period map[string]interface{}) map[string]interface{} {
-	periodCopy := period
+// dateTimeMapper formats a FHIR dateTime as an RFC3339 time, keeping its
+// offset. Values that are not dateTimes are returned unchanged
+func dateTimeMapper(value interface{}) interface{} {
+	dateTime, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	parsed, err := domain.ParseDateTime(dateTime)
+	if err != nil {
+		log.Errorf("cannot parse the date time: %v", err)
 
-	parsedStartDate := helpers.ParseDate(periodCopy["start"].(string))
+		return value
+	}
 
-	periodCopy["start"] = scalarutils.DateTime(parsedStartDate.Format(timeFormatStr))
+	return domain.FormatDateTime(parsed)
+}
 
-	parsedEndDate := helpers.ParseDate(periodCopy["end"].(string))
+func periodMapper(period map[string]interface{}) map[string]interface{} {
+	periodCopy := period
 
-	periodCopy["end"] = scalarutils.DateTime(parsedEndDate.Format(timeFormatStr))
+	for _, key := range []string{"start", "end"} {
+		if value, ok := periodCopy[key]; ok {
+			periodCopy[key] = dateTimeMapper(value)
+		}
+	}
 
 	return periodCopy
 }
@@ -144,9 +165,9 @@ func photoMapper(resource map[string]interface{}) map[string]interface{} {
 		for _, photo := range resource["photo"].([]interface{}) {
 			photo := photo.(map[string]interface{})
 
-			parsedDate := helpers.ParseDate(photo["creation"].(string))
-
-			photo["creation"] = scalarutils.DateTime(parsedDate.Format(timeFormatStr))
+			if creation, ok := photo["creation"]; ok {
+				photo["creation"] = dateTimeMapper(creation)
+			}
 
 			newPhotos = append(newPhotos, photo)
 		}
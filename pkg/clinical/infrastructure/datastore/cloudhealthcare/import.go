@@ -0,0 +1,27 @@
+package fhir
+
+import (
+	"context"
+	"fmt"
+)
+
+// ImportFHIRResource creates a resource from a raw FHIR payload and returns
+// the ID that it was assigned.
+//
+// A payload with a source identifier is only created once, so importing it
+// again returns the ID of the resource that was created the first time.
+func (fh StoreImpl) ImportFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}) (string, error) {
+	resource := map[string]interface{}{}
+
+	err := fh.Dataset.CreateFHIRResource(ctx, resourceType, payload, &resource)
+	if err != nil {
+		return "", fmt.Errorf("unable to create %s: %w", resourceType, err)
+	}
+
+	id, _ := resource["id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("the created %s has no ID", resourceType)
+	}
+
+	return id, nil
+}
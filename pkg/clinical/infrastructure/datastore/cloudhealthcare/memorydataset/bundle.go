@@ -0,0 +1,405 @@
+package memorydataset
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// the content type of JSON patch documents carried in a bundle's Binary resources
+const jsonPatchContentType = "application/json-patch+json"
+
+// transactionOrder is the order in which a transaction's interactions are
+// processed, regardless of their order in the bundle
+//
+// See: https://www.hl7.org/fhir/http.html#trules
+var transactionOrder = map[string]int{
+	http.MethodDelete: 0,
+	http.MethodPost:   1,
+	http.MethodPut:    2,
+	http.MethodPatch:  2,
+	http.MethodGet:    3,
+}
+
+// ExecuteBundle executes the entries of a transaction or batch Bundle.
+//
+// A transaction is atomic: if any entry fails none of its changes are kept
+// and an error is returned. The entries of a batch are executed independently
+// and the outcome of each one is reported in its response.
+func (r *Repository) ExecuteBundle(_ context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+	var responseType domain.FHIRBundleTypeEnum
+
+	switch bundle.Type {
+	case domain.FHIRBundleTypeEnumTransaction:
+		responseType = domain.FHIRBundleTypeEnumTransactionResponse
+	case domain.FHIRBundleTypeEnumBatch:
+		responseType = domain.FHIRBundleTypeEnumBatchResponse
+	default:
+		return nil, fmt.Errorf("unable to execute a bundle of type %q", bundle.Type)
+	}
+
+	transaction := bundle.Type == domain.FHIRBundleTypeEnumTransaction
+
+	entries, ids, err := resolvePlaceholders(bundle.Entry, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := r.snapshot()
+
+	responses := make([]domain.FHIRBundleEntry, len(entries))
+
+	for _, index := range processingOrder(entries, transaction) {
+		response, err := r.executeEntry(entries[index], ids[index])
+		if err != nil {
+			if transaction {
+				r.restore(saved)
+				return nil, fmt.Errorf("transaction failed at entry %d: %w", index, err)
+			}
+
+			response = &domain.FHIRBundleEntry{
+				Response: &domain.FHIRBundleEntryResponse{
+					Status:  statusOf(err),
+					Outcome: operationOutcome(err),
+				},
+			}
+		}
+
+		responses[index] = *response
+	}
+
+	err = r.save()
+	if err != nil {
+		r.restore(saved)
+		return nil, err
+	}
+
+	return &domain.FHIRBundle{
+		ResourceType: "Bundle",
+		Type:         responseType,
+		Entry:        responses,
+	}, nil
+}
+
+// executeEntry executes a single bundle entry. The caller should hold the lock
+func (r *Repository) executeEntry(entry domain.FHIRBundleEntry, assignedID string) (*domain.FHIRBundleEntry, error) {
+	if entry.Request == nil {
+		return nil, fmt.Errorf("bundle entry does not have a request")
+	}
+
+	resourceType, id, err := parseEntryURL(entry.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Request.IfMatch != "" {
+		err = r.checkVersion(resourceType, id, domain.FHIRVersionFromETag(entry.Request.IfMatch))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		resource map[string]interface{}
+		status   string
+	)
+
+	switch entry.Request.Method {
+	case http.MethodPost:
+		if assignedID == "" {
+			assignedID = uuid.New().String()
+		}
+
+		resource, err = r.create(resourceType, assignedID, entry.Resource)
+		status = "201 Created"
+
+	case http.MethodPut:
+		var created bool
+
+		resource, created, err = r.update(resourceType, id, entry.Resource)
+
+		status = "200 OK"
+		if created {
+			status = "201 Created"
+		}
+
+	case http.MethodPatch:
+		var operations []map[string]interface{}
+
+		operations, err = patchOperations(entry.Resource)
+		if err != nil {
+			return nil, err
+		}
+
+		resource, err = r.patch(resourceType, id, operations)
+		status = "200 OK"
+
+	case http.MethodDelete:
+		r.delete(resourceType, id)
+
+		return &domain.FHIRBundleEntry{
+			Response: &domain.FHIRBundleEntryResponse{Status: "204 No Content"},
+		}, nil
+
+	case http.MethodGet:
+		var stored *storedResource
+
+		stored, err = r.get(resourceType, id)
+		if err == nil {
+			resource = stored.resource
+		}
+
+		status = "200 OK"
+
+	default:
+		return nil, fmt.Errorf("unsupported bundle request method %q", entry.Request.Method)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	copied, err := clone(resource)
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy %s: %w", referenceOf(resource), err)
+	}
+
+	meta, _ := resource["meta"].(map[string]interface{})
+	lastUpdated, _ := meta["lastUpdated"].(string)
+	version := versionOf(resource)
+
+	return &domain.FHIRBundleEntry{
+		FullURL:  referenceOf(resource),
+		Resource: copied,
+		Response: &domain.FHIRBundleEntryResponse{
+			Status:       status,
+			Location:     fmt.Sprintf("%s/_history/%d", referenceOf(resource), version),
+			Etag:         fmt.Sprintf(`W/"%d"`, version),
+			LastModified: lastUpdated,
+		},
+	}, nil
+}
+
+// parseEntryURL reads the resource type and ID from an entry's request URL
+// e.g `Patient` or `Patient/123`
+func parseEntryURL(request *domain.FHIRBundleEntryRequest) (resourceType string, id string, err error) {
+	path := strings.Trim(request.URL, "/")
+	if strings.Contains(path, "?") {
+		return "", "", fmt.Errorf("conditional bundle request %q is not supported", request.URL)
+	}
+
+	parts := strings.Split(path, "/")
+
+	switch {
+	case request.Method == http.MethodPost && len(parts) == 1 && parts[0] != "":
+		return parts[0], "", nil
+	case request.Method != http.MethodPost && len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid %s bundle request URL %q", request.Method, request.URL)
+	}
+}
+
+// patchOperations decodes the JSON patch document carried by a Binary resource
+func patchOperations(binary map[string]interface{}) ([]map[string]interface{}, error) {
+	contentType, _ := binary["contentType"].(string)
+	data, _ := binary["data"].(string)
+
+	if binary["resourceType"] != "Binary" || contentType != jsonPatchContentType {
+		return nil, fmt.Errorf("a PATCH bundle entry should have a %s Binary resource", jsonPatchContentType)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode JSON patch: %w", err)
+	}
+
+	operations := []map[string]interface{}{}
+
+	err = json.Unmarshal(decoded, &operations)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal JSON patch: %w", err)
+	}
+
+	return operations, nil
+}
+
+// resolvePlaceholders assigns IDs to the resources that a transaction creates
+// and rewrites references to their `urn:uuid:` placeholders. The returned
+// entries are copies so the caller's bundle is left unchanged.
+func resolvePlaceholders(entries []domain.FHIRBundleEntry, transaction bool) ([]domain.FHIRBundleEntry, []string, error) {
+	resolved := make([]domain.FHIRBundleEntry, len(entries))
+	ids := make([]string, len(entries))
+	references := map[string]string{}
+
+	for i, entry := range entries {
+		resolved[i] = entry
+
+		if entry.Resource != nil {
+			resource, err := clone(entry.Resource)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to copy bundle entry %d: %w", i, err)
+			}
+
+			resolved[i].Resource = resource
+		}
+
+		if !transaction || entry.Request == nil || entry.Request.Method != http.MethodPost {
+			continue
+		}
+
+		if strings.HasPrefix(entry.FullURL, "urn:uuid:") {
+			ids[i] = uuid.New().String()
+			references[entry.FullURL] = fmt.Sprintf("%s/%s", strings.Trim(entry.Request.URL, "/"), ids[i])
+		}
+	}
+
+	for _, entry := range resolved {
+		replaceReferences(entry.Resource, references)
+	}
+
+	return resolved, ids, nil
+}
+
+// replaceReferences rewrites reference elements that point to placeholders
+func replaceReferences(node interface{}, references map[string]string) {
+	switch element := node.(type) {
+	case map[string]interface{}:
+		for key, child := range element {
+			if reference, ok := child.(string); ok && key == "reference" {
+				if resolved, found := references[reference]; found {
+					element[key] = resolved
+				}
+
+				continue
+			}
+
+			replaceReferences(child, references)
+		}
+
+	case []interface{}:
+		for _, child := range element {
+			replaceReferences(child, references)
+		}
+	}
+}
+
+// processingOrder returns the indices of the entries in the order that they
+// should be executed
+func processingOrder(entries []domain.FHIRBundleEntry, transaction bool) []int {
+	order := make([]int, len(entries))
+	for i := range entries {
+		order[i] = i
+	}
+
+	if !transaction {
+		return order
+	}
+
+	rank := func(index int) int {
+		if entries[index].Request == nil {
+			return len(transactionOrder)
+		}
+
+		position, ok := transactionOrder[entries[index].Request.Method]
+		if !ok {
+			return len(transactionOrder)
+		}
+
+		return position
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return rank(order[i]) < rank(order[j])
+	})
+
+	return order
+}
+
+// snapshot is a copy of the dataset's state used to roll back transactions
+type snapshot struct {
+	sequence  int64
+	resources map[string]map[string]storedResource
+	history   map[string][]map[string]interface{}
+}
+
+// snapshot copies the dataset's state. Stored resources are replaced rather
+// than modified on writes so the resources themselves can be shared. The
+// caller should hold the lock
+func (r *Repository) snapshot() snapshot {
+	saved := snapshot{
+		sequence:  r.sequence,
+		resources: map[string]map[string]storedResource{},
+		history:   map[string][]map[string]interface{}{},
+	}
+
+	// versions are only ever appended so keeping the length of each history
+	// is enough to drop the versions added after the snapshot
+	for reference, versions := range r.history {
+		saved.history[reference] = versions
+	}
+
+	for resourceType, byID := range r.resources {
+		saved.resources[resourceType] = map[string]storedResource{}
+
+		for id, stored := range byID {
+			saved.resources[resourceType][id] = *stored
+		}
+	}
+
+	return saved
+}
+
+// restore puts back a snapshot of the dataset. The caller should hold the lock
+func (r *Repository) restore(saved snapshot) {
+	r.sequence = saved.sequence
+	r.resources = map[string]map[string]*storedResource{}
+	r.history = saved.history
+
+	for resourceType, byID := range saved.resources {
+		r.resources[resourceType] = map[string]*storedResource{}
+
+		for id, stored := range byID {
+			stored := stored
+			r.resources[resourceType][id] = &stored
+		}
+	}
+}
+
+// statusOf maps an error to the HTTP status of a failed batch entry
+func statusOf(err error) string {
+	if errors.Is(err, errNotFound) {
+		return "404 Not Found"
+	}
+
+	if errors.Is(err, domain.ErrFHIRVersionConflict) || errors.Is(err, errMultipleMatches) {
+		return "412 Precondition Failed"
+	}
+
+	return "400 Bad Request"
+}
+
+// operationOutcome describes an error as a FHIR OperationOutcome
+func operationOutcome(err error) map[string]interface{} {
+	return map[string]interface{}{
+		"resourceType": "OperationOutcome",
+		"issue": []interface{}{
+			map[string]interface{}{
+				"severity":    "error",
+				"code":        "processing",
+				"diagnostics": err.Error(),
+			},
+		},
+	}
+}
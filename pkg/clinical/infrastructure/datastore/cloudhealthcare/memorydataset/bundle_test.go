@@ -0,0 +1,140 @@
+package memorydataset_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/memorydataset"
+)
+
+func TestRepository_ExecuteBundle(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	patient := createResource(t, repo, "Patient", map[string]interface{}{"active": true})
+	patientID := patient["id"].(string)
+
+	t.Run("transaction resolves placeholders", func(t *testing.T) {
+		response, err := repo.ExecuteBundle(context.Background(), domain.FHIRBundle{
+			Type: domain.FHIRBundleTypeEnumTransaction,
+			Entry: []domain.FHIRBundleEntry{
+				{
+					FullURL: "urn:uuid:episode",
+					Resource: map[string]interface{}{
+						"resourceType": "EpisodeOfCare",
+						"patient":      map[string]interface{}{"reference": "Patient/" + patientID},
+					},
+					Request: &domain.FHIRBundleEntryRequest{Method: http.MethodPost, URL: "EpisodeOfCare"},
+				},
+				{
+					FullURL: "urn:uuid:encounter",
+					Resource: map[string]interface{}{
+						"resourceType":  "Encounter",
+						"episodeOfCare": []interface{}{map[string]interface{}{"reference": "urn:uuid:episode"}},
+					},
+					Request: &domain.FHIRBundleEntryRequest{Method: http.MethodPost, URL: "Encounter"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ExecuteBundle() error = %v", err)
+		}
+
+		if response.Type != domain.FHIRBundleTypeEnumTransactionResponse || len(response.Entry) != 2 {
+			t.Fatalf("expected a transaction-response with 2 entries, got: %v", response)
+		}
+
+		episodeReference := response.Entry[0].FullURL
+
+		encounter := map[string]interface{}{}
+
+		err = repo.GetFHIRResource(context.Background(), "Encounter", strings.TrimPrefix(response.Entry[1].FullURL, "Encounter/"), &encounter)
+		if err != nil {
+			t.Fatalf("unable to get encounter: %v", err)
+		}
+
+		reference := encounter["episodeOfCare"].([]interface{})[0].(map[string]interface{})["reference"]
+		if reference != episodeReference {
+			t.Errorf("expected the placeholder to be replaced by %s, got: %v", episodeReference, reference)
+		}
+	})
+
+	t.Run("failed transaction is rolled back", func(t *testing.T) {
+		_, err := repo.ExecuteBundle(context.Background(), domain.FHIRBundle{
+			Type: domain.FHIRBundleTypeEnumTransaction,
+			Entry: []domain.FHIRBundleEntry{
+				{
+					Request: &domain.FHIRBundleEntryRequest{Method: http.MethodDelete, URL: "Patient/" + patientID},
+				},
+				{
+					Resource: map[string]interface{}{"resourceType": "Observation"},
+					Request:  &domain.FHIRBundleEntryRequest{Method: http.MethodGet, URL: "Observation/missing"},
+				},
+			},
+		})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		err = repo.GetFHIRResource(context.Background(), "Patient", patientID, &map[string]interface{}{})
+		if err != nil {
+			t.Errorf("expected the deleted patient to be restored: %v", err)
+		}
+	})
+
+	t.Run("batch reports the outcome of each entry", func(t *testing.T) {
+		patch := base64.StdEncoding.EncodeToString([]byte(`[{"op": "replace", "path": "/active", "value": false}]`))
+
+		response, err := repo.ExecuteBundle(context.Background(), domain.FHIRBundle{
+			Type: domain.FHIRBundleTypeEnumBatch,
+			Entry: []domain.FHIRBundleEntry{
+				{
+					Resource: map[string]interface{}{
+						"resourceType": "Binary",
+						"contentType":  "application/json-patch+json",
+						"data":         patch,
+					},
+					Request: &domain.FHIRBundleEntryRequest{Method: http.MethodPatch, URL: "Patient/" + patientID},
+				},
+				{
+					Request: &domain.FHIRBundleEntryRequest{Method: http.MethodGet, URL: "Patient/missing"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ExecuteBundle() error = %v", err)
+		}
+
+		if status := response.Entry[0].Response.Status; status != "200 OK" {
+			t.Errorf("expected the patch to succeed, got: %s", status)
+		}
+
+		if status := response.Entry[1].Response.Status; status != "404 Not Found" {
+			t.Errorf("expected the read to fail, got: %s", status)
+		}
+
+		patched := map[string]interface{}{}
+
+		err = repo.GetFHIRResource(context.Background(), "Patient", patientID, &patched)
+		if err != nil {
+			t.Fatalf("unable to get patient: %v", err)
+		}
+
+		if patched["active"] != false {
+			t.Errorf("expected the patient to be patched, got: %v", patched)
+		}
+	})
+
+	t.Run("unsupported bundle type", func(t *testing.T) {
+		_, err := repo.ExecuteBundle(context.Background(), domain.FHIRBundle{Type: "collection"})
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,562 @@
+package memorydataset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// Repository is an in-memory implementation of the FHIR dataset.
+//
+// It is meant for local runs and integration tests that should not depend on
+// Google Cloud Healthcare. When a path is provided, the contents of the
+// dataset are persisted to that file as a FHIR `collection` Bundle after every
+// write and loaded back on start up. The same file format can be used to seed
+// the dataset with fixtures.
+type Repository struct {
+	mu sync.RWMutex
+
+	path      string
+	sequence  int64
+	resources map[string]map[string]*storedResource
+
+	// history keeps every version of a resource, oldest first, keyed by the
+	// resource's reference e.g `Condition/123`
+	history map[string][]map[string]interface{}
+}
+
+// errNotFound is returned when a resource is not in the dataset
+var errNotFound = errors.New("not found")
+
+// errMultipleMatches is returned when a conditional create matches more than
+// one resource
+var errMultipleMatches = errors.New("the condition is not selective enough")
+
+// storedResource keeps a resource together with the order in which it was
+// created so that search results are returned in a stable order
+type storedResource struct {
+	sequence int64
+	resource map[string]interface{}
+}
+
+// NewMemoryRepository initializes an in-memory FHIR repository.
+//
+// If `path` is empty the data only lives as long as the process. Otherwise the
+// file is loaded, if it exists, and kept up to date on every write.
+func NewMemoryRepository(path string) (*Repository, error) {
+	repo := &Repository{
+		path:      path,
+		resources: map[string]map[string]*storedResource{},
+		history:   map[string][]map[string]interface{}{},
+	}
+
+	if path == "" {
+		return repo, nil
+	}
+
+	err := repo.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// CreateFHIRResource creates an FHIR resource.
+//
+// The payload should be the result of marshalling a resource to JSON.
+//
+// If the payload has a source identifier (see
+// `domain.SourceIdentifierSystem`) the resource is only created if no resource
+// with that identifier exists. Otherwise the existing resource is returned.
+func (r *Repository) CreateFHIRResource(_ context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, err := r.findExisting(resourceType, domain.FHIRSourceIdentifierQuery(payload))
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return decode(existing, resource)
+	}
+
+	created, err := r.create(resourceType, uuid.New().String(), payload)
+	if err != nil {
+		return err
+	}
+
+	err = r.save()
+	if err != nil {
+		return err
+	}
+
+	return decode(created, resource)
+}
+
+// DeleteFHIRResource deletes an FHIR resource.
+func (r *Repository) DeleteFHIRResource(_ context.Context, resourceType, fhirResourceID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delete(resourceType, fhirResourceID)
+
+	return r.save()
+}
+
+// PatchFHIRResource patches a FHIR resource.
+// The payload is a JSON patch document that follows guidance on Patch from the
+// FHIR standard. The `add`, `remove`, `replace` and `test` operations are
+// supported.
+//
+// A `test` operation on `/meta/versionId` that does not match the current
+// version is reported as `domain.ErrFHIRVersionConflict`.
+//
+// See: https://www.hl7.org/fhir/http.html#patch
+func (r *Repository) PatchFHIRResource(
+	_ context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.checkVersion(resourceType, fhirResourceID, domain.FHIRPatchVersion(payload))
+	if err != nil {
+		return err
+	}
+
+	patched, err := r.patch(resourceType, fhirResourceID, payload)
+	if err != nil {
+		return err
+	}
+
+	err = r.save()
+	if err != nil {
+		return err
+	}
+
+	return decode(patched, resource)
+}
+
+// UpdateFHIRResource updates the entire contents of a resource.
+//
+// Like a FHIR store with `enableUpdateCreate` set, updating a resource that
+// does not exist creates it with the given ID.
+//
+// If the payload has a `meta.versionId` the update only succeeds if that is
+// still the current version of the resource. Otherwise an error wrapping
+// `domain.ErrFHIRVersionConflict` is returned.
+func (r *Repository) UpdateFHIRResource(
+	_ context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.checkVersion(resourceType, fhirResourceID, domain.FHIRPayloadVersion(payload))
+	if err != nil {
+		return err
+	}
+
+	updated, _, err := r.update(resourceType, fhirResourceID, payload)
+	if err != nil {
+		return err
+	}
+
+	err = r.save()
+	if err != nil {
+		return err
+	}
+
+	return decode(updated, resource)
+}
+
+// GetFHIRPatientAllData gets all resources associated with a particular
+// patient compartment.
+//
+// The compartment is made up of the patient and every resource that holds a
+// reference to the patient. It is returned as a `searchset` Bundle.
+func (r *Repository) GetFHIRPatientAllData(_ context.Context, fhirResourceID string) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	patient, err := r.get("Patient", fhirResourceID)
+	if err != nil {
+		return nil, fmt.Errorf("PatientAllData: %w", err)
+	}
+
+	patientReference := fmt.Sprintf("Patient/%s", fhirResourceID)
+	compartment := []*storedResource{patient}
+
+	for _, stored := range r.all() {
+		if stored == patient {
+			continue
+		}
+
+		if referencesTarget(stored.resource, patientReference) {
+			compartment = append(compartment, stored)
+		}
+	}
+
+	return json.Marshal(searchBundle(compartment, len(compartment), nil))
+}
+
+// GetFHIRResource gets an FHIR resource.
+func (r *Repository) GetFHIRResource(_ context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, err := r.get(resourceType, fhirResourceID)
+	if err != nil {
+		return err
+	}
+
+	return decode(stored.resource, resource)
+}
+
+// create stores a new resource with the given ID. The caller should hold the lock
+func (r *Repository) create(resourceType, id string, payload map[string]interface{}) (map[string]interface{}, error) {
+	created, err := clone(payload)
+	if err != nil {
+		return nil, fmt.Errorf("json.Encode: %w", err)
+	}
+
+	created["resourceType"] = resourceType
+	created["id"] = id
+	stampMeta(created, 1)
+
+	r.put(resourceType, created)
+
+	return created, nil
+}
+
+// findExisting returns the resource that matches the search query of a
+// conditional create, or nil if there is none or the query is empty. The
+// caller should hold the lock
+func (r *Repository) findExisting(resourceType, query string) (map[string]interface{}, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-None-Exist query %q: %w", query, err)
+	}
+
+	matches := []map[string]interface{}{}
+
+	for _, stored := range r.resources[resourceType] {
+		matched, err := matchesCriteria(stored.resource, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			matches = append(matches, stored.resource)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d %s resources match %q: %w", len(matches), resourceType, query, errMultipleMatches)
+	}
+}
+
+// update replaces a resource, creating it if it does not exist. The caller
+// should hold the lock
+func (r *Repository) update(
+	resourceType, id string, payload map[string]interface{}) (updated map[string]interface{}, created bool, err error) {
+	existing, err := r.get(resourceType, id)
+	if err != nil {
+		resource, err := r.create(resourceType, id, payload)
+		return resource, true, err
+	}
+
+	updated, err = clone(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("json.Encode: %w", err)
+	}
+
+	updated["resourceType"] = resourceType
+	updated["id"] = id
+	stampMeta(updated, versionOf(existing.resource)+1)
+
+	existing.resource = updated
+	r.record(updated)
+
+	return updated, false, nil
+}
+
+// patch applies JSON patch operations to a resource. The caller should hold
+// the lock
+func (r *Repository) patch(
+	resourceType, id string, operations []map[string]interface{}) (map[string]interface{}, error) {
+	existing, err := r.get(resourceType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := clone(existing.resource)
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy %s resource: %w", resourceType, err)
+	}
+
+	err = applyPatch(patched, operations)
+	if err != nil {
+		return nil, fmt.Errorf("patch: %w", err)
+	}
+
+	patched["resourceType"] = resourceType
+	patched["id"] = id
+	stampMeta(patched, versionOf(existing.resource)+1)
+
+	existing.resource = patched
+	r.record(patched)
+
+	return patched, nil
+}
+
+// checkVersion ensures that a resource is at the expected version. There is
+// nothing to check when the version is empty. The caller should hold the lock
+func (r *Repository) checkVersion(resourceType, id, versionID string) error {
+	if versionID == "" {
+		return nil
+	}
+
+	current := ""
+
+	if stored, err := r.get(resourceType, id); err == nil {
+		current = fmt.Sprint(versionOf(stored.resource))
+	}
+
+	if current != versionID {
+		return fmt.Errorf(
+			"%s/%s is not at version %s: %w", resourceType, id, versionID, domain.ErrFHIRVersionConflict)
+	}
+
+	return nil
+}
+
+// delete removes a resource. The caller should hold the lock
+func (r *Repository) delete(resourceType, id string) {
+	delete(r.resources[resourceType], id)
+}
+
+// get returns the stored resource. The caller should hold the lock
+func (r *Repository) get(resourceType, fhirResourceID string) (*storedResource, error) {
+	stored, ok := r.resources[resourceType][fhirResourceID]
+	if !ok {
+		return nil, fmt.Errorf("resource %s/%s %w", resourceType, fhirResourceID, errNotFound)
+	}
+
+	return stored, nil
+}
+
+// put adds a new resource to the dataset. The caller should hold the lock
+func (r *Repository) put(resourceType string, resource map[string]interface{}) {
+	if _, ok := r.resources[resourceType]; !ok {
+		r.resources[resourceType] = map[string]*storedResource{}
+	}
+
+	r.sequence++
+
+	id, _ := resource["id"].(string)
+	r.resources[resourceType][id] = &storedResource{
+		sequence: r.sequence,
+		resource: resource,
+	}
+
+	r.record(resource)
+}
+
+// record adds a version of a resource to its history. The caller should hold
+// the lock
+func (r *Repository) record(resource map[string]interface{}) {
+	reference := referenceOf(resource)
+	r.history[reference] = append(r.history[reference], resource)
+}
+
+// all returns every stored resource in the order they were created
+func (r *Repository) all() []*storedResource {
+	resources := []*storedResource{}
+
+	for _, byID := range r.resources {
+		for _, stored := range byID {
+			resources = append(resources, stored)
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].sequence < resources[j].sequence
+	})
+
+	return resources
+}
+
+// load reads the persisted dataset from disk
+func (r *Repository) load() error {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to read dataset file %s: %w", r.path, err)
+	}
+
+	bundle := struct {
+		Entry []struct {
+			Resource map[string]interface{} `json:"resource"`
+		} `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(data, &bundle)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal dataset file %s: %w", r.path, err)
+	}
+
+	for _, entry := range bundle.Entry {
+		resourceType, _ := entry.Resource["resourceType"].(string)
+		id, _ := entry.Resource["id"].(string)
+
+		if resourceType == "" || id == "" {
+			return fmt.Errorf("dataset file %s has a resource without a resourceType or id", r.path)
+		}
+
+		if _, ok := entry.Resource["meta"]; !ok {
+			stampMeta(entry.Resource, 1)
+		}
+
+		r.put(resourceType, entry.Resource)
+	}
+
+	return nil
+}
+
+// save persists the dataset to disk, if a path was configured. The caller
+// should hold the lock
+func (r *Repository) save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	resources := r.all()
+	entries := []map[string]interface{}{}
+
+	for _, stored := range resources {
+		entries = append(entries, map[string]interface{}{
+			"fullUrl":  referenceOf(stored.resource),
+			"resource": stored.resource,
+		})
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"resourceType": "Bundle",
+		"type":         "collection",
+		"entry":        entries,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal dataset: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to persist dataset: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("unable to persist dataset: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("unable to persist dataset: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), r.path)
+	if err != nil {
+		return fmt.Errorf("unable to persist dataset: %w", err)
+	}
+
+	return nil
+}
+
+// stampMeta sets the server managed `meta.versionId` and `meta.lastUpdated`
+// elements while keeping any tags on the resource
+func stampMeta(resource map[string]interface{}, version int) {
+	meta, ok := resource["meta"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+	}
+
+	meta["versionId"] = fmt.Sprint(version)
+	meta["lastUpdated"] = time.Now().UTC().Format(time.RFC3339Nano)
+	resource["meta"] = meta
+}
+
+// versionOf returns the numeric version of a stored resource
+func versionOf(resource map[string]interface{}) int {
+	meta, _ := resource["meta"].(map[string]interface{})
+	versionID, _ := meta["versionId"].(string)
+
+	version := 0
+	_, _ = fmt.Sscan(versionID, &version)
+
+	return version
+}
+
+// referenceOf composes the relative reference of a resource e.g `Patient/123`
+func referenceOf(resource map[string]interface{}) string {
+	return fmt.Sprintf("%s/%s", resource["resourceType"], resource["id"])
+}
+
+// clone returns a deep copy of a JSON value as generic maps and slices
+func clone(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := map[string]interface{}{}
+
+	err = json.Unmarshal(data, &copied)
+	if err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+// decode unmarshals a stored resource into the caller's resource
+func decode(stored map[string]interface{}, resource interface{}) error {
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s: %w", referenceOf(stored), err)
+	}
+
+	err = json.Unmarshal(data, resource)
+	if err != nil {
+		return fmt.Errorf(
+			"unable to unmarshal %s response JSON: data: %v\n, error: %w",
+			referenceOf(stored), string(data), err)
+	}
+
+	return nil
+}
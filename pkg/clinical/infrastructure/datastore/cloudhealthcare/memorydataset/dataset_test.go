@@ -0,0 +1,666 @@
+package memorydataset_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/memorydataset"
+)
+
+var _ fhir.Dataset = (*memorydataset.Repository)(nil)
+
+var tenant = dto.TenantIdentifiers{
+	OrganizationID: "org-1",
+	FacilityID:     "facility-1",
+}
+
+func tenantMeta(identifiers dto.TenantIdentifiers) map[string]interface{} {
+	return map[string]interface{}{
+		"tag": []map[string]interface{}{
+			{
+				"system": "http://mycarehub/tenant-identification/organisation",
+				"code":   identifiers.OrganizationID,
+			},
+			{
+				"system": "http://mycarehub/tenant-identification/facility",
+				"code":   identifiers.FacilityID,
+			},
+		},
+	}
+}
+
+func createResource(t *testing.T, repo *memorydataset.Repository, resourceType string, payload map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	resource := map[string]interface{}{}
+
+	err := repo.CreateFHIRResource(context.Background(), resourceType, payload, &resource)
+	if err != nil {
+		t.Fatalf("unable to create %s: %v", resourceType, err)
+	}
+
+	return resource
+}
+
+func TestRepository_CRUD(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	created := createResource(t, repo, "Patient", map[string]interface{}{
+		"active": true,
+		"meta":   tenantMeta(tenant),
+	})
+
+	id, _ := created["id"].(string)
+	if id == "" {
+		t.Fatalf("expected the created resource to have an ID")
+	}
+
+	patient := domain.FHIRPatient{}
+
+	err = repo.GetFHIRResource(context.Background(), "Patient", id, &patient)
+	if err != nil {
+		t.Fatalf("unable to get patient: %v", err)
+	}
+
+	if patient.Meta == nil || patient.Meta.VersionID != "1" || len(patient.Meta.Tag) != 2 {
+		t.Errorf("expected version 1 with the tenant tags, got %#v", patient.Meta)
+	}
+
+	updated := map[string]interface{}{}
+
+	err = repo.UpdateFHIRResource(context.Background(), "Patient", id, map[string]interface{}{"active": false, "meta": tenantMeta(tenant)}, &updated)
+	if err != nil {
+		t.Fatalf("unable to update patient: %v", err)
+	}
+
+	if updated["active"] != false || updated["meta"].(map[string]interface{})["versionId"] != "2" {
+		t.Errorf("expected an inactive patient at version 2, got %v", updated)
+	}
+
+	patched := map[string]interface{}{}
+
+	err = repo.PatchFHIRResource(context.Background(), "Patient", id, []map[string]interface{}{
+		{"op": "test", "path": "/active", "value": false},
+		{"op": "replace", "path": "/active", "value": true},
+		{"op": "add", "path": "/name", "value": []map[string]interface{}{{"family": "Doe"}}},
+		{"op": "add", "path": "/name/-", "value": map[string]interface{}{"family": "Roe"}},
+		{"op": "remove", "path": "/name/0"},
+	}, &patched)
+	if err != nil {
+		t.Fatalf("unable to patch patient: %v", err)
+	}
+
+	names, _ := patched["name"].([]interface{})
+	if patched["active"] != true || len(names) != 1 || names[0].(map[string]interface{})["family"] != "Roe" {
+		t.Errorf("unexpected patched patient: %v", patched)
+	}
+
+	err = repo.PatchFHIRResource(context.Background(), "Patient", id, []map[string]interface{}{
+		{"op": "test", "path": "/active", "value": false},
+	}, &patched)
+	if err == nil {
+		t.Errorf("expected a failing test operation to return an error")
+	}
+
+	err = repo.DeleteFHIRResource(context.Background(), "Patient", id)
+	if err != nil {
+		t.Fatalf("unable to delete patient: %v", err)
+	}
+
+	err = repo.GetFHIRResource(context.Background(), "Patient", id, &patient)
+	if err == nil {
+		t.Errorf("expected an error getting a deleted patient")
+	}
+}
+
+func TestRepository_VersionConflict(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	encounter := createResource(t, repo, "Encounter", map[string]interface{}{"status": "in-progress"})
+	id := encounter["id"].(string)
+
+	withVersion := func(versionID string) map[string]interface{} {
+		return map[string]interface{}{
+			"status": "finished",
+			"meta":   map[string]interface{}{"versionId": versionID},
+		}
+	}
+
+	err = repo.UpdateFHIRResource(context.Background(), "Encounter", id, withVersion("1"), &map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("UpdateFHIRResource() with the current version error = %v", err)
+	}
+
+	err = repo.UpdateFHIRResource(context.Background(), "Encounter", id, withVersion("1"), &map[string]interface{}{})
+	if !errors.Is(err, domain.ErrFHIRVersionConflict) {
+		t.Errorf("expected a version conflict when updating a stale version, got: %v", err)
+	}
+
+	patch := func(versionID string) []map[string]interface{} {
+		return []map[string]interface{}{
+			domain.FHIRVersionTestOperation(versionID),
+			{"op": "replace", "path": "/status", "value": "cancelled"},
+		}
+	}
+
+	err = repo.PatchFHIRResource(context.Background(), "Encounter", id, patch("1"), &map[string]interface{}{})
+	if !errors.Is(err, domain.ErrFHIRVersionConflict) {
+		t.Errorf("expected a version conflict when patching a stale version, got: %v", err)
+	}
+
+	err = repo.PatchFHIRResource(context.Background(), "Encounter", id, patch("2"), &map[string]interface{}{})
+	if err != nil {
+		t.Errorf("PatchFHIRResource() with the current version error = %v", err)
+	}
+
+	err = repo.UpdateFHIRResource(context.Background(), "Encounter", "missing", withVersion("1"), &map[string]interface{}{})
+	if !errors.Is(err, domain.ErrFHIRVersionConflict) {
+		t.Errorf("expected a version conflict when updating a missing resource, got: %v", err)
+	}
+}
+
+func TestRepository_ConditionalCreate(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	withSource := func(value string) map[string]interface{} {
+		return map[string]interface{}{
+			"status": "final",
+			"identifier": []interface{}{
+				map[string]interface{}{"system": domain.SourceIdentifierSystem, "value": value},
+			},
+		}
+	}
+
+	first := createResource(t, repo, "Observation", withSource("vitals/1"))
+	replay := createResource(t, repo, "Observation", withSource("vitals/1"))
+
+	if replay["id"] != first["id"] {
+		t.Errorf("expected a replay to return observation %v, got %v", first["id"], replay["id"])
+	}
+
+	other := createResource(t, repo, "Observation", withSource("vitals/2"))
+	if other["id"] == first["id"] {
+		t.Errorf("expected a different source identifier to create a new observation")
+	}
+
+	allergy := createResource(t, repo, "AllergyIntolerance", withSource("vitals/1"))
+	if allergy["id"] == first["id"] {
+		t.Errorf("expected the source identifier to only be matched within a resource type")
+	}
+
+	plain := createResource(t, repo, "Observation", map[string]interface{}{"status": "final"})
+	again := createResource(t, repo, "Observation", map[string]interface{}{"status": "final"})
+
+	if plain["id"] == again["id"] {
+		t.Errorf("expected a payload without a source identifier to always be created")
+	}
+
+	// a duplicate that was written directly makes the condition ambiguous
+	err = repo.UpdateFHIRResource(context.Background(), "Observation", "duplicate", withSource("vitals/1"), &map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unable to add duplicate observation: %v", err)
+	}
+
+	err = repo.CreateFHIRResource(context.Background(), "Observation", withSource("vitals/1"), &map[string]interface{}{})
+	if err == nil {
+		t.Errorf("expected an error when more than one resource matches the source identifier")
+	}
+}
+
+func TestRepository_SearchFHIRResource(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	otherTenant := dto.TenantIdentifiers{OrganizationID: "org-2", FacilityID: "facility-2"}
+
+	for _, date := range []string{"2023-01-01T08:00:00+03:00", "2023-02-01T08:00:00+03:00", "2023-03-01T08:00:00+03:00"} {
+		createResource(t, repo, "Observation", map[string]interface{}{
+			"status":            "final",
+			"subject":           map[string]interface{}{"reference": "Patient/123"},
+			"effectiveDateTime": date,
+			"code": map[string]interface{}{
+				"coding": []map[string]interface{}{{"system": "https://CIELterminology.org", "code": "5088"}},
+			},
+			"meta": tenantMeta(tenant),
+		})
+	}
+
+	createResource(t, repo, "Observation", map[string]interface{}{
+		"status":  "final",
+		"subject": map[string]interface{}{"reference": "Patient/123"},
+		"meta":    tenantMeta(otherTenant),
+	})
+
+	first := 2
+
+	type args struct {
+		params     domain.SearchParams
+		tenant     dto.TenantIdentifiers
+		pagination dto.Pagination
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantCount     int
+		wantTotal     int
+		wantNextPage  bool
+		wantFirstDate string
+		wantErr       bool
+	}{
+		{
+			name: "happy case: search by reference and token within a tenant",
+			args: args{
+				params: domain.NewSearchParams().
+					Reference("patient", "Patient/123").
+					Token("code", "", "5088"),
+				tenant: tenant,
+			},
+			wantCount:     3,
+			wantTotal:     3,
+			wantFirstDate: "2023-01-01T08:00:00+03:00",
+		},
+		{
+			name: "happy case: sort descending by date and page",
+			args: args{
+				params: domain.NewSearchParams().
+					Reference("subject", "123").
+					SortDescending("date"),
+				tenant:     tenant,
+				pagination: dto.Pagination{First: &first},
+			},
+			wantCount:     2,
+			wantTotal:     3,
+			wantNextPage:  true,
+			wantFirstDate: "2023-03-01T08:00:00+03:00",
+		},
+		{
+			name: "happy case: second page",
+			args: args{
+				params:     domain.NewSearchParams().SortDescending("date"),
+				tenant:     tenant,
+				pagination: dto.Pagination{First: &first, After: "2"},
+			},
+			wantCount:     1,
+			wantTotal:     3,
+			wantFirstDate: "2023-01-01T08:00:00+03:00",
+		},
+		{
+			name: "happy case: search by date prefix",
+			args: args{
+				params: domain.NewSearchParams().
+					Date("date", domain.SearchPrefixGreaterOrEqual, time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)),
+				tenant: tenant,
+			},
+			wantCount:     2,
+			wantTotal:     2,
+			wantFirstDate: "2023-02-01T08:00:00+03:00",
+		},
+		{
+			name: "happy case: search by date range",
+			args: args{
+				params: domain.NewSearchParams().
+					Date("date", domain.SearchPrefixGreaterOrEqual, time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC)).
+					Date("date", domain.SearchPrefixLessThan, time.Date(2023, time.February, 15, 0, 0, 0, 0, time.UTC)),
+				tenant: tenant,
+			},
+			wantCount:     1,
+			wantTotal:     1,
+			wantFirstDate: "2023-02-01T08:00:00+03:00",
+		},
+		{
+			name: "happy case: other tenant only sees its own data",
+			args: args{
+				params: domain.NewSearchParams(),
+				tenant: otherTenant,
+			},
+			wantCount: 1,
+			wantTotal: 1,
+		},
+		{
+			name: "sad case: unsupported search parameter",
+			args: args{
+				params: domain.NewSearchParams().Token("unknown", "", "value"),
+				tenant: tenant,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.SearchFHIRResource(context.Background(), "Observation", tt.args.params, tt.args.tenant, tt.args.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Repository.SearchFHIRResource(context.Background(), ) error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Resources) != tt.wantCount || got.TotalCount != tt.wantTotal || got.HasNextPage != tt.wantNextPage {
+				t.Errorf("expected %d of %d resources (next page %v), got %d of %d (next page %v)",
+					tt.wantCount, tt.wantTotal, tt.wantNextPage, len(got.Resources), got.TotalCount, got.HasNextPage)
+				return
+			}
+
+			if tt.wantFirstDate != "" && got.Resources[0]["effectiveDateTime"] != tt.wantFirstDate {
+				t.Errorf("expected the first result to be from %s, got %v", tt.wantFirstDate, got.Resources[0]["effectiveDateTime"])
+			}
+		})
+	}
+}
+
+func TestRepository_SearchFHIRResource_PageBackward(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	for day := 1; day <= 5; day++ {
+		createResource(t, repo, "Condition", map[string]interface{}{
+			"subject":      map[string]interface{}{"reference": "Patient/123"},
+			"recordedDate": fmt.Sprintf("2023-01-0%d", day),
+			"meta":         tenantMeta(tenant),
+		})
+	}
+
+	params := domain.NewSearchParams().Reference("subject", "Patient/123").Sort("recorded-date")
+	size := 2
+
+	dates := func(page *domain.PagedFHIRResource) []interface{} {
+		values := []interface{}{}
+		for _, resource := range page.Resources {
+			values = append(values, resource["recordedDate"])
+		}
+
+		return values
+	}
+
+	first, err := repo.SearchFHIRResource(context.Background(), "Condition", params, tenant, dto.Pagination{First: &size})
+	if err != nil {
+		t.Fatalf("unable to search: %v", err)
+	}
+
+	if first.HasPreviousPage {
+		t.Errorf("expected the first page to not have a previous page")
+	}
+
+	second, err := repo.SearchFHIRResource(
+		context.Background(), "Condition", params, tenant, dto.Pagination{First: &size, After: first.NextCursor})
+	if err != nil {
+		t.Fatalf("unable to get the second page: %v", err)
+	}
+
+	if !second.HasPreviousPage || !second.HasNextPage {
+		t.Fatalf("expected the second page to have a previous and a next page, got %#v", second)
+	}
+
+	previous, err := repo.SearchFHIRResource(
+		context.Background(), "Condition", params, tenant, dto.Pagination{Last: &size, Before: second.PreviousCursor})
+	if err != nil {
+		t.Fatalf("unable to get the previous page: %v", err)
+	}
+
+	if !reflect.DeepEqual(dates(previous), dates(first)) || previous.HasPreviousPage || !previous.HasNextPage {
+		t.Errorf("expected paging back to return the first page %v, got %v", dates(first), dates(previous))
+	}
+
+	partial, err := repo.SearchFHIRResource(
+		context.Background(), "Condition", params, tenant, dto.Pagination{Last: &size, Before: "1"})
+	if err != nil {
+		t.Fatalf("unable to page backward: %v", err)
+	}
+
+	if got := dates(partial); len(got) != 1 || got[0] != "2023-01-01" || partial.HasPreviousPage {
+		t.Errorf("expected only the first condition before the cursor, got %v", got)
+	}
+
+	_, err = repo.SearchFHIRResource(
+		context.Background(), "Condition", params, tenant, dto.Pagination{Last: &size, Before: "-1"})
+	if err == nil {
+		t.Errorf("expected an invalid cursor to be rejected")
+	}
+}
+
+func TestRepository_GetFHIRPatientAllData(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	patient := createResource(t, repo, "Patient", map[string]interface{}{"meta": tenantMeta(tenant)})
+	patientRef := "Patient/" + patient["id"].(string)
+
+	encounter := createResource(t, repo, "Encounter", map[string]interface{}{
+		"subject": map[string]interface{}{"reference": patientRef},
+	})
+	createResource(t, repo, "Observation", map[string]interface{}{
+		"subject":   map[string]interface{}{"reference": patientRef},
+		"encounter": map[string]interface{}{"reference": "Encounter/" + encounter["id"].(string)},
+	})
+	createResource(t, repo, "Observation", map[string]interface{}{
+		"subject": map[string]interface{}{"reference": "Patient/someone-else"},
+	})
+
+	tests := []struct {
+		name        string
+		patientID   string
+		wantEntries int
+		wantErr     bool
+	}{
+		{
+			name:        "happy case: get patient compartment",
+			patientID:   patient["id"].(string),
+			wantEntries: 3,
+		},
+		{
+			name:      "sad case: unknown patient",
+			patientID: "unknown",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetFHIRPatientAllData(context.Background(), tt.patientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Repository.GetFHIRPatientAllData(context.Background(), ) error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			bundle := struct {
+				Entry []map[string]interface{} `json:"entry"`
+			}{}
+
+			err = json.Unmarshal(got, &bundle)
+			if err != nil {
+				t.Errorf("unable to unmarshal bundle: %v", err)
+				return
+			}
+
+			if len(bundle.Entry) != tt.wantEntries {
+				t.Errorf("expected %d entries, got %d", tt.wantEntries, len(bundle.Entry))
+			}
+		})
+	}
+}
+
+func TestNewMemoryRepository_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.json")
+
+	repo, err := memorydataset.NewMemoryRepository(path)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	created := createResource(t, repo, "Organization", map[string]interface{}{
+		"name": "Test Facility",
+		"meta": tenantMeta(tenant),
+	})
+
+	reloaded, err := memorydataset.NewMemoryRepository(path)
+	if err != nil {
+		t.Fatalf("unable to reload repository: %v", err)
+	}
+
+	organization := domain.FHIROrganization{}
+
+	err = reloaded.GetFHIRResource(context.Background(), "Organization", created["id"].(string), &organization)
+	if err != nil {
+		t.Fatalf("expected the organization to be persisted: %v", err)
+	}
+
+	if organization.Name == nil || *organization.Name != "Test Facility" {
+		t.Errorf("unexpected organization after reload: %#v", organization)
+	}
+}
+
+func TestRepository_SearchFHIRResource_Include(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	patient := createResource(t, repo, "Patient", map[string]interface{}{"meta": tenantMeta(tenant)})
+	patientReference := fmt.Sprintf("Patient/%s", patient["id"])
+
+	encounter := createResource(t, repo, "Encounter", map[string]interface{}{
+		"status":  "in-progress",
+		"subject": map[string]interface{}{"reference": patientReference},
+		"meta":    tenantMeta(tenant),
+	})
+	encounterReference := fmt.Sprintf("Encounter/%s", encounter["id"])
+
+	for i := 0; i < 2; i++ {
+		createResource(t, repo, "Observation", map[string]interface{}{
+			"status":    "final",
+			"subject":   map[string]interface{}{"reference": patientReference},
+			"encounter": map[string]interface{}{"reference": encounterReference},
+			"meta":      tenantMeta(tenant),
+		})
+	}
+
+	createResource(t, repo, "Observation", map[string]interface{}{
+		"status":  "final",
+		"subject": map[string]interface{}{"reference": "Patient/other"},
+		"meta":    tenantMeta(tenant),
+	})
+
+	// the patient's observation at another facility is not included
+	createResource(t, repo, "Observation", map[string]interface{}{
+		"status":  "final",
+		"subject": map[string]interface{}{"reference": patientReference},
+		"meta":    tenantMeta(dto.TenantIdentifiers{OrganizationID: tenant.OrganizationID, FacilityID: "facility-2"}),
+	})
+
+	tests := []struct {
+		name          string
+		resourceType  string
+		params        domain.SearchParams
+		wantMatches   int
+		wantIncluded  int
+		wantReference string
+		wantErr       bool
+	}{
+		{
+			name:          "happy case: include the patient of an encounter",
+			resourceType:  "Encounter",
+			params:        domain.NewSearchParams().ID(encounter["id"].(string)).Include("Encounter", "patient"),
+			wantMatches:   1,
+			wantIncluded:  1,
+			wantReference: patientReference,
+		},
+		{
+			name:         "happy case: reverse include the observations of a patient",
+			resourceType: "Patient",
+			params: domain.NewSearchParams().
+				ID(patient["id"].(string)).
+				RevInclude("Observation", "patient").
+				RevInclude("Encounter", "patient"),
+			wantMatches:   1,
+			wantIncluded:  3,
+			wantReference: encounterReference,
+		},
+		{
+			name:         "happy case: nothing is included without matches",
+			resourceType: "Patient",
+			params:       domain.NewSearchParams().ID("missing").RevInclude("Observation", "patient"),
+		},
+		{
+			name:         "sad case: unsupported include parameter",
+			resourceType: "Encounter",
+			params:       domain.NewSearchParams().Include("Encounter", "location"),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.SearchFHIRResource(context.Background(), tt.resourceType, tt.params, tenant, dto.Pagination{Skip: true})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchFHIRResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(page.Resources) != tt.wantMatches || len(page.Included) != tt.wantIncluded {
+				t.Errorf("expected %d matches and %d included resources, got %d and %d",
+					tt.wantMatches, tt.wantIncluded, len(page.Resources), len(page.Included))
+			}
+
+			if tt.wantReference != "" && page.Included[tt.wantReference] == nil {
+				t.Errorf("expected %s to be included, got %v", tt.wantReference, page.Included)
+			}
+		})
+	}
+}
+
+func TestRepository_SearchFHIRResource_NotModifier(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	active := createResource(t, repo, "Patient", map[string]interface{}{"active": true, "meta": tenantMeta(tenant)})
+	unknown := createResource(t, repo, "Patient", map[string]interface{}{"meta": tenantMeta(tenant)})
+	createResource(t, repo, "Patient", map[string]interface{}{"active": false, "meta": tenantMeta(tenant)})
+
+	got, err := repo.SearchFHIRResource(context.Background(), "Patient", domain.NewSearchParams().NotToken("active", "", "false"), tenant, dto.Pagination{Skip: true})
+	if err != nil {
+		t.Fatalf("Repository.SearchFHIRResource() error = %v", err)
+	}
+
+	ids := map[interface{}]bool{}
+	for _, resource := range got.Resources {
+		ids[resource["id"]] = true
+	}
+
+	if len(got.Resources) != 2 || !ids[active["id"]] || !ids[unknown["id"]] {
+		t.Errorf("expected the active patient and the patient without an active flag, got %v", got.Resources)
+	}
+}
@@ -0,0 +1,68 @@
+package memorydataset
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// GetFHIRResourceHistory lists the versions of a resource, newest first.
+//
+// The history is kept in memory only. A resource loaded from the dataset file
+// starts with the version it was saved at.
+func (r *Repository) GetFHIRResourceHistory(
+	_ context.Context, resourceType, fhirResourceID string, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
+	err := pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions, ok := r.history[fmt.Sprintf("%s/%s", resourceType, fhirResourceID)]
+	if !ok {
+		return nil, fmt.Errorf("resource %s/%s %w", resourceType, fhirResourceID, errNotFound)
+	}
+
+	start, end, err := pageWindow(url.Values{}, pagination, len(versions))
+	if err != nil {
+		return nil, err
+	}
+
+	response := domain.PagedFHIRResource{
+		Resources:  []map[string]interface{}{},
+		TotalCount: len(versions),
+	}
+
+	setCursors(&response, start, end)
+
+	for i := start; i < end; i++ {
+		version, err := clone(versions[len(versions)-1-i])
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy %s/%s: %w", resourceType, fhirResourceID, err)
+		}
+
+		response.Resources = append(response.Resources, version)
+	}
+
+	return &response, nil
+}
+
+// GetFHIRResourceVersion gets a specific version of a resource (vread)
+func (r *Repository) GetFHIRResourceVersion(_ context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, version := range r.history[fmt.Sprintf("%s/%s", resourceType, fhirResourceID)] {
+		if fmt.Sprint(versionOf(version)) == versionID {
+			return decode(version, resource)
+		}
+	}
+
+	return fmt.Errorf("resource %s/%s version %s %w", resourceType, fhirResourceID, versionID, errNotFound)
+}
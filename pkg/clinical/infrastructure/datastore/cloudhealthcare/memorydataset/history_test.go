@@ -0,0 +1,112 @@
+package memorydataset_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/memorydataset"
+)
+
+func TestRepository_GetFHIRResourceHistory(t *testing.T) {
+	repo, err := memorydataset.NewMemoryRepository("")
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	condition := createResource(t, repo, "Condition", map[string]interface{}{"note": "first"})
+	id := condition["id"].(string)
+
+	err = repo.UpdateFHIRResource(context.Background(), "Condition", id, map[string]interface{}{"note": "second"}, &map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unable to update condition: %v", err)
+	}
+
+	patch := []map[string]interface{}{{"op": "replace", "path": "/note", "value": "third"}}
+
+	err = repo.PatchFHIRResource(context.Background(), "Condition", id, patch, &map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unable to patch condition: %v", err)
+	}
+
+	first := 2
+
+	tests := []struct {
+		name       string
+		id         string
+		pagination dto.Pagination
+		wantNotes  []string
+		wantNext   bool
+		wantErr    bool
+	}{
+		{
+			name:       "happy case: every version newest first",
+			id:         id,
+			pagination: dto.Pagination{Skip: true},
+			wantNotes:  []string{"third", "second", "first"},
+		},
+		{
+			name:       "happy case: first page",
+			id:         id,
+			pagination: dto.Pagination{First: &first},
+			wantNotes:  []string{"third", "second"},
+			wantNext:   true,
+		},
+		{
+			name:       "happy case: next page",
+			id:         id,
+			pagination: dto.Pagination{First: &first, After: "2"},
+			wantNotes:  []string{"first"},
+		},
+		{
+			name:       "sad case: unknown resource",
+			id:         "missing",
+			pagination: dto.Pagination{Skip: true},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetFHIRResourceHistory(context.Background(), "Condition", tt.id, tt.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetFHIRResourceHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.TotalCount != 3 || got.HasNextPage != tt.wantNext {
+				t.Errorf("expected 3 versions and next page %v, got: %v and %v", tt.wantNext, got.TotalCount, got.HasNextPage)
+			}
+
+			if len(got.Resources) != len(tt.wantNotes) {
+				t.Fatalf("expected %d versions, got: %d", len(tt.wantNotes), len(got.Resources))
+			}
+
+			for i, note := range tt.wantNotes {
+				if got.Resources[i]["note"] != note {
+					t.Errorf("expected version %d to have note %q, got: %v", i, note, got.Resources[i]["note"])
+				}
+			}
+		})
+	}
+
+	t.Run("vread", func(t *testing.T) {
+		version := map[string]interface{}{}
+
+		err := repo.GetFHIRResourceVersion(context.Background(), "Condition", id, "2", &version)
+		if err != nil {
+			t.Fatalf("GetFHIRResourceVersion() error = %v", err)
+		}
+
+		if version["note"] != "second" {
+			t.Errorf("expected the second version, got: %v", version)
+		}
+
+		err = repo.GetFHIRResourceVersion(context.Background(), "Condition", id, "4", &version)
+		if err == nil {
+			t.Errorf("expected an error reading a version that does not exist")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package memorydataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// applyPatch applies JSON patch operations to a resource in place
+//
+// See: https://datatracker.ietf.org/doc/html/rfc6902
+func applyPatch(resource map[string]interface{}, operations []map[string]interface{}) error {
+	for _, operation := range operations {
+		op, _ := operation["op"].(string)
+		path, _ := operation["path"].(string)
+
+		tokens, err := pointerTokens(path)
+		if err != nil {
+			return err
+		}
+
+		value, err := normalize(operation["value"])
+		if err != nil {
+			return fmt.Errorf("invalid value for %s %s: %w", op, path, err)
+		}
+
+		switch op {
+		case "add", "replace", "remove":
+			_, err = patchNode(resource, tokens, op, value)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", op, path, err)
+			}
+
+		case "test":
+			current, found := lookup(resource, tokens)
+			if !found || !reflect.DeepEqual(current, value) {
+				return fmt.Errorf("test %s: value does not match", path)
+			}
+
+		default:
+			return fmt.Errorf("unsupported patch operation %q", op)
+		}
+	}
+
+	return nil
+}
+
+// pointerTokens splits a JSON pointer into its unescaped reference tokens
+func pointerTokens(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// patchNode applies an add, replace or remove operation below a node and
+// returns the updated node since list operations may reallocate the list
+func patchNode(node interface{}, tokens []string, op string, value interface{}) (interface{}, error) {
+	key := tokens[0]
+	last := len(tokens) == 1
+
+	switch element := node.(type) {
+	case map[string]interface{}:
+		child, exists := element[key]
+
+		if !last {
+			if !exists {
+				return nil, fmt.Errorf("path element %q not found", key)
+			}
+
+			updated, err := patchNode(child, tokens[1:], op, value)
+			if err != nil {
+				return nil, err
+			}
+
+			element[key] = updated
+
+			return element, nil
+		}
+
+		if op != "add" && !exists {
+			return nil, fmt.Errorf("path element %q not found", key)
+		}
+
+		if op == "remove" {
+			delete(element, key)
+		} else {
+			element[key] = value
+		}
+
+		return element, nil
+
+	case []interface{}:
+		if last && op == "add" && key == "-" {
+			return append(element, value), nil
+		}
+
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index > len(element) || (index == len(element) && !(last && op == "add")) {
+			return nil, fmt.Errorf("invalid list index %q", key)
+		}
+
+		if !last {
+			updated, err := patchNode(element[index], tokens[1:], op, value)
+			if err != nil {
+				return nil, err
+			}
+
+			element[index] = updated
+
+			return element, nil
+		}
+
+		switch op {
+		case "add":
+			element = append(element, nil)
+			copy(element[index+1:], element[index:])
+			element[index] = value
+		case "replace":
+			element[index] = value
+		case "remove":
+			element = append(element[:index], element[index+1:]...)
+		}
+
+		return element, nil
+
+	default:
+		return nil, fmt.Errorf("path element %q is not a container", key)
+	}
+}
+
+// lookup returns the value at the location referred to by the tokens
+func lookup(node interface{}, tokens []string) (interface{}, bool) {
+	for _, token := range tokens {
+		switch element := node.(type) {
+		case map[string]interface{}:
+			child, ok := element[token]
+			if !ok {
+				return nil, false
+			}
+
+			node = child
+
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(element) {
+				return nil, false
+			}
+
+			node = element[index]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// normalize converts a value to the generic representation that JSON decoding
+// produces so that it can be stored and compared with existing values
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+
+	err = json.Unmarshal(data, &normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,815 @@
+package memorydataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// the tenant tag systems used to isolate each tenant's data
+const (
+	organisationTagSystem = "http://mycarehub/tenant-identification/organisation"
+	facilityTagSystem     = "http://mycarehub/tenant-identification/facility"
+)
+
+// referenceParameters maps the supported reference search parameters to the
+// resource elements that they are evaluated against
+var referenceParameters = map[string][]string{
+	"patient":         {"patient", "subject"},
+	"subject":         {"subject"},
+	"encounter":       {"encounter", "context"},
+	"organization":    {"managingOrganization", "organization"},
+	"episode-of-care": {"episodeOfCare"},
+	"based-on":        {"basedOn"},
+	"part-of":         {"partOf"},
+}
+
+// tokenParameters maps the supported token search parameters to the resource
+// elements that they are evaluated against
+var tokenParameters = map[string][]string{
+	"_id":                 {"id"},
+	"_tag":                {"meta.tag"},
+	"_security":           {"meta.security"},
+	"code":                {"code"},
+	"status":              {"status"},
+	"identifier":          {"identifier"},
+	"category":            {"category"},
+	"clinical-status":     {"clinicalStatus"},
+	"verification-status": {"verificationStatus"},
+	"active":              {"active"},
+	"gender":              {"gender"},
+	"type":                {"type"},
+	"link":                {"link.other"},
+}
+
+// dateParameters maps the supported date search parameters to the resource
+// elements that they are evaluated against
+var dateParameters = map[string][]string{
+	"_lastUpdated": {"meta.lastUpdated"},
+	"date": {
+		"effectiveDateTime", "effectivePeriod", "recordedDate", "onsetDateTime",
+		"period", "date", "authoredOn", "issued",
+	},
+	"birthdate":      {"birthDate"},
+	"effective":      {"effectiveDateTime", "effectivePeriod"},
+	"recorded-date":  {"recordedDate"},
+	"onset-date":     {"onsetDateTime"},
+	"authoredon":     {"authoredOn"},
+	"abatement-date": {"abatementDateTime"},
+}
+
+// dateLayouts are the FHIR date, dateTime and instant layouts in order of
+// decreasing precision
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// SearchFHIRResource searches the dataset for resources of the given type.
+//
+// Results are always filtered by the tenant's organisation and facility tags.
+// The page token is an opaque offset into the result set, which can be paged
+// forward with `First` and `After` or backward with `Last` and `Before`.
+func (r *Repository) SearchFHIRResource(
+	_ context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
+	err := pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := params.Values()
+
+	tenantTags := []string{
+		fmt.Sprintf("%s|%s", organisationTagSystem, tenant.OrganizationID),
+		fmt.Sprintf("%s|%s", facilityTagSystem, tenant.FacilityID),
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []*storedResource{}
+
+	for _, stored := range r.all() {
+		if stored.resource["resourceType"] != resourceType {
+			continue
+		}
+
+		matched, err := matchesTags(stored.resource, tenantTags)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matched {
+			continue
+		}
+
+		matched, err = matchesCriteria(stored.resource, criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			matches = append(matches, stored)
+		}
+	}
+
+	err = sortResources(matches, criteria.Get("_sort"))
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := pageWindow(criteria, pagination, len(matches))
+	if err != nil {
+		return nil, err
+	}
+
+	response := domain.PagedFHIRResource{
+		Resources:  []map[string]interface{}{},
+		TotalCount: len(matches),
+	}
+
+	setCursors(&response, start, end)
+
+	for _, stored := range matches[start:end] {
+		resource, err := clone(stored.resource)
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy %s: %w", referenceOf(stored.resource), err)
+		}
+
+		response.Resources = append(response.Resources, resource)
+	}
+
+	response.Included, err = r.included(matches[start:end], criteria, tenantTags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// included finds the resources that the `_include` and `_revinclude`
+// parameters of a search add to a page of matches. They are keyed by their
+// reference. Reverse included resources must carry the tenant tags too, so that
+// the records of other facilities are not returned. The caller should hold the
+// lock
+func (r *Repository) included(matches []*storedResource, criteria url.Values, tenantTags []string) (map[string]map[string]interface{}, error) {
+	if len(criteria["_include"]) == 0 && len(criteria["_revinclude"]) == 0 {
+		return nil, nil
+	}
+
+	included := map[string]map[string]interface{}{}
+
+	add := func(stored *storedResource) error {
+		reference := referenceOf(stored.resource)
+		if _, ok := included[reference]; ok {
+			return nil
+		}
+
+		resource, err := clone(stored.resource)
+		if err != nil {
+			return fmt.Errorf("unable to copy %s: %w", reference, err)
+		}
+
+		included[reference] = resource
+
+		return nil
+	}
+
+	// `_include=Encounter:patient` follows the patient references of the matches
+	for _, include := range criteria["_include"] {
+		sourceType, parameter, paths, err := includeParameter(include)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range matches {
+			if match.resource["resourceType"] != sourceType {
+				continue
+			}
+
+			for _, value := range elementValues(match.resource, paths) {
+				element, ok := value.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				reference, _ := element["reference"].(string)
+
+				targetType, id, found := strings.Cut(reference, "/")
+				if !found {
+					continue
+				}
+
+				stored, ok := r.resources[targetType][id]
+				if !ok {
+					continue
+				}
+
+				err := add(stored)
+				if err != nil {
+					return nil, fmt.Errorf("unable to include %s: %w", parameter, err)
+				}
+			}
+		}
+	}
+
+	// `_revinclude=Observation:patient` finds the observations of the matches
+	for _, revinclude := range criteria["_revinclude"] {
+		sourceType, parameter, paths, err := includeParameter(revinclude)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stored := range r.all() {
+			if stored.resource["resourceType"] != sourceType {
+				continue
+			}
+
+			matched, err := matchesTags(stored.resource, tenantTags)
+			if err != nil {
+				return nil, err
+			}
+
+			if !matched {
+				continue
+			}
+
+			for _, match := range matches {
+				if !matchesReference(elementValues(stored.resource, paths), referenceOf(match.resource)) {
+					continue
+				}
+
+				err := add(stored)
+				if err != nil {
+					return nil, fmt.Errorf("unable to include %s: %w", parameter, err)
+				}
+
+				break
+			}
+		}
+	}
+
+	return included, nil
+}
+
+// includeParameter parses an `_include` or `_revinclude` value of the form
+// `ResourceType:parameter[:targetType]` and returns the element paths of its
+// reference parameter
+func includeParameter(value string) (resourceType string, parameter string, paths []string, err error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 {
+		return "", "", nil, fmt.Errorf("invalid include value %q", value)
+	}
+
+	paths, ok := referenceParameters[parts[1]]
+	if !ok {
+		return "", "", nil, fmt.Errorf("unsupported include parameter %q", value)
+	}
+
+	return parts[0], parts[1], paths, nil
+}
+
+// pageWindow works out which of the matches of a search are on the requested
+// page. Cursors are offsets into the matches: a page starts at the `After`
+// cursor or, when paging backward, ends just before the `Before` cursor. A page
+// size of zero means that every match should be returned
+func pageWindow(criteria url.Values, pagination dto.Pagination, total int) (start int, end int, err error) {
+	size := 0
+
+	if raw := criteria.Get("_count"); raw != "" {
+		size, err = strconv.Atoi(raw)
+		if err != nil || size < 0 {
+			return 0, 0, fmt.Errorf("invalid _count value %q", raw)
+		}
+	}
+
+	after, before := criteria.Get("_page_token"), ""
+
+	if !pagination.Skip {
+		if pagination.Last != nil {
+			size = *pagination.Last
+		} else {
+			size = *pagination.First
+		}
+
+		if pagination.After != "" {
+			after = pagination.After
+		}
+
+		before = pagination.Before
+	}
+
+	if before != "" {
+		end, err = parseCursor(before, total)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		start = 0
+		if size > 0 && end-size > 0 {
+			start = end - size
+		}
+
+		return start, end, nil
+	}
+
+	if after != "" {
+		start, err = parseCursor(after, total)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	end = total
+	if size > 0 && start+size < total {
+		end = start + size
+	}
+
+	return start, end, nil
+}
+
+// parseCursor returns the offset of a page cursor, capped at the number of
+// matches
+func parseCursor(cursor string, total int) (int, error) {
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page token %q", cursor)
+	}
+
+	if offset > total {
+		offset = total
+	}
+
+	return offset, nil
+}
+
+// setCursors sets the cursors of the pages before and after the one that
+// spans the matches from start up to end
+func setCursors(response *domain.PagedFHIRResource, start, end int) {
+	if end < response.TotalCount {
+		response.HasNextPage = true
+		response.NextCursor = strconv.Itoa(end)
+	}
+
+	if start > 0 {
+		response.HasPreviousPage = true
+		response.PreviousCursor = strconv.Itoa(start)
+	}
+}
+
+// matchesTags checks that a resource carries every one of the given tags
+func matchesTags(resource map[string]interface{}, tags []string) (bool, error) {
+	for _, tag := range tags {
+		matched, err := matchesParameter(resource, "_tag", tag)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matchesCriteria checks that a resource satisfies every search parameter. A
+// parameter that is repeated e.g for a date range should match every value
+func matchesCriteria(resource map[string]interface{}, criteria url.Values) (bool, error) {
+	for name, values := range criteria {
+		for _, value := range values {
+			matched, err := matchesParameter(resource, name, value)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// matchesParameter evaluates a single search parameter. Comma separated
+// values are treated as alternatives.
+func matchesParameter(resource map[string]interface{}, name, value string) (bool, error) {
+	parameter, modifier, _ := strings.Cut(name, ":")
+
+	switch parameter {
+	case "_count", "_page_token", "_sort", "_include", "_revinclude":
+		return true, nil
+
+	case "_content":
+		return matchesContent(resource, value)
+	}
+
+	if modifier == string(domain.SearchModifierNot) {
+		paths, ok := tokenParameters[parameter]
+		if !ok {
+			return false, fmt.Errorf("unsupported search parameter %q", name)
+		}
+
+		for _, alternative := range strings.Split(value, ",") {
+			if matchesToken(elementValues(resource, paths), alternative) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+
+	for _, alternative := range strings.Split(value, ",") {
+		var (
+			matched bool
+			err     error
+		)
+
+		if paths, ok := referenceParameters[parameter]; ok {
+			matched = matchesReference(elementValues(resource, paths), alternative)
+		} else if paths, ok := tokenParameters[parameter]; ok {
+			matched = matchesToken(elementValues(resource, paths), alternative)
+		} else if paths, ok := dateParameters[parameter]; ok {
+			matched, err = matchesDate(elementValues(resource, paths), alternative)
+		} else {
+			return false, fmt.Errorf("unsupported search parameter %q", name)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// matchesContent does a case insensitive full text match of every term in the
+// value against the resource
+func matchesContent(resource map[string]interface{}, value string) (bool, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return false, fmt.Errorf("unable to marshal %s: %w", referenceOf(resource), err)
+	}
+
+	text := strings.ToLower(string(data))
+
+	for _, term := range strings.Fields(strings.ToLower(value)) {
+		if !strings.Contains(text, term) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchesReference matches reference elements against a value that is either
+// a relative reference e.g `Patient/123` or a bare resource ID
+func matchesReference(values []interface{}, value string) bool {
+	for _, v := range values {
+		element, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		reference, _ := element["reference"].(string)
+		if reference == "" {
+			continue
+		}
+
+		if reference == value || strings.HasSuffix(reference, "/"+value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesToken matches codes, Codings, CodeableConcepts and Identifiers
+// against a token of the form `[system|]code`
+func matchesToken(values []interface{}, value string) bool {
+	system, code, hasSystem := strings.Cut(value, "|")
+	if !hasSystem {
+		code, system = system, ""
+	}
+
+	matches := func(candidateSystem, candidateCode string) bool {
+		if hasSystem && candidateSystem != system {
+			return false
+		}
+
+		return code == "" || candidateCode == code
+	}
+
+	for _, v := range values {
+		switch element := v.(type) {
+		case string:
+			if !hasSystem && element == code {
+				return true
+			}
+
+		case bool:
+			if !hasSystem && strconv.FormatBool(element) == code {
+				return true
+			}
+
+		case map[string]interface{}:
+			if codings, ok := element["coding"].([]interface{}); ok {
+				if matchesToken(codings, value) {
+					return true
+				}
+
+				continue
+			}
+
+			candidateSystem, _ := element["system"].(string)
+			candidateCode, _ := element["code"].(string)
+
+			// identifiers carry their code in the value element
+			if identifierValue, ok := element["value"].(string); ok {
+				candidateCode = identifierValue
+			}
+
+			if candidateCode != "" && matches(candidateSystem, candidateCode) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesDate compares date elements against a value with an optional FHIR
+// comparison prefix e.g `ge2023-01-01`. The value is treated as the range
+// implied by its precision, so `2023-01-01` matches any time on that day.
+func matchesDate(values []interface{}, value string) (bool, error) {
+	prefix := "eq"
+
+	if len(value) > 2 && value[0] >= 'a' && value[0] <= 'z' {
+		prefix, value = value[:2], value[2:]
+	}
+
+	start, end, err := dateRange(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid date search value %q: %w", value, err)
+	}
+
+	for _, v := range values {
+		candidate, ok := dateOf(v)
+		if !ok {
+			continue
+		}
+
+		within := !candidate.Before(start) && candidate.Before(end)
+
+		var matched bool
+
+		switch prefix {
+		case "eq":
+			matched = within
+		case "ne":
+			matched = !within
+		case "gt", "sa":
+			matched = !candidate.Before(end)
+		case "lt", "eb":
+			matched = candidate.Before(start)
+		case "ge":
+			matched = !candidate.Before(start)
+		case "le":
+			matched = candidate.Before(end)
+		default:
+			return false, fmt.Errorf("unsupported date prefix %q", prefix)
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// dateOf reads a date from a date, dateTime, instant or Period element
+func dateOf(value interface{}) (time.Time, bool) {
+	if period, ok := value.(map[string]interface{}); ok {
+		value = period["start"]
+	}
+
+	raw, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	start, _, err := dateRange(raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return start, true
+}
+
+// dateRange parses any of the FHIR date formats and returns the range of time
+// that the value covers given its precision
+func dateRange(value string) (time.Time, time.Time, error) {
+	var err error
+
+	for _, layout := range dateLayouts {
+		var start time.Time
+
+		start, err = time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+
+		switch layout {
+		case "2006":
+			return start, start.AddDate(1, 0, 0), nil
+		case "2006-01":
+			return start, start.AddDate(0, 1, 0), nil
+		case "2006-01-02":
+			return start, start.AddDate(0, 0, 1), nil
+		default:
+			return start, start.Add(time.Second), nil
+		}
+	}
+
+	return time.Time{}, time.Time{}, err
+}
+
+// elementValues collects the values found at the given dot separated element
+// paths. Lists along the way are flattened.
+func elementValues(resource map[string]interface{}, paths []string) []interface{} {
+	values := []interface{}{}
+
+	for _, path := range paths {
+		current := []interface{}{resource}
+
+		for _, name := range strings.Split(path, ".") {
+			next := []interface{}{}
+
+			for _, node := range current {
+				element, ok := node.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				switch child := element[name].(type) {
+				case nil:
+				case []interface{}:
+					next = append(next, child...)
+				default:
+					next = append(next, child)
+				}
+			}
+
+			current = next
+		}
+
+		values = append(values, current...)
+	}
+
+	return values
+}
+
+// referencesTarget checks whether any reference element in a resource points
+// to the target reference
+func referencesTarget(node interface{}, target string) bool {
+	switch element := node.(type) {
+	case map[string]interface{}:
+		for key, child := range element {
+			if key == "reference" && child == target {
+				return true
+			}
+
+			if referencesTarget(child, target) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, child := range element {
+			if referencesTarget(child, target) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sortResources orders search results using a FHIR `_sort` value e.g
+// `-date,_id`. Without a sort value resources keep their creation order.
+func sortResources(resources []*storedResource, sortBy string) error {
+	if sortBy == "" {
+		return nil
+	}
+
+	keys := strings.Split(sortBy, ",")
+
+	for _, key := range keys {
+		parameter := strings.TrimPrefix(key, "-")
+		if _, ok := sortPaths(parameter); !ok {
+			return fmt.Errorf("unsupported sort parameter %q", parameter)
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		for _, key := range keys {
+			descending := strings.HasPrefix(key, "-")
+			paths, _ := sortPaths(strings.TrimPrefix(key, "-"))
+
+			comparison := compareValues(
+				elementValues(resources[i].resource, paths),
+				elementValues(resources[j].resource, paths),
+			)
+			if comparison == 0 {
+				continue
+			}
+
+			if descending {
+				return comparison > 0
+			}
+
+			return comparison < 0
+		}
+
+		return false
+	})
+
+	return nil
+}
+
+// sortPaths returns the element paths that a sort parameter refers to
+func sortPaths(parameter string) ([]string, bool) {
+	if paths, ok := dateParameters[parameter]; ok {
+		return paths, true
+	}
+
+	if paths, ok := tokenParameters[parameter]; ok {
+		return paths, true
+	}
+
+	return nil, false
+}
+
+// compareValues compares the first value of two element lists. Dates are
+// compared chronologically and missing values sort last.
+func compareValues(a, b []interface{}) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	dateA, okA := dateOf(a[0])
+	dateB, okB := dateOf(b[0])
+
+	if okA && okB {
+		switch {
+		case dateA.Before(dateB):
+			return -1
+		case dateA.After(dateB):
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a[0]), fmt.Sprint(b[0]))
+}
+
+// searchBundle composes a `searchset` Bundle from stored resources
+func searchBundle(resources []*storedResource, total int, links []map[string]interface{}) map[string]interface{} {
+	entries := []map[string]interface{}{}
+
+	for _, stored := range resources {
+		entries = append(entries, map[string]interface{}{
+			"fullUrl":  referenceOf(stored.resource),
+			"resource": stored.resource,
+			"search":   map[string]interface{}{"mode": "match"},
+		})
+	}
+
+	if links == nil {
+		links = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"resourceType": "Bundle",
+		"type":         "searchset",
+		"total":        total,
+		"link":         links,
+		"entry":        entries,
+	}
+}
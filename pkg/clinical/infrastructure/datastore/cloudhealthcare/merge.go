@@ -0,0 +1,288 @@
+package fhir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/scalarutils"
+)
+
+// mergedResourceTypes are the resource types in a patient's compartment that
+// are moved to the patient that replaces it when patients are merged
+var mergedResourceTypes = []string{
+	episodeOfCareResourceType,
+	encounterResourceType,
+	observationResourceType,
+	conditionResourceType,
+	allergyIntoleranceResourceType,
+	medicationStatementResourceType,
+	medicationRequestResourceType,
+	serviceRequestResourceType,
+	compositionResourceType,
+}
+
+// MergeFHIRPatients merges a duplicate patient, the source, into the patient
+// that replaces it, the target, and returns the target.
+//
+// The resources of the tenant's organisation that reference the source, at
+// any of its facilities, are repointed to the target, the source is marked
+// inactive and the two are linked with `replaced-by` and `replaces` links. The
+// source records the resources that were moved so that the merge can be
+// reversed with `UnmergeFHIRPatient`, and a Provenance of the merge is
+// recorded. All of it is written in a single transaction, which fails if any of
+// the resources changed in the meantime. A patient with more resources than a
+// transaction can hold is not merged and nothing is written.
+func (fh StoreImpl) MergeFHIRPatients(ctx context.Context, sourceID, targetID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("a %s cannot be merged into itself", patientResourceType)
+	}
+
+	source, err := fh.getTenantFHIRPatient(ctx, sourceID, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := fh.getTenantFHIRPatient(ctx, targetID, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if mergedInto, _, _, merged := source.Merge(); merged {
+		return nil, fmt.Errorf("%s with ID %s has already been merged into %s", patientResourceType, sourceID, mergedInto)
+	}
+
+	if mergedInto, _, _, merged := target.Merge(); merged {
+		return nil, fmt.Errorf("%s with ID %s has been merged into %s and cannot be merged into", patientResourceType, targetID, mergedInto)
+	}
+
+	store, tx := fh.beginTransaction()
+
+	moved, err := fh.movePatientResources(ctx, store, sourceID, targetID, tenant, func(string) bool { return true })
+	if errors.Is(err, domain.ErrFHIRTransactionTooLarge) {
+		return nil, fmt.Errorf("%s with ID %s has too many resources to merge: %w", patientResourceType, sourceID, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	source.MergeInto(target, moved, fh.clock().Now())
+
+	_, target, err = fh.commitPatientMerge(ctx, store, tx, domain.ProvenanceActivityMerge, tenant, source, target, moved)
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// UnmergeFHIRPatient reverses the merge of a patient into another and returns
+// the restored patient.
+//
+// The resources that were moved by the merge are repointed back to the
+// patient, except those that no longer reference the patient it was merged
+// into. Resources added to that patient after the merge are left with it. A
+// Provenance of the unmerge is recorded.
+func (fh StoreImpl) UnmergeFHIRPatient(ctx context.Context, sourceID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	source, err := fh.getTenantFHIRPatient(ctx, sourceID, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID, moved, _, merged := source.Merge()
+	if !merged {
+		return nil, fmt.Errorf("%s with ID %s has not been merged", patientResourceType, sourceID)
+	}
+
+	target, err := fh.getTenantFHIRPatient(ctx, targetID, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	wasMoved := map[string]bool{}
+	for _, reference := range moved {
+		wasMoved[reference] = true
+	}
+
+	store, tx := fh.beginTransaction()
+
+	restored, err := fh.movePatientResources(ctx, store, targetID, sourceID, tenant, func(reference string) bool {
+		return wasMoved[reference]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	source.Unmerge(target)
+
+	source, _, err = fh.commitPatientMerge(ctx, store, tx, domain.ProvenanceActivityUnmerge, tenant, source, target, restored)
+	if err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// movePatientResources repoints the references to one patient in the
+// resources of the tenant's organisation that `include` accepts to another
+// patient, within the transaction of `store`. A patient's records are moved
+// from every facility of the organisation, not only the caller's. It returns
+// the resources that were changed
+func (fh StoreImpl) movePatientResources(
+	ctx context.Context, store StoreImpl, fromID, toID string, tenant dto.TenantIdentifiers, include func(reference string) bool,
+) ([]string, error) {
+	from := fmt.Sprintf("%s/%s", patientResourceType, fromID)
+	to := fmt.Sprintf("%s/%s", patientResourceType, toID)
+
+	organisation := dto.TenantIdentifiers{OrganizationID: tenant.OrganizationID}
+
+	moved := []string{}
+
+	for _, resourceType := range mergedResourceTypes {
+		params := domain.NewSearchParams().Reference("patient", from)
+
+		err := fh.eachFHIRResource(ctx, resourceType, params, organisation, func(resource map[string]interface{}) error {
+			id, _ := resource["id"].(string)
+			reference := fmt.Sprintf("%s/%s", resourceType, id)
+
+			if !include(reference) || !repointReferences(resource, from, to) {
+				return nil
+			}
+
+			err := store.Dataset.UpdateFHIRResource(ctx, resourceType, id, resource, &map[string]interface{}{})
+			if err != nil {
+				return fmt.Errorf("unable to update %s: %w", reference, err)
+			}
+
+			moved = append(moved, reference)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to move the %s resources of %s: %w", resourceType, from, err)
+		}
+	}
+
+	return moved, nil
+}
+
+// commitPatientMerge writes the source and target patients and a Provenance of
+// the activity, then commits the transaction
+func (fh StoreImpl) commitPatientMerge(
+	ctx context.Context, store StoreImpl, tx *transactionDataset, activity string, tenant dto.TenantIdentifiers,
+	source, target *domain.FHIRPatient, moved []string,
+) (*domain.FHIRPatient, *domain.FHIRPatient, error) {
+	updated := []*domain.FHIRPatient{}
+
+	for _, patient := range []*domain.FHIRPatient{source, target} {
+		payload, err := converterandformatter.StructToMap(patient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to turn the updated %s into a map: %w", patientResourceType, err)
+		}
+
+		resource := &domain.FHIRPatient{}
+
+		err = store.Dataset.UpdateFHIRResource(ctx, patientResourceType, *patient.ID, payload, resource)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to update %s resource: %w", patientResourceType, err)
+		}
+
+		updated = append(updated, resource)
+	}
+
+	references := []string{
+		fmt.Sprintf("%s/%s", patientResourceType, *source.ID),
+		fmt.Sprintf("%s/%s", patientResourceType, *target.ID),
+	}
+
+	provenance := newProvenance(activity, tenant, target.Meta, append(references, moved...), fh.clock().Now())
+
+	payload, err := converterandformatter.StructToMap(provenance)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to turn the %s into a map: %w", provenanceResourceType, err)
+	}
+
+	err = store.Dataset.CreateFHIRResource(ctx, provenanceResourceType, payload, &domain.FHIRProvenance{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create %s resource: %w", provenanceResourceType, err)
+	}
+
+	err = fh.commitTransaction(ctx, tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to %s %s %s: %w", activity, references[0], references[1], err)
+	}
+
+	return updated[0], updated[1], nil
+}
+
+// newProvenance records an activity on the target resources at a time. It is tagged
+// like the resource that `meta` belongs to and names the tenant's facility, or
+// organisation, as the agent
+func newProvenance(activity string, tenant dto.TenantIdentifiers, meta *domain.FHIRMeta, targets []string, recorded time.Time) domain.FHIRProvenance {
+	system := scalarutils.URI(domain.ProvenanceActivitySystem)
+
+	provenance := domain.FHIRProvenance{
+		Recorded: recorded.UTC().Format(time.RFC3339),
+		Activity: &domain.FHIRCodeableConcept{
+			Coding: []*domain.FHIRCoding{{System: &system, Code: scalarutils.Code(activity)}},
+			Text:   activity,
+		},
+	}
+
+	if meta != nil {
+		provenance.Meta = &domain.FHIRMeta{Tag: meta.Tag}
+	}
+
+	for _, target := range targets {
+		target := target
+		provenance.Target = append(provenance.Target, &domain.FHIRReference{Reference: &target})
+	}
+
+	agent := tenant.FacilityID
+	if agent == "" {
+		agent = tenant.OrganizationID
+	}
+
+	if agent != "" {
+		who := fmt.Sprintf("%s/%s", organizationResource, agent)
+		provenance.Agent = []*domain.FHIRProvenanceAgent{{Who: &domain.FHIRReference{Reference: &who}}}
+	}
+
+	return provenance
+}
+
+// repointReferences changes every reference to one resource, anywhere within
+// a resource, to another and reports whether any was changed
+func repointReferences(value interface{}, from, to string) bool {
+	changed := false
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if reference, ok := item.(string); ok && key == "reference" && reference == from {
+				value[key] = to
+				changed = true
+
+				continue
+			}
+
+			if repointReferences(item, from, to) {
+				changed = true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range value {
+			if repointReferences(item, from, to) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
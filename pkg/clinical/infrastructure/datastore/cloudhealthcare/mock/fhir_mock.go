@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/scalarutils"
 )
@@ -15,12 +16,12 @@ import (
 // FHIRMock struct implements mocks of FHIR methods.
 type FHIRMock struct {
 	MockCreateEpisodeOfCareFn    func(ctx context.Context, episode domain.FHIREpisodeOfCareInput) (*domain.EpisodeOfCarePayload, error)
-	MockSearchFHIRConditionFn    func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error)
+	MockSearchFHIRConditionFn    func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error)
 	MockCreateFHIRConditionFn    func(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error)
 	MockCreateFHIROrganizationFn func(ctx context.Context, input domain.FHIROrganizationInput) (*domain.FHIROrganizationRelayPayload, error)
-	MockSearchFHIROrganizationFn func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error)
+	MockSearchFHIROrganizationFn func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error)
 	MockGetFHIROrganizationFn    func(ctx context.Context, organisationID string) (*domain.FHIROrganizationRelayPayload, error)
-	MockSearchEpisodesByParamFn  func(ctx context.Context, searchParams map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error)
+	MockSearchEpisodesByParamFn  func(ctx context.Context, searchParams domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error)
 	MockHasOpenEpisodeFn         func(
 		ctx context.Context,
 		patient domain.FHIRPatient,
@@ -29,54 +30,70 @@ type FHIRMock struct {
 	) (bool, error)
 	MockOpenEpisodesFn func(
 		ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error)
-	MockCreateFHIREncounterFn             func(ctx context.Context, input domain.FHIREncounterInput) (*domain.FHIREncounterRelayPayload, error)
-	MockGetFHIREpisodeOfCareFn            func(ctx context.Context, id string) (*domain.FHIREpisodeOfCareRelayPayload, error)
-	MockSearchPatientEncountersFn         func(ctx context.Context, patientReference string, status *domain.EncounterStatusEnum, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
-	MockSearchFHIREpisodeOfCareFn         func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error)
-	MockStartEncounterFn                  func(ctx context.Context, episodeID string) (string, error)
-	MockUpgradeEpisodeFn                  func(ctx context.Context, input domain.OTPEpisodeUpgradeInput) (*domain.EpisodeOfCarePayload, error)
-	MockSearchEpisodeEncounterFn          func(ctx context.Context, episodeReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
-	MockEndEncounterFn                    func(ctx context.Context, encounterID string) (bool, error)
-	MockEndEpisodeFn                      func(ctx context.Context, episodeID string) (bool, error)
-	MockGetActiveEpisodeFn                func(ctx context.Context, episodeID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCare, error)
-	MockSearchFHIRServiceRequestFn        func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error)
-	MockCreateFHIRServiceRequestFn        func(ctx context.Context, input domain.FHIRServiceRequestInput) (*domain.FHIRServiceRequestRelayPayload, error)
-	MockSearchFHIRAllergyIntoleranceFn    func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
-	MockCreateFHIRAllergyIntoleranceFn    func(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
-	MockUpdateFHIRAllergyIntoleranceFn    func(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
-	MockSearchFHIRCompositionFn           func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error)
-	MockCreateFHIRCompositionFn           func(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error)
-	MockUpdateFHIRCompositionFn           func(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error)
-	MockDeleteFHIRCompositionFn           func(ctx context.Context, id string) (bool, error)
-	MockUpdateFHIRConditionFn             func(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error)
-	MockGetFHIREncounterFn                func(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error)
-	MockSearchFHIREncounterFn             func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
-	MockSearchFHIRMedicationRequestFn     func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error)
-	MockCreateFHIRMedicationRequestFn     func(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error)
-	MockUpdateFHIRMedicationRequestFn     func(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error)
-	MockDeleteFHIRMedicationRequestFn     func(ctx context.Context, id string) (bool, error)
-	MockSearchFHIRObservationFn           func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error)
-	MockCreateFHIRObservationFn           func(ctx context.Context, input domain.FHIRObservationInput) (*domain.FHIRObservationRelayPayload, error)
-	MockDeleteFHIRObservationFn           func(ctx context.Context, id string) (bool, error)
-	MockGetFHIRPatientFn                  func(ctx context.Context, id string) (*domain.FHIRPatientRelayPayload, error)
-	MockDeleteFHIRPatientFn               func(ctx context.Context, id string) (bool, error)
-	MockDeleteFHIRServiceRequestFn        func(ctx context.Context, id string) (bool, error)
-	MockDeleteFHIRResourceTypeFn          func(results []map[string]string) error
-	MockCreateFHIRMedicationStatementFn   func(ctx context.Context, input domain.FHIRMedicationStatementInput) (*domain.FHIRMedicationStatementRelayPayload, error)
-	MockCreateFHIRMedicationFn            func(ctx context.Context, input domain.FHIRMedicationInput) (*domain.FHIRMedicationRelayPayload, error)
-	MockSearchFHIRMedicationStatementFn   func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error)
-	MockCreateFHIRPatientFn               func(ctx context.Context, input domain.FHIRPatientInput) (*domain.PatientPayload, error)
-	MockPatchFHIRPatientFn                func(ctx context.Context, id string, params []map[string]interface{}) (*domain.FHIRPatient, error)
-	MockUpdateFHIREpisodeOfCareFn         func(ctx context.Context, fhirResourceID string, payload map[string]interface{}) (*domain.FHIREpisodeOfCare, error)
-	MockSearchFHIRPatientFn               func(ctx context.Context, searchParams string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PatientConnection, error)
-	MockSearchPatientObservationsFn       func(ctx context.Context, patientReference, conceptID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIRObservation, error)
-	MockGetFHIRAllergyIntoleranceFn       func(ctx context.Context, id string) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
-	MockSearchPatientAllergyIntoleranceFn func(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
+	MockCreateFHIREncounterFn                func(ctx context.Context, input domain.FHIREncounterInput) (*domain.FHIREncounterRelayPayload, error)
+	MockGetFHIREpisodeOfCareFn               func(ctx context.Context, id string) (*domain.FHIREpisodeOfCareRelayPayload, error)
+	MockSearchPatientEncountersFn            func(ctx context.Context, patientReference string, status *domain.EncounterStatusEnum, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
+	MockSearchFHIREpisodeOfCareFn            func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error)
+	MockStartEncounterFn                     func(ctx context.Context, episodeID string) (string, error)
+	MockUpgradeEpisodeFn                     func(ctx context.Context, input domain.OTPEpisodeUpgradeInput) (*domain.EpisodeOfCarePayload, error)
+	MockSearchEpisodeEncounterFn             func(ctx context.Context, episodeReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
+	MockEndEncounterFn                       func(ctx context.Context, encounterID string, endTime time.Time) (bool, error)
+	MockEndEpisodeFn                         func(ctx context.Context, episodeID string, endTime time.Time) (bool, error)
+	MockGetActiveEpisodeFn                   func(ctx context.Context, episodeID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCare, error)
+	MockSearchFHIRServiceRequestFn           func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error)
+	MockCreateFHIRServiceRequestFn           func(ctx context.Context, input domain.FHIRServiceRequestInput) (*domain.FHIRServiceRequestRelayPayload, error)
+	MockSearchFHIRAllergyIntoleranceFn       func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
+	MockCreateFHIRAllergyIntoleranceFn       func(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
+	MockUpdateFHIRAllergyIntoleranceFn       func(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
+	MockSearchFHIRCompositionFn              func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error)
+	MockCreateFHIRCompositionFn              func(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error)
+	MockUpdateFHIRCompositionFn              func(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error)
+	MockDeleteFHIRCompositionFn              func(ctx context.Context, id string) (bool, error)
+	MockUpdateFHIRConditionFn                func(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error)
+	MockGetFHIREncounterFn                   func(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error)
+	MockSearchFHIREncounterFn                func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
+	MockSearchFHIRMedicationRequestFn        func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error)
+	MockCreateFHIRMedicationRequestFn        func(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error)
+	MockUpdateFHIRMedicationRequestFn        func(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error)
+	MockDeleteFHIRMedicationRequestFn        func(ctx context.Context, id string) (bool, error)
+	MockSearchFHIRObservationFn              func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error)
+	MockCreateFHIRObservationFn              func(ctx context.Context, input domain.FHIRObservationInput) (*domain.FHIRObservationRelayPayload, error)
+	MockDeleteFHIRObservationFn              func(ctx context.Context, id string) (bool, error)
+	MockGetFHIRPatientFn                     func(ctx context.Context, id string) (*domain.FHIRPatientRelayPayload, error)
+	MockDeleteFHIRPatientFn                  func(ctx context.Context, id string) (bool, error)
+	MockDeleteFHIRServiceRequestFn           func(ctx context.Context, id string) (bool, error)
+	MockDeleteFHIRResourceTypeFn             func(ctx context.Context, results []map[string]string) error
+	MockCreateFHIRMedicationStatementFn      func(ctx context.Context, input domain.FHIRMedicationStatementInput) (*domain.FHIRMedicationStatementRelayPayload, error)
+	MockCreateFHIRMedicationFn               func(ctx context.Context, input domain.FHIRMedicationInput) (*domain.FHIRMedicationRelayPayload, error)
+	MockSearchFHIRMedicationStatementFn      func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error)
+	MockCreateFHIRPatientFn                  func(ctx context.Context, input domain.FHIRPatientInput) (*domain.PatientPayload, error)
+	MockPatchFHIRPatientFn                   func(ctx context.Context, id string, params []map[string]interface{}) (*domain.FHIRPatient, error)
+	MockUpdateFHIREpisodeOfCareFn            func(ctx context.Context, fhirResourceID string, payload map[string]interface{}) (*domain.FHIREpisodeOfCare, error)
+	MockSearchFHIRPatientFn                  func(ctx context.Context, searchParams string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PatientConnection, error)
+	MockSearchPatientObservationsFn          func(ctx context.Context, patientReference, conceptID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIRObservation, error)
+	MockGetFHIRAllergyIntoleranceFn          func(ctx context.Context, id string) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
+	MockSearchPatientAllergyIntoleranceFn    func(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
+	MockRunInTransactionFn                   func(ctx context.Context, work func(fhir repository.FHIR) error) error
+	MockGetFHIRConditionHistoryFn            func(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRConditionHistory, error)
+	MockGetFHIRAllergyIntoleranceHistoryFn   func(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergyHistory, error)
+	MockGetFHIREncounterWithPatientFn        func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error)
+	MockGetFHIREpisodeOfCareWithEncountersFn func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREpisodeOfCareRelayPayload, []domain.FHIREncounter, error)
+	MockGetFHIRPatientTimelineFn             func(ctx context.Context, patientID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatientTimeline, error)
+	MockExportFHIRResourcesFn                func(ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error) error
+	MockImportFHIRResourceFn                 func(ctx context.Context, resourceType string, payload map[string]interface{}) (string, error)
+	MockGetFHIRPatientEverythingFn           func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRBundle, error)
+	MockRetireFHIRPatientFn                  func(ctx context.Context, id, reason string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	MockReinstateFHIRPatientFn               func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	MockMergeFHIRPatientsFn                  func(ctx context.Context, sourceID, targetID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	MockUnmergeFHIRPatientFn                 func(ctx context.Context, sourceID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	MockCascadeDeleteFHIRPatientFn           func(ctx context.Context, id string, tenant dto.TenantIdentifiers, dryRun bool) (*domain.FHIRDeletion, error)
+	MockSearchExpiredFHIRResourcesFn         func(ctx context.Context, resourceType string, cutoff time.Time, tenant dto.TenantIdentifiers, fn func(resource map[string]interface{}) error) error
+	MockPurgeFHIRResourceFn                  func(ctx context.Context, resourceType, id string) error
 }
 
 // NewFHIRMock initializes a new instance of FHIR mock
 func NewFHIRMock() *FHIRMock {
-	return &FHIRMock{
+	fh := &FHIRMock{
 		MockCreateEpisodeOfCareFn: func(ctx context.Context, episode domain.FHIREpisodeOfCareInput) (*domain.EpisodeOfCarePayload, error) {
 			UUID := uuid.New().String()
 			PatientRef := "Patient/1"
@@ -196,10 +213,10 @@ func NewFHIRMock() *FHIRMock {
 				},
 			}, nil
 		},
-		MockSearchFHIROrganizationFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error) {
+		MockSearchFHIROrganizationFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error) {
 			return &domain.FHIROrganizationRelayConnection{}, nil
 		},
-		MockSearchEpisodesByParamFn: func(ctx context.Context, searchParams map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
+		MockSearchEpisodesByParamFn: func(ctx context.Context, searchParams domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
 			return []*domain.FHIREpisodeOfCare{}, nil
 		},
 		MockHasOpenEpisodeFn: func(ctx context.Context, patient domain.FHIRPatient, tenant dto.TenantIdentifiers, pagination dto.Pagination) (bool, error) {
@@ -295,7 +312,7 @@ func NewFHIRMock() *FHIRMock {
 				TotalCount:      0,
 			}, nil
 		},
-		MockSearchFHIREpisodeOfCareFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
+		MockSearchFHIREpisodeOfCareFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
 			PatientRef := "Patient/1"
 			OrgRef := "Organization/1"
 			return &domain.FHIREpisodeOfCareRelayConnection{
@@ -387,22 +404,22 @@ func NewFHIRMock() *FHIRMock {
 				TotalCount:      0,
 			}, nil
 		},
-		MockEndEncounterFn: func(ctx context.Context, encounterID string) (bool, error) {
+		MockEndEncounterFn: func(ctx context.Context, encounterID string, endTime time.Time) (bool, error) {
 			return true, nil
 		},
-		MockEndEpisodeFn: func(ctx context.Context, episodeID string) (bool, error) {
+		MockEndEpisodeFn: func(ctx context.Context, episodeID string, endTime time.Time) (bool, error) {
 			return true, nil
 		},
 		MockGetActiveEpisodeFn: func(ctx context.Context, episodeID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCare, error) {
 			return &domain.FHIREpisodeOfCare{}, nil
 		},
-		MockSearchFHIRServiceRequestFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error) {
+		MockSearchFHIRServiceRequestFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error) {
 			return &domain.FHIRServiceRequestRelayConnection{}, nil
 		},
 		MockCreateFHIRServiceRequestFn: func(ctx context.Context, input domain.FHIRServiceRequestInput) (*domain.FHIRServiceRequestRelayPayload, error) {
 			return &domain.FHIRServiceRequestRelayPayload{}, nil
 		},
-		MockSearchFHIRAllergyIntoleranceFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+		MockSearchFHIRAllergyIntoleranceFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 			UID := gofakeit.UUID()
 			system := scalarutils.URI("/orgs/CIEL/sources/CIEL/concepts/148888/")
 			return &domain.PagedFHIRAllergy{
@@ -554,7 +571,7 @@ func NewFHIRMock() *FHIRMock {
 		MockUpdateFHIRAllergyIntoleranceFn: func(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error) {
 			return &domain.FHIRAllergyIntoleranceRelayPayload{}, nil
 		},
-		MockSearchFHIRCompositionFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error) {
+		MockSearchFHIRCompositionFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error) {
 			return &domain.FHIRCompositionRelayConnection{}, nil
 		},
 		MockCreateFHIRCompositionFn: func(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error) {
@@ -566,7 +583,7 @@ func NewFHIRMock() *FHIRMock {
 		MockDeleteFHIRCompositionFn: func(ctx context.Context, id string) (bool, error) {
 			return true, nil
 		},
-		MockSearchFHIRConditionFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
+		MockSearchFHIRConditionFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
 			id := gofakeit.UUID()
 			statusSystem := scalarutils.URI("http://terminology.hl7.org/CodeSystem/condition-clinical")
 			status := "inactive"
@@ -650,7 +667,7 @@ func NewFHIRMock() *FHIRMock {
 				},
 			}, nil
 		},
-		MockSearchFHIREncounterFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
+		MockSearchFHIREncounterFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
 			PatientRef := "Patient/" + uuid.NewString()
 			UID := gofakeit.UUID()
 			return &domain.PagedFHIREncounter{
@@ -704,7 +721,7 @@ func NewFHIRMock() *FHIRMock {
 				TotalCount:      0,
 			}, nil
 		},
-		MockSearchFHIRMedicationRequestFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error) {
+		MockSearchFHIRMedicationRequestFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error) {
 			return &domain.FHIRMedicationRequestRelayConnection{}, nil
 		},
 		MockCreateFHIRMedicationRequestFn: func(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error) {
@@ -716,7 +733,7 @@ func NewFHIRMock() *FHIRMock {
 		MockDeleteFHIRMedicationRequestFn: func(ctx context.Context, id string) (bool, error) {
 			return true, nil
 		},
-		MockSearchFHIRObservationFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+		MockSearchFHIRObservationFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 			uuid := uuid.New().String()
 			finalStatus := domain.ObservationStatusEnumFinal
 			return &domain.FHIRObservationRelayConnection{
@@ -823,13 +840,13 @@ func NewFHIRMock() *FHIRMock {
 		MockDeleteFHIRPatientFn: func(ctx context.Context, id string) (bool, error) {
 			return true, nil
 		},
-		MockDeleteFHIRResourceTypeFn: func(results []map[string]string) error {
+		MockDeleteFHIRResourceTypeFn: func(ctx context.Context, results []map[string]string) error {
 			return nil
 		},
 		MockDeleteFHIRServiceRequestFn: func(ctx context.Context, id string) (bool, error) {
 			return true, nil
 		},
-		MockSearchFHIRMedicationStatementFn: func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+		MockSearchFHIRMedicationStatementFn: func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 			return &domain.FHIRMedicationStatementRelayConnection{}, nil
 		},
 		MockGetFHIROrganizationFn: func(ctx context.Context, organisationID string) (*domain.FHIROrganizationRelayPayload, error) {
@@ -952,7 +969,271 @@ func NewFHIRMock() *FHIRMock {
 				},
 			}}, nil
 		},
+		MockGetFHIRConditionHistoryFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRConditionHistory, error) {
+			statusSystem := scalarutils.URI("http://terminology.hl7.org/CodeSystem/condition-clinical")
+			status := "active"
+			uri := scalarutils.URI("1234567345")
+			recorder := "Practitioner/" + gofakeit.UUID()
+
+			condition := domain.FHIRCondition{
+				ID: &id,
+				ClinicalStatus: &domain.FHIRCodeableConcept{
+					Coding: []*domain.FHIRCoding{
+						{
+							System:  &statusSystem,
+							Code:    scalarutils.Code(status),
+							Display: status,
+						},
+					},
+					Text: status,
+				},
+				Code: &domain.FHIRCodeableConcept{
+					Coding: []*domain.FHIRCoding{
+						{
+							System:  &uri,
+							Code:    scalarutils.Code("1234"),
+							Display: "1234567",
+						},
+					},
+					Text: "1234",
+				},
+				RecordedDate: &scalarutils.Date{},
+				Subject: &domain.FHIRReference{
+					ID: &id,
+				},
+				Encounter: &domain.FHIRReference{
+					ID: &id,
+				},
+				Recorder: &domain.FHIRReference{
+					Reference: &recorder,
+					Display:   gofakeit.Name(),
+				},
+				Meta: &domain.FHIRMeta{
+					VersionID: "1",
+				},
+			}
+
+			return &domain.PagedFHIRConditionHistory{
+				Versions: []domain.FHIRConditionVersion{
+					{
+						Resource:    condition,
+						LastUpdated: time.Now(),
+					},
+				},
+				TotalCount: 1,
+			}, nil
+		},
+		MockGetFHIRAllergyIntoleranceHistoryFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergyHistory, error) {
+			system := scalarutils.URI("/orgs/CIEL/sources/CIEL/concepts/148888/")
+			recorder := "Practitioner/" + gofakeit.UUID()
+
+			allergy := domain.FHIRAllergyIntolerance{
+				ID: &id,
+				Code: &domain.FHIRCodeableConcept{
+					Coding: []*domain.FHIRCoding{
+						{
+							Code:   scalarutils.Code("124"),
+							System: &system,
+						},
+					},
+				},
+				Patient: &domain.FHIRReference{
+					ID: &id,
+				},
+				Encounter: &domain.FHIRReference{
+					ID: &id,
+				},
+				Recorder: &domain.FHIRReference{
+					Reference: &recorder,
+				},
+				Meta: &domain.FHIRMeta{
+					VersionID: "1",
+				},
+			}
+
+			return &domain.PagedFHIRAllergyHistory{
+				Versions: []domain.FHIRAllergyIntoleranceVersion{
+					{
+						Resource:    allergy,
+						LastUpdated: time.Now(),
+					},
+				},
+				TotalCount: 1,
+			}, nil
+		},
+		MockGetFHIREncounterWithPatientFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
+			encounterID := uuid.New().String()
+			patientID := uuid.New().String()
+			patientRef := "Patient/" + patientID
+			patientName := gofakeit.Name()
+			return &domain.FHIREncounterRelayPayload{
+				Resource: &domain.FHIREncounter{
+					ID:     &encounterID,
+					Status: domain.EncounterStatusEnumInProgress,
+					Subject: &domain.FHIRReference{
+						ID:        &patientID,
+						Reference: &patientRef,
+					},
+				},
+			}, &domain.FHIRPatientRelayPayload{
+				Resource: &domain.FHIRPatient{
+					ID: &patientID,
+					Name: []*domain.FHIRHumanName{
+						{
+							Given: []*string{&patientName},
+						},
+					},
+				},
+			}, nil
+		},
+		MockGetFHIREpisodeOfCareWithEncountersFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREpisodeOfCareRelayPayload, []domain.FHIREncounter, error) {
+			episodeID := uuid.New().String()
+			encounterID := uuid.New().String()
+			patientID := uuid.New().String()
+			patientRef := "Patient/" + patientID
+			status := domain.EpisodeOfCareStatusEnumActive
+			return &domain.FHIREpisodeOfCareRelayPayload{
+				Resource: &domain.FHIREpisodeOfCare{
+					ID:     &episodeID,
+					Status: &status,
+					Patient: &domain.FHIRReference{
+						ID:        &patientID,
+						Reference: &patientRef,
+					},
+				},
+			}, []domain.FHIREncounter{
+				{
+					ID:     &encounterID,
+					Status: domain.EncounterStatusEnumInProgress,
+				},
+			}, nil
+		},
+		MockGetFHIRPatientTimelineFn: func(ctx context.Context, patientID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatientTimeline, error) {
+			id := uuid.New().String()
+			status := domain.ObservationStatusEnumFinal
+			date := scalarutils.Date{Year: 2023, Month: 1, Day: 1}
+			return &domain.FHIRPatientTimeline{
+				AllergyIntolerances: []domain.FHIRAllergyIntolerance{
+					{
+						ID: &id,
+						Code: &domain.FHIRCodeableConcept{
+							Text: "Peanuts",
+						},
+						ClinicalStatus: domain.FHIRCodeableConcept{
+							Text: "active",
+						},
+						Reaction: []*domain.FHIRAllergyintoleranceReaction{
+							{
+								Manifestation: []*domain.FHIRCodeableConcept{
+									{
+										Text: "Rash",
+									},
+								},
+							},
+						},
+						RecordedDate: &date,
+					},
+				},
+				Observations: []domain.FHIRObservation{
+					{
+						ID:     &id,
+						Status: &status,
+						Code: domain.FHIRCodeableConcept{
+							Coding: []*domain.FHIRCoding{
+								{
+									Display: "Weight",
+								},
+							},
+							Text: "Weight",
+						},
+						EffectiveDateTime: &date,
+					},
+				},
+				MedicationStatements: []domain.FHIRMedicationStatement{},
+			}, nil
+		},
+		MockExportFHIRResourcesFn: func(ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error) error {
+			return write(map[string]interface{}{
+				"resourceType": resourceType,
+				"id":           uuid.New().String(),
+			})
+		},
+		MockImportFHIRResourceFn: func(ctx context.Context, resourceType string, payload map[string]interface{}) (string, error) {
+			return uuid.New().String(), nil
+		},
+		MockGetFHIRPatientEverythingFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRBundle, error) {
+			return &domain.FHIRBundle{
+				ResourceType: "Bundle",
+				Type:         domain.FHIRBundleTypeEnumCollection,
+				Entry: []domain.FHIRBundleEntry{
+					{
+						FullURL:  "Patient/" + id,
+						Resource: map[string]interface{}{"resourceType": "Patient", "id": id},
+					},
+				},
+			}, nil
+		},
+		MockRetireFHIRPatientFn: func(ctx context.Context, id, reason string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+			patient := &domain.FHIRPatient{
+				ID:   &id,
+				Name: []*domain.FHIRHumanName{{Text: gofakeit.Name()}},
+			}
+			patient.Retire(reason, time.Now())
+
+			return patient, nil
+		},
+		MockReinstateFHIRPatientFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+			patient := &domain.FHIRPatient{
+				ID:   &id,
+				Name: []*domain.FHIRHumanName{{Text: gofakeit.Name()}},
+			}
+			patient.Reinstate()
+
+			return patient, nil
+		},
+		MockMergeFHIRPatientsFn: func(ctx context.Context, sourceID, targetID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+			active := true
+			source := &domain.FHIRPatient{ID: &sourceID}
+			target := &domain.FHIRPatient{
+				ID:     &targetID,
+				Active: &active,
+				Name:   []*domain.FHIRHumanName{{Text: gofakeit.Name()}},
+			}
+			source.MergeInto(target, []string{"Encounter/" + uuid.New().String()}, time.Now())
+
+			return target, nil
+		},
+		MockUnmergeFHIRPatientFn: func(ctx context.Context, sourceID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+			active := true
+
+			return &domain.FHIRPatient{
+				ID:     &sourceID,
+				Active: &active,
+				Name:   []*domain.FHIRHumanName{{Text: gofakeit.Name()}},
+			}, nil
+		},
+		MockCascadeDeleteFHIRPatientFn: func(ctx context.Context, id string, tenant dto.TenantIdentifiers, dryRun bool) (*domain.FHIRDeletion, error) {
+			return &domain.FHIRDeletion{
+				DryRun:  dryRun,
+				Deleted: []string{"Encounter/" + uuid.New().String(), "Patient/" + id},
+			}, nil
+		},
+		MockSearchExpiredFHIRResourcesFn: func(ctx context.Context, resourceType string, cutoff time.Time, tenant dto.TenantIdentifiers, fn func(resource map[string]interface{}) error) error {
+			return fn(map[string]interface{}{
+				"resourceType": resourceType,
+				"id":           uuid.New().String(),
+			})
+		},
+		MockPurgeFHIRResourceFn: func(ctx context.Context, resourceType, id string) error {
+			return nil
+		},
 	}
+
+	fh.MockRunInTransactionFn = func(ctx context.Context, work func(fhir repository.FHIR) error) error {
+		return work(fh)
+	}
+
+	return fh
 }
 
 // CreateEpisodeOfCare is a mock implementation of CreateEpisodeOfCare method
@@ -971,12 +1252,12 @@ func (fh *FHIRMock) CreateFHIROrganization(ctx context.Context, input domain.FHI
 }
 
 // SearchFHIROrganization is a mock implementation of SearchFHIROrganization method
-func (fh *FHIRMock) SearchFHIROrganization(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIROrganization(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error) {
 	return fh.MockSearchFHIROrganizationFn(ctx, params, tenant, pagination)
 }
 
 // SearchEpisodesByParam is a mock implementation of SearchEpisodesByParam method
-func (fh *FHIRMock) SearchEpisodesByParam(ctx context.Context, searchParams map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
+func (fh *FHIRMock) SearchEpisodesByParam(ctx context.Context, searchParams domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error) {
 	return fh.MockSearchEpisodesByParamFn(ctx, searchParams, tenant, pagination)
 }
 
@@ -1006,7 +1287,7 @@ func (fh *FHIRMock) SearchPatientEncounters(ctx context.Context, patientReferenc
 }
 
 // SearchFHIREpisodeOfCare is a mock implementation of SearchFHIREpisodeOfCare method
-func (fh *FHIRMock) SearchFHIREpisodeOfCare(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIREpisodeOfCare(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
 	return fh.MockSearchFHIREpisodeOfCareFn(ctx, params, tenant, pagination)
 }
 
@@ -1026,13 +1307,13 @@ func (fh *FHIRMock) SearchEpisodeEncounter(ctx context.Context, episodeReference
 }
 
 // EndEncounter is a mock implementation of EndEncounter method
-func (fh *FHIRMock) EndEncounter(ctx context.Context, encounterID string) (bool, error) {
-	return fh.MockEndEncounterFn(ctx, encounterID)
+func (fh *FHIRMock) EndEncounter(ctx context.Context, encounterID string, endTime time.Time) (bool, error) {
+	return fh.MockEndEncounterFn(ctx, encounterID, endTime)
 }
 
 // EndEpisode is a mock implementation of EndEpisode method
-func (fh *FHIRMock) EndEpisode(ctx context.Context, episodeID string) (bool, error) {
-	return fh.MockEndEpisodeFn(ctx, episodeID)
+func (fh *FHIRMock) EndEpisode(ctx context.Context, episodeID string, endTime time.Time) (bool, error) {
+	return fh.MockEndEpisodeFn(ctx, episodeID, endTime)
 }
 
 // GetActiveEpisode is a mock implementation of GetActiveEpisode method
@@ -1041,7 +1322,7 @@ func (fh *FHIRMock) GetActiveEpisode(ctx context.Context, episodeID string, tena
 }
 
 // SearchFHIRServiceRequest is a mock implementation of SearchFHIRServiceRequest method
-func (fh *FHIRMock) SearchFHIRServiceRequest(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIRServiceRequest(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error) {
 	return fh.MockSearchFHIRServiceRequestFn(ctx, params, tenant, pagination)
 }
 
@@ -1051,7 +1332,7 @@ func (fh *FHIRMock) CreateFHIRServiceRequest(ctx context.Context, input domain.F
 }
 
 // SearchFHIRAllergyIntolerance is a mock implementation of SearchFHIRAllergyIntolerance method
-func (fh *FHIRMock) SearchFHIRAllergyIntolerance(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+func (fh *FHIRMock) SearchFHIRAllergyIntolerance(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 	return fh.MockSearchFHIRAllergyIntoleranceFn(ctx, params, tenant, pagination)
 }
 
@@ -1066,7 +1347,7 @@ func (fh *FHIRMock) UpdateFHIRAllergyIntolerance(ctx context.Context, input doma
 }
 
 // SearchFHIRComposition is a mock implementation of SearchFHIRComposition method
-func (fh *FHIRMock) SearchFHIRComposition(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIRComposition(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error) {
 	return fh.MockSearchFHIRCompositionFn(ctx, params, tenant, pagination)
 }
 
@@ -1086,7 +1367,7 @@ func (fh *FHIRMock) DeleteFHIRComposition(ctx context.Context, id string) (bool,
 }
 
 // SearchFHIRCondition is a mock implementation of SearchFHIRCondition method
-func (fh *FHIRMock) SearchFHIRCondition(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
+func (fh *FHIRMock) SearchFHIRCondition(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
 	return fh.MockSearchFHIRConditionFn(ctx, params, tenant, pagination)
 }
 
@@ -1101,12 +1382,12 @@ func (fh *FHIRMock) GetFHIREncounter(ctx context.Context, id string) (*domain.FH
 }
 
 // SearchFHIREncounter is a mock implementation of SearchFHIREncounter method
-func (fh *FHIRMock) SearchFHIREncounter(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
+func (fh *FHIRMock) SearchFHIREncounter(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
 	return fh.MockSearchFHIREncounterFn(ctx, params, tenant, pagination)
 }
 
 // SearchFHIRMedicationRequest is a mock implementation of SearchFHIRMedicationRequest method
-func (fh *FHIRMock) SearchFHIRMedicationRequest(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIRMedicationRequest(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error) {
 	return fh.MockSearchFHIRMedicationRequestFn(ctx, params, tenant, pagination)
 }
 
@@ -1126,7 +1407,7 @@ func (fh *FHIRMock) DeleteFHIRMedicationRequest(ctx context.Context, id string)
 }
 
 // SearchFHIRObservation is a mock implementation of SearchFHIRObservation method
-func (fh *FHIRMock) SearchFHIRObservation(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIRObservation(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 	return fh.MockSearchFHIRObservationFn(ctx, params, tenant, pagination)
 }
 
@@ -1151,8 +1432,8 @@ func (fh *FHIRMock) DeleteFHIRPatient(ctx context.Context, id string) (bool, err
 }
 
 // DeleteFHIRResourceType is a mock implementation of DeleteFHIRResourceType method
-func (fh *FHIRMock) DeleteFHIRResourceType(results []map[string]string) error {
-	return fh.MockDeleteFHIRResourceTypeFn(results)
+func (fh *FHIRMock) DeleteFHIRResourceType(ctx context.Context, results []map[string]string) error {
+	return fh.MockDeleteFHIRResourceTypeFn(ctx, results)
 }
 
 // DeleteFHIRServiceRequest is a mock implementation of DeleteFHIRServiceRequest method
@@ -1161,7 +1442,7 @@ func (fh *FHIRMock) DeleteFHIRServiceRequest(ctx context.Context, id string) (bo
 }
 
 // SearchFHIRMedicationStatement is a mock implementation of SearchFHIRMedicationStatement method
-func (fh *FHIRMock) SearchFHIRMedicationStatement(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+func (fh *FHIRMock) SearchFHIRMedicationStatement(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 	return fh.MockSearchFHIRMedicationStatementFn(ctx, params, tenant, pagination)
 }
 
@@ -1214,3 +1495,83 @@ func (fh *FHIRMock) GetFHIRAllergyIntolerance(ctx context.Context, id string) (*
 func (fh *FHIRMock) SearchPatientAllergyIntolerance(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 	return fh.MockSearchPatientAllergyIntoleranceFn(ctx, patientReference, tenant, pagination)
 }
+
+// RunInTransaction mocks the implementation of running work in a FHIR transaction
+func (fh *FHIRMock) RunInTransaction(ctx context.Context, work func(fhir repository.FHIR) error) error {
+	return fh.MockRunInTransactionFn(ctx, work)
+}
+
+// GetFHIRConditionHistory mocks the implementation of listing a condition's versions
+func (fh *FHIRMock) GetFHIRConditionHistory(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRConditionHistory, error) {
+	return fh.MockGetFHIRConditionHistoryFn(ctx, id, tenant, pagination)
+}
+
+// GetFHIRAllergyIntoleranceHistory mocks the implementation of listing an allergy intolerance's versions
+func (fh *FHIRMock) GetFHIRAllergyIntoleranceHistory(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergyHistory, error) {
+	return fh.MockGetFHIRAllergyIntoleranceHistoryFn(ctx, id, tenant, pagination)
+}
+
+// GetFHIREncounterWithPatient mocks the implementation of getting an encounter together with its patient
+func (fh *FHIRMock) GetFHIREncounterWithPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
+	return fh.MockGetFHIREncounterWithPatientFn(ctx, id, tenant)
+}
+
+// GetFHIREpisodeOfCareWithEncounters mocks the implementation of getting an episode of care together with its encounters
+func (fh *FHIRMock) GetFHIREpisodeOfCareWithEncounters(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREpisodeOfCareRelayPayload, []domain.FHIREncounter, error) {
+	return fh.MockGetFHIREpisodeOfCareWithEncountersFn(ctx, id, tenant)
+}
+
+// GetFHIRPatientTimeline mocks the implementation of getting a patient's timeline records
+func (fh *FHIRMock) GetFHIRPatientTimeline(ctx context.Context, patientID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatientTimeline, error) {
+	return fh.MockGetFHIRPatientTimelineFn(ctx, patientID, tenant)
+}
+
+// ExportFHIRResources mocks the implementation of paging through a tenant's resources
+func (fh *FHIRMock) ExportFHIRResources(ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error) error {
+	return fh.MockExportFHIRResourcesFn(ctx, resourceType, tenant, write)
+}
+
+// ImportFHIRResource mocks the implementation of creating a resource from a raw payload
+func (fh *FHIRMock) ImportFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}) (string, error) {
+	return fh.MockImportFHIRResourceFn(ctx, resourceType, payload)
+}
+
+// GetFHIRPatientEverything mocks the implementation of getting a patient's compartment
+func (fh *FHIRMock) GetFHIRPatientEverything(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRBundle, error) {
+	return fh.MockGetFHIRPatientEverythingFn(ctx, id, tenant)
+}
+
+// RetireFHIRPatient mocks the implementation of retiring a patient
+func (fh *FHIRMock) RetireFHIRPatient(ctx context.Context, id, reason string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	return fh.MockRetireFHIRPatientFn(ctx, id, reason, tenant)
+}
+
+// ReinstateFHIRPatient mocks the implementation of reinstating a retired patient
+func (fh *FHIRMock) ReinstateFHIRPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	return fh.MockReinstateFHIRPatientFn(ctx, id, tenant)
+}
+
+// MergeFHIRPatients mocks the implementation of merging a duplicate patient into another
+func (fh *FHIRMock) MergeFHIRPatients(ctx context.Context, sourceID, targetID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	return fh.MockMergeFHIRPatientsFn(ctx, sourceID, targetID, tenant)
+}
+
+// UnmergeFHIRPatient mocks the implementation of reversing a patient merge
+func (fh *FHIRMock) UnmergeFHIRPatient(ctx context.Context, sourceID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+	return fh.MockUnmergeFHIRPatientFn(ctx, sourceID, tenant)
+}
+
+// CascadeDeleteFHIRPatient mocks the implementation of deleting a patient and their compartment
+func (fh *FHIRMock) CascadeDeleteFHIRPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers, dryRun bool) (*domain.FHIRDeletion, error) {
+	return fh.MockCascadeDeleteFHIRPatientFn(ctx, id, tenant, dryRun)
+}
+
+// SearchExpiredFHIRResources mocks the implementation of paging through a tenant's expired resources
+func (fh *FHIRMock) SearchExpiredFHIRResources(ctx context.Context, resourceType string, cutoff time.Time, tenant dto.TenantIdentifiers, fn func(resource map[string]interface{}) error) error {
+	return fh.MockSearchExpiredFHIRResourcesFn(ctx, resourceType, cutoff, tenant, fn)
+}
+
+// PurgeFHIRResource mocks the implementation of deleting an expired resource
+func (fh *FHIRMock) PurgeFHIRResource(ctx context.Context, resourceType, id string) error {
+	return fh.MockPurgeFHIRResourceFn(ctx, resourceType, id)
+}
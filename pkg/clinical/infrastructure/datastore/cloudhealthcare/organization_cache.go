@@ -0,0 +1,157 @@
+package fhir
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Measures and views of the organization cache
+var (
+	// OrganizationCacheRequests counts the organizations that are looked up in the cache
+	OrganizationCacheRequests = stats.Int64(
+		"organization_cache_requests",
+		"The number of organizations looked up in the organization cache",
+		stats.UnitDimensionless,
+	)
+
+	// CacheResult is whether an organization was found in the cache, `hit`, or read from the FHIR store, `miss`
+	CacheResult = tag.MustNewKey("cache.result")
+
+	OrganizationCacheRequestCountView = &view.View{
+		Name:        "organization_cache_request_count",
+		Description: "The number of organizations looked up in the organization cache",
+		Measure:     OrganizationCacheRequests,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{CacheResult},
+	}
+
+	// OrganizationCacheViews should be registered for the organization cache metrics to be exported
+	OrganizationCacheViews = []*view.View{OrganizationCacheRequestCountView}
+)
+
+// cachedOrganization is an organization and when it stops being used
+type cachedOrganization struct {
+	organization *domain.FHIROrganizationRelayPayload
+	expiresAt    time.Time
+}
+
+// OrganizationCache keeps the organizations read from the FHIR store for a
+// while. Tenants are checked against their organizations on every request, and
+// the organizations rarely change.
+//
+// The organizations that are created through the cache are dropped from it, so
+// an organization is at most the TTL out of date when it is changed elsewhere.
+// Failed reads are not cached. The other FHIR methods go straight to the store.
+type OrganizationCache struct {
+	repository.FHIR
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu            sync.Mutex
+	organizations map[string]cachedOrganization
+}
+
+// NewOrganizationCache initializes a cache in front of the FHIR store that
+// keeps organizations for the given TTL
+func NewOrganizationCache(store repository.FHIR, ttl time.Duration) *OrganizationCache {
+	return &OrganizationCache{
+		FHIR:          store,
+		ttl:           ttl,
+		now:           time.Now,
+		organizations: map[string]cachedOrganization{},
+	}
+}
+
+// GetFHIROrganization returns the cached organization, or reads it from the
+// FHIR store and caches it. The returned organization is shared and should not
+// be modified
+func (c *OrganizationCache) GetFHIROrganization(ctx context.Context, organizationID string) (*domain.FHIROrganizationRelayPayload, error) {
+	if organization, ok := c.get(organizationID); ok {
+		recordCacheResult(ctx, "hit")
+
+		return organization, nil
+	}
+
+	recordCacheResult(ctx, "miss")
+
+	organization, err := c.FHIR.GetFHIROrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.put(organizationID, organization)
+
+	return organization, nil
+}
+
+// CreateFHIROrganization creates an organization and drops any earlier copy
+// of it from the cache
+func (c *OrganizationCache) CreateFHIROrganization(ctx context.Context, input domain.FHIROrganizationInput) (*domain.FHIROrganizationRelayPayload, error) {
+	if input.ID != nil {
+		c.Invalidate(*input.ID)
+	}
+
+	organization, err := c.FHIR.CreateFHIROrganization(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if organization.Resource != nil && organization.Resource.ID != nil {
+		c.Invalidate(*organization.Resource.ID)
+	}
+
+	return organization, nil
+}
+
+// Invalidate drops an organization from the cache
+func (c *OrganizationCache) Invalidate(organizationID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.organizations, organizationID)
+}
+
+// get returns an organization that has not expired
+func (c *OrganizationCache) get(organizationID string) (*domain.FHIROrganizationRelayPayload, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.organizations[organizationID]
+	if !ok {
+		return nil, false
+	}
+
+	if !c.now().Before(cached.expiresAt) {
+		delete(c.organizations, organizationID)
+
+		return nil, false
+	}
+
+	return cached.organization, true
+}
+
+// put caches an organization for the TTL
+func (c *OrganizationCache) put(organizationID string, organization *domain.FHIROrganizationRelayPayload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.organizations[organizationID] = cachedOrganization{
+		organization: organization,
+		expiresAt:    c.now().Add(c.ttl),
+	}
+}
+
+// recordCacheResult counts a lookup in the organization cache
+func recordCacheResult(ctx context.Context, result string) {
+	ctx, _ = tag.New(ctx, tag.Upsert(CacheResult, result))
+
+	stats.Record(ctx, OrganizationCacheRequests.M(1))
+}
@@ -0,0 +1,101 @@
+package fhir_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	FHIR "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
+)
+
+func TestOrganizationCache_GetFHIROrganization(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		// between runs before the second read
+		between   func(cache *FHIR.OrganizationCache)
+		readErr   error
+		wantReads int
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: the second read is cached",
+			ttl:       time.Minute,
+			wantReads: 1,
+		},
+		{
+			name: "Happy case: expired organizations are read again",
+			ttl:  time.Millisecond,
+			between: func(cache *FHIR.OrganizationCache) {
+				time.Sleep(5 * time.Millisecond)
+			},
+			wantReads: 2,
+		},
+		{
+			name: "Happy case: invalidated organizations are read again",
+			ttl:  time.Minute,
+			between: func(cache *FHIR.OrganizationCache) {
+				cache.Invalidate("organization")
+			},
+			wantReads: 2,
+		},
+		{
+			name: "Happy case: created organizations are read again",
+			ttl:  time.Minute,
+			between: func(cache *FHIR.OrganizationCache) {
+				id := "organization"
+				_, _ = cache.CreateFHIROrganization(context.Background(), domain.FHIROrganizationInput{ID: &id})
+			},
+			wantReads: 2,
+		},
+		{
+			name:      "Sad case: failed reads are not cached",
+			ttl:       time.Minute,
+			readErr:   fmt.Errorf("an error occurred"),
+			wantReads: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := fakeFHIRMock.NewFHIRMock()
+			reads := 0
+
+			store.MockGetFHIROrganizationFn = func(ctx context.Context, organisationID string) (*domain.FHIROrganizationRelayPayload, error) {
+				reads++
+
+				if tt.readErr != nil {
+					return nil, tt.readErr
+				}
+
+				return &domain.FHIROrganizationRelayPayload{
+					Resource: &domain.FHIROrganization{ID: &organisationID},
+				}, nil
+			}
+
+			cache := FHIR.NewOrganizationCache(store, tt.ttl)
+
+			for i := 0; i < 2; i++ {
+				if i == 1 && tt.between != nil {
+					tt.between(cache)
+				}
+
+				organization, err := cache.GetFHIROrganization(context.Background(), "organization")
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("OrganizationCache.GetFHIROrganization() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				if !tt.wantErr && *organization.Resource.ID != "organization" {
+					t.Errorf("expected the organization, got %v", *organization.Resource.ID)
+				}
+			}
+
+			if reads != tt.wantReads {
+				t.Errorf("expected %d reads from the store, got %d", tt.wantReads, reads)
+			}
+		})
+	}
+}
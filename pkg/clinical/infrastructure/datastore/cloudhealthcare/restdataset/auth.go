@@ -0,0 +1,53 @@
+package restdataset
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Authenticator adds credentials to the requests sent to a FHIR server
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// NoAuth is used for FHIR servers that do not require authentication e.g a
+// HAPI server that is only reachable on a private network
+type NoAuth struct{}
+
+// Authenticate leaves the request unchanged
+func (NoAuth) Authenticate(_ *http.Request) error {
+	return nil
+}
+
+// BasicAuth authenticates requests using HTTP basic authentication
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Authenticate sets the basic authentication header
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	if a.Username == "" {
+		return fmt.Errorf("a username is required for basic authentication")
+	}
+
+	req.SetBasicAuth(a.Username, a.Password)
+
+	return nil
+}
+
+// BearerAuth authenticates requests using a static bearer token
+type BearerAuth struct {
+	Token string
+}
+
+// Authenticate sets the bearer token authorization header
+func (a BearerAuth) Authenticate(req *http.Request) error {
+	if a.Token == "" {
+		return fmt.Errorf("a token is required for bearer authentication")
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.Token))
+
+	return nil
+}
@@ -0,0 +1,684 @@
+package restdataset
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/serverutils"
+)
+
+// constants used to talk to a FHIR R4 REST server
+const (
+	defaultTimeoutSeconds = 10
+
+	fhirContentType      = "application/fhir+json; charset=utf-8"
+	jsonPatchContentType = "application/json-patch+json"
+	searchContentType    = "application/x-www-form-urlencoded"
+)
+
+// Repository accesses and updates patient data that is stored on any FHIR R4
+// server that implements the RESTful API e.g HAPI FHIR
+type Repository struct {
+	baseURL    string
+	auth       Authenticator
+	httpClient *http.Client
+}
+
+// NewFHIRRepository initializes a FHIR R4 REST repository.
+//
+// The base URL is the FHIR service base e.g `http://hapi:8080/fhir`. A nil
+// authenticator sends requests without credentials. Requests are sent through
+// the given transport e.g the shared `httpclient.Transport`, or
+// `http.DefaultTransport` if it is nil.
+func NewFHIRRepository(baseURL string, auth Authenticator, transport http.RoundTripper) (*Repository, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid FHIR base URL %q", baseURL)
+	}
+
+	if auth == nil {
+		auth = NoAuth{}
+	}
+
+	return &Repository{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		auth:       auth,
+		httpClient: &http.Client{Transport: transport, Timeout: time.Second * defaultTimeoutSeconds},
+	}, nil
+}
+
+// CreateFHIRResource creates an FHIR resource.
+//
+// The payload should be the result of marshalling a resource to JSON.
+//
+// If the payload has a source identifier (see
+// `domain.SourceIdentifierSystem`) the resource is only created if no resource
+// with that identifier exists. Otherwise the existing resource is returned.
+func (fr Repository) CreateFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+	payload["resourceType"] = resourceType
+
+	respBytes, err := fr.request(
+		ctx, http.MethodPost, fr.resourceURL(resourceType), ifNoneExist(domain.FHIRSourceIdentifierQuery(payload)),
+		fhirContentType, payload)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	return unmarshalResource(resourceType, respBytes, resource)
+}
+
+// DeleteFHIRResource deletes an FHIR resource.
+func (fr Repository) DeleteFHIRResource(ctx context.Context, resourceType, fhirResourceID string) error {
+	_, err := fr.request(ctx, http.MethodDelete, fr.resourceURL(resourceType, fhirResourceID), nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// PatchFHIRResource patches a FHIR resource.
+// The payload is a JSON patch document that follows guidance on Patch from the
+// FHIR standard.
+//
+// A `test` operation on `/meta/versionId` is also sent as an `If-Match`
+// precondition so that a stale version is reported as a conflict.
+//
+// See: https://www.hl7.org/fhir/http.html#patch
+func (fr Repository) PatchFHIRResource(
+	ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{}) error {
+	respBytes, err := fr.request(
+		ctx, http.MethodPatch, fr.resourceURL(resourceType, fhirResourceID),
+		ifMatch(domain.FHIRPatchVersion(payload)), jsonPatchContentType, payload)
+	if err != nil {
+		return fmt.Errorf("patch: %w", err)
+	}
+
+	return unmarshalResource(resourceType, respBytes, resource)
+}
+
+// UpdateFHIRResource updates the entire contents of a resource.
+//
+// If the payload has a `meta.versionId` the update only succeeds if that is
+// still the current version of the resource. Otherwise an error wrapping
+// `domain.ErrFHIRVersionConflict` is returned.
+func (fr Repository) UpdateFHIRResource(
+	ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+	payload["resourceType"] = resourceType
+
+	respBytes, err := fr.request(
+		ctx, http.MethodPut, fr.resourceURL(resourceType, fhirResourceID),
+		ifMatch(domain.FHIRPayloadVersion(payload)), fhirContentType, payload)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	return unmarshalResource(resourceType, respBytes, resource)
+}
+
+// GetFHIRResource gets an FHIR resource.
+func (fr Repository) GetFHIRResource(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+	respBytes, err := fr.request(ctx, http.MethodGet, fr.resourceURL(resourceType, fhirResourceID), nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	return unmarshalResource(resourceType, respBytes, resource)
+}
+
+// GetFHIRResourceVersion gets a specific version of a resource (vread)
+func (fr Repository) GetFHIRResourceVersion(ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{}) error {
+	respBytes, err := fr.request(
+		ctx, http.MethodGet, fr.resourceURL(resourceType, fhirResourceID, "_history", versionID), nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("vread: %w", err)
+	}
+
+	return unmarshalResource(resourceType, respBytes, resource)
+}
+
+// GetFHIRResourceHistory lists the versions of a resource, newest first, using
+// `GET [type]/[id]/_history`.
+//
+// Versions that record the deletion of the resource do not have a resource
+// and are left out. Like search, the cursors only carry the server's page
+// tokens and the history URL is composed again for every page.
+func (fr Repository) GetFHIRResourceHistory(
+	ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
+	err := pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+
+	if !pagination.Skip {
+		query.Set("_count", strconv.Itoa(pageSize(pagination)))
+	}
+
+	pageURL := fr.resourceURL(resourceType, fhirResourceID, "_history")
+
+	if cursor := pageCursor(pagination); cursor != "" {
+		pageURL, err = fr.cursorURL(pageURL, cursor, query)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(query) > 0 {
+		pageURL = pageURL + "?" + query.Encode()
+	}
+
+	respBytes, err := fr.request(ctx, http.MethodGet, pageURL, nil, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+
+	bundle := struct {
+		Type  string       `json:"type"`
+		Total int          `json:"total"`
+		Link  []bundleLink `json:"link"`
+		Entry []struct {
+			Resource map[string]interface{} `json:"resource"`
+		} `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(respBytes, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %s history: %w", resourceType, err)
+	}
+
+	if bundle.Type != "history" {
+		return nil, fmt.Errorf("server error: expected a history Bundle, got %s", bundle.Type)
+	}
+
+	response := domain.PagedFHIRResource{
+		Resources:  []map[string]interface{}{},
+		TotalCount: bundle.Total,
+	}
+
+	for _, entry := range bundle.Entry {
+		if entry.Resource == nil {
+			continue
+		}
+
+		response.Resources = append(response.Resources, entry.Resource)
+	}
+
+	err = fr.setCursors(&response, bundle.Link)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetFHIRPatientAllData gets all resources associated with a particular
+// patient compartment using the `Patient/$everything` operation.
+//
+// Servers page the operation's results so every page is fetched and the
+// entries are returned as a single `searchset` Bundle.
+func (fr Repository) GetFHIRPatientAllData(ctx context.Context, fhirResourceID string) ([]byte, error) {
+	pageURL := fr.resourceURL("Patient", fhirResourceID, "$everything")
+	entries := []interface{}{}
+
+	for pageURL != "" {
+		respBytes, err := fr.request(ctx, http.MethodGet, pageURL, nil, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("PatientAllData: %w", err)
+		}
+
+		bundle := struct {
+			Entry []interface{} `json:"entry"`
+			Link  []bundleLink  `json:"link"`
+		}{}
+
+		err = json.Unmarshal(respBytes, &bundle)
+		if err != nil {
+			return nil, fmt.Errorf("PatientAllData: unable to unmarshal bundle: %w", err)
+		}
+
+		entries = append(entries, bundle.Entry...)
+
+		pageURL, err = fr.pageURL(bundle.Link, "next")
+		if err != nil {
+			return nil, fmt.Errorf("PatientAllData: %w", err)
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"resourceType": "Bundle",
+		"type":         "searchset",
+		"total":        len(entries),
+		"entry":        entries,
+	})
+}
+
+// ExecuteBundle executes the entries of a transaction or batch Bundle by
+// posting it to the service base.
+//
+// A transaction is executed atomically by the FHIR server and fails as a
+// whole if any of its entries fail.
+func (fr Repository) ExecuteBundle(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+	bundle.ResourceType = "Bundle"
+
+	respBytes, err := fr.request(ctx, http.MethodPost, fr.baseURL, nil, fhirContentType, bundle)
+	if err != nil {
+		return nil, fmt.Errorf("execute bundle: %w", err)
+	}
+
+	response := &domain.FHIRBundle{}
+
+	err = json.Unmarshal(respBytes, response)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal bundle response JSON: data: %v\n, error: %w", string(respBytes), err)
+	}
+
+	return response, nil
+}
+
+// SearchFHIRResource searches for resources using `POST [type]/_search`.
+//
+// The cursors of the next and previous pages only carry the page tokens of the
+// server's `next` and `previous` links e.g `_getpages` and `_getpagesoffset`.
+// The page is requested with the search parameters and the tenant's tags
+// composed again, and resources that do not carry the tenant's tags are
+// dropped, so that a cursor cannot reach another tenant's resources.
+func (fr Repository) SearchFHIRResource(
+	ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
+	err := pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var respBytes []byte
+
+	form := params.Values()
+
+	if !pagination.Skip {
+		form.Set("_count", strconv.Itoa(pageSize(pagination)))
+	}
+
+	form.Set("_total", "accurate")
+	form.Add("_tag", fmt.Sprintf("%s|%s", dto.OrganisationTagSystem, tenant.OrganizationID))
+	form.Add("_tag", fmt.Sprintf("%s|%s", dto.FacilityTagSystem, tenant.FacilityID))
+
+	if cursor := pageCursor(pagination); cursor != "" {
+		pageURL, err := fr.cursorURL(fr.resourceURL(resourceType), cursor, form)
+		if err != nil {
+			return nil, err
+		}
+
+		respBytes, err = fr.request(ctx, http.MethodGet, pageURL, nil, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search: %w", err)
+		}
+	} else {
+		respBytes, err = fr.request(
+			ctx, http.MethodPost, fr.resourceURL(resourceType, "_search"), nil, searchContentType, form.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("unable to search: %w", err)
+		}
+	}
+
+	bundle := struct {
+		ResourceType string       `json:"resourceType"`
+		Type         string       `json:"type"`
+		Total        int          `json:"total"`
+		Link         []bundleLink `json:"link"`
+		Entry        []struct {
+			Resource map[string]interface{} `json:"resource"`
+			Search   struct {
+				Mode string `json:"mode"`
+			} `json:"search"`
+		} `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(respBytes, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s could not be found with search params %s: %w", resourceType, params.Encode(), err)
+	}
+
+	if bundle.ResourceType != "Bundle" || bundle.Type != "searchset" {
+		return nil, fmt.Errorf("server error: expected a searchset Bundle, got %s %s", bundle.Type, bundle.ResourceType)
+	}
+
+	response := domain.PagedFHIRResource{
+		Resources:  []map[string]interface{}{},
+		TotalCount: bundle.Total,
+	}
+
+	for _, entry := range bundle.Entry {
+		if entry.Resource == nil {
+			return nil, fmt.Errorf("server error: FHIR search entry does not have a resource")
+		}
+
+		if !tenant.Tagged(entry.Resource) {
+			continue
+		}
+
+		// resources returned by `_include` and `_revinclude` are not matches
+		if entry.Search.Mode == "include" {
+			if response.Included == nil {
+				response.Included = map[string]map[string]interface{}{}
+			}
+
+			response.Included[fmt.Sprintf("%s/%s", entry.Resource["resourceType"], entry.Resource["id"])] = entry.Resource
+
+			continue
+		}
+
+		response.Resources = append(response.Resources, entry.Resource)
+	}
+
+	err = fr.setCursors(&response, bundle.Link)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// bundleLink is a link in a Bundle e.g to the next page of a search
+type bundleLink struct {
+	Relation string `json:"relation"`
+	URL      string `json:"url"`
+}
+
+// pageURL returns the bundle's link with one of the given relations, if any,
+// after checking that it points back to the configured server
+func (fr Repository) pageURL(links []bundleLink, relations ...string) (string, error) {
+	for _, link := range links {
+		for _, relation := range relations {
+			if link.Relation != relation {
+				continue
+			}
+
+			if !fr.isServerURL(link.URL) {
+				return "", fmt.Errorf("server error: %s link %s is not on the FHIR server", relation, link.URL)
+			}
+
+			return link.URL, nil
+		}
+	}
+
+	return "", nil
+}
+
+// setCursors sets the cursors of the pages that the bundle's `next` and
+// `previous` links point to. Some servers use the older `prev` relation
+func (fr Repository) setCursors(response *domain.PagedFHIRResource, links []bundleLink) error {
+	next, err := fr.pageURL(links, "next")
+	if err != nil {
+		return err
+	}
+
+	if next != "" {
+		response.HasNextPage = true
+		response.NextCursor, err = encodeCursor(next)
+		if err != nil {
+			return err
+		}
+	}
+
+	previous, err := fr.pageURL(links, "previous", "prev")
+	if err != nil {
+		return err
+	}
+
+	if previous != "" {
+		response.HasPreviousPage = true
+		response.PreviousCursor, err = encodeCursor(previous)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pageSize returns the number of results per page, which is `Last` when
+// paging backward
+func pageSize(pagination dto.Pagination) int {
+	if pagination.Last != nil {
+		return *pagination.Last
+	}
+
+	return *pagination.First
+}
+
+// pageCursor returns the cursor of the requested page, if any
+func pageCursor(pagination dto.Pagination) string {
+	if pagination.Skip {
+		return ""
+	}
+
+	if pagination.Before != "" {
+		return pagination.Before
+	}
+
+	return pagination.After
+}
+
+// pageTokenParams are the parameters of a page link that identify the page.
+// HAPI FHIR uses `_getpages` and `_getpagesoffset`, and other servers use an
+// offset or a page number
+var pageTokenParams = map[string]bool{
+	"_getpages":       true,
+	"_getpagesoffset": true,
+	"_bundletype":     true,
+	"_page_token":     true,
+	"_offset":         true,
+	"page":            true,
+}
+
+// encodeCursor turns the page tokens of a page link into an opaque cursor.
+// The rest of the link e.g its search parameters is left out
+func encodeCursor(link string) (string, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("server error: invalid page link %s: %w", link, err)
+	}
+
+	tokens := url.Values{}
+
+	for name, values := range parsed.Query() {
+		if pageTokenParams[name] {
+			tokens[name] = values
+		}
+	}
+
+	if len(tokens) == 0 {
+		return "", fmt.Errorf("server error: page link %s does not have a page token", link)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(tokens.Encode())), nil
+}
+
+// cursorURL composes the URL of the page of a cursor from the given query and
+// the cursor's page tokens. HAPI FHIR serves the pages of `_getpages` at the
+// service base, and other servers at the URL that was first requested
+func (fr Repository) cursorURL(requestURL string, cursor string, query url.Values) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid page cursor %q", cursor)
+	}
+
+	tokens, err := url.ParseQuery(string(decoded))
+	if err != nil || len(tokens) == 0 {
+		return "", fmt.Errorf("invalid page cursor %q", cursor)
+	}
+
+	pageQuery := url.Values{}
+
+	for name, values := range query {
+		pageQuery[name] = values
+	}
+
+	for name, values := range tokens {
+		if !pageTokenParams[name] {
+			return "", fmt.Errorf("invalid page cursor %q", cursor)
+		}
+
+		pageQuery[name] = values
+	}
+
+	if tokens.Get("_getpages") != "" {
+		requestURL = fr.baseURL
+	}
+
+	return requestURL + "?" + pageQuery.Encode(), nil
+}
+
+// isServerURL checks that a URL is on the configured FHIR server
+func (fr Repository) isServerURL(link string) bool {
+	return link == fr.baseURL || strings.HasPrefix(link, fr.baseURL+"/") || strings.HasPrefix(link, fr.baseURL+"?")
+}
+
+// resourceURL composes a URL under the FHIR base e.g `[base]/Patient/123`
+func (fr Repository) resourceURL(segments ...string) string {
+	escaped := []string{fr.baseURL}
+
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	return strings.Join(escaped, "/")
+}
+
+// request sends a request to the FHIR server and returns the response body.
+//
+// A string body is sent as is while any other body is marshalled to JSON.
+// Responses with a status code > 299 are returned as errors.
+func (fr Repository) request(
+	ctx context.Context, method, requestURL string, headers http.Header, contentType string, body interface{},
+) ([]byte, error) {
+	var reader io.Reader
+
+	switch payload := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(payload)
+	default:
+		jsonPayload, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("json.Encode: %w", err)
+		}
+
+		if serverutils.IsDebug() {
+			log.Printf("FHIR %s payload: %s", method, string(jsonPayload))
+		}
+
+		reader = bytes.NewReader(jsonPayload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compose FHIR %s request: %w", method, err)
+	}
+
+	for k, v := range headers {
+		for _, h := range v {
+			req.Header.Add(k, h)
+		}
+	}
+
+	req.Header.Set("Accept", fhirContentType)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+		req.Header.Set("Prefer", "return=representation")
+	}
+
+	err = fr.auth.Authenticate(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate FHIR request: %w", err)
+	}
+
+	resp, err := fr.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP response error: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("%s: %s: %w", resp.Status, getErrorMessage(respBytes), domain.ErrFHIRVersionConflict)
+	}
+
+	if resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s: %s", resp.Status, getErrorMessage(respBytes))
+	}
+
+	return respBytes, nil
+}
+
+// ifMatch composes the `If-Match` header for a resource version. There is no
+// precondition when the version is empty
+func ifMatch(versionID string) http.Header {
+	if versionID == "" {
+		return nil
+	}
+
+	return http.Header{"If-Match": []string{domain.FHIRVersionETag(versionID)}}
+}
+
+// ifNoneExist returns the header that makes a create conditional on no
+// resource matching the query, if there is one
+func ifNoneExist(query string) http.Header {
+	if query == "" {
+		return nil
+	}
+
+	return http.Header{"If-None-Exist": []string{query}}
+}
+
+// getErrorMessage extracts the details of an OperationOutcome returned by the
+// FHIR server, falling back to the raw response
+func getErrorMessage(respBytes []byte) string {
+	var errorResponse dto.ErrorResponse
+
+	err := json.Unmarshal(respBytes, &errorResponse)
+	if err != nil || len(errorResponse.Issue) == 0 {
+		return string(respBytes)
+	}
+
+	issue := errorResponse.Issue[0]
+
+	return fmt.Sprintf("%s: %s", issue.Details.Text, issue.Diagnostics)
+}
+
+// unmarshalResource decodes a resource returned by the FHIR server
+func unmarshalResource(resourceType string, respBytes []byte, resource interface{}) error {
+	err := json.Unmarshal(respBytes, resource)
+	if err != nil {
+		return fmt.Errorf(
+			"unable to unmarshal %s response JSON: data: %v\n, error: %w",
+			resourceType, string(respBytes), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,582 @@
+package restdataset_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/restdataset"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/httpclient"
+)
+
+var _ fhir.Dataset = (*restdataset.Repository)(nil)
+
+// newTestServer starts a fake FHIR server that is shut down with the test
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/fhir+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestNewFHIRRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{
+			name:    "happy case: valid base url",
+			baseURL: "http://localhost:8080/fhir",
+		},
+		{
+			name:    "sad case: relative base url",
+			baseURL: "/fhir",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := restdataset.NewFHIRRepository(tt.baseURL, nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFHIRRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepository_CRUD(t *testing.T) {
+	var (
+		method, path, authorization, contentType string
+	)
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		contentType = r.Header.Get("Content-Type")
+
+		switch {
+		case r.URL.Path == "/fhir/Patient/missing":
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{
+				"resourceType": "OperationOutcome",
+				"issue":        []map[string]interface{}{{"diagnostics": "Resource Patient/missing is not known"}},
+			})
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"resourceType": "Patient", "id": "123", "active": true})
+		}
+	})
+
+	repo, err := restdataset.NewFHIRRepository(server.URL+"/fhir/", restdataset.BearerAuth{Token: "secret"}, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	patient := domain.FHIRPatient{}
+
+	tests := []struct {
+		name            string
+		call            func() error
+		wantMethod      string
+		wantPath        string
+		wantContentType string
+		wantErr         bool
+	}{
+		{
+			name: "happy case: create",
+			call: func() error {
+				return repo.CreateFHIRResource(context.Background(), "Patient", map[string]interface{}{"active": true}, &patient)
+			},
+			wantMethod:      http.MethodPost,
+			wantPath:        "/fhir/Patient",
+			wantContentType: "application/fhir+json; charset=utf-8",
+		},
+		{
+			name: "happy case: read",
+			call: func() error {
+				return repo.GetFHIRResource(context.Background(), "Patient", "123", &patient)
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/fhir/Patient/123",
+		},
+		{
+			name: "happy case: update",
+			call: func() error {
+				return repo.UpdateFHIRResource(context.Background(), "Patient", "123", map[string]interface{}{"active": true}, &patient)
+			},
+			wantMethod:      http.MethodPut,
+			wantPath:        "/fhir/Patient/123",
+			wantContentType: "application/fhir+json; charset=utf-8",
+		},
+		{
+			name: "happy case: patch",
+			call: func() error {
+				return repo.PatchFHIRResource(context.Background(), "Patient", "123", []map[string]interface{}{
+					{"op": "replace", "path": "/active", "value": true},
+				}, &patient)
+			},
+			wantMethod:      http.MethodPatch,
+			wantPath:        "/fhir/Patient/123",
+			wantContentType: "application/json-patch+json",
+		},
+		{
+			name: "happy case: delete",
+			call: func() error {
+				return repo.DeleteFHIRResource(context.Background(), "Patient", "123")
+			},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/fhir/Patient/123",
+		},
+		{
+			name: "sad case: resource not found",
+			call: func() error {
+				return repo.GetFHIRResource(context.Background(), "Patient", "missing", &patient)
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/fhir/Patient/missing",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if method != tt.wantMethod || path != tt.wantPath {
+				t.Errorf("expected %s %s, got %s %s", tt.wantMethod, tt.wantPath, method, path)
+			}
+
+			if contentType != tt.wantContentType {
+				t.Errorf("expected content type %q, got %q", tt.wantContentType, contentType)
+			}
+
+			if authorization != "Bearer secret" {
+				t.Errorf("expected the bearer token to be sent, got %q", authorization)
+			}
+		})
+	}
+}
+
+func TestRepository_VersionConflict(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != `W/"2"` {
+			writeJSON(w, http.StatusPreconditionFailed, map[string]interface{}{
+				"resourceType": "OperationOutcome",
+				"issue":        []interface{}{map[string]interface{}{"diagnostics": "version mismatch"}},
+			})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"resourceType": "Encounter", "id": "123"})
+	})
+
+	repo, err := restdataset.NewFHIRRepository(server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		version      string
+		wantConflict bool
+	}{
+		{
+			name:         "happy case: current version",
+			version:      "2",
+			wantConflict: false,
+		},
+		{
+			name:         "sad case: stale version",
+			version:      "1",
+			wantConflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := map[string]interface{}{"meta": map[string]interface{}{"versionId": tt.version}}
+
+			err := repo.UpdateFHIRResource(context.Background(), "Encounter", "123", payload, &map[string]interface{}{})
+			if errors.Is(err, domain.ErrFHIRVersionConflict) != tt.wantConflict {
+				t.Errorf("UpdateFHIRResource() error = %v, wantConflict %v", err, tt.wantConflict)
+			}
+
+			patch := []map[string]interface{}{domain.FHIRVersionTestOperation(tt.version)}
+
+			err = repo.PatchFHIRResource(context.Background(), "Encounter", "123", patch, &map[string]interface{}{})
+			if errors.Is(err, domain.ErrFHIRVersionConflict) != tt.wantConflict {
+				t.Errorf("PatchFHIRResource() error = %v, wantConflict %v", err, tt.wantConflict)
+			}
+		})
+	}
+}
+
+func TestRepository_ConditionalCreate(t *testing.T) {
+	var ifNoneExist string
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ifNoneExist = r.Header.Get("If-None-Exist")
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"resourceType": "Observation", "id": "123"})
+	})
+
+	repo, err := restdataset.NewFHIRRepository(server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		payload         map[string]interface{}
+		wantIfNoneExist string
+	}{
+		{
+			name: "happy case: payload with a source identifier",
+			payload: map[string]interface{}{
+				"identifier": []interface{}{
+					map[string]interface{}{"system": "http://example.com", "value": "other"},
+					map[string]interface{}{"system": domain.SourceIdentifierSystem, "value": "vitals/1"},
+				},
+			},
+			wantIfNoneExist: url.Values{"identifier": {domain.SourceIdentifierSystem + "|vitals/1"}}.Encode(),
+		},
+		{
+			name:            "happy case: payload without a source identifier",
+			payload:         map[string]interface{}{"status": "final"},
+			wantIfNoneExist: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.CreateFHIRResource(context.Background(), "Observation", tt.payload, &map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("CreateFHIRResource() error = %v", err)
+			}
+
+			if ifNoneExist != tt.wantIfNoneExist {
+				t.Errorf("expected If-None-Exist %q, got %q", tt.wantIfNoneExist, ifNoneExist)
+			}
+		})
+	}
+}
+
+func TestRepository_GetFHIRResourceHistory(t *testing.T) {
+	var server *httptest.Server
+
+	server = newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Condition/123/_history":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"resourceType": "Bundle",
+				"type":         "history",
+				"total":        3,
+				"link": []interface{}{
+					map[string]interface{}{"relation": "next", "url": server.URL + "/Condition/123/_history?page=2"},
+				},
+				"entry": []interface{}{
+					map[string]interface{}{"resource": map[string]interface{}{"resourceType": "Condition", "id": "123"}},
+					// a deletion does not have a resource
+					map[string]interface{}{"request": map[string]interface{}{"method": "DELETE"}},
+				},
+			})
+		case "/Condition/123/_history/1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"resourceType": "Condition", "id": "123"})
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"resourceType": "OperationOutcome"})
+		}
+	})
+
+	repo, err := restdataset.NewFHIRRepository(server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	first := 10
+
+	got, err := repo.GetFHIRResourceHistory(context.Background(), "Condition", "123", dto.Pagination{First: &first})
+	if err != nil {
+		t.Fatalf("GetFHIRResourceHistory() error = %v", err)
+	}
+
+	if len(got.Resources) != 1 || !got.HasNextPage || got.TotalCount != 3 {
+		t.Errorf("expected one version and a next page, got: %#v", got)
+	}
+
+	err = repo.GetFHIRResourceVersion(context.Background(), "Condition", "123", "1", &map[string]interface{}{})
+	if err != nil {
+		t.Errorf("GetFHIRResourceVersion() error = %v", err)
+	}
+
+	err = repo.GetFHIRResourceVersion(context.Background(), "Condition", "123", "2", &map[string]interface{}{})
+	if err == nil {
+		t.Errorf("expected an error reading a version that does not exist")
+	}
+}
+
+// tenantMeta is the meta of a resource of the test tenant
+var tenantMeta = map[string]interface{}{
+	"tag": []map[string]interface{}{
+		{"system": dto.OrganisationTagSystem, "code": "org"},
+		{"system": dto.FacilityTagSystem, "code": "facility"},
+	},
+}
+
+func TestRepository_SearchFHIRResource(t *testing.T) {
+	var form url.Values
+
+	// pageQueries are the queries of the pages that were requested with a cursor
+	pageQueries := []url.Values{}
+
+	var server *httptest.Server
+
+	server = newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		if username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Query().Get("_getpages") != "" {
+			if r.URL.Path != "/fhir" {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{"resourceType": "OperationOutcome"})
+
+				return
+			}
+
+			pageQueries = append(pageQueries, r.URL.Query())
+
+			if r.URL.Query().Get("_getpagesoffset") == "0" {
+				writeJSON(w, http.StatusOK, map[string]interface{}{
+					"resourceType": "Bundle",
+					"type":         "searchset",
+					"total":        2,
+					"link": []map[string]interface{}{
+						{"relation": "next", "url": fmt.Sprintf("%s/fhir?_getpages=abc&_getpagesoffset=1", server.URL)},
+					},
+					"entry": []map[string]interface{}{
+						{"resource": map[string]interface{}{"resourceType": "Condition", "id": "1", "meta": tenantMeta}},
+					},
+				})
+
+				return
+			}
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"resourceType": "Bundle",
+				"type":         "searchset",
+				"total":        2,
+				"link": []map[string]interface{}{
+					{"relation": "previous", "url": fmt.Sprintf("%s/fhir?_getpages=abc&_getpagesoffset=0", server.URL)},
+				},
+				"entry": []map[string]interface{}{
+					{"resource": map[string]interface{}{"resourceType": "Condition", "id": "2", "meta": tenantMeta}},
+					// another tenant's condition is dropped
+					{"resource": map[string]interface{}{"resourceType": "Condition", "id": "3"}},
+				},
+			})
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		form, _ = url.ParseQuery(string(body))
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"resourceType": "Bundle",
+			"type":         "searchset",
+			"total":        2,
+			"link": []map[string]interface{}{
+				{"relation": "next", "url": fmt.Sprintf("%s/fhir?_getpages=abc&_getpagesoffset=1", server.URL)},
+			},
+			"entry": []map[string]interface{}{
+				{
+					"resource": map[string]interface{}{"resourceType": "Condition", "id": "1", "meta": tenantMeta},
+					"search":   map[string]interface{}{"mode": "match"},
+				},
+				{
+					"resource": map[string]interface{}{"resourceType": "Patient", "id": "1", "meta": tenantMeta},
+					"search":   map[string]interface{}{"mode": "include"},
+				},
+			},
+		})
+	})
+
+	repo, err := restdataset.NewFHIRRepository(server.URL+"/fhir", restdataset.BasicAuth{Username: "user", Password: "pass"}, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	tenant := dto.TenantIdentifiers{OrganizationID: "org", FacilityID: "facility"}
+	first := 1
+
+	params := domain.NewSearchParams().Reference("subject", "Patient/1").Include("Condition", "patient")
+
+	page, err := repo.SearchFHIRResource(context.Background(), "Condition", params, tenant, dto.Pagination{First: &first})
+	if err != nil {
+		t.Fatalf("unable to search: %v", err)
+	}
+
+	if len(page.Resources) != 1 || page.TotalCount != 2 || !page.HasNextPage {
+		t.Fatalf("unexpected first page: %#v", page)
+	}
+
+	if len(page.Included) != 1 || page.Included["Patient/1"] == nil {
+		t.Errorf("expected the patient to be included, got %v", page.Included)
+	}
+
+	if form.Get("subject") != "Patient/1" || form.Get("_count") != "1" || form.Get("_include") != "Condition:patient" || len(form["_tag"]) != 2 {
+		t.Errorf("unexpected search form: %v", form)
+	}
+
+	next, err := repo.SearchFHIRResource(context.Background(), "Condition", domain.NewSearchParams(), tenant, dto.Pagination{First: &first, After: page.NextCursor})
+	if err != nil {
+		t.Fatalf("unable to get the next page: %v", err)
+	}
+
+	if len(next.Resources) != 1 || next.Resources[0]["id"] != "2" || next.HasNextPage || !next.HasPreviousPage {
+		t.Errorf("unexpected second page: %#v", next)
+	}
+
+	previous, err := repo.SearchFHIRResource(context.Background(), "Condition", domain.NewSearchParams(), tenant, dto.Pagination{Last: &first, Before: next.PreviousCursor})
+	if err != nil {
+		t.Fatalf("unable to get the previous page: %v", err)
+	}
+
+	if len(previous.Resources) != 1 || previous.Resources[0]["id"] != "1" || !previous.HasNextPage || previous.HasPreviousPage {
+		t.Errorf("unexpected previous page: %#v", previous)
+	}
+
+	for _, query := range pageQueries {
+		if len(query["_tag"]) != 2 || query.Get("_count") != "1" {
+			t.Errorf("expected the tenant's tags to be sent with every page, got %v", query)
+		}
+	}
+
+	forged := []string{
+		// a URL instead of page tokens
+		base64.RawURLEncoding.EncodeToString([]byte(server.URL + "/fhir/Patient?_count=100")),
+		// search parameters besides the page tokens
+		base64.RawURLEncoding.EncodeToString([]byte("_getpages=abc&_tag=http://mycarehub/tenant-identification/organisation|other")),
+		"not a cursor",
+	}
+
+	for _, cursor := range forged {
+		_, err = repo.SearchFHIRResource(context.Background(), "Condition", domain.NewSearchParams(), tenant, dto.Pagination{First: &first, After: cursor})
+		if err == nil {
+			t.Errorf("expected the cursor %q to be rejected", cursor)
+		}
+	}
+}
+
+func TestRepository_GetFHIRPatientAllData(t *testing.T) {
+	var server *httptest.Server
+
+	server = newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"resourceType": "Bundle",
+				"type":         "searchset",
+				"entry": []map[string]interface{}{
+					{"resource": map[string]interface{}{"resourceType": "Encounter", "id": "2"}},
+				},
+			})
+
+			return
+		}
+
+		if r.URL.Path != "/fhir/Patient/1/$everything" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"resourceType": "Bundle",
+			"type":         "searchset",
+			"link": []map[string]interface{}{
+				{"relation": "next", "url": server.URL + "/fhir/Patient/1/$everything?page=2"},
+			},
+			"entry": []map[string]interface{}{
+				{"resource": map[string]interface{}{"resourceType": "Patient", "id": "1"}},
+			},
+		})
+	})
+
+	repo, err := restdataset.NewFHIRRepository(server.URL+"/fhir", restdataset.NoAuth{}, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	bs, err := repo.GetFHIRPatientAllData(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("unable to get patient data: %v", err)
+	}
+
+	bundle := struct {
+		Entry []interface{} `json:"entry"`
+	}{}
+
+	err = json.Unmarshal(bs, &bundle)
+	if err != nil {
+		t.Fatalf("unable to unmarshal bundle: %v", err)
+	}
+
+	if len(bundle.Entry) != 2 {
+		t.Errorf("expected the entries of both pages, got %d", len(bundle.Entry))
+	}
+}
+
+func TestRepository_Transport(t *testing.T) {
+	calls := 0
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"resourceType": "OperationOutcome"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"resourceType": "Patient", "id": "1"})
+	})
+
+	transport := httpclient.NewTransport(httpclient.Config{MaxRetries: 1, MaxBackoff: time.Millisecond}, nil)
+
+	repo, err := restdataset.NewFHIRRepository(server.URL, nil, transport)
+	if err != nil {
+		t.Fatalf("unable to initialize repository: %v", err)
+	}
+
+	err = repo.GetFHIRResource(context.Background(), "Patient", "1", &map[string]interface{}{})
+	if err != nil {
+		t.Errorf("expected an unavailable server to be retried, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = repo.GetFHIRResource(ctx, "Patient", "1", &map[string]interface{}{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the request to be cancelled with the context, got: %v", err)
+	}
+}
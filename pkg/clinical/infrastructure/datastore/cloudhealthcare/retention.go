@@ -0,0 +1,39 @@
+package fhir
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// SearchExpiredFHIRResources pages through the tenant's resources of the given
+// type that were last updated before the cutoff, and passes each one to fn.
+//
+// A tenant without a facility ID matches the resources of every facility in
+// the organisation. Paging stops at the first error returned by fn.
+func (fh StoreImpl) SearchExpiredFHIRResources(
+	ctx context.Context, resourceType string, cutoff time.Time, tenant dto.TenantIdentifiers,
+	fn func(resource map[string]interface{}) error,
+) error {
+	params := domain.NewSearchParams().Date("_lastUpdated", domain.SearchPrefixLessThan, cutoff)
+
+	err := fh.eachFHIRResource(ctx, resourceType, params, tenant, fn)
+	if err != nil {
+		return fmt.Errorf("unable to search expired %s: %w", resourceType, err)
+	}
+
+	return nil
+}
+
+// PurgeFHIRResource deletes a resource whose retention period has passed
+func (fh StoreImpl) PurgeFHIRResource(ctx context.Context, resourceType, id string) error {
+	err := fh.Dataset.DeleteFHIRResource(ctx, resourceType, id)
+	if err != nil {
+		return fmt.Errorf("unable to purge %s/%s: %w", resourceType, id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package fhir
+
+import (
+	"context"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// organisationTagSystem is the system of the meta tag that identifies the
+// organisation a resource belongs to
+const organisationTagSystem = "http://mycarehub/tenant-identification/organisation"
+
+// TenantRouter is a Dataset that sends each request to the store of the
+// organisation it is made for, so that the clinical data of some organisations
+// can be kept in stores of their own. Organisations without a store of their own
+// share the default store.
+//
+// The organisation of a request is, in order of precedence, the tenant it
+// searches, the organisation tag of the resource it writes, or the organisation
+// in its context. Organization resources are the directory of tenants and are
+// always kept in the default store.
+type TenantRouter struct {
+	fallback      Dataset
+	organisations map[string]Dataset
+}
+
+// NewTenantRouter initializes a router whose requests go to the default store
+// until organisations are routed to stores of their own
+func NewTenantRouter(fallback Dataset) *TenantRouter {
+	return &TenantRouter{
+		fallback:      fallback,
+		organisations: map[string]Dataset{},
+	}
+}
+
+// Route sends the requests made for an organisation to the given store
+func (r *TenantRouter) Route(organisationID string, dataset Dataset) {
+	r.organisations[organisationID] = dataset
+}
+
+// DatasetFor returns the store that keeps a tenant's resources
+func (r *TenantRouter) DatasetFor(tenant dto.TenantIdentifiers) Dataset {
+	if dataset, ok := r.organisations[tenant.OrganizationID]; ok {
+		return dataset
+	}
+
+	return r.fallback
+}
+
+// dataset returns the store of the organisation that a request is made for
+func (r *TenantRouter) dataset(ctx context.Context, resourceType string, payload map[string]interface{}) Dataset {
+	if resourceType == organizationResource {
+		return r.fallback
+	}
+
+	if organisationID := payloadOrganisation(payload); organisationID != "" {
+		return r.DatasetFor(dto.TenantIdentifiers{OrganizationID: organisationID})
+	}
+
+	organisationID, _ := ctx.Value(utils.OrganizationIDContextKey).(string)
+
+	return r.DatasetFor(dto.TenantIdentifiers{OrganizationID: organisationID})
+}
+
+// payloadOrganisation returns the organisation that a resource is tagged with
+func payloadOrganisation(payload map[string]interface{}) string {
+	meta, _ := payload["meta"].(map[string]interface{})
+	tags, _ := meta["tag"].([]interface{})
+
+	for _, tag := range tags {
+		coding, _ := tag.(map[string]interface{})
+		if coding["system"] == organisationTagSystem {
+			code, _ := coding["code"].(string)
+
+			return code
+		}
+	}
+
+	return ""
+}
+
+// GetFHIRResource gets a resource from the store of the request's organisation
+func (r *TenantRouter) GetFHIRResource(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+	return r.dataset(ctx, resourceType, nil).GetFHIRResource(ctx, resourceType, fhirResourceID, resource)
+}
+
+// CreateFHIRResource creates a resource in the store of the organisation that it is tagged with
+func (r *TenantRouter) CreateFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+	return r.dataset(ctx, resourceType, payload).CreateFHIRResource(ctx, resourceType, payload, resource)
+}
+
+// DeleteFHIRResource deletes a resource from the store of the request's organisation
+func (r *TenantRouter) DeleteFHIRResource(ctx context.Context, resourceType, fhirResourceID string) error {
+	return r.dataset(ctx, resourceType, nil).DeleteFHIRResource(ctx, resourceType, fhirResourceID)
+}
+
+// PatchFHIRResource patches a resource in the store of the request's organisation
+func (r *TenantRouter) PatchFHIRResource(
+	ctx context.Context, resourceType, fhirResourceID string, payload []map[string]interface{}, resource interface{},
+) error {
+	return r.dataset(ctx, resourceType, nil).PatchFHIRResource(ctx, resourceType, fhirResourceID, payload, resource)
+}
+
+// UpdateFHIRResource updates a resource in the store of the organisation that it is tagged with
+func (r *TenantRouter) UpdateFHIRResource(
+	ctx context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{},
+) error {
+	return r.dataset(ctx, resourceType, payload).UpdateFHIRResource(ctx, resourceType, fhirResourceID, payload, resource)
+}
+
+// SearchFHIRResource searches the store of the tenant
+func (r *TenantRouter) SearchFHIRResource(
+	ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
+	dataset := r.DatasetFor(tenant)
+	if resourceType == organizationResource || tenant.OrganizationID == "" {
+		dataset = r.dataset(ctx, resourceType, nil)
+	}
+
+	return dataset.SearchFHIRResource(ctx, resourceType, params, tenant, pagination)
+}
+
+// GetFHIRPatientAllData gets a patient's compartment from the store of the request's organisation
+func (r *TenantRouter) GetFHIRPatientAllData(ctx context.Context, fhirResourceID string) ([]byte, error) {
+	return r.dataset(ctx, patientResourceType, nil).GetFHIRPatientAllData(ctx, fhirResourceID)
+}
+
+// ExecuteBundle executes a bundle in the store of the organisation that its
+// first tagged resource belongs to, or else of the request's organisation. All
+// of the bundle's entries are executed in the same store
+func (r *TenantRouter) ExecuteBundle(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+	for _, entry := range bundle.Entry {
+		if organisationID := payloadOrganisation(entry.Resource); organisationID != "" {
+			return r.DatasetFor(dto.TenantIdentifiers{OrganizationID: organisationID}).ExecuteBundle(ctx, bundle)
+		}
+	}
+
+	return r.dataset(ctx, "", nil).ExecuteBundle(ctx, bundle)
+}
+
+// GetFHIRResourceHistory gets a resource's history from the store of the request's organisation
+func (r *TenantRouter) GetFHIRResourceHistory(
+	ctx context.Context, resourceType, fhirResourceID string, pagination dto.Pagination,
+) (*domain.PagedFHIRResource, error) {
+	return r.dataset(ctx, resourceType, nil).GetFHIRResourceHistory(ctx, resourceType, fhirResourceID, pagination)
+}
+
+// GetFHIRResourceVersion gets a version of a resource from the store of the request's organisation
+func (r *TenantRouter) GetFHIRResourceVersion(
+	ctx context.Context, resourceType, fhirResourceID, versionID string, resource interface{},
+) error {
+	return r.dataset(ctx, resourceType, nil).GetFHIRResourceVersion(ctx, resourceType, fhirResourceID, versionID, resource)
+}
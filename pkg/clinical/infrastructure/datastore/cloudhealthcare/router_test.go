@@ -0,0 +1,219 @@
+package fhir_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	FHIR "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	fakeDataset "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/fhirdataset/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
+	"github.com/savannahghi/scalarutils"
+)
+
+// recordingDataset returns a dataset that records its name each time that it
+// is used
+func recordingDataset(name string, used *[]string) *fakeDataset.FakeFHIRRepository {
+	dataset := fakeDataset.NewFakeFHIRRepositoryMock()
+
+	dataset.MockGetFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string, resource interface{}) error {
+		*used = append(*used, name)
+		return nil
+	}
+	dataset.MockCreateFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+		*used = append(*used, name)
+		return nil
+	}
+	dataset.MockDeleteFHIRResourceFn = func(ctx context.Context, resourceType, fhirResourceID string) error {
+		*used = append(*used, name)
+		return nil
+	}
+	dataset.MockSearchFHIRResourceFn = func(ctx context.Context, resourceType string, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRResource, error) {
+		*used = append(*used, name)
+		return &domain.PagedFHIRResource{}, nil
+	}
+	dataset.MockExecuteBundleFn = func(ctx context.Context, bundle domain.FHIRBundle) (*domain.FHIRBundle, error) {
+		*used = append(*used, name)
+		return &domain.FHIRBundle{}, nil
+	}
+
+	return dataset
+}
+
+// taggedPayload returns a resource tagged with an organisation
+func taggedPayload(organisationID string) map[string]interface{} {
+	return map[string]interface{}{
+		"meta": map[string]interface{}{
+			"tag": []interface{}{
+				map[string]interface{}{
+					"system": "http://mycarehub/tenant-identification/facility",
+					"code":   "facility",
+				},
+				map[string]interface{}{
+					"system": "http://mycarehub/tenant-identification/organisation",
+					"code":   organisationID,
+				},
+			},
+		},
+	}
+}
+
+func TestTenantRouter(t *testing.T) {
+	routedCtx := utils.WithTenant(context.Background(), "routed", "facility")
+	sharedCtx := utils.WithTenant(context.Background(), "shared", "facility")
+
+	tests := []struct {
+		name string
+		call func(router *FHIR.TenantRouter) error
+		want string
+	}{
+		{
+			name: "Happy case: get a resource of a routed organisation",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.GetFHIRResource(routedCtx, "Patient", "1", &domain.FHIRPatient{})
+			},
+			want: "routed",
+		},
+		{
+			name: "Happy case: get a resource of an organisation that is not routed",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.GetFHIRResource(sharedCtx, "Patient", "1", &domain.FHIRPatient{})
+			},
+			want: "default",
+		},
+		{
+			name: "Happy case: get a resource without a tenant",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.GetFHIRResource(context.Background(), "Patient", "1", &domain.FHIRPatient{})
+			},
+			want: "default",
+		},
+		{
+			name: "Happy case: organisations are kept in the default store",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.GetFHIRResource(routedCtx, "Organization", "1", &domain.FHIROrganization{})
+			},
+			want: "default",
+		},
+		{
+			name: "Happy case: create a resource tagged with a routed organisation",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.CreateFHIRResource(context.Background(), "Patient", taggedPayload("routed"), &domain.FHIRPatient{})
+			},
+			want: "routed",
+		},
+		{
+			name: "Happy case: the resource's tag takes precedence over the context",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.CreateFHIRResource(routedCtx, "Patient", taggedPayload("shared"), &domain.FHIRPatient{})
+			},
+			want: "default",
+		},
+		{
+			name: "Happy case: delete a resource of a routed organisation",
+			call: func(router *FHIR.TenantRouter) error {
+				return router.DeleteFHIRResource(routedCtx, "Patient", "1")
+			},
+			want: "routed",
+		},
+		{
+			name: "Happy case: search a routed tenant",
+			call: func(router *FHIR.TenantRouter) error {
+				_, err := router.SearchFHIRResource(
+					sharedCtx, "Patient", domain.NewSearchParams(), dto.TenantIdentifiers{OrganizationID: "routed"}, dto.Pagination{})
+				return err
+			},
+			want: "routed",
+		},
+		{
+			name: "Happy case: search without a tenant",
+			call: func(router *FHIR.TenantRouter) error {
+				_, err := router.SearchFHIRResource(routedCtx, "Patient", domain.NewSearchParams(), dto.TenantIdentifiers{}, dto.Pagination{})
+				return err
+			},
+			want: "routed",
+		},
+		{
+			name: "Happy case: execute a bundle of a routed organisation",
+			call: func(router *FHIR.TenantRouter) error {
+				_, err := router.ExecuteBundle(context.Background(), domain.FHIRBundle{
+					Entry: []domain.FHIRBundleEntry{
+						{Request: &domain.FHIRBundleEntryRequest{Method: "DELETE", URL: "Patient/1"}},
+						{Resource: taggedPayload("routed")},
+					},
+				})
+				return err
+			},
+			want: "routed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := []string{}
+
+			router := FHIR.NewTenantRouter(recordingDataset("default", &used))
+			router.Route("routed", recordingDataset("routed", &used))
+
+			err := tt.call(router)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(used) != 1 || used[0] != tt.want {
+				t.Errorf("expected the %s store to be used, got %v", tt.want, used)
+			}
+		})
+	}
+}
+
+func TestStoreImpl_TenantRouting(t *testing.T) {
+	system := scalarutils.URI("http://mycarehub/tenant-identification/organisation")
+	input := domain.FHIRPatientInput{
+		Meta: domain.FHIRMetaInput{
+			Tag: []domain.FHIRCodingInput{{System: &system, Code: "routed"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		transaction bool
+	}{
+		{
+			name: "Happy case: create a patient in the organisation's store",
+		},
+		{
+			name:        "Happy case: commit a transaction in the organisation's store",
+			transaction: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := []string{}
+
+			router := FHIR.NewTenantRouter(recordingDataset("default", &used))
+			router.Route("routed", recordingDataset("routed", &used))
+
+			fh := FHIR.NewFHIRStoreImpl(router)
+
+			var err error
+			if tt.transaction {
+				err = fh.RunInTransaction(context.Background(), func(fhir repository.FHIR) error {
+					_, err := fhir.CreateFHIRPatient(context.Background(), input)
+					return err
+				})
+			} else {
+				_, err = fh.CreateFHIRPatient(context.Background(), input)
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(used) != 1 || used[0] != "routed" {
+				t.Errorf("expected the routed store to be used, got %v", used)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package fhir
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
+)
+
+// maxTransactionEntries is the most entries that the Cloud Healthcare API
+// accepts in a transaction Bundle
+const maxTransactionEntries = 4500
+
+// transactionDataset buffers the writes made through it as the entries of a
+// transaction Bundle. Reads go to the underlying dataset so they see the data
+// as it was before the transaction started.
+type transactionDataset struct {
+	Dataset
+
+	entries []domain.FHIRBundleEntry
+}
+
+// CreateFHIRResource adds the creation of a resource to the transaction.
+//
+// The resource is assigned its ID up front and is created with an update so
+// that later writes in the same transaction can reference it. A conditional
+// create, of a payload with a source identifier, is not supported since the
+// resource's ID is only known once the transaction is committed.
+func (tx *transactionDataset) CreateFHIRResource(_ context.Context, resourceType string, payload map[string]interface{}, resource interface{}) error {
+	if domain.FHIRSourceIdentifierQuery(payload) != "" {
+		return fmt.Errorf("unable to create %s: conditional create is not supported within a transaction", resourceType)
+	}
+
+	return tx.put(resourceType, uuid.New().String(), payload, "", resource)
+}
+
+// UpdateFHIRResource adds the update of a resource to the transaction.
+//
+// If the payload has a `meta.versionId` the transaction fails unless that is
+// still the current version of the resource when it is committed.
+func (tx *transactionDataset) UpdateFHIRResource(
+	_ context.Context, resourceType, fhirResourceID string, payload map[string]interface{}, resource interface{}) error {
+	ifMatch := ""
+	if versionID := domain.FHIRPayloadVersion(payload); versionID != "" {
+		ifMatch = domain.FHIRVersionETag(versionID)
+	}
+
+	return tx.put(resourceType, fhirResourceID, payload, ifMatch, resource)
+}
+
+// reserve fails once the transaction is full, so that a transaction that
+// would be rejected fails before it is committed
+func (tx *transactionDataset) reserve() error {
+	if len(tx.entries) >= maxTransactionEntries {
+		return fmt.Errorf("%w: a transaction can have at most %d entries", domain.ErrFHIRTransactionTooLarge, maxTransactionEntries)
+	}
+
+	return nil
+}
+
+// put adds an update entry to the transaction
+func (tx *transactionDataset) put(
+	resourceType, fhirResourceID string, payload map[string]interface{}, ifMatch string, resource interface{}) error {
+	err := tx.reserve()
+	if err != nil {
+		return err
+	}
+
+	payload["resourceType"] = resourceType
+	payload["id"] = fhirResourceID
+
+	tx.entries = append(tx.entries, domain.FHIRBundleEntry{
+		FullURL:  fmt.Sprintf("%s/%s", resourceType, fhirResourceID),
+		Resource: payload,
+		Request: &domain.FHIRBundleEntryRequest{
+			Method:  http.MethodPut,
+			URL:     fmt.Sprintf("%s/%s", resourceType, fhirResourceID),
+			IfMatch: ifMatch,
+		},
+	})
+
+	bs, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("json.Encode: %w", err)
+	}
+
+	err = json.Unmarshal(bs, resource)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal %s: %w", resourceType, err)
+	}
+
+	return nil
+}
+
+// DeleteFHIRResource adds the deletion of a resource to the transaction
+func (tx *transactionDataset) DeleteFHIRResource(_ context.Context, resourceType, fhirResourceID string) error {
+	err := tx.reserve()
+	if err != nil {
+		return err
+	}
+
+	tx.entries = append(tx.entries, domain.FHIRBundleEntry{
+		Request: &domain.FHIRBundleEntryRequest{
+			Method: http.MethodDelete,
+			URL:    fmt.Sprintf("%s/%s", resourceType, fhirResourceID),
+		},
+	})
+
+	return nil
+}
+
+// PatchFHIRResource is not supported within a transaction since the patched
+// resource is only known once the transaction is committed
+func (tx *transactionDataset) PatchFHIRResource(
+	_ context.Context, resourceType, fhirResourceID string, _ []map[string]interface{}, _ interface{}) error {
+	return fmt.Errorf("unable to patch %s/%s: patching is not supported within a transaction", resourceType, fhirResourceID)
+}
+
+// ExecuteBundle is not supported within a transaction
+func (tx *transactionDataset) ExecuteBundle(_ context.Context, _ domain.FHIRBundle) (*domain.FHIRBundle, error) {
+	return nil, fmt.Errorf("bundles cannot be executed within a transaction")
+}
+
+// beginTransaction returns a copy of the store whose writes are buffered in
+// the returned transaction. The rest of the store, such as its clock and
+// organization reader, is kept
+func (fh StoreImpl) beginTransaction() (StoreImpl, *transactionDataset) {
+	tx := &transactionDataset{Dataset: fh.Dataset}
+
+	store := fh
+	store.Dataset = tx
+
+	return store, tx
+}
+
+// commitTransaction executes the buffered writes as a single transaction
+func (fh StoreImpl) commitTransaction(ctx context.Context, tx *transactionDataset) error {
+	if len(tx.entries) == 0 {
+		return nil
+	}
+
+	_, err := fh.Dataset.ExecuteBundle(ctx, domain.FHIRBundle{
+		Type:  domain.FHIRBundleTypeEnumTransaction,
+		Entry: tx.entries,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RunInTransaction runs `work` against a copy of the store whose writes are
+// buffered and committed as a single FHIR transaction Bundle once `work`
+// returns. Nothing is written if `work` returns an error or if any of the
+// writes fail.
+//
+// Resources created within the transaction are assigned their IDs up front.
+// Reads see the data as it was before the transaction started.
+func (fh StoreImpl) RunInTransaction(ctx context.Context, work func(fhir repository.FHIR) error) error {
+	store, tx := fh.beginTransaction()
+
+	err := work(store)
+	if err != nil {
+		return err
+	}
+
+	return fh.commitTransaction(ctx, tx)
+}
@@ -0,0 +1,175 @@
+// Package datastore selects and configures the FHIR dataset backend
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/fhirdataset"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/memorydataset"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/restdataset"
+	"github.com/savannahghi/serverutils"
+)
+
+// environment variables used to select and configure the FHIR dataset backend
+const (
+	// FHIRDatasetBackendEnvVarName selects the backend. It defaults to Google Cloud Healthcare
+	FHIRDatasetBackendEnvVarName = "FHIR_DATASET_BACKEND"
+
+	// FHIRMemoryDatasetPathEnvVarName is an optional file used to persist the in-memory dataset
+	FHIRMemoryDatasetPathEnvVarName = "FHIR_MEMORY_DATASET_PATH"
+
+	// FHIRRESTBaseURLEnvVarName is the service base URL of a FHIR R4 REST server e.g HAPI
+	FHIRRESTBaseURLEnvVarName = "FHIR_REST_BASE_URL"
+
+	// FHIRRESTAuthEnvVarName selects how requests to the FHIR R4 REST server are authenticated
+	FHIRRESTAuthEnvVarName = "FHIR_REST_AUTH"
+
+	// credentials used with `basic` and `bearer` FHIR REST authentication
+	FHIRRESTUsernameEnvVarName    = "FHIR_REST_USERNAME"
+	FHIRRESTPasswordEnvVarName    = "FHIR_REST_PASSWORD"
+	FHIRRESTBearerTokenEnvVarName = "FHIR_REST_BEARER_TOKEN"
+
+	// FHIRStoreRoutesPathEnvVarName is an optional JSON file that lists the
+	// organisations that keep their resources in FHIR stores of their own
+	FHIRStoreRoutesPathEnvVarName = "FHIR_STORE_ROUTES_PATH"
+)
+
+// the supported FHIR dataset backends
+const (
+	CloudHealthcareDatasetBackend = "cloudhealthcare"
+	MemoryDatasetBackend          = "memory"
+	RESTDatasetBackend            = "rest"
+)
+
+// NewFHIRDataset initializes the FHIR dataset backend selected by the
+// `FHIR_DATASET_BACKEND` environment variable. Requests to a remote FHIR
+// server are sent through the given transport.
+//
+// When `FHIR_STORE_ROUTES_PATH` is set, the organisations that it lists keep
+// their resources in stores of their own and the returned dataset routes each
+// request to the store of its organisation
+func NewFHIRDataset(ctx context.Context, transport http.RoundTripper) (fhir.Dataset, error) {
+	backend := os.Getenv(FHIRDatasetBackendEnvVarName)
+
+	dataset, err := newFHIRDataset(ctx, transport, backend, StoreRoute{})
+	if err != nil {
+		return nil, err
+	}
+
+	path := os.Getenv(FHIRStoreRoutesPathEnvVarName)
+	if path == "" {
+		return dataset, nil
+	}
+
+	routes, err := ReadStoreRoutes(path, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	router := fhir.NewTenantRouter(dataset)
+
+	for _, route := range routes {
+		routed, err := newFHIRDataset(ctx, transport, backend, route)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize the FHIR store of organization %s: %w", route.OrganizationID, err)
+		}
+
+		router.Route(route.OrganizationID, routed)
+	}
+
+	return router, nil
+}
+
+// newFHIRDataset initializes a store of the backend. The settings of the route
+// take the place of those of the default store
+func newFHIRDataset(ctx context.Context, transport http.RoundTripper, backend string, route StoreRoute) (fhir.Dataset, error) {
+	switch backend {
+	case "", CloudHealthcareDatasetBackend:
+		project := serverutils.MustGetEnvVar(serverutils.GoogleCloudProjectIDEnvVarName)
+
+		datasetID := route.DatasetID
+		if datasetID == "" {
+			datasetID = serverutils.MustGetEnvVar("CLOUD_HEALTH_DATASET_ID")
+		}
+
+		datasetLocation := route.DatasetLocation
+		if datasetLocation == "" {
+			datasetLocation = serverutils.MustGetEnvVar("CLOUD_HEALTH_DATASET_LOCATION")
+		}
+
+		fhirStoreID := route.FHIRStoreID
+		if fhirStoreID == "" {
+			fhirStoreID = serverutils.MustGetEnvVar("CLOUD_HEALTH_FHIRSTORE_ID")
+		}
+
+		repo, err := fhirdataset.NewFHIRRepository(ctx, nil, transport, project, datasetID, datasetLocation, fhirStoreID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize Google Cloud Healthcare FHIR dataset: %w", err)
+		}
+
+		return repo, nil
+
+	case MemoryDatasetBackend:
+		path := route.MemoryPath
+		if route.OrganizationID == "" {
+			path = os.Getenv(FHIRMemoryDatasetPathEnvVarName)
+		}
+
+		repo, err := memorydataset.NewMemoryRepository(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize in-memory FHIR dataset: %w", err)
+		}
+
+		return repo, nil
+
+	case RESTDatasetBackend:
+		auth, err := newFHIRRESTAuthenticator()
+		if err != nil {
+			return nil, err
+		}
+
+		baseURL := route.RESTBaseURL
+		if baseURL == "" {
+			baseURL = serverutils.MustGetEnvVar(FHIRRESTBaseURLEnvVarName)
+		}
+
+		repo, err := restdataset.NewFHIRRepository(baseURL, auth, transport)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize FHIR REST dataset: %w", err)
+		}
+
+		return repo, nil
+
+	default:
+		return nil, fmt.Errorf("unknown FHIR dataset backend %q", backend)
+	}
+}
+
+// newFHIRRESTAuthenticator configures the authentication selected by the
+// `FHIR_REST_AUTH` environment variable. It can be `none`, `basic` or `bearer`
+func newFHIRRESTAuthenticator() (restdataset.Authenticator, error) {
+	auth := os.Getenv(FHIRRESTAuthEnvVarName)
+
+	switch auth {
+	case "", "none":
+		return restdataset.NoAuth{}, nil
+
+	case "basic":
+		return restdataset.BasicAuth{
+			Username: serverutils.MustGetEnvVar(FHIRRESTUsernameEnvVarName),
+			Password: serverutils.MustGetEnvVar(FHIRRESTPasswordEnvVarName),
+		}, nil
+
+	case "bearer":
+		return restdataset.BearerAuth{
+			Token: serverutils.MustGetEnvVar(FHIRRESTBearerTokenEnvVarName),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown FHIR REST authentication %q", auth)
+	}
+}
@@ -0,0 +1,82 @@
+package datastore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// StoreRoute keeps the resources of an organisation in a FHIR store of its own.
+// The settings that are used depend on the dataset backend, and those that are
+// not set are shared with the default store
+type StoreRoute struct {
+	OrganizationID string `json:"organizationID"`
+
+	// Google Cloud Healthcare: the FHIR store, and optionally the dataset, of
+	// the organisation
+	FHIRStoreID     string `json:"fhirStoreID,omitempty"`
+	DatasetID       string `json:"datasetID,omitempty"`
+	DatasetLocation string `json:"datasetLocation,omitempty"`
+
+	// FHIR REST server: the service base URL of the organisation's server
+	RESTBaseURL string `json:"restBaseURL,omitempty"`
+
+	// in-memory: the file that persists the organisation's data. The data only
+	// lives as long as the process when it is empty
+	MemoryPath string `json:"memoryPath,omitempty"`
+}
+
+// ReadStoreRoutes reads a JSON file that holds a list of store routes and
+// checks that each one sets the store of the backend
+func ReadStoreRoutes(path, backend string) ([]StoreRoute, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read FHIR store routes: %w", err)
+	}
+
+	routes := []StoreRoute{}
+
+	err = json.Unmarshal(data, &routes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse FHIR store routes: %w", err)
+	}
+
+	routed := map[string]bool{}
+
+	for i, route := range routes {
+		err := validateStoreRoute(route, backend)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FHIR store route %d: %w", i+1, err)
+		}
+
+		if routed[route.OrganizationID] {
+			return nil, fmt.Errorf("invalid FHIR store route %d: organization %s is routed more than once", i+1, route.OrganizationID)
+		}
+
+		routed[route.OrganizationID] = true
+	}
+
+	return routes, nil
+}
+
+// validateStoreRoute checks that a route names an organisation and the store
+// that it is sent to
+func validateStoreRoute(route StoreRoute, backend string) error {
+	if route.OrganizationID == "" {
+		return fmt.Errorf("expected an organization ID")
+	}
+
+	switch backend {
+	case "", CloudHealthcareDatasetBackend:
+		if route.FHIRStoreID == "" {
+			return fmt.Errorf("expected the FHIR store ID of organization %s", route.OrganizationID)
+		}
+
+	case RESTDatasetBackend:
+		if route.RESTBaseURL == "" {
+			return fmt.Errorf("expected the FHIR REST base URL of organization %s", route.OrganizationID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package datastore_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore"
+	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
+)
+
+// writeRoutes writes the routes file of a test and returns its path
+func writeRoutes(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "routes.json")
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReadStoreRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		backend    string
+		wantRoutes int
+		wantErr    bool
+	}{
+		{
+			name:       "Happy case: Google Cloud Healthcare routes",
+			content:    `[{"organizationID": "a", "fhirStoreID": "store-a"}, {"organizationID": "b", "fhirStoreID": "store-b", "datasetID": "dataset-b"}]`,
+			backend:    datastore.CloudHealthcareDatasetBackend,
+			wantRoutes: 2,
+		},
+		{
+			name:       "Happy case: in-memory routes",
+			content:    `[{"organizationID": "a"}]`,
+			backend:    datastore.MemoryDatasetBackend,
+			wantRoutes: 1,
+		},
+		{
+			name:    "Sad case: invalid JSON",
+			content: `{`,
+			backend: datastore.MemoryDatasetBackend,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: missing organization ID",
+			content: `[{"fhirStoreID": "store-a"}]`,
+			backend: datastore.CloudHealthcareDatasetBackend,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: missing FHIR store ID",
+			content: `[{"organizationID": "a"}]`,
+			backend: datastore.CloudHealthcareDatasetBackend,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: missing FHIR REST base URL",
+			content: `[{"organizationID": "a", "fhirStoreID": "store-a"}]`,
+			backend: datastore.RESTDatasetBackend,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: organization routed twice",
+			content: `[{"organizationID": "a", "fhirStoreID": "store-a"}, {"organizationID": "a", "fhirStoreID": "store-b"}]`,
+			backend: datastore.CloudHealthcareDatasetBackend,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes, err := datastore.ReadStoreRoutes(writeRoutes(t, tt.content), tt.backend)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadStoreRoutes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && len(routes) != tt.wantRoutes {
+				t.Errorf("expected %d routes, got %d", tt.wantRoutes, len(routes))
+			}
+		})
+	}
+}
+
+func TestNewFHIRDataset(t *testing.T) {
+	tests := []struct {
+		name       string
+		routes     string
+		wantRouter bool
+		wantErr    bool
+	}{
+		{
+			name: "Happy case: a single store",
+		},
+		{
+			name:       "Happy case: stores routed per organisation",
+			routes:     `[{"organizationID": "a"}]`,
+			wantRouter: true,
+		},
+		{
+			name:    "Sad case: invalid routes",
+			routes:  `[{"memoryPath": "a.json"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(datastore.FHIRDatasetBackendEnvVarName, datastore.MemoryDatasetBackend)
+			t.Setenv(datastore.FHIRMemoryDatasetPathEnvVarName, "")
+			t.Setenv(datastore.FHIRStoreRoutesPathEnvVarName, "")
+
+			if tt.routes != "" {
+				t.Setenv(datastore.FHIRStoreRoutesPathEnvVarName, writeRoutes(t, tt.routes))
+			}
+
+			dataset, err := datastore.NewFHIRDataset(context.Background(), http.DefaultTransport)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFHIRDataset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if _, ok := dataset.(*fhir.TenantRouter); ok != tt.wantRouter {
+				t.Errorf("expected a tenant router %v, got %T", tt.wantRouter, dataset)
+			}
+		})
+	}
+}
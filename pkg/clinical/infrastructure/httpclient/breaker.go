@@ -0,0 +1,75 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, without calling the upstream, while the upstream
+// is considered to be down after failing repeatedly
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// outcome is the result of a request as seen by a circuit breaker
+type outcome int
+
+const (
+	succeeded outcome = iota
+	failed
+	// ignored is used for requests that say nothing about the upstream's
+	// health e.g those cancelled by the caller
+	ignored
+)
+
+// breaker is a circuit breaker for a single upstream.
+//
+// It opens after `threshold` consecutive failures and rejects requests until
+// `cooldown` has passed. A single request is then let through: the circuit
+// closes if it succeeds and opens again if it fails.
+type breaker struct {
+	mu sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow reports whether a request can be sent to the upstream
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold == 0 || b.failures < b.threshold {
+		return true
+	}
+
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+
+	b.probing = true
+
+	return true
+}
+
+// record updates the state of the circuit with the result of a request
+func (b *breaker) record(result outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	switch result {
+	case succeeded:
+		b.failures = 0
+
+	case failed:
+		b.failures++
+		if b.threshold > 0 && b.failures >= b.threshold {
+			b.openedAt = time.Now()
+		}
+	}
+}
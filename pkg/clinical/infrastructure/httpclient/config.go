@@ -0,0 +1,107 @@
+// Package httpclient provides the HTTP transport shared by the clients of
+// upstream services e.g the FHIR server and OpenConceptLab. It retries
+// transient failures and stops calling an upstream that keeps failing.
+package httpclient
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// environment variables used to configure the shared HTTP transport
+const (
+	// MaxRetriesEnvVarName is the number of times a failed request is retried
+	MaxRetriesEnvVarName = "HTTP_CLIENT_MAX_RETRIES"
+
+	// InitialBackoffEnvVarName is the wait before the first retry e.g `200ms`
+	InitialBackoffEnvVarName = "HTTP_CLIENT_INITIAL_BACKOFF"
+
+	// MaxBackoffEnvVarName is the longest wait between retries e.g `5s`
+	MaxBackoffEnvVarName = "HTTP_CLIENT_MAX_BACKOFF"
+
+	// BreakerThresholdEnvVarName is the number of consecutive failures that open an upstream's circuit
+	BreakerThresholdEnvVarName = "HTTP_CLIENT_BREAKER_THRESHOLD"
+
+	// BreakerCooldownEnvVarName is how long an open circuit rejects requests e.g `30s`
+	BreakerCooldownEnvVarName = "HTTP_CLIENT_BREAKER_COOLDOWN"
+)
+
+// Config configures the retries and circuit breakers of the transport
+type Config struct {
+	// MaxRetries is the number of times a failed request is retried. Zero
+	// disables retries
+	MaxRetries int
+
+	// InitialBackoff is the wait before the first retry. It doubles with
+	// every retry, up to MaxBackoff, and is jittered
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// BreakerThreshold is the number of consecutive failures after which
+	// requests to an upstream are rejected. Zero disables circuit breaking
+	BreakerThreshold int
+
+	// BreakerCooldown is how long the circuit stays open before a single
+	// request is let through to probe the upstream
+	BreakerCooldown time.Duration
+}
+
+// DefaultConfig returns the configuration used when none is provided
+func DefaultConfig() Config {
+	return Config{
+		MaxRetries:       3,
+		InitialBackoff:   200 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// ConfigFromEnv returns the default configuration overridden by any of the
+// `HTTP_CLIENT_*` environment variables that are set
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	ints := map[string]*int{
+		MaxRetriesEnvVarName:       &config.MaxRetries,
+		BreakerThresholdEnvVarName: &config.BreakerThreshold,
+	}
+
+	for name, value := range ints {
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return Config{}, fmt.Errorf("invalid %s value %q: expected a non-negative integer", name, raw)
+		}
+
+		*value = parsed
+	}
+
+	durations := map[string]*time.Duration{
+		InitialBackoffEnvVarName:  &config.InitialBackoff,
+		MaxBackoffEnvVarName:      &config.MaxBackoff,
+		BreakerCooldownEnvVarName: &config.BreakerCooldown,
+	}
+
+	for name, value := range durations {
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < 0 {
+			return Config{}, fmt.Errorf("invalid %s value %q: expected a duration e.g 500ms", name, raw)
+		}
+
+		*value = parsed
+	}
+
+	return config, nil
+}
@@ -0,0 +1,244 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Transport is an `http.RoundTripper` that retries transient failures with a
+// jittered exponential backoff and keeps a circuit breaker for each upstream
+// host. It is safe for concurrent use and is meant to be shared by every
+// client of an upstream.
+//
+// A request is retried when the upstream is throttling or unavailable i.e
+// `429 Too Many Requests` or `503 Service Unavailable`. Network errors,
+// `502 Bad Gateway` and `504 Gateway Timeout` are only retried for idempotent
+// methods since the upstream may have acted on the request. A `Retry-After`
+// header is honored: the request is not retried if the upstream asks for a
+// longer wait than the maximum backoff.
+//
+// Waiting stops as soon as the request's context is done.
+type Transport struct {
+	config Config
+	base   http.RoundTripper
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// NewTransport initializes a transport that sends requests through `base`. A
+// nil base uses `http.DefaultTransport`
+func NewTransport(config Config, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		config:   config,
+		base:     base,
+		breakers: map[string]*breaker{},
+	}
+}
+
+// NewClient returns a client that sends its requests through the transport.
+// The timeout covers the whole exchange including any retries
+func NewClient(transport http.RoundTripper, timeout time.Duration) *http.Client {
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// RoundTrip sends the request, retrying it if it fails transiently
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	upstream := t.breaker(req.URL.Host)
+
+	for attempt := 0; ; attempt++ {
+		if !upstream.allow() {
+			return nil, fmt.Errorf("%s: %w", req.URL.Host, ErrCircuitOpen)
+		}
+
+		attemptReq, err := rewind(req, attempt)
+		if err != nil {
+			upstream.record(ignored)
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		upstream.record(outcomeOf(req, resp, err))
+
+		if attempt >= t.config.MaxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait, ok := t.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		err = sleep(req, wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// breaker returns the circuit breaker of an upstream host
+func (t *Transport) breaker(host string) *breaker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, ok := t.breakers[host]
+	if !ok {
+		b = &breaker{
+			threshold: t.config.BreakerThreshold,
+			cooldown:  t.config.BreakerCooldown,
+		}
+		t.breakers[host] = b
+	}
+
+	return b
+}
+
+// outcomeOf classifies the result of a request for the circuit breaker. Server
+// errors and network errors count against the upstream, except when the
+// caller gave up on the request
+func outcomeOf(req *http.Request, resp *http.Response, err error) outcome {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return ignored
+		}
+
+		return failed
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return failed
+	}
+
+	return succeeded
+}
+
+// backoff works out how long to wait before retrying. The wait is the
+// `Retry-After` of the response if it has one, otherwise it grows
+// exponentially with each attempt and half of it is random
+func (t *Transport) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			return wait, wait <= t.config.MaxBackoff
+		}
+	}
+
+	ceiling := t.config.InitialBackoff << attempt
+	if ceiling <= 0 || ceiling > t.config.MaxBackoff {
+		ceiling = t.config.MaxBackoff
+	}
+
+	half := int64(ceiling / 2)
+	if half == 0 {
+		return ceiling, true
+	}
+
+	return time.Duration(half + rand.Int63n(half)), true
+}
+
+// retryAfter parses the `Retry-After` header, which is either a number of
+// seconds or an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := time.Until(date)
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, true
+}
+
+// retryable reports whether a failed attempt can be sent again
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// the body can only be sent again if it can be recreated
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return idempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+
+	default:
+		return false
+	}
+}
+
+// idempotent reports whether sending a request more than once has the same
+// effect as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewind returns the request to send on an attempt, with a fresh body for
+// every attempt after the first
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("unable to recreate the request body for a retry: %w", err)
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Body = body
+
+	return retry, nil
+}
+
+// sleep waits before a retry unless the request's context is done first
+func sleep(req *http.Request, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
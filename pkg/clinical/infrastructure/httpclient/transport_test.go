@@ -0,0 +1,280 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/httpclient"
+)
+
+var testConfig = httpclient.Config{
+	MaxRetries:       2,
+	InitialBackoff:   time.Millisecond,
+	MaxBackoff:       10 * time.Millisecond,
+	BreakerThreshold: 0,
+}
+
+// newTestServer starts a server that responds with the given statuses in
+// order, and then with `200 OK`
+func newTestServer(t *testing.T, headers http.Header, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Body", string(body))
+
+		if call <= len(statuses) {
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+
+			w.WriteHeader(statuses[call-1])
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestTransport_Retries(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		headers    http.Header
+		statuses   []int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			name:       "happy case: retry when throttled",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "happy case: retry a bad gateway for an idempotent method",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadGateway},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "happy case: honor a short Retry-After",
+			method:     http.MethodGet,
+			headers:    http.Header{"Retry-After": []string{"0"}},
+			statuses:   []int{http.StatusServiceUnavailable},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "sad case: give up after the maximum retries",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  3,
+		},
+		{
+			name:       "sad case: do not retry a bad gateway for a create",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusBadGateway},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  1,
+		},
+		{
+			name:       "sad case: do not retry a client error",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadRequest},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+		{
+			name:       "sad case: do not wait longer than the maximum backoff",
+			method:     http.MethodGet,
+			headers:    http.Header{"Retry-After": []string{"120"}},
+			statuses:   []int{http.StatusTooManyRequests},
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newTestServer(t, tt.headers, tt.statuses...)
+			client := httpclient.NewClient(httpclient.NewTransport(testConfig, nil), time.Second)
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatalf("unable to compose request: %v", err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			if resp.Header.Get("X-Body") != "payload" {
+				t.Errorf("expected the body to be sent on every attempt, got %q", resp.Header.Get("X-Body"))
+			}
+
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestTransport_CircuitBreaker(t *testing.T) {
+	server, calls := newTestServer(t, nil, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	config := httpclient.Config{
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	}
+	client := httpclient.NewClient(httpclient.NewTransport(config, nil), time.Second)
+
+	get := func() (*http.Response, error) {
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		return resp, err
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := get()
+		if err != nil {
+			t.Fatalf("expected the upstream to be called while the circuit is closed, got: %v", err)
+		}
+	}
+
+	_, err := get()
+	if !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Errorf("expected the circuit to be open after repeated failures, got: %v", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected the open circuit to not call the upstream, got %d calls", got)
+	}
+
+	time.Sleep(config.BreakerCooldown)
+
+	resp, err := get()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the probe after the cooldown to succeed, got: %v", err)
+	}
+
+	_, err = get()
+	if err != nil {
+		t.Errorf("expected the circuit to close after a successful probe, got: %v", err)
+	}
+}
+
+func TestTransport_Cancellation(t *testing.T) {
+	server, calls := newTestServer(t, http.Header{"Retry-After": []string{"1"}}, http.StatusServiceUnavailable)
+
+	config := testConfig
+	config.MaxBackoff = time.Minute
+	client := httpclient.NewClient(httpclient.NewTransport(config, nil), time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to compose request: %v", err)
+	}
+
+	started := time.Now()
+
+	_, err = client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the retry to stop when the context is done, got: %v", err)
+	}
+
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the wait to be cut short, took %v", elapsed)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected a single call, got %d", got)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    httpclient.Config
+		wantErr bool
+	}{
+		{
+			name: "happy case: defaults",
+			env:  map[string]string{},
+			want: httpclient.DefaultConfig(),
+		},
+		{
+			name: "happy case: overrides",
+			env: map[string]string{
+				httpclient.MaxRetriesEnvVarName:       "1",
+				httpclient.InitialBackoffEnvVarName:   "1s",
+				httpclient.MaxBackoffEnvVarName:       "2s",
+				httpclient.BreakerThresholdEnvVarName: "0",
+				httpclient.BreakerCooldownEnvVarName:  "1m",
+			},
+			want: httpclient.Config{
+				MaxRetries:       1,
+				InitialBackoff:   time.Second,
+				MaxBackoff:       2 * time.Second,
+				BreakerThreshold: 0,
+				BreakerCooldown:  time.Minute,
+			},
+		},
+		{
+			name:    "sad case: invalid retries",
+			env:     map[string]string{httpclient.MaxRetriesEnvVarName: "many"},
+			wantErr: true,
+		},
+		{
+			name:    "sad case: invalid backoff",
+			env:     map[string]string{httpclient.MaxBackoffEnvVarName: "5"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := httpclient.ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ConfigFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,7 +16,7 @@ import (
 
 // ServiceOCL ...
 type ServiceOCL interface {
-	MakeRequest(method string, path string, params url.Values, body io.Reader) (*http.Response, error)
+	MakeRequest(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error)
 	ListConcepts(
 		ctx context.Context, org string, source string, verbose bool, q *string,
 		sortAsc *string, sortDesc *string, conceptClass *string, dataType *string,
@@ -0,0 +1,423 @@
+package openconceptlab
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Measures and views of the concept cache
+var (
+	// ConceptCacheRequests counts the concepts that are looked up in the cache
+	ConceptCacheRequests = stats.Int64(
+		"ocl_concept_cache_requests",
+		"The number of concepts looked up in the OpenConceptLab concept cache",
+		stats.UnitDimensionless,
+	)
+
+	// ConceptCacheResult is how a concept was found: `hit` in the cache, `miss`
+	// read from OpenConceptLab or `stale` from the cache when OpenConceptLab failed
+	ConceptCacheResult = tag.MustNewKey("cache.result")
+
+	ConceptCacheRequestCountView = &view.View{
+		Name:        "ocl_concept_cache_request_count",
+		Description: "The number of concepts looked up in the OpenConceptLab concept cache",
+		Measure:     ConceptCacheRequests,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{ConceptCacheResult},
+	}
+
+	// ConceptCacheViews should be registered for the concept cache metrics to be exported
+	ConceptCacheViews = []*view.View{ConceptCacheRequestCountView}
+)
+
+// ConceptCacheConfig configures a concept cache
+type ConceptCacheConfig struct {
+	// Size is the most concepts that are kept. The least recently used
+	// concepts are dropped first
+	Size int
+
+	// TTL is how long a concept is used before it is read again
+	TTL time.Duration
+
+	// NegativeTTL is how long a concept that OpenConceptLab does not have is
+	// remembered as missing
+	NegativeTTL time.Duration
+
+	// Path is an optional file that the cache is kept in, so that it survives
+	// restarts
+	Path string
+
+	// SaveInterval is how often the cache is written to its file when concepts
+	// were added to it. It defaults to a minute
+	SaveInterval time.Duration
+}
+
+// defaultConceptCacheSaveInterval is how often the cache is written to its
+// file when no interval is configured
+const defaultConceptCacheSaveInterval = time.Minute
+
+// cachedConcept is a concept and when it should be read again. A concept that
+// OpenConceptLab does not have is cached without a concept
+type cachedConcept struct {
+	Key       string          `json:"key"`
+	Concept   *domain.Concept `json:"concept,omitempty"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+}
+
+// ConceptCache keeps the concepts read from OpenConceptLab. Every observation,
+// condition and allergy that is recorded looks up its concept, and the same few
+// concepts are looked up again and again.
+//
+// Expired concepts are kept until they are read again, and are used when
+// OpenConceptLab cannot be reached so that writes keep working during an
+// outage. Concepts that OpenConceptLab does not have are cached for the
+// negative TTL. Concept searches and other requests go straight to
+// OpenConceptLab.
+//
+// The cache is written to its file in the background, so lookups do not wait
+// for it, and once more when it is closed.
+type ConceptCache struct {
+	infrastructure.ServiceOCL
+
+	config ConceptCacheConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	concepts map[string]*list.Element
+	// the most recently used concepts are at the front
+	recent *list.List
+	// changed is set when concepts were added since the cache was last
+	// written to its file
+	changed bool
+
+	// saving is held while the cache is written to its file
+	saving sync.Mutex
+
+	// closing stops the background saves, and saved is closed when they stop
+	closing   chan struct{}
+	saved     chan struct{}
+	closeOnce sync.Once
+}
+
+// NewConceptCache initializes a cache in front of OpenConceptLab. The concepts
+// that were kept in the cache's file, if any, are read. A cache that is kept in
+// a file should be closed when the service stops
+func NewConceptCache(service infrastructure.ServiceOCL, config ConceptCacheConfig) (*ConceptCache, error) {
+	if config.Size <= 0 {
+		return nil, fmt.Errorf("the concept cache size must be positive, got %d", config.Size)
+	}
+
+	cache := &ConceptCache{
+		ServiceOCL: service,
+		config:     config,
+		now:        time.Now,
+		concepts:   map[string]*list.Element{},
+		recent:     list.New(),
+	}
+
+	err := cache.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Path != "" {
+		interval := config.SaveInterval
+		if interval <= 0 {
+			interval = defaultConceptCacheSaveInterval
+		}
+
+		cache.closing = make(chan struct{})
+		cache.saved = make(chan struct{})
+
+		go cache.saveEvery(interval)
+	}
+
+	return cache, nil
+}
+
+// Close stops writing the cache to its file in the background and writes it
+// one last time
+func (c *ConceptCache) Close() error {
+	if c.config.Path == "" {
+		return nil
+	}
+
+	c.closeOnce.Do(func() {
+		close(c.closing)
+	})
+
+	<-c.saved
+
+	err := c.save()
+	if err != nil {
+		return fmt.Errorf("unable to save the concept cache: %w", err)
+	}
+
+	return nil
+}
+
+// GetConcept returns the cached concept, or reads it from OpenConceptLab and
+// caches it. An expired concept is returned when it cannot be read again
+func (c *ConceptCache) GetConcept(
+	ctx context.Context, org string, source string, concept string,
+	includeMappings bool, includeInverseMappings bool) (*domain.Concept, error) {
+	key := conceptCacheKey(org, source, concept, includeMappings, includeInverseMappings)
+
+	cached, found := c.get(key)
+	if found && c.now().Before(cached.ExpiresAt) {
+		recordConceptCacheResult(ctx, "hit")
+
+		return cached.result(source, concept)
+	}
+
+	result, err := c.ServiceOCL.GetConcept(ctx, org, source, concept, includeMappings, includeInverseMappings)
+
+	switch {
+	case err == nil:
+		recordConceptCacheResult(ctx, "miss")
+		c.put(key, result, c.config.TTL)
+
+		return copyConcept(result), nil
+
+	case errors.Is(err, domain.ErrConceptNotFound):
+		recordConceptCacheResult(ctx, "miss")
+		c.put(key, nil, c.config.NegativeTTL)
+
+		return nil, err
+
+	case found:
+		log.Warnf("using a cached %s concept %s because OpenConceptLab failed: %v", source, concept, err)
+		recordConceptCacheResult(ctx, "stale")
+
+		return cached.result(source, concept)
+
+	default:
+		recordConceptCacheResult(ctx, "miss")
+
+		return nil, err
+	}
+}
+
+// Len returns the number of cached concepts
+func (c *ConceptCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.recent.Len()
+}
+
+// result returns a copy of the cached concept, or an error if OpenConceptLab
+// does not have it
+func (c cachedConcept) result(source, concept string) (*domain.Concept, error) {
+	if c.Concept == nil {
+		return nil, fmt.Errorf("%w: %s concept %s", domain.ErrConceptNotFound, source, concept)
+	}
+
+	return copyConcept(c.Concept), nil
+}
+
+// get returns a cached concept, expired or not, and marks it as recently used
+func (c *ConceptCache) get(key string) (cachedConcept, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.concepts[key]
+	if !ok {
+		return cachedConcept{}, false
+	}
+
+	c.recent.MoveToFront(element)
+
+	return element.Value.(cachedConcept), true
+}
+
+// put caches a concept for the TTL, dropping the least recently used concepts
+// when the cache is full. The cache is written to its file later
+func (c *ConceptCache) put(key string, concept *domain.Concept, ttl time.Duration) {
+	cached := cachedConcept{
+		Key:       key,
+		Concept:   copyConcept(concept),
+		ExpiresAt: c.now().Add(ttl),
+	}
+
+	c.mu.Lock()
+	c.add(cached)
+	c.changed = true
+	c.mu.Unlock()
+}
+
+// add caches a concept as the most recently used. The caller holds the lock
+func (c *ConceptCache) add(cached cachedConcept) {
+	if element, ok := c.concepts[cached.Key]; ok {
+		element.Value = cached
+		c.recent.MoveToFront(element)
+
+		return
+	}
+
+	c.concepts[cached.Key] = c.recent.PushFront(cached)
+
+	for c.recent.Len() > c.config.Size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.concepts, oldest.Value.(cachedConcept).Key)
+	}
+}
+
+// load reads the concepts kept in the cache's file
+func (c *ConceptCache) load() error {
+	if c.config.Path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.config.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to read the concept cache: %w", err)
+	}
+
+	// the concepts are kept from the most to the least recently used
+	concepts := []cachedConcept{}
+
+	err = json.Unmarshal(data, &concepts)
+	if err != nil {
+		return fmt.Errorf("unable to parse the concept cache: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(concepts) - 1; i >= 0; i-- {
+		c.add(concepts[i])
+	}
+
+	return nil
+}
+
+// saveEvery writes the cache to its file at every interval until the cache is
+// closed
+func (c *ConceptCache) saveEvery(interval time.Duration) {
+	defer close(c.saved)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := c.save()
+			if err != nil {
+				log.Errorf("unable to save the concept cache: %v", err)
+			}
+
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+// save writes the cached concepts to the cache's file if concepts were added
+// since it was last written
+func (c *ConceptCache) save() error {
+	if c.config.Path == "" {
+		return nil
+	}
+
+	c.saving.Lock()
+	defer c.saving.Unlock()
+
+	c.mu.Lock()
+	if !c.changed {
+		c.mu.Unlock()
+
+		return nil
+	}
+
+	concepts := make([]cachedConcept, 0, c.recent.Len())
+
+	for element := c.recent.Front(); element != nil; element = element.Next() {
+		concepts = append(concepts, element.Value.(cachedConcept))
+	}
+
+	c.changed = false
+	c.mu.Unlock()
+
+	data, err := json.Marshal(concepts)
+	if err == nil {
+		err = writeFile(c.config.Path, data)
+	}
+
+	if err != nil {
+		// the concepts are written again at the next save
+		c.mu.Lock()
+		c.changed = true
+		c.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// conceptCacheKey identifies a concept lookup
+func conceptCacheKey(org, source, concept string, includeMappings, includeInverseMappings bool) string {
+	return strings.Join([]string{
+		org, source, concept,
+		strconv.FormatBool(includeMappings), strconv.FormatBool(includeInverseMappings),
+	}, "/")
+}
+
+// copyConcept returns a copy of a concept so that callers cannot change the
+// cached concept
+func copyConcept(concept *domain.Concept) *domain.Concept {
+	if concept == nil {
+		return nil
+	}
+
+	copied := *concept
+
+	if concept.Locale != nil {
+		locale := *concept.Locale
+		copied.Locale = &locale
+	}
+
+	if concept.Mappings != nil {
+		copied.Mappings = make([]*domain.ConceptMapping, 0, len(concept.Mappings))
+
+		for _, mapping := range concept.Mappings {
+			if mapping == nil {
+				continue
+			}
+
+			copiedMapping := *mapping
+			copied.Mappings = append(copied.Mappings, &copiedMapping)
+		}
+	}
+
+	return &copied
+}
+
+// recordConceptCacheResult counts a lookup in the concept cache
+func recordConceptCacheResult(ctx context.Context, result string) {
+	ctx, _ = tag.New(ctx, tag.Upsert(ConceptCacheResult, result))
+
+	stats.Record(ctx, ConceptCacheRequests.M(1))
+}
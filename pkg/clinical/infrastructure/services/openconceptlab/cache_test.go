@@ -0,0 +1,236 @@
+package openconceptlab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab"
+	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
+)
+
+func TestConceptCache_GetConcept(t *testing.T) {
+	tests := []struct {
+		name   string
+		config openconceptlab.ConceptCacheConfig
+		// the concepts that are looked up, in order
+		lookups []string
+		// between runs before the last lookup
+		between func()
+		// outage fails the lookups in OpenConceptLab after the first one
+		outage       bool
+		notFound     bool
+		wantCalls    int
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{
+			name:      "Happy case: the second lookup is cached",
+			config:    openconceptlab.ConceptCacheConfig{Size: 10, TTL: time.Minute},
+			lookups:   []string{"5088", "5088"},
+			wantCalls: 1,
+		},
+		{
+			name:    "Happy case: expired concepts are read again",
+			config:  openconceptlab.ConceptCacheConfig{Size: 10, TTL: time.Millisecond},
+			lookups: []string{"5088", "5088"},
+			between: func() {
+				time.Sleep(5 * time.Millisecond)
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "Happy case: the least recently used concepts are dropped",
+			config:    openconceptlab.ConceptCacheConfig{Size: 2, TTL: time.Minute},
+			lookups:   []string{"5088", "5089", "5088", "5090", "5089"},
+			wantCalls: 4,
+		},
+		{
+			name:    "Happy case: expired concepts are used during an outage",
+			config:  openconceptlab.ConceptCacheConfig{Size: 10, TTL: time.Millisecond},
+			lookups: []string{"5088", "5088"},
+			between: func() {
+				time.Sleep(5 * time.Millisecond)
+			},
+			outage:    true,
+			wantCalls: 2,
+		},
+		{
+			name:         "Happy case: missing concepts are cached",
+			config:       openconceptlab.ConceptCacheConfig{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute},
+			lookups:      []string{"0000", "0000"},
+			notFound:     true,
+			wantCalls:    1,
+			wantNotFound: true,
+			wantErr:      true,
+		},
+		{
+			name:         "Happy case: missing concepts are looked up again after the negative TTL",
+			config:       openconceptlab.ConceptCacheConfig{Size: 10, TTL: time.Minute, NegativeTTL: time.Millisecond},
+			lookups:      []string{"0000", "0000"},
+			notFound:     true,
+			between:      func() { time.Sleep(5 * time.Millisecond) },
+			wantCalls:    2,
+			wantNotFound: true,
+			wantErr:      true,
+		},
+		{
+			name:      "Sad case: concepts that were never cached fail during an outage",
+			config:    openconceptlab.ConceptCacheConfig{Size: 10, TTL: time.Minute},
+			lookups:   []string{"5088", "5089"},
+			outage:    true,
+			wantCalls: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ocl := fakeOCLMock.NewFakeOCLMock()
+			calls := 0
+
+			ocl.MockGetConceptFn = func(ctx context.Context, org, source, concept string, includeMappings, includeInverseMappings bool) (*domain.Concept, error) {
+				calls++
+
+				if tt.outage && calls > 1 {
+					return nil, fmt.Errorf("OCL API request error: connection refused")
+				}
+
+				if tt.notFound {
+					return nil, fmt.Errorf("%w: %s concept %s", domain.ErrConceptNotFound, source, concept)
+				}
+
+				return &domain.Concept{ID: concept, DisplayName: "Concept " + concept}, nil
+			}
+
+			cache, err := openconceptlab.NewConceptCache(ocl, tt.config)
+			if err != nil {
+				t.Fatalf("unable to create the cache: %v", err)
+			}
+
+			for i, lookup := range tt.lookups {
+				last := i == len(tt.lookups)-1
+
+				if last && tt.between != nil {
+					tt.between()
+				}
+
+				concept, err := cache.GetConcept(context.Background(), "CIEL", "CIEL", lookup, false, false)
+				if !last {
+					continue
+				}
+
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("ConceptCache.GetConcept() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				if errors.Is(err, domain.ErrConceptNotFound) != tt.wantNotFound {
+					t.Errorf("expected a missing concept %v, got %v", tt.wantNotFound, err)
+				}
+
+				if !tt.wantErr && concept.ID != lookup {
+					t.Errorf("expected concept %s, got %s", lookup, concept.ID)
+				}
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d lookups in OpenConceptLab, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestConceptCache_Persistence(t *testing.T) {
+	config := openconceptlab.ConceptCacheConfig{
+		Size: 10,
+		TTL:  time.Minute,
+		Path: filepath.Join(t.TempDir(), "concepts.json"),
+	}
+
+	ocl := fakeOCLMock.NewFakeOCLMock()
+
+	cache, err := openconceptlab.NewConceptCache(ocl, config)
+	if err != nil {
+		t.Fatalf("unable to create the cache: %v", err)
+	}
+
+	_, err = cache.GetConcept(context.Background(), "CIEL", "CIEL", "5088", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the cache is written in the background, not on each lookup
+	if _, err := os.Stat(config.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the cache not to be written on a lookup, got %v", err)
+	}
+
+	err = cache.Close()
+	if err != nil {
+		t.Fatalf("unable to close the cache: %v", err)
+	}
+
+	// OpenConceptLab cannot be reached when the cache is read again
+	ocl.MockGetConceptFn = func(ctx context.Context, org, source, concept string, includeMappings, includeInverseMappings bool) (*domain.Concept, error) {
+		return nil, fmt.Errorf("OCL API request error: connection refused")
+	}
+
+	reloaded, err := openconceptlab.NewConceptCache(ocl, config)
+	if err != nil {
+		t.Fatalf("unable to reload the cache: %v", err)
+	}
+
+	if reloaded.Len() != 1 {
+		t.Errorf("expected 1 concept to be reloaded, got %d", reloaded.Len())
+	}
+
+	concept, err := reloaded.GetConcept(context.Background(), "CIEL", "CIEL", "5088", false, false)
+	if err != nil {
+		t.Fatalf("expected the reloaded concept, got error: %v", err)
+	}
+
+	if concept.ID != "1234" {
+		t.Errorf("expected the concept that was cached, got %v", concept.ID)
+	}
+}
+
+func TestConceptCache_SaveInterval(t *testing.T) {
+	config := openconceptlab.ConceptCacheConfig{
+		Size:         10,
+		TTL:          time.Minute,
+		Path:         filepath.Join(t.TempDir(), "concepts.json"),
+		SaveInterval: 10 * time.Millisecond,
+	}
+
+	cache, err := openconceptlab.NewConceptCache(fakeOCLMock.NewFakeOCLMock(), config)
+	if err != nil {
+		t.Fatalf("unable to create the cache: %v", err)
+	}
+
+	defer func() {
+		_ = cache.Close()
+	}()
+
+	_, err = cache.GetConcept(context.Background(), "CIEL", "CIEL", "5088", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		_, err := os.Stat(config.Path)
+		if err == nil {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the cache to be written in the background, got %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,314 @@
+package openconceptlab
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// the formats of the concept exports that can be imported
+const (
+	// JSONExportFormat is an OpenConceptLab source version export, or a list of
+	// concepts returned by the OpenConceptLab API
+	JSONExportFormat = "json"
+
+	// CSVExportFormat is a CSV file with a header row. The `id` column is
+	// required
+	CSVExportFormat = "csv"
+)
+
+// namesSeparator separates the names of a concept in a CSV export
+const namesSeparator = "|"
+
+// OfflineConcept is an imported concept and the names it is searched by
+type OfflineConcept struct {
+	domain.Concept
+
+	// Names are the synonyms of the concept, besides its display name
+	Names []string `json:"names,omitempty"`
+}
+
+// OfflineSource is the file that an imported terminology source is kept in
+type OfflineSource struct {
+	Organisation string            `json:"organisation"`
+	Source       string            `json:"source"`
+	ImportedAt   time.Time         `json:"importedAt"`
+	Concepts     []*OfflineConcept `json:"concepts"`
+}
+
+// oclExportConcept is a concept in an OpenConceptLab export, whose names are
+// objects
+type oclExportConcept struct {
+	domain.Concept
+
+	Names []struct {
+		Name string `json:"name"`
+	} `json:"names"`
+}
+
+// ReadConceptExport reads the concepts of an OpenConceptLab JSON export or of
+// a CSV export
+func ReadConceptExport(r io.Reader, format string) ([]*OfflineConcept, error) {
+	switch strings.ToLower(format) {
+	case JSONExportFormat:
+		return readJSONExport(r)
+
+	case CSVExportFormat:
+		return readCSVExport(r)
+
+	default:
+		return nil, fmt.Errorf("unsupported concept export format %q", format)
+	}
+}
+
+// readJSONExport reads a source version export, which lists its concepts in
+// `concepts` and their mappings in `mappings`, or a list of concepts
+func readJSONExport(r io.Reader) ([]*OfflineConcept, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the concept export: %w", err)
+	}
+
+	exported := []*oclExportConcept{}
+	// the mappings of a source version export are listed apart from its concepts
+	mappings := []*domain.ConceptMapping{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &exported)
+	} else {
+		export := struct {
+			Concepts []*oclExportConcept      `json:"concepts"`
+			Mappings []*domain.ConceptMapping `json:"mappings"`
+		}{}
+
+		err = json.Unmarshal(data, &export)
+		exported = export.Concepts
+		mappings = export.Mappings
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the concept export: %w", err)
+	}
+
+	concepts := []*OfflineConcept{}
+
+	for _, concept := range exported {
+		if concept == nil {
+			continue
+		}
+
+		if concept.ID == "" {
+			return nil, fmt.Errorf("concept %d of the export has no id", len(concepts)+1)
+		}
+
+		offline := &OfflineConcept{Concept: concept.Concept}
+
+		for _, name := range concept.Names {
+			offline.addName(name.Name)
+		}
+
+		concepts = append(concepts, offline)
+	}
+
+	byID := map[string]*OfflineConcept{}
+	for _, concept := range concepts {
+		byID[concept.ID] = concept
+	}
+
+	for _, mapping := range mappings {
+		if mapping == nil {
+			continue
+		}
+
+		if concept, ok := byID[mapping.FromConceptCode]; ok {
+			concept.Mappings = append(concept.Mappings, mapping)
+		}
+	}
+
+	return concepts, nil
+}
+
+// readCSVExport reads a CSV export. Besides `id`, the `display_name` (or
+// `name`), `concept_class`, `datatype`, `display_locale`, `retired`, `owner`,
+// `source` and `names` columns are read. A concept's names are separated by `|`
+func readCSVExport(r io.Reader) ([]*OfflineConcept, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the header of the concept export: %w", err)
+	}
+
+	columns := map[string]int{}
+
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "name" {
+			column = "display_name"
+		}
+
+		columns[column] = i
+	}
+
+	if _, ok := columns["id"]; !ok {
+		return nil, fmt.Errorf("the concept export has no id column")
+	}
+
+	concepts := []*OfflineConcept{}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the concept export: %w", err)
+		}
+
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		if value("id") == "" {
+			return nil, fmt.Errorf("line %d of the concept export has no id", line)
+		}
+
+		concept := &OfflineConcept{
+			Concept: domain.Concept{
+				ID:            value("id"),
+				DisplayName:   value("display_name"),
+				ConceptClass:  value("concept_class"),
+				DataType:      value("datatype"),
+				DisplayLocale: value("display_locale"),
+				Owner:         value("owner"),
+				Source:        value("source"),
+			},
+		}
+
+		if retired := value("retired"); retired != "" {
+			concept.Retired, err = strconv.ParseBool(retired)
+			if err != nil {
+				return nil, fmt.Errorf("line %d of the concept export has an invalid retired value %q", line, retired)
+			}
+		}
+
+		for _, name := range strings.Split(value("names"), namesSeparator) {
+			concept.addName(name)
+		}
+
+		concepts = append(concepts, concept)
+	}
+
+	return concepts, nil
+}
+
+// addName adds a synonym of the concept, skipping blanks and duplicates
+func (c *OfflineConcept) addName(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, c.DisplayName) {
+		return
+	}
+
+	for _, existing := range c.Names {
+		if strings.EqualFold(existing, name) {
+			return
+		}
+	}
+
+	c.Names = append(c.Names, name)
+}
+
+// ImportConcepts keeps the concepts of a terminology source in the directory
+// of the offline terminology service. The concepts that were imported before
+// are replaced, and a running service reads the new concepts on its next lookup
+func ImportConcepts(directory string, organisation string, source string, concepts []*OfflineConcept, importedAt time.Time) error {
+	path, err := offlineSourcePath(directory, organisation, source)
+	if err != nil {
+		return err
+	}
+
+	for _, concept := range concepts {
+		if concept.Owner == "" {
+			concept.Owner = organisation
+		}
+
+		if concept.Source == "" {
+			concept.Source = source
+		}
+	}
+
+	data, err := json.Marshal(OfflineSource{
+		Organisation: organisation,
+		Source:       source,
+		ImportedAt:   importedAt,
+		Concepts:     concepts,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal the %s concepts: %w", source, err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("unable to create the directory of the %s concepts: %w", source, err)
+	}
+
+	err = writeFile(path, data)
+	if err != nil {
+		return fmt.Errorf("unable to save the %s concepts: %w", source, err)
+	}
+
+	return nil
+}
+
+// offlineSourcePath returns the file that the concepts of a terminology source
+// are kept in
+func offlineSourcePath(directory string, organisation string, source string) (string, error) {
+	for _, name := range []string{organisation, source} {
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return "", fmt.Errorf("invalid terminology organisation or source %q", name)
+		}
+	}
+
+	return filepath.Join(directory, organisation, source+".json"), nil
+}
+
+// writeFile replaces a file so that it is never left half written
+func writeFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,114 @@
+package openconceptlab_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab"
+)
+
+func TestReadConceptExport(t *testing.T) {
+	tests := []struct {
+		name      string
+		export    string
+		format    string
+		wantIDs   []string
+		wantNames []string
+		// wantMappings is the number of mappings of the first concept
+		wantMappings int
+		wantErr      bool
+	}{
+		{
+			name: "Happy case: OpenConceptLab source version export",
+			export: `{"type": "Source Version", "concepts": [
+				{"id": "5088", "display_name": "Temperature (C)", "concept_class": "Test", "datatype": "Numeric",
+				 "owner": "CIEL", "source": "CIEL", "names": [{"name": "Temperature (C)"}, {"name": "Body temperature"}]},
+				{"id": "5089", "display_name": "Weight (kg)", "owner": "CIEL", "source": "CIEL"}
+			], "mappings": [
+				{"map_type": "SAME-AS", "from_source_name": "CIEL", "from_concept_code": "5088",
+				 "to_source_name": "SNOMED-CT", "to_concept_code": "703421000"},
+				{"map_type": "SAME-AS", "from_source_name": "CIEL", "from_concept_code": "9999",
+				 "to_source_name": "SNOMED-CT", "to_concept_code": "27113001"}
+			]}`,
+			format:       openconceptlab.JSONExportFormat,
+			wantIDs:      []string{"5088", "5089"},
+			wantNames:    []string{"Body temperature"},
+			wantMappings: 1,
+		},
+		{
+			name:    "Happy case: list of concepts",
+			export:  `[{"id": "A15.1", "display_name": "Tuberculosis of lung, confirmed by culture only"}]`,
+			format:  "JSON",
+			wantIDs: []string{"A15.1"},
+		},
+		{
+			name: "Happy case: CSV export",
+			export: "ID,Name,Concept_Class,Datatype,Retired,Names\n" +
+				"5088,Temperature (C),Test,Numeric,false,Body temperature|Temp\n" +
+				"5089,Weight (kg),Test,Numeric,,\n",
+			format:    openconceptlab.CSVExportFormat,
+			wantIDs:   []string{"5088", "5089"},
+			wantNames: []string{"Body temperature", "Temp"},
+		},
+		{
+			name:    "Sad case: CSV export without an id column",
+			export:  "name\nTemperature (C)\n",
+			format:  openconceptlab.CSVExportFormat,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: CSV concept without an id",
+			export:  "id,name\n,Temperature (C)\n",
+			format:  openconceptlab.CSVExportFormat,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid retired value",
+			export:  "id,retired\n5088,sometimes\n",
+			format:  openconceptlab.CSVExportFormat,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid JSON",
+			export:  `{"concepts": [`,
+			format:  openconceptlab.JSONExportFormat,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unsupported format",
+			export:  "<concepts/>",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			concepts, err := openconceptlab.ReadConceptExport(strings.NewReader(tt.export), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadConceptExport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(concepts) != len(tt.wantIDs) {
+				t.Fatalf("expected %d concepts, got %d", len(tt.wantIDs), len(concepts))
+			}
+
+			for i, id := range tt.wantIDs {
+				if concepts[i].ID != id {
+					t.Errorf("expected concept %s, got %s", id, concepts[i].ID)
+				}
+			}
+
+			if strings.Join(concepts[0].Names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("expected the names %v, got %v", tt.wantNames, concepts[0].Names)
+			}
+
+			if len(concepts[0].Mappings) != tt.wantMappings {
+				t.Errorf("expected %d mappings, got %d", tt.wantMappings, len(concepts[0].Mappings))
+			}
+		})
+	}
+}
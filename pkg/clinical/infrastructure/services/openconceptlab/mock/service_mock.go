@@ -11,7 +11,7 @@ import (
 
 // FakeOCL is an mock of the Open concept lab
 type FakeOCL struct {
-	MockMakeRequestFn  func(method string, path string, params url.Values, body io.Reader) (*http.Response, error)
+	MockMakeRequestFn  func(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error)
 	MockListConceptsFn func(
 		ctx context.Context, org string, source string, verbose bool, q *string,
 		sortAsc *string, sortDesc *string, conceptClass *string, dataType *string,
@@ -25,7 +25,7 @@ type FakeOCL struct {
 // NewFakeOCLMock initializes a new instance of ocl mock
 func NewFakeOCLMock() *FakeOCL {
 	return &FakeOCL{
-		MockMakeRequestFn: func(method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
+		MockMakeRequestFn: func(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
 			return &http.Response{
 				StatusCode: 200,
 			}, nil
@@ -53,8 +53,8 @@ func NewFakeOCLMock() *FakeOCL {
 }
 
 // MakeRequest is a mock implementation of making a http request
-func (o *FakeOCL) MakeRequest(method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
-	return o.MockMakeRequestFn(method, path, params, body)
+func (o *FakeOCL) MakeRequest(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
+	return o.MockMakeRequestFn(ctx, method, path, params, body)
 }
 
 // ListConcepts is a mock implementation of listing concepts
@@ -0,0 +1,393 @@
+package openconceptlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+)
+
+// OCLOfflineDirectoryEnvVarName is the directory of the imported concepts.
+// When it is set, concepts are looked up in the imported files instead of
+// OpenConceptLab
+const OCLOfflineDirectoryEnvVarName = "OPENCONCEPTLAB_OFFLINE_DIRECTORY"
+
+// offlineSearchLimit is the most concepts that a search returns
+const offlineSearchLimit = 25
+
+// how well a concept matches a search
+const (
+	nameMatch = iota + 1
+	displayNameMatch
+	idMatch
+)
+
+// OfflineService looks up concepts in terminology sources that were imported
+// from OpenConceptLab or CSV exports, for sites that cannot rely on reaching
+// OpenConceptLab. Each source is read on its first lookup and read again when
+// it is imported again
+type OfflineService struct {
+	directory string
+
+	mu      sync.Mutex
+	sources map[string]*offlineIndex
+}
+
+// offlineIndex is an imported terminology source and the index of the words
+// in its concepts' names
+type offlineIndex struct {
+	modTime time.Time
+	size    int64
+
+	concepts []*OfflineConcept
+	byID     map[string]*OfflineConcept
+
+	// words are the sorted words of the concepts' names, so that the words
+	// that start with a search term are next to each other
+	words []string
+	// postings are the concepts that each word is in
+	postings map[string][]int
+}
+
+// NewOfflineService initializes a terminology service backed by the concepts
+// imported into a directory
+func NewOfflineService(directory string) (*OfflineService, error) {
+	info, err := os.Stat(directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the terminology directory: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("the terminology path %s is not a directory", directory)
+	}
+
+	return &OfflineService{
+		directory: directory,
+		sources:   map[string]*offlineIndex{},
+	}, nil
+}
+
+// MakeRequest is not supported because the service does not reach OpenConceptLab
+func (s *OfflineService) MakeRequest(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
+	return nil, fmt.Errorf("the offline terminology service cannot make OpenConceptLab requests")
+}
+
+// GetConcept looks up an imported concept by its id. Only the concept's own
+// mappings are imported, so inverse mappings are not returned
+func (s *OfflineService) GetConcept(
+	ctx context.Context, org string, source string, concept string,
+	includeMappings bool, includeInverseMappings bool) (*domain.Concept, error) {
+	index, err := s.source(org, source)
+	if err != nil {
+		return nil, err
+	}
+
+	found, ok := index.byID[concept]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s concept %s", domain.ErrConceptNotFound, source, concept)
+	}
+
+	return offlineResult(&found.Concept, includeMappings), nil
+}
+
+// ListConcepts searches the imported concepts. Every word of the query must
+// start a word in a concept's display name or synonyms, or the query must be
+// the concept's id. Concepts are ranked by how well they match unless a sort
+// by `id` or `name` is asked for
+func (s *OfflineService) ListConcepts(
+	ctx context.Context, org string, source string, verbose bool, q *string,
+	sortAsc *string, sortDesc *string, conceptClass *string, dataType *string,
+	locale *string, includeRetired *bool,
+	includeMappings *bool, includeInverseMappings *bool) ([]*domain.Concept, error) {
+	index, err := s.source(org, source)
+	if err != nil {
+		return nil, err
+	}
+
+	query := ""
+	if q != nil {
+		query = *q
+	}
+
+	scores := index.search(query)
+
+	classes := conceptClasses(conceptClass)
+	// the positions of the matching concepts in the source
+	matches := []int{}
+
+	for i, concept := range index.concepts {
+		if query != "" && scores[i] == 0 {
+			continue
+		}
+
+		if concept.Retired && (includeRetired == nil || !*includeRetired) {
+			continue
+		}
+
+		if len(classes) > 0 && !classes[strings.ToLower(concept.ConceptClass)] {
+			continue
+		}
+
+		if dataType != nil && *dataType != "" && !strings.EqualFold(concept.DataType, *dataType) {
+			continue
+		}
+
+		if locale != nil && *locale != "" && !strings.EqualFold(concept.DisplayLocale, *locale) {
+			continue
+		}
+
+		matches = append(matches, i)
+	}
+
+	less := func(a, b int) bool {
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+
+		return strings.ToLower(index.concepts[a].DisplayName) < strings.ToLower(index.concepts[b].DisplayName)
+	}
+
+	switch {
+	case sortAsc != nil && *sortAsc != "":
+		less = sortBy(index.concepts, *sortAsc, false, less)
+
+	case sortDesc != nil && *sortDesc != "":
+		less = sortBy(index.concepts, *sortDesc, true, less)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return less(matches[i], matches[j])
+	})
+
+	if len(matches) > offlineSearchLimit {
+		matches = matches[:offlineSearchLimit]
+	}
+
+	concepts := []*domain.Concept{}
+
+	for _, match := range matches {
+		concepts = append(concepts, offlineResult(&index.concepts[match].Concept, includeMappings != nil && *includeMappings))
+	}
+
+	return concepts, nil
+}
+
+// offlineResult returns a copy of an imported concept, with its mappings only
+// when they are asked for like OpenConceptLab does
+func offlineResult(concept *domain.Concept, includeMappings bool) *domain.Concept {
+	result := copyConcept(concept)
+
+	if !includeMappings {
+		result.Mappings = nil
+	}
+
+	return result
+}
+
+// source returns the index of an imported terminology source, reading the
+// source again when it was imported again
+func (s *OfflineService) source(org string, source string) (*offlineIndex, error) {
+	path, err := offlineSourcePath(s.directory, org, source)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("the %s source of %s has not been imported", source, org)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the %s concepts: %w", source, err)
+	}
+
+	key := org + "/" + source
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, ok := s.sources[key]
+	if ok && index.modTime.Equal(info.ModTime()) && index.size == info.Size() {
+		return index, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the %s concepts: %w", source, err)
+	}
+
+	imported := OfflineSource{}
+
+	err = json.Unmarshal(data, &imported)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the %s concepts: %w", source, err)
+	}
+
+	index = newOfflineIndex(imported.Concepts)
+	index.modTime = info.ModTime()
+	index.size = info.Size()
+
+	s.sources[key] = index
+
+	return index, nil
+}
+
+// newOfflineIndex indexes the words in the names of the concepts
+func newOfflineIndex(concepts []*OfflineConcept) *offlineIndex {
+	index := &offlineIndex{
+		concepts: concepts,
+		byID:     map[string]*OfflineConcept{},
+		postings: map[string][]int{},
+	}
+
+	for i, concept := range concepts {
+		index.byID[concept.ID] = concept
+
+		seen := map[string]bool{}
+
+		for _, name := range append([]string{concept.DisplayName}, concept.Names...) {
+			for _, word := range searchWords(name) {
+				if seen[word] {
+					continue
+				}
+
+				seen[word] = true
+
+				if _, ok := index.postings[word]; !ok {
+					index.words = append(index.words, word)
+				}
+
+				index.postings[word] = append(index.postings[word], i)
+			}
+		}
+	}
+
+	sort.Strings(index.words)
+
+	return index
+}
+
+// search scores how well each concept matches a query. Concepts that do not
+// match score zero
+func (i *offlineIndex) search(query string) []int {
+	scores := make([]int, len(i.concepts))
+
+	terms := searchWords(query)
+	if len(terms) == 0 {
+		return scores
+	}
+
+	var matching map[int]bool
+
+	for _, term := range terms {
+		found := map[int]bool{}
+
+		for w := sort.SearchStrings(i.words, term); w < len(i.words) && strings.HasPrefix(i.words[w], term); w++ {
+			for _, concept := range i.postings[i.words[w]] {
+				if matching == nil || matching[concept] {
+					found[concept] = true
+				}
+			}
+		}
+
+		matching = found
+	}
+
+	for concept := range matching {
+		scores[concept] = nameMatch
+
+		if matchesAll(searchWords(i.concepts[concept].DisplayName), terms) {
+			scores[concept] = displayNameMatch
+		}
+	}
+
+	for position, concept := range i.concepts {
+		if strings.EqualFold(concept.ID, strings.TrimSpace(query)) {
+			scores[position] = idMatch
+		}
+	}
+
+	return scores
+}
+
+// searchWords splits text into the lower case words that are searched
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchesAll reports whether every term starts one of the words
+func matchesAll(words []string, terms []string) bool {
+	for _, term := range terms {
+		matched := false
+
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// conceptClasses reads a concept class filter, which may list several classes
+// the way OpenConceptLab does e.g `"Symptom" OR "Diagnosis"`
+func conceptClasses(conceptClass *string) map[string]bool {
+	classes := map[string]bool{}
+
+	if conceptClass == nil {
+		return classes
+	}
+
+	for _, class := range strings.Split(*conceptClass, " OR ") {
+		class = strings.ToLower(strings.Trim(strings.TrimSpace(class), `"`))
+		if class != "" {
+			classes[class] = true
+		}
+	}
+
+	return classes
+}
+
+// sortBy sorts concepts by their `id` or `name`. Other fields keep the ranking
+func sortBy(concepts []*OfflineConcept, field string, descending bool, ranking func(a, b int) bool) func(a, b int) bool {
+	var key func(concept *OfflineConcept) string
+
+	switch strings.TrimPrefix(strings.ToLower(field), "_") {
+	case "id":
+		key = func(concept *OfflineConcept) string { return concept.ID }
+
+	case "name":
+		key = func(concept *OfflineConcept) string { return strings.ToLower(concept.DisplayName) }
+
+	default:
+		return ranking
+	}
+
+	return func(a, b int) bool {
+		if descending {
+			return key(concepts[a]) > key(concepts[b])
+		}
+
+		return key(concepts[a]) < key(concepts[b])
+	}
+}
@@ -0,0 +1,221 @@
+package openconceptlab_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab"
+)
+
+// offlineConcepts is a small CIEL export
+const offlineConcepts = "id,name,concept_class,datatype,retired,names\n" +
+	"5088,Temperature (C),Test,Numeric,false,Body temperature\n" +
+	"5089,Weight (kg),Test,Numeric,false,Body weight\n" +
+	"5090,Height (cm),Test,Numeric,false,\n" +
+	"140238,Fever,Symptom,N/A,false,Pyrexia\n" +
+	"117399,Hypertension,Diagnosis,N/A,false,High blood pressure\n" +
+	"1000,Old temperature,Test,Numeric,true,\n"
+
+func newOfflineService(t *testing.T) (*openconceptlab.OfflineService, string) {
+	t.Helper()
+
+	directory := t.TempDir()
+
+	concepts, err := openconceptlab.ReadConceptExport(strings.NewReader(offlineConcepts), openconceptlab.CSVExportFormat)
+	if err != nil {
+		t.Fatalf("unable to read the concepts: %v", err)
+	}
+
+	err = openconceptlab.ImportConcepts(directory, "CIEL", "CIEL", concepts, time.Now())
+	if err != nil {
+		t.Fatalf("unable to import the concepts: %v", err)
+	}
+
+	service, err := openconceptlab.NewOfflineService(directory)
+	if err != nil {
+		t.Fatalf("unable to initialize the offline service: %v", err)
+	}
+
+	return service, directory
+}
+
+func TestOfflineService_GetConcept(t *testing.T) {
+	service, _ := newOfflineService(t)
+
+	tests := []struct {
+		name         string
+		org          string
+		source       string
+		concept      string
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{
+			name:    "Happy case: imported concept",
+			org:     "CIEL",
+			source:  "CIEL",
+			concept: "5088",
+		},
+		{
+			name:         "Sad case: concept that was not imported",
+			org:          "CIEL",
+			source:       "CIEL",
+			concept:      "9999",
+			wantNotFound: true,
+			wantErr:      true,
+		},
+		{
+			name:    "Sad case: source that was not imported",
+			org:     "WHO",
+			source:  "ICD-10-WHO",
+			concept: "A15.1",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid source",
+			org:     "CIEL",
+			source:  "../CIEL",
+			concept: "5088",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			concept, err := service.GetConcept(context.Background(), tt.org, tt.source, tt.concept, false, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OfflineService.GetConcept() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if errors.Is(err, domain.ErrConceptNotFound) != tt.wantNotFound {
+				t.Errorf("expected a missing concept %v, got %v", tt.wantNotFound, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if concept.ID != tt.concept || concept.Owner != "CIEL" || concept.Source != "CIEL" {
+				t.Errorf("unexpected concept %+v", concept)
+			}
+		})
+	}
+}
+
+func TestOfflineService_ListConcepts(t *testing.T) {
+	service, _ := newOfflineService(t)
+
+	retired := true
+	symptomOrDiagnosis := `"Symptom" OR "Diagnosis"`
+	name := "name"
+	id := "id"
+
+	tests := []struct {
+		name           string
+		q              string
+		conceptClass   *string
+		includeRetired *bool
+		sortAsc        *string
+		sortDesc       *string
+		wantIDs        []string
+	}{
+		{
+			name:    "Happy case: every word must match",
+			q:       "body t",
+			wantIDs: []string{"5088"},
+		},
+		{
+			name:    "Happy case: words are matched by their start",
+			q:       "temp",
+			wantIDs: []string{"5088"},
+		},
+		{
+			name:           "Happy case: retired concepts are included when asked for",
+			q:              "temp",
+			includeRetired: &retired,
+			wantIDs:        []string{"1000", "5088"},
+		},
+		{
+			name:    "Happy case: synonyms are searched",
+			q:       "pyrexia",
+			wantIDs: []string{"140238"},
+		},
+		{
+			name:    "Happy case: concept id",
+			q:       "117399",
+			wantIDs: []string{"117399"},
+		},
+		{
+			name:         "Happy case: concept classes",
+			conceptClass: &symptomOrDiagnosis,
+			sortAsc:      &name,
+			wantIDs:      []string{"140238", "117399"},
+		},
+		{
+			name:     "Happy case: sorted by id",
+			q:        "body",
+			sortDesc: &id,
+			wantIDs:  []string{"5089", "5088"},
+		},
+		{
+			name:    "Happy case: nothing matches",
+			q:       "malaria",
+			wantIDs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q *string
+			if tt.q != "" {
+				q = &tt.q
+			}
+
+			concepts, err := service.ListConcepts(
+				context.Background(), "CIEL", "CIEL", true, q, tt.sortAsc, tt.sortDesc,
+				tt.conceptClass, nil, nil, tt.includeRetired, nil, nil,
+			)
+			if err != nil {
+				t.Fatalf("OfflineService.ListConcepts() error = %v", err)
+			}
+
+			got := []string{}
+			for _, concept := range concepts {
+				got = append(got, concept.ID)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("OfflineService.ListConcepts() = %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestOfflineService_Refresh(t *testing.T) {
+	service, directory := newOfflineService(t)
+
+	_, err := service.GetConcept(context.Background(), "CIEL", "CIEL", "5092", false, false)
+	if !errors.Is(err, domain.ErrConceptNotFound) {
+		t.Fatalf("expected the concept to be missing, got %v", err)
+	}
+
+	concepts := []*openconceptlab.OfflineConcept{
+		{Concept: domain.Concept{ID: "5092", DisplayName: "Blood oxygen saturation"}},
+	}
+
+	err = openconceptlab.ImportConcepts(directory, "CIEL", "CIEL", concepts, time.Now())
+	if err != nil {
+		t.Fatalf("unable to import the concepts: %v", err)
+	}
+
+	concept, err := service.GetConcept(context.Background(), "CIEL", "CIEL", "5092", false, false)
+	if err != nil {
+		t.Fatalf("expected the imported concept, got error: %v", err)
+	}
+
+	if concept.DisplayName != "Blood oxygen saturation" {
+		t.Errorf("unexpected concept %+v", concept)
+	}
+}
@@ -25,15 +25,17 @@ const (
 	OCLAPITimeoutSeconds = 30
 )
 
-// NewServiceOCL creates a new open conceptlab Service
-func NewServiceOCL() *Service {
+// NewServiceOCL creates a new open conceptlab Service. Requests are sent
+// through the given transport, or `http.DefaultTransport` if it is nil
+func NewServiceOCL(transport http.RoundTripper) *Service {
 	baseURL := serverutils.MustGetEnvVar(OCLAPIURLEnvVarName)
 	token := serverutils.MustGetEnvVar(OCLTokenEnvVarName)
 	header := fmt.Sprintf("Authorization: Token %s", token)
 
 	srv := &Service{
-		baseURL: baseURL,
-		header:  header,
+		baseURL:    baseURL,
+		header:     header,
+		httpClient: &http.Client{Transport: transport, Timeout: time.Second * OCLAPITimeoutSeconds},
 	}
 	srv.enforcePreconditions()
 
@@ -42,8 +44,9 @@ func NewServiceOCL() *Service {
 
 // Service is an OpenConceptLab service
 type Service struct {
-	baseURL string
-	header  string
+	baseURL    string
+	header     string
+	httpClient *http.Client
 }
 
 func (s Service) enforcePreconditions() {
@@ -57,10 +60,10 @@ func (s Service) enforcePreconditions() {
 }
 
 // MakeRequest composes an authenticated OCL request that has the correct content type
-func (s Service) MakeRequest(method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
+func (s Service) MakeRequest(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s/%s/?%s", s.baseURL, path, params.Encode())
 
-	req, reqErr := http.NewRequest(method, url, body)
+	req, reqErr := http.NewRequestWithContext(ctx, method, url, body)
 	if reqErr != nil {
 		return nil, reqErr
 	}
@@ -69,8 +72,7 @@ func (s Service) MakeRequest(method string, path string, params url.Values, body
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", s.header)
 
-	httpClient := &http.Client{Timeout: time.Second * OCLAPITimeoutSeconds}
-	resp, respErr := httpClient.Do(req)
+	resp, respErr := s.httpClient.Do(req)
 
 	if respErr != nil {
 		return nil, respErr
@@ -83,7 +85,7 @@ func (s Service) MakeRequest(method string, path string, params url.Values, body
 // The URL that is composed follows this pattern: GET /orgs/:org/sources/:source/[:sourceVersion/]concepts/:concept/
 // e.g GET /orgs/WHO/sources/ICD-10-2010/concepts/A15.1/?includeInverseMappings=true
 func (s Service) GetConcept(
-	_ context.Context, org string, source string, concept string,
+	ctx context.Context, org string, source string, concept string,
 	includeMappings bool, includeInverseMappings bool) (*domain.Concept, error) {
 	s.enforcePreconditions()
 
@@ -91,15 +93,19 @@ func (s Service) GetConcept(
 
 	params := url.Values{}
 	params.Add("includeMappings", strconv.FormatBool(includeMappings))
-	params.Add("includeMappings", strconv.FormatBool(includeInverseMappings))
+	params.Add("includeInverseMappings", strconv.FormatBool(includeInverseMappings))
 
-	resp, err := s.MakeRequest("GET", path, params, nil)
+	resp, err := s.MakeRequest(ctx, http.MethodGet, path, params, nil)
 
 	if err != nil {
 		return nil, fmt.Errorf("OCL API request error: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s concept %s", domain.ErrConceptNotFound, source, concept)
+	}
+
 	output := make(map[string]interface{})
 
 	data, err := io.ReadAll(resp.Body)
@@ -133,7 +139,7 @@ func (s Service) GetConcept(
 // The URL that is composed follows this pattern: GET /orgs/:org/sources/:source/[:sourceVersion/]concepts/
 // e.g GET /orgs/PEPFAR-Test7/sources/MER/concepts/?conceptClass="Symptom"+OR+"Diagnosis"
 func (s Service) ListConcepts(
-	_ context.Context, org string, source string, verbose bool, q *string,
+	ctx context.Context, org string, source string, verbose bool, q *string,
 	sortAsc *string, sortDesc *string, conceptClass *string, dataType *string,
 	locale *string, includeRetired *bool,
 	includeMappings *bool, includeInverseMappings *bool) ([]*domain.Concept, error) {
@@ -184,7 +190,7 @@ func (s Service) ListConcepts(
 		params.Add("includeReverseMappings", "true")
 	}
 
-	resp, err := s.MakeRequest("GET", path, params, nil)
+	resp, err := s.MakeRequest(ctx, http.MethodGet, path, params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("OCL API request error: %w", err)
 	}
@@ -75,7 +75,7 @@ func TestService_GetConcept(t *testing.T) {
 				)
 			}
 
-			s := openconceptlab.NewServiceOCL()
+			s := openconceptlab.NewServiceOCL(nil)
 			got, err := s.GetConcept(tt.args.ctx, tt.args.org, tt.args.source, tt.args.concept, tt.args.includeMappings, tt.args.includeInverseMappings)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.GetConcept() error = %v, wantErr %v", err, tt.wantErr)
@@ -182,7 +182,7 @@ func TestService_ListConcepts(t *testing.T) {
 				)
 			}
 
-			s := openconceptlab.NewServiceOCL()
+			s := openconceptlab.NewServiceOCL(nil)
 
 			got, err := s.ListConcepts(tt.args.ctx, tt.args.org, tt.args.source, tt.args.verbose, tt.args.q, tt.args.sortAsc, tt.args.sortDesc, tt.args.conceptClass, tt.args.dataType, tt.args.locale, tt.args.includeRetired, tt.args.includeMappings, tt.args.includeInverseMappings)
 			if (err != nil) != tt.wantErr {
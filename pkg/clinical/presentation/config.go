@@ -2,9 +2,14 @@ package presentation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -16,17 +21,21 @@ import (
 	"github.com/savannahghi/clinical/pkg/clinical/application/common"
 	"github.com/savannahghi/clinical/pkg/clinical/application/extensions"
 	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore"
 	fhir "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare"
 	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/fhirdataset"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/httpclient"
 	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub"
 	"github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab"
 	pubsubmessaging "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/pubsub"
 	"github.com/savannahghi/clinical/pkg/clinical/presentation/graph"
 	"github.com/savannahghi/clinical/pkg/clinical/presentation/graph/generated"
 	"github.com/savannahghi/clinical/pkg/clinical/presentation/rest"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
 	"github.com/savannahghi/clinical/pkg/clinical/usecases"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
 	"github.com/savannahghi/serverutils"
-	"google.golang.org/api/healthcare/v1"
+	"go.opencensus.io/stats/view"
 )
 
 // ClinicalAllowedOrigins is a list of CORS origins allowed to interact with
@@ -72,24 +81,99 @@ func StartServer(
 		serverutils.LogStartupError(ctx, fmt.Errorf("unable to initialize pubsub client: %w", err))
 	}
 
-	project := serverutils.MustGetEnvVar(serverutils.GoogleCloudProjectIDEnvVarName)
 	_ = serverutils.MustGetEnvVar("CLOUD_HEALTH_PUBSUB_TOPIC")
-	datasetID := serverutils.MustGetEnvVar("CLOUD_HEALTH_DATASET_ID")
-	datasetLocation := serverutils.MustGetEnvVar("CLOUD_HEALTH_DATASET_LOCATION")
-	fhirStoreID := serverutils.MustGetEnvVar("CLOUD_HEALTH_FHIRSTORE_ID")
 
-	hsv, err := healthcare.NewService(ctx)
+	transportConfig, err := httpclient.ConfigFromEnv()
 	if err != nil {
-		log.Panicf("unable to initialize new Google Cloud Healthcare Service: %s", err)
+		log.Panicf("unable to configure the HTTP transport: %s", err)
+	}
+
+	// shared by the clients of every upstream so that each upstream has a
+	// single circuit breaker
+	transport := httpclient.NewTransport(transportConfig, nil)
+
+	repo, err := datastore.NewFHIRDataset(ctx, transport)
+	if err != nil {
+		log.Panicf("unable to initialize the FHIR dataset: %s", err)
+	}
+
+	err = view.Register(fhirdataset.Views...)
+	if err != nil {
+		serverutils.LogStartupError(ctx, fmt.Errorf("unable to register FHIR metrics views: %w", err))
+	}
+
+	err = view.Register(fhir.OrganizationCacheViews...)
+	if err != nil {
+		serverutils.LogStartupError(ctx, fmt.Errorf("unable to register organization cache metrics views: %w", err))
+	}
+
+	err = view.Register(openconceptlab.ConceptCacheViews...)
+	if err != nil {
+		serverutils.LogStartupError(ctx, fmt.Errorf("unable to register concept cache metrics views: %w", err))
+	}
+
+	stopExporters, err := serverutils.EnableStatsAndTraceExporters(ctx, "clinical")
+	if err != nil {
+		serverutils.LogStartupError(ctx, err)
+	} else {
+		defer stopExporters()
+	}
+
+	_, err = common.LoadDefaultLocation()
+	if err != nil {
+		log.Panicf("unable to load the default time zone: %s", err)
+	}
+
+	organizationCacheTTL, err := organizationCacheTTL()
+	if err != nil {
+		log.Panicf("unable to configure the organization cache: %s", err)
+	}
+
+	store := fhir.NewFHIRStoreImpl(repo)
+
+	var fhirStore repository.FHIR = store
+
+	// shared by the tenant middleware, the usecases and the store, which reads
+	// the time zones of the facilities that times are recorded in
+	if organizationCacheTTL > 0 {
+		organizationCache := fhir.NewOrganizationCache(store, organizationCacheTTL)
+		store.Organizations = organizationCache
+		fhirStore = organizationCache
+	}
+
+	conceptCacheConfig, err := conceptCacheConfig()
+	if err != nil {
+		log.Panicf("unable to configure the concept cache: %s", err)
+	}
+
+	var ocl infrastructure.ServiceOCL
+
+	// closed when the server stops
+	var conceptCache *openconceptlab.ConceptCache
+
+	// sites that cannot rely on reaching OpenConceptLab use imported concepts
+	if directory := os.Getenv(openconceptlab.OCLOfflineDirectoryEnvVarName); directory != "" {
+		ocl, err = openconceptlab.NewOfflineService(directory)
+		if err != nil {
+			log.Panicf("unable to initialize the offline terminology service: %s", err)
+		}
+	} else {
+		ocl = openconceptlab.NewServiceOCL(transport)
+
+		if conceptCacheConfig.TTL > 0 {
+			conceptCache, err = openconceptlab.NewConceptCache(ocl, conceptCacheConfig)
+			if err != nil {
+				log.Panicf("unable to initialize the concept cache: %s", err)
+			}
+
+			ocl = conceptCache
+		}
 	}
 
-	repo := fhirdataset.NewFHIRRepository(ctx, hsv, project, datasetID, datasetLocation, fhirStoreID)
-	fhir := fhir.NewFHIRStoreImpl(repo)
-	ocl := openconceptlab.NewServiceOCL()
 	myCareHubClient := common.NewInterServiceClient("mycarehub", baseExtension)
 	mycarehub := mycarehub.NewServiceMyCareHub(myCareHubClient)
 
-	infrastructure := infrastructure.NewInfrastructureInteractor(baseExtension, fhir, ocl, mycarehub)
+	infrastructure := infrastructure.NewInfrastructureInteractor(baseExtension, fhirStore, ocl, mycarehub)
 
 	_, err = pubsubmessaging.NewServicePubSubMessaging(ctx, pubSubClient, baseExtension)
 	if err != nil {
@@ -110,17 +194,142 @@ func StartServer(
 		serverutils.LogStartupError(ctx, err)
 	}
 
+	_, err = clinicalUsecase.BulkExportTTL()
+	if err != nil {
+		log.Panicf("unable to configure bulk exports: %s", err)
+	}
+
 	usecases := usecases.NewUsecasesInteractor(infrastructure)
 
 	r := gin.Default()
 
 	SetupRoutes(r, authclient, usecases, infrastructure)
 
-	addr := fmt.Sprintf(":%d", port)
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: r,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err := r.Run(addr); err != nil {
+	purgesStopped, err := startRetentionPurges(ctx, usecases)
+	if err != nil {
+		log.Panicf("unable to schedule retention purges: %s", err)
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Printf("unable to stop the server: %s", err)
+		}
+	}()
+
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		serverutils.LogStartupError(ctx, err)
 	}
+
+	// the server may have failed to start rather than been stopped
+	stop()
+	<-stopped
+	<-purgesStopped
+
+	if conceptCache != nil {
+		err = conceptCache.Close()
+		if err != nil {
+			log.Printf("unable to close the concept cache: %s", err)
+		}
+	}
+}
+
+// shutdownTimeout is how long the requests in progress are waited for when the
+// server stops
+const shutdownTimeout = 10 * time.Second
+
+// defaultOrganizationCacheTTL is how long organizations are cached when
+// `ORGANIZATION_CACHE_TTL` is not set
+const defaultOrganizationCacheTTL = 5 * time.Minute
+
+// organizationCacheTTL returns how long organizations are cached. The cache is
+// off when it is zero
+func organizationCacheTTL() (time.Duration, error) {
+	value := os.Getenv(common.OrganizationCacheTTLEnvVar)
+	if value == "" {
+		return defaultOrganizationCacheTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid organization cache TTL %q", value)
+	}
+
+	return ttl, nil
+}
+
+// the concept cache defaults used when its environment variables are not set
+const (
+	defaultConceptCacheTTL         = 24 * time.Hour
+	defaultConceptCacheNegativeTTL = 10 * time.Minute
+	defaultConceptCacheSize        = 10000
+)
+
+// conceptCacheConfig returns the configuration of the OpenConceptLab concept
+// cache. The cache is off when its TTL is zero
+func conceptCacheConfig() (openconceptlab.ConceptCacheConfig, error) {
+	config := openconceptlab.ConceptCacheConfig{
+		TTL:         defaultConceptCacheTTL,
+		NegativeTTL: defaultConceptCacheNegativeTTL,
+		Size:        defaultConceptCacheSize,
+		Path:        os.Getenv(common.OCLCachePathEnvVar),
+	}
+
+	if value := os.Getenv(common.OCLCacheTTLEnvVar); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return config, fmt.Errorf("invalid concept cache TTL %q", value)
+		}
+
+		config.TTL = ttl
+	}
+
+	if value := os.Getenv(common.OCLCacheNegativeTTLEnvVar); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return config, fmt.Errorf("invalid concept cache negative TTL %q", value)
+		}
+
+		config.NegativeTTL = ttl
+	}
+
+	if value := os.Getenv(common.OCLCacheSaveIntervalEnvVar); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return config, fmt.Errorf("invalid concept cache save interval %q", value)
+		}
+
+		config.SaveInterval = interval
+	}
+
+	if value := os.Getenv(common.OCLCacheSizeEnvVar); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return config, fmt.Errorf("invalid concept cache size %q", value)
+		}
+
+		config.Size = size
+	}
+
+	return config, nil
 }
 
 func SetupRoutes(r *gin.Engine, authclient *authutils.Client, usecases usecases.Interactor, infra infrastructure.Infrastructure) {
@@ -172,6 +381,21 @@ func SetupRoutes(r *gin.Engine, authclient *authutils.Client, usecases usecases.
 
 	facilities := v1.Group("/facilities")
 	facilities.POST("", handlers.RegisterFacility)
+
+	exports := v1.Group("")
+	exports.Use(rest.TenantIdentifierExtractionMiddleware(infra.FHIR))
+	exports.GET("/$export", handlers.StartBulkExport)
+	exports.GET("/bulk-exports/:id", handlers.GetBulkExport)
+	exports.GET("/bulk-exports/:id/:resourceType", handlers.DownloadBulkExportFile)
+	exports.DELETE("/bulk-exports/:id", handlers.DeleteBulkExport)
+
+	imports := v1.Group("")
+	imports.Use(rest.TenantIdentifierExtractionMiddleware(infra.FHIR))
+	imports.POST("/$import", handlers.BulkImport)
+
+	patients := v1.Group("/patients")
+	patients.Use(rest.TenantIdentifierExtractionMiddleware(infra.FHIR))
+	patients.GET("/:id/$everything", handlers.ExportPatientRecord)
 }
 
 // GQLHandler sets up a GraphQL resolver
@@ -188,6 +412,7 @@ func GQLHandler(service usecases.Interactor) gin.HandlerFunc {
 			},
 		),
 	)
+	server.SetErrorPresenter(graph.ErrorPresenter)
 
 	return func(ctx *gin.Context) {
 		server.ServeHTTP(ctx.Writer, ctx.Request)
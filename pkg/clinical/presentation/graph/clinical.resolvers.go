@@ -6,8 +6,10 @@ package graph
 
 import (
 	"context"
+	"time"
 
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/clinical/pkg/clinical/presentation/graph/generated"
 )
 
@@ -19,9 +21,9 @@ func (r *mutationResolver) CreateEpisodeOfCare(ctx context.Context, episodeOfCar
 }
 
 // EndEpisodeOfCare is the resolver for the endEpisodeOfCare field.
-func (r *mutationResolver) EndEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error) {
+func (r *mutationResolver) EndEpisodeOfCare(ctx context.Context, id string, endTime *time.Time) (*dto.EpisodeOfCare, error) {
 	r.CheckDependencies()
-	return r.usecases.EndEpisodeOfCare(ctx, id)
+	return r.usecases.EndEpisodeOfCare(ctx, id, endTime)
 }
 
 // StartEncounter is the resolver for the startEncounter field.
@@ -31,9 +33,9 @@ func (r *mutationResolver) StartEncounter(ctx context.Context, episodeID string)
 }
 
 // EndEncounter is the resolver for the endEncounter field.
-func (r *mutationResolver) EndEncounter(ctx context.Context, encounterID string) (bool, error) {
+func (r *mutationResolver) EndEncounter(ctx context.Context, encounterID string, endTime *time.Time) (bool, error) {
 	r.CheckDependencies()
-	return r.usecases.Clinical.EndEncounter(ctx, encounterID)
+	return r.usecases.Clinical.EndEncounter(ctx, encounterID, endTime)
 }
 
 // RecordTemperature is the resolver for the recordTemperature field.
@@ -85,6 +87,42 @@ func (r *mutationResolver) CreatePatient(ctx context.Context, input dto.PatientI
 	return r.usecases.CreatePatient(ctx, input)
 }
 
+// RetirePatient is the resolver for the retirePatient field.
+func (r *mutationResolver) RetirePatient(ctx context.Context, input domain.RetirePatientInput) (*dto.Patient, error) {
+	r.CheckDependencies()
+
+	return r.usecases.RetirePatient(ctx, input)
+}
+
+// ReinstatePatient is the resolver for the reinstatePatient field.
+func (r *mutationResolver) ReinstatePatient(ctx context.Context, id string) (*dto.Patient, error) {
+	r.CheckDependencies()
+
+	return r.usecases.ReinstatePatient(ctx, id)
+}
+
+// MergePatients is the resolver for the mergePatients field.
+func (r *mutationResolver) MergePatients(ctx context.Context, sourceID string, targetID string) (*dto.Patient, error) {
+	r.CheckDependencies()
+
+	return r.usecases.MergePatients(ctx, sourceID, targetID)
+}
+
+// UnmergePatient is the resolver for the unmergePatient field.
+func (r *mutationResolver) UnmergePatient(ctx context.Context, sourceID string) (*dto.Patient, error) {
+	r.CheckDependencies()
+
+	return r.usecases.UnmergePatient(ctx, sourceID)
+}
+
+// DeletePatient is the resolver for the deletePatient field.
+func (r *mutationResolver) DeletePatient(ctx context.Context, id string, dryRun *bool) (*dto.PatientDeletion, error) {
+	r.CheckDependencies()
+
+	// nothing is deleted unless it is asked for
+	return r.usecases.DeletePatient(ctx, id, dryRun == nil || *dryRun)
+}
+
 // CreateCondition is the resolver for the createCondition field.
 func (r *mutationResolver) CreateCondition(ctx context.Context, input dto.ConditionInput) (*dto.Condition, error) {
 	r.CheckDependencies()
@@ -110,6 +148,13 @@ func (r *queryResolver) GetMedicalData(ctx context.Context, patientID string) (*
 	return r.usecases.GetMedicalData(ctx, patientID)
 }
 
+// ExportPatientRecord is the resolver for the exportPatientRecord field.
+func (r *queryResolver) ExportPatientRecord(ctx context.Context, patientID string) (map[string]interface{}, error) {
+	r.CheckDependencies()
+
+	return r.usecases.ExportPatientRecord(ctx, patientID)
+}
+
 // GetEpisodeOfCare is the resolver for the getEpisodeOfCare field.
 func (r *queryResolver) GetEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error) {
 	r.CheckDependencies()
@@ -122,6 +167,13 @@ func (r *queryResolver) ListPatientConditions(ctx context.Context, patientID str
 	return r.usecases.ListPatientConditions(ctx, patientID, pagination)
 }
 
+// ConditionHistory is the resolver for the conditionHistory field.
+func (r *queryResolver) ConditionHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.ConditionHistoryConnection, error) {
+	r.CheckDependencies()
+
+	return r.usecases.ConditionHistory(ctx, id, pagination)
+}
+
 // ListPatientEncounters is the resolver for the listPatientEncounters field.
 func (r *queryResolver) ListPatientEncounters(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.EncounterConnection, error) {
 	r.CheckDependencies()
@@ -189,6 +241,20 @@ func (r *queryResolver) ListPatientAllergies(ctx context.Context, patientID stri
 	return r.usecases.ListPatientAllergies(ctx, patientID, pagination)
 }
 
+// AllergyHistory is the resolver for the allergyHistory field.
+func (r *queryResolver) AllergyHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.AllergyHistoryConnection, error) {
+	r.CheckDependencies()
+
+	return r.usecases.AllergyHistory(ctx, id, pagination)
+}
+
+// TranslateConcept is the resolver for the translateConcept field.
+func (r *queryResolver) TranslateConcept(ctx context.Context, code string, from dto.TerminologySource, to dto.TerminologySource) ([]*dto.ConceptTranslation, error) {
+	r.CheckDependencies()
+
+	return r.usecases.TranslateConcept(ctx, code, from, to)
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ConflictErrorCode is the `extensions.code` of errors caused by a record being
+// changed by someone else while it was being updated. The client can fetch the
+// record again and retry.
+const ConflictErrorCode = "CONFLICT"
+
+// ErrorPresenter presents resolver errors to GraphQL clients. Errors that a
+// client can act on are tagged with a code in the error's extensions.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	if errors.Is(err, domain.ErrFHIRVersionConflict) {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+
+		gqlErr.Extensions["code"] = ConflictErrorCode
+	}
+
+	return gqlErr
+}
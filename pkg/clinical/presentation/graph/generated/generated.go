@@ -10,11 +10,13 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
 	"github.com/99designs/gqlgen/plugin/federation/fedruntime"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/scalarutils"
 	gqlparser "github.com/vektah/gqlparser/v2"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -66,6 +68,31 @@ type ComplexityRoot struct {
 		Node   func(childComplexity int) int
 	}
 
+	AllergyHistoryConnection struct {
+		Edges      func(childComplexity int) int
+		PageInfo   func(childComplexity int) int
+		TotalCount func(childComplexity int) int
+	}
+
+	AllergyVersion struct {
+		Allergy   func(childComplexity int) int
+		Author    func(childComplexity int) int
+		Timestamp func(childComplexity int) int
+		VersionID func(childComplexity int) int
+	}
+
+	AllergyVersionEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
+
+	ConceptTranslation struct {
+		Code        func(childComplexity int) int
+		Equivalence func(childComplexity int) int
+		Name        func(childComplexity int) int
+		System      func(childComplexity int) int
+	}
+
 	Condition struct {
 		Code         func(childComplexity int) int
 		EncounterID  func(childComplexity int) int
@@ -90,8 +117,27 @@ type ComplexityRoot struct {
 		Node   func(childComplexity int) int
 	}
 
+	ConditionHistoryConnection struct {
+		Edges      func(childComplexity int) int
+		PageInfo   func(childComplexity int) int
+		TotalCount func(childComplexity int) int
+	}
+
+	ConditionVersion struct {
+		Author    func(childComplexity int) int
+		Condition func(childComplexity int) int
+		Timestamp func(childComplexity int) int
+		VersionID func(childComplexity int) int
+	}
+
+	ConditionVersionEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
+
 	Encounter struct {
 		Class           func(childComplexity int) int
+		Duration        func(childComplexity int) int
 		EpisodeOfCareID func(childComplexity int) int
 		ID              func(childComplexity int) int
 		PatientID       func(childComplexity int) int
@@ -146,8 +192,10 @@ type ComplexityRoot struct {
 		CreateCondition          func(childComplexity int, input dto.ConditionInput) int
 		CreateEpisodeOfCare      func(childComplexity int, episodeOfCare dto.EpisodeOfCareInput) int
 		CreatePatient            func(childComplexity int, input dto.PatientInput) int
-		EndEncounter             func(childComplexity int, encounterID string) int
-		EndEpisodeOfCare         func(childComplexity int, id string) int
+		DeletePatient            func(childComplexity int, id string, dryRun *bool) int
+		EndEncounter             func(childComplexity int, encounterID string, endTime *time.Time) int
+		EndEpisodeOfCare         func(childComplexity int, id string, endTime *time.Time) int
+		MergePatients            func(childComplexity int, sourceID string, targetID string) int
 		RecordBloodPressure      func(childComplexity int, input dto.ObservationInput) int
 		RecordBmi                func(childComplexity int, input dto.ObservationInput) int
 		RecordHeight             func(childComplexity int, input dto.ObservationInput) int
@@ -155,7 +203,10 @@ type ComplexityRoot struct {
 		RecordRespiratoryRate    func(childComplexity int, input dto.ObservationInput) int
 		RecordTemperature        func(childComplexity int, input dto.ObservationInput) int
 		RecordWeight             func(childComplexity int, input dto.ObservationInput) int
+		ReinstatePatient         func(childComplexity int, id string) int
+		RetirePatient            func(childComplexity int, input domain.RetirePatientInput) int
 		StartEncounter           func(childComplexity int, episodeID string) int
+		UnmergePatient           func(childComplexity int, sourceID string) int
 	}
 
 	Observation struct {
@@ -179,11 +230,26 @@ type ComplexityRoot struct {
 		BirthDate   func(childComplexity int) int
 		Gender      func(childComplexity int) int
 		ID          func(childComplexity int) int
+		MergedInto  func(childComplexity int) int
 		Name        func(childComplexity int) int
 		PhoneNumber func(childComplexity int) int
+		Retirement  func(childComplexity int) int
+	}
+
+	PatientDeletion struct {
+		Deleted func(childComplexity int) int
+		DryRun  func(childComplexity int) int
+	}
+
+	PatientRetirement struct {
+		Reason    func(childComplexity int) int
+		RetiredAt func(childComplexity int) int
 	}
 
 	Query struct {
+		AllergyHistory                   func(childComplexity int, id string, pagination dto.Pagination) int
+		ConditionHistory                 func(childComplexity int, id string, pagination dto.Pagination) int
+		ExportPatientRecord              func(childComplexity int, patientID string) int
 		GetAllergy                       func(childComplexity int, id string) int
 		GetEpisodeOfCare                 func(childComplexity int, id string) int
 		GetMedicalData                   func(childComplexity int, patientID string) int
@@ -199,6 +265,7 @@ type ComplexityRoot struct {
 		ListPatientEncounters            func(childComplexity int, patientID string, pagination dto.Pagination) int
 		PatientHealthTimeline            func(childComplexity int, input dto.HealthTimelineInput) int
 		SearchAllergy                    func(childComplexity int, name string) int
+		TranslateConcept                 func(childComplexity int, code string, from dto.TerminologySource, to dto.TerminologySource) int
 		__resolve__service               func(childComplexity int) int
 	}
 
@@ -230,9 +297,9 @@ type ComplexityRoot struct {
 
 type MutationResolver interface {
 	CreateEpisodeOfCare(ctx context.Context, episodeOfCare dto.EpisodeOfCareInput) (*dto.EpisodeOfCare, error)
-	EndEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error)
+	EndEpisodeOfCare(ctx context.Context, id string, endTime *time.Time) (*dto.EpisodeOfCare, error)
 	StartEncounter(ctx context.Context, episodeID string) (string, error)
-	EndEncounter(ctx context.Context, encounterID string) (bool, error)
+	EndEncounter(ctx context.Context, encounterID string, endTime *time.Time) (bool, error)
 	RecordTemperature(ctx context.Context, input dto.ObservationInput) (*dto.Observation, error)
 	RecordHeight(ctx context.Context, input dto.ObservationInput) (*dto.Observation, error)
 	RecordWeight(ctx context.Context, input dto.ObservationInput) (*dto.Observation, error)
@@ -241,14 +308,21 @@ type MutationResolver interface {
 	RecordBloodPressure(ctx context.Context, input dto.ObservationInput) (*dto.Observation, error)
 	RecordBmi(ctx context.Context, input dto.ObservationInput) (*dto.Observation, error)
 	CreatePatient(ctx context.Context, input dto.PatientInput) (*dto.Patient, error)
+	RetirePatient(ctx context.Context, input domain.RetirePatientInput) (*dto.Patient, error)
+	ReinstatePatient(ctx context.Context, id string) (*dto.Patient, error)
+	MergePatients(ctx context.Context, sourceID string, targetID string) (*dto.Patient, error)
+	UnmergePatient(ctx context.Context, sourceID string) (*dto.Patient, error)
+	DeletePatient(ctx context.Context, id string, dryRun *bool) (*dto.PatientDeletion, error)
 	CreateCondition(ctx context.Context, input dto.ConditionInput) (*dto.Condition, error)
 	CreateAllergyIntolerance(ctx context.Context, input dto.AllergyInput) (*dto.Allergy, error)
 }
 type QueryResolver interface {
 	PatientHealthTimeline(ctx context.Context, input dto.HealthTimelineInput) (*dto.HealthTimeline, error)
 	GetMedicalData(ctx context.Context, patientID string) (*dto.MedicalData, error)
+	ExportPatientRecord(ctx context.Context, patientID string) (map[string]interface{}, error)
 	GetEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error)
 	ListPatientConditions(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.ConditionConnection, error)
+	ConditionHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.ConditionHistoryConnection, error)
 	ListPatientEncounters(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.EncounterConnection, error)
 	GetPatientTemperatureEntries(ctx context.Context, patientID string) ([]*dto.Observation, error)
 	GetPatientBloodPressureEntries(ctx context.Context, patientID string) ([]*dto.Observation, error)
@@ -260,6 +334,8 @@ type QueryResolver interface {
 	SearchAllergy(ctx context.Context, name string) ([]*dto.Terminology, error)
 	GetAllergy(ctx context.Context, id string) (*dto.Allergy, error)
 	ListPatientAllergies(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.AllergyConnection, error)
+	AllergyHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.AllergyHistoryConnection, error)
+	TranslateConcept(ctx context.Context, code string, from dto.TerminologySource, to dto.TerminologySource) ([]*dto.ConceptTranslation, error)
 }
 
 type executableSchema struct {
@@ -354,6 +430,97 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.AllergyEdge.Node(childComplexity), true
 
+	case "AllergyHistoryConnection.edges":
+		if e.complexity.AllergyHistoryConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.AllergyHistoryConnection.Edges(childComplexity), true
+
+	case "AllergyHistoryConnection.pageInfo":
+		if e.complexity.AllergyHistoryConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.AllergyHistoryConnection.PageInfo(childComplexity), true
+
+	case "AllergyHistoryConnection.totalCount":
+		if e.complexity.AllergyHistoryConnection.TotalCount == nil {
+			break
+		}
+
+		return e.complexity.AllergyHistoryConnection.TotalCount(childComplexity), true
+
+	case "AllergyVersion.allergy":
+		if e.complexity.AllergyVersion.Allergy == nil {
+			break
+		}
+
+		return e.complexity.AllergyVersion.Allergy(childComplexity), true
+
+	case "AllergyVersion.author":
+		if e.complexity.AllergyVersion.Author == nil {
+			break
+		}
+
+		return e.complexity.AllergyVersion.Author(childComplexity), true
+
+	case "AllergyVersion.timestamp":
+		if e.complexity.AllergyVersion.Timestamp == nil {
+			break
+		}
+
+		return e.complexity.AllergyVersion.Timestamp(childComplexity), true
+
+	case "AllergyVersion.versionID":
+		if e.complexity.AllergyVersion.VersionID == nil {
+			break
+		}
+
+		return e.complexity.AllergyVersion.VersionID(childComplexity), true
+
+	case "AllergyVersionEdge.cursor":
+		if e.complexity.AllergyVersionEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.AllergyVersionEdge.Cursor(childComplexity), true
+
+	case "AllergyVersionEdge.node":
+		if e.complexity.AllergyVersionEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.AllergyVersionEdge.Node(childComplexity), true
+
+	case "ConceptTranslation.code":
+		if e.complexity.ConceptTranslation.Code == nil {
+			break
+		}
+
+		return e.complexity.ConceptTranslation.Code(childComplexity), true
+
+	case "ConceptTranslation.equivalence":
+		if e.complexity.ConceptTranslation.Equivalence == nil {
+			break
+		}
+
+		return e.complexity.ConceptTranslation.Equivalence(childComplexity), true
+
+	case "ConceptTranslation.name":
+		if e.complexity.ConceptTranslation.Name == nil {
+			break
+		}
+
+		return e.complexity.ConceptTranslation.Name(childComplexity), true
+
+	case "ConceptTranslation.system":
+		if e.complexity.ConceptTranslation.System == nil {
+			break
+		}
+
+		return e.complexity.ConceptTranslation.System(childComplexity), true
+
 	case "Condition.code":
 		if e.complexity.Condition.Code == nil {
 			break
@@ -459,6 +626,69 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ConditionEdge.Node(childComplexity), true
 
+	case "ConditionHistoryConnection.edges":
+		if e.complexity.ConditionHistoryConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.ConditionHistoryConnection.Edges(childComplexity), true
+
+	case "ConditionHistoryConnection.pageInfo":
+		if e.complexity.ConditionHistoryConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.ConditionHistoryConnection.PageInfo(childComplexity), true
+
+	case "ConditionHistoryConnection.totalCount":
+		if e.complexity.ConditionHistoryConnection.TotalCount == nil {
+			break
+		}
+
+		return e.complexity.ConditionHistoryConnection.TotalCount(childComplexity), true
+
+	case "ConditionVersion.author":
+		if e.complexity.ConditionVersion.Author == nil {
+			break
+		}
+
+		return e.complexity.ConditionVersion.Author(childComplexity), true
+
+	case "ConditionVersion.condition":
+		if e.complexity.ConditionVersion.Condition == nil {
+			break
+		}
+
+		return e.complexity.ConditionVersion.Condition(childComplexity), true
+
+	case "ConditionVersion.timestamp":
+		if e.complexity.ConditionVersion.Timestamp == nil {
+			break
+		}
+
+		return e.complexity.ConditionVersion.Timestamp(childComplexity), true
+
+	case "ConditionVersion.versionID":
+		if e.complexity.ConditionVersion.VersionID == nil {
+			break
+		}
+
+		return e.complexity.ConditionVersion.VersionID(childComplexity), true
+
+	case "ConditionVersionEdge.cursor":
+		if e.complexity.ConditionVersionEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.ConditionVersionEdge.Cursor(childComplexity), true
+
+	case "ConditionVersionEdge.node":
+		if e.complexity.ConditionVersionEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.ConditionVersionEdge.Node(childComplexity), true
+
 	case "Encounter.class":
 		if e.complexity.Encounter.Class == nil {
 			break
@@ -466,6 +696,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Encounter.Class(childComplexity), true
 
+	case "Encounter.duration":
+		if e.complexity.Encounter.Duration == nil {
+			break
+		}
+
+		return e.complexity.Encounter.Duration(childComplexity), true
+
 	case "Encounter.episodeOfCareID":
 		if e.complexity.Encounter.EpisodeOfCareID == nil {
 			break
@@ -696,6 +933,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.CreatePatient(childComplexity, args["input"].(dto.PatientInput)), true
 
+	case "Mutation.deletePatient":
+		if e.complexity.Mutation.DeletePatient == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deletePatient_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeletePatient(childComplexity, args["id"].(string), args["dryRun"].(*bool)), true
+
 	case "Mutation.endEncounter":
 		if e.complexity.Mutation.EndEncounter == nil {
 			break
@@ -706,7 +955,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.EndEncounter(childComplexity, args["encounterID"].(string)), true
+		return e.complexity.Mutation.EndEncounter(childComplexity, args["encounterID"].(string), args["endTime"].(*time.Time)), true
 
 	case "Mutation.endEpisodeOfCare":
 		if e.complexity.Mutation.EndEpisodeOfCare == nil {
@@ -718,7 +967,19 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.EndEpisodeOfCare(childComplexity, args["id"].(string)), true
+		return e.complexity.Mutation.EndEpisodeOfCare(childComplexity, args["id"].(string), args["endTime"].(*time.Time)), true
+
+	case "Mutation.mergePatients":
+		if e.complexity.Mutation.MergePatients == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_mergePatients_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.MergePatients(childComplexity, args["sourceID"].(string), args["targetID"].(string)), true
 
 	case "Mutation.recordBloodPressure":
 		if e.complexity.Mutation.RecordBloodPressure == nil {
@@ -804,6 +1065,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.RecordWeight(childComplexity, args["input"].(dto.ObservationInput)), true
 
+	case "Mutation.reinstatePatient":
+		if e.complexity.Mutation.ReinstatePatient == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_reinstatePatient_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ReinstatePatient(childComplexity, args["id"].(string)), true
+
+	case "Mutation.retirePatient":
+		if e.complexity.Mutation.RetirePatient == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_retirePatient_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RetirePatient(childComplexity, args["input"].(domain.RetirePatientInput)), true
+
 	case "Mutation.startEncounter":
 		if e.complexity.Mutation.StartEncounter == nil {
 			break
@@ -816,6 +1101,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.StartEncounter(childComplexity, args["episodeID"].(string)), true
 
+	case "Mutation.unmergePatient":
+		if e.complexity.Mutation.UnmergePatient == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_unmergePatient_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UnmergePatient(childComplexity, args["sourceID"].(string)), true
+
 	case "Observation.encounterID":
 		if e.complexity.Observation.EncounterID == nil {
 			break
@@ -914,6 +1211,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Patient.ID(childComplexity), true
 
+	case "Patient.mergedInto":
+		if e.complexity.Patient.MergedInto == nil {
+			break
+		}
+
+		return e.complexity.Patient.MergedInto(childComplexity), true
+
 	case "Patient.name":
 		if e.complexity.Patient.Name == nil {
 			break
@@ -928,6 +1232,77 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Patient.PhoneNumber(childComplexity), true
 
+	case "Patient.retirement":
+		if e.complexity.Patient.Retirement == nil {
+			break
+		}
+
+		return e.complexity.Patient.Retirement(childComplexity), true
+
+	case "PatientDeletion.deleted":
+		if e.complexity.PatientDeletion.Deleted == nil {
+			break
+		}
+
+		return e.complexity.PatientDeletion.Deleted(childComplexity), true
+
+	case "PatientDeletion.dryRun":
+		if e.complexity.PatientDeletion.DryRun == nil {
+			break
+		}
+
+		return e.complexity.PatientDeletion.DryRun(childComplexity), true
+
+	case "PatientRetirement.reason":
+		if e.complexity.PatientRetirement.Reason == nil {
+			break
+		}
+
+		return e.complexity.PatientRetirement.Reason(childComplexity), true
+
+	case "PatientRetirement.retiredAt":
+		if e.complexity.PatientRetirement.RetiredAt == nil {
+			break
+		}
+
+		return e.complexity.PatientRetirement.RetiredAt(childComplexity), true
+
+	case "Query.allergyHistory":
+		if e.complexity.Query.AllergyHistory == nil {
+			break
+		}
+
+		args, err := ec.field_Query_allergyHistory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.AllergyHistory(childComplexity, args["id"].(string), args["pagination"].(dto.Pagination)), true
+
+	case "Query.conditionHistory":
+		if e.complexity.Query.ConditionHistory == nil {
+			break
+		}
+
+		args, err := ec.field_Query_conditionHistory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ConditionHistory(childComplexity, args["id"].(string), args["pagination"].(dto.Pagination)), true
+
+	case "Query.exportPatientRecord":
+		if e.complexity.Query.ExportPatientRecord == nil {
+			break
+		}
+
+		args, err := ec.field_Query_exportPatientRecord_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ExportPatientRecord(childComplexity, args["patientID"].(string)), true
+
 	case "Query.getAllergy":
 		if e.complexity.Query.GetAllergy == nil {
 			break
@@ -1108,6 +1483,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.SearchAllergy(childComplexity, args["name"].(string)), true
 
+	case "Query.translateConcept":
+		if e.complexity.Query.TranslateConcept == nil {
+			break
+		}
+
+		args, err := ec.field_Query_translateConcept_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TranslateConcept(childComplexity, args["code"].(string), args["from"].(dto.TerminologySource), args["to"].(dto.TerminologySource)), true
+
 	case "Query._service":
 		if e.complexity.Query.__resolve__service == nil {
 			break
@@ -1224,6 +1611,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputPagination,
 		ec.unmarshalInputPatientInput,
 		ec.unmarshalInputReactionInput,
+		ec.unmarshalInputRetirePatientInput,
 	)
 	first := true
 
@@ -1287,11 +1675,13 @@ var sources = []*ast.Source{
 	{Name: "../clinical.graphql", Input: `extend type Query {
     patientHealthTimeline(input: HealthTimelineInput!): HealthTimeline!
     getMedicalData(patientID: String!): MedicalData
+    exportPatientRecord(patientID: ID!): Map!
 
     getEpisodeOfCare(id: ID!): EpisodeOfCare
 
     # Conditions
     listPatientConditions(patientID: ID!, pagination:Pagination!): ConditionConnection
+    conditionHistory(id: ID!, pagination: Pagination!): ConditionHistoryConnection
 
     # Encounter
     listPatientEncounters(patientID: String!, pagination: Pagination!): EncounterConnection
@@ -1309,16 +1699,20 @@ var sources = []*ast.Source{
     searchAllergy(name: String!): [Terminology]
     getAllergy(id: ID!): Allergy!
     listPatientAllergies(patientID: ID!, pagination:Pagination!): AllergyConnection
+    allergyHistory(id: ID!, pagination: Pagination!): AllergyHistoryConnection
+
+    # Terminology
+    translateConcept(code: String!, from: TerminologySource!, to: TerminologySource!): [ConceptTranslation!]!
 }
 
 extend type Mutation {
     # EpisodeOfCare
     createEpisodeOfCare(episodeOfCare: EpisodeOfCareInput!): EpisodeOfCare
-    endEpisodeOfCare(id: ID!): EpisodeOfCare
+    endEpisodeOfCare(id: ID!, endTime: Time): EpisodeOfCare
 
     # Encounter
     startEncounter(episodeID: String!): String!
-    endEncounter(encounterID: String!): Boolean!
+    endEncounter(encounterID: String!, endTime: Time): Boolean!
 
     # Observation
     recordTemperature(input: ObservationInput!): Observation!
@@ -1331,6 +1725,11 @@ extend type Mutation {
 
     # Patient
     createPatient(input: PatientInput!): Patient!
+    retirePatient(input: RetirePatientInput!): Patient!
+    reinstatePatient(id: ID!): Patient!
+    mergePatients(sourceID: ID!, targetID: ID!): Patient!
+    unmergePatient(sourceID: ID!): Patient!
+    deletePatient(id: ID!, dryRun: Boolean = true): PatientDeletion!
 
     #  Conditions
     createCondition(input: ConditionInput!): Condition!
@@ -1411,6 +1810,12 @@ enum TerminologySource {
 	CIEL
 	SNOMED_CT
 	LOINC
+}
+
+enum ConceptEquivalence {
+	EQUIVALENT
+	WIDER
+	NARROWER
 }`, BuiltIn: false},
 	{Name: "../external.graphql", Input: `scalar Map
 scalar Any
@@ -1461,6 +1866,11 @@ input PatientInput {
   contacts: [ContactInput!]!
 }
 
+input RetirePatientInput {
+  id: ID!
+  reason: String!
+}
+
 input IdentifierInput {
   type: IdentifierType!
   value: String!
@@ -1575,6 +1985,8 @@ type Encounter {
     episodeOfCareID: String
     status: EncounterStatusEnum
     patientID: String
+    # how long a finished encounter lasted, in seconds
+    duration: Int
 }
 
 type Patient {
@@ -1584,6 +1996,18 @@ type Patient {
     phoneNumber: [String!]!
     gender: Gender!
     birthDate: Date
+    retirement: PatientRetirement
+    mergedInto: ID
+}
+
+type PatientRetirement {
+    reason: String!
+    retiredAt: Time!
+}
+
+type PatientDeletion {
+    dryRun: Boolean!
+    deleted: [String!]!
 }
 
 type Condition {
@@ -1629,6 +2053,13 @@ type Terminology {
   name: String!
 }
 
+type ConceptTranslation {
+  code: String!
+  system: TerminologySource!
+  name: String!
+  equivalence: ConceptEquivalence!
+}
+
 type AllergyEdge {
     node:  Allergy
     cursor: String
@@ -1650,6 +2081,42 @@ type EncounterConnection {
     edges:      [EncounterEdge]
     pageInfo:   PageInfo
 }
+
+type ConditionVersion {
+    versionID: String!
+    timestamp: Time!
+    author: String
+    condition: Condition!
+}
+
+type ConditionVersionEdge {
+    node:  ConditionVersion
+    cursor: String
+}
+
+type ConditionHistoryConnection {
+    totalCount: Int
+    edges:      [ConditionVersionEdge]
+    pageInfo:   PageInfo
+}
+
+type AllergyVersion {
+    versionID: String!
+    timestamp: Time!
+    author: String
+    allergy: Allergy!
+}
+
+type AllergyVersionEdge {
+    node:  AllergyVersion
+    cursor: String
+}
+
+type AllergyHistoryConnection {
+    totalCount: Int
+    edges:      [AllergyVersionEdge]
+    pageInfo:   PageInfo
+}
 `, BuiltIn: false},
 	{Name: "../../../../../federation/directives.graphql", Input: `
 	scalar _Any
@@ -1737,18 +2204,51 @@ func (ec *executionContext) field_Mutation_createPatient_args(ctx context.Contex
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_endEncounter_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_deletePatient_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["encounterID"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("encounterID"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 *bool
+	if tmp, ok := rawArgs["dryRun"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dryRun"))
+		arg1, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["dryRun"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_endEncounter_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["encounterID"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("encounterID"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
 	args["encounterID"] = arg0
+	var arg1 *time.Time
+	if tmp, ok := rawArgs["endTime"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("endTime"))
+		arg1, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["endTime"] = arg1
 	return args, nil
 }
 
@@ -1764,6 +2264,39 @@ func (ec *executionContext) field_Mutation_endEpisodeOfCare_args(ctx context.Con
 		}
 	}
 	args["id"] = arg0
+	var arg1 *time.Time
+	if tmp, ok := rawArgs["endTime"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("endTime"))
+		arg1, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["endTime"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_mergePatients_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["sourceID"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sourceID"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sourceID"] = arg0
+	var arg1 string
+	if tmp, ok := rawArgs["targetID"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("targetID"))
+		arg1, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["targetID"] = arg1
 	return args, nil
 }
 
@@ -1872,6 +2405,36 @@ func (ec *executionContext) field_Mutation_recordWeight_args(ctx context.Context
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_reinstatePatient_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_retirePatient_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 domain.RetirePatientInput
+	if tmp, ok := rawArgs["input"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
+		arg0, err = ec.unmarshalNRetirePatientInput2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋdomainᚐRetirePatientInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_startEncounter_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1887,6 +2450,21 @@ func (ec *executionContext) field_Mutation_startEncounter_args(ctx context.Conte
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_unmergePatient_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["sourceID"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sourceID"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sourceID"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1902,6 +2480,69 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_allergyHistory_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 dto.Pagination
+	if tmp, ok := rawArgs["pagination"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pagination"))
+		arg1, err = ec.unmarshalNPagination2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPagination(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["pagination"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_conditionHistory_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 dto.Pagination
+	if tmp, ok := rawArgs["pagination"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pagination"))
+		arg1, err = ec.unmarshalNPagination2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPagination(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["pagination"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_exportPatientRecord_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["patientID"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("patientID"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["patientID"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_getAllergy_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -2154,6 +2795,39 @@ func (ec *executionContext) field_Query_searchAllergy_args(ctx context.Context,
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_translateConcept_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["code"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("code"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["code"] = arg0
+	var arg1 dto.TerminologySource
+	if tmp, ok := rawArgs["from"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("from"))
+		arg1, err = ec.unmarshalNTerminologySource2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐTerminologySource(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["from"] = arg1
+	var arg2 dto.TerminologySource
+	if tmp, ok := rawArgs["to"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("to"))
+		arg2, err = ec.unmarshalNTerminologySource2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐTerminologySource(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["to"] = arg2
+	return args, nil
+}
+
 func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -2687,8 +3361,8 @@ func (ec *executionContext) fieldContext_AllergyEdge_cursor(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_id(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_id(ctx, field)
+func (ec *executionContext) _AllergyHistoryConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyHistoryConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyHistoryConnection_totalCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2701,7 +3375,7 @@ func (ec *executionContext) _Condition_id(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2710,26 +3384,26 @@ func (ec *executionContext) _Condition_id(ctx context.Context, field graphql.Col
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOID2string(ctx, field.Selections, res)
+	return ec.marshalOInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyHistoryConnection_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyHistoryConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_status(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_status(ctx, field)
+func (ec *executionContext) _AllergyHistoryConnection_edges(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyHistoryConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyHistoryConnection_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2742,7 +3416,7 @@ func (ec *executionContext) _Condition_status(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2751,26 +3425,32 @@ func (ec *executionContext) _Condition_status(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(dto.ConditionStatus)
+	res := resTmp.([]dto.AllergyVersionEdge)
 	fc.Result = res
-	return ec.marshalOConditionStatus2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionStatus(ctx, field.Selections, res)
+	return ec.marshalOAllergyVersionEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyVersionEdge(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyHistoryConnection_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyHistoryConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ConditionStatus does not have child fields")
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_AllergyVersionEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_AllergyVersionEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AllergyVersionEdge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_name(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_name(ctx, field)
+func (ec *executionContext) _AllergyHistoryConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyHistoryConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyHistoryConnection_pageInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2783,7 +3463,7 @@ func (ec *executionContext) _Condition_name(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2792,26 +3472,36 @@ func (ec *executionContext) _Condition_name(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(dto.PageInfo)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyHistoryConnection_pageInfo(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyHistoryConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_code(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_code(ctx, field)
+func (ec *executionContext) _AllergyVersion_versionID(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyVersion_versionID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2824,7 +3514,7 @@ func (ec *executionContext) _Condition_code(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Code, nil
+		return obj.VersionID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2841,9 +3531,9 @@ func (ec *executionContext) _Condition_code(ctx context.Context, field graphql.C
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_code(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyVersion_versionID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2854,8 +3544,8 @@ func (ec *executionContext) fieldContext_Condition_code(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_system(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_system(ctx, field)
+func (ec *executionContext) _AllergyVersion_timestamp(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyVersion_timestamp(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2868,7 +3558,7 @@ func (ec *executionContext) _Condition_system(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.System, nil
+		return obj.Timestamp, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2880,26 +3570,26 @@ func (ec *executionContext) _Condition_system(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_system(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyVersion_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_onsetDate(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_onsetDate(ctx, field)
+func (ec *executionContext) _AllergyVersion_author(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyVersion_author(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2912,7 +3602,7 @@ func (ec *executionContext) _Condition_onsetDate(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OnsetDate, nil
+		return obj.Author, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2921,26 +3611,26 @@ func (ec *executionContext) _Condition_onsetDate(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(scalarutils.Date)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_onsetDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyVersion_author(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Date does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_recordedDate(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_recordedDate(ctx, field)
+func (ec *executionContext) _AllergyVersion_allergy(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyVersion_allergy(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2953,35 +3643,52 @@ func (ec *executionContext) _Condition_recordedDate(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.RecordedDate, nil
+		return obj.Allergy, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(scalarutils.Date)
+	res := resTmp.(dto.Allergy)
 	fc.Result = res
-	return ec.marshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx, field.Selections, res)
+	return ec.marshalNAllergy2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergy(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_recordedDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyVersion_allergy(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Date does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Allergy_id(ctx, field)
+			case "code":
+				return ec.fieldContext_Allergy_code(ctx, field)
+			case "system":
+				return ec.fieldContext_Allergy_system(ctx, field)
+			case "terminologySource":
+				return ec.fieldContext_Allergy_terminologySource(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Allergy_encounterID(ctx, field)
+			case "reaction":
+				return ec.fieldContext_Allergy_reaction(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Allergy", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_note(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_note(ctx, field)
+func (ec *executionContext) _AllergyVersionEdge_node(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyVersionEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyVersionEdge_node(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2994,7 +3701,7 @@ func (ec *executionContext) _Condition_note(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Note, nil
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3003,26 +3710,36 @@ func (ec *executionContext) _Condition_note(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(dto.AllergyVersion)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOAllergyVersion2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyVersion(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_note(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyVersionEdge_node(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyVersionEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "versionID":
+				return ec.fieldContext_AllergyVersion_versionID(ctx, field)
+			case "timestamp":
+				return ec.fieldContext_AllergyVersion_timestamp(ctx, field)
+			case "author":
+				return ec.fieldContext_AllergyVersion_author(ctx, field)
+			case "allergy":
+				return ec.fieldContext_AllergyVersion_allergy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AllergyVersion", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_patientID(ctx, field)
+func (ec *executionContext) _AllergyVersionEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *dto.AllergyVersionEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AllergyVersionEdge_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3035,7 +3752,7 @@ func (ec *executionContext) _Condition_patientID(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PatientID, nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3049,9 +3766,9 @@ func (ec *executionContext) _Condition_patientID(ctx context.Context, field grap
 	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_AllergyVersionEdge_cursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "AllergyVersionEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3062,8 +3779,8 @@ func (ec *executionContext) fieldContext_Condition_patientID(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Condition_encounterID(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Condition_encounterID(ctx, field)
+func (ec *executionContext) _ConceptTranslation_code(ctx context.Context, field graphql.CollectedField, obj *dto.ConceptTranslation) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConceptTranslation_code(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3076,23 +3793,26 @@ func (ec *executionContext) _Condition_encounterID(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EncounterID, nil
+		return obj.Code, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Condition_encounterID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConceptTranslation_code(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Condition",
+		Object:     "ConceptTranslation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3103,8 +3823,8 @@ func (ec *executionContext) fieldContext_Condition_encounterID(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ConditionConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConditionConnection_totalCount(ctx, field)
+func (ec *executionContext) _ConceptTranslation_system(ctx context.Context, field graphql.CollectedField, obj *dto.ConceptTranslation) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConceptTranslation_system(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3117,35 +3837,38 @@ func (ec *executionContext) _ConditionConnection_totalCount(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return obj.System, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(dto.TerminologySource)
 	fc.Result = res
-	return ec.marshalOInt2int(ctx, field.Selections, res)
+	return ec.marshalNTerminologySource2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐTerminologySource(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConditionConnection_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConceptTranslation_system(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConditionConnection",
+		Object:     "ConceptTranslation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type TerminologySource does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConditionConnection_edges(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConditionConnection_edges(ctx, field)
+func (ec *executionContext) _ConceptTranslation_name(ctx context.Context, field graphql.CollectedField, obj *dto.ConceptTranslation) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConceptTranslation_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3158,41 +3881,38 @@ func (ec *executionContext) _ConditionConnection_edges(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Edges, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]dto.ConditionEdge)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOConditionEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionEdge(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConditionConnection_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConceptTranslation_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConditionConnection",
+		Object:     "ConceptTranslation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "node":
-				return ec.fieldContext_ConditionEdge_node(ctx, field)
-			case "cursor":
-				return ec.fieldContext_ConditionEdge_cursor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ConditionEdge", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConditionConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConditionConnection_pageInfo(ctx, field)
+func (ec *executionContext) _ConceptTranslation_equivalence(ctx context.Context, field graphql.CollectedField, obj *dto.ConceptTranslation) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConceptTranslation_equivalence(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3205,45 +3925,38 @@ func (ec *executionContext) _ConditionConnection_pageInfo(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PageInfo, nil
+		return obj.Equivalence, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(dto.PageInfo)
+	res := resTmp.(dto.ConceptEquivalence)
 	fc.Result = res
-	return ec.marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPageInfo(ctx, field.Selections, res)
+	return ec.marshalNConceptEquivalence2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptEquivalence(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConditionConnection_pageInfo(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConceptTranslation_equivalence(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConditionConnection",
+		Object:     "ConceptTranslation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "hasNextPage":
-				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
-			case "startCursor":
-				return ec.fieldContext_PageInfo_startCursor(ctx, field)
-			case "hasPreviousPage":
-				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
-			case "endCursor":
-				return ec.fieldContext_PageInfo_endCursor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+			return nil, errors.New("field of type ConceptEquivalence does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConditionEdge_node(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConditionEdge_node(ctx, field)
+func (ec *executionContext) _Condition_id(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3256,7 +3969,7 @@ func (ec *executionContext) _ConditionEdge_node(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Node, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3265,48 +3978,26 @@ func (ec *executionContext) _ConditionEdge_node(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(dto.Condition)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOCondition2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐCondition(ctx, field.Selections, res)
+	return ec.marshalOID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConditionEdge_node(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConditionEdge",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Condition_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Condition_status(ctx, field)
-			case "name":
-				return ec.fieldContext_Condition_name(ctx, field)
-			case "code":
-				return ec.fieldContext_Condition_code(ctx, field)
-			case "system":
-				return ec.fieldContext_Condition_system(ctx, field)
-			case "onsetDate":
-				return ec.fieldContext_Condition_onsetDate(ctx, field)
-			case "recordedDate":
-				return ec.fieldContext_Condition_recordedDate(ctx, field)
-			case "note":
-				return ec.fieldContext_Condition_note(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Condition_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Condition_encounterID(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Condition", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConditionEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConditionEdge_cursor(ctx, field)
+func (ec *executionContext) _Condition_status(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_status(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3319,7 +4010,7 @@ func (ec *executionContext) _ConditionEdge_cursor(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Cursor, nil
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3328,26 +4019,26 @@ func (ec *executionContext) _ConditionEdge_cursor(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(dto.ConditionStatus)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOConditionStatus2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionStatus(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConditionEdge_cursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConditionEdge",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ConditionStatus does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Encounter_id(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Encounter_id(ctx, field)
+func (ec *executionContext) _Condition_name(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3360,7 +4051,7 @@ func (ec *executionContext) _Encounter_id(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3374,9 +4065,9 @@ func (ec *executionContext) _Encounter_id(ctx context.Context, field graphql.Col
 	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Encounter_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Encounter",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3387,8 +4078,8 @@ func (ec *executionContext) fieldContext_Encounter_id(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Encounter_class(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Encounter_class(ctx, field)
+func (ec *executionContext) _Condition_code(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_code(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3401,35 +4092,38 @@ func (ec *executionContext) _Encounter_class(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Class, nil
+		return obj.Code, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(dto.EncounterClass)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOEncounterClass2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounterClass(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Encounter_class(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_code(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Encounter",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type EncounterClass does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Encounter_episodeOfCareID(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Encounter_episodeOfCareID(ctx, field)
+func (ec *executionContext) _Condition_system(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_system(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3442,23 +4136,26 @@ func (ec *executionContext) _Encounter_episodeOfCareID(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EpisodeOfCareID, nil
+		return obj.System, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Encounter_episodeOfCareID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_system(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Encounter",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3469,8 +4166,8 @@ func (ec *executionContext) fieldContext_Encounter_episodeOfCareID(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _Encounter_status(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Encounter_status(ctx, field)
+func (ec *executionContext) _Condition_onsetDate(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_onsetDate(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3483,7 +4180,7 @@ func (ec *executionContext) _Encounter_status(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.OnsetDate, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3492,26 +4189,26 @@ func (ec *executionContext) _Encounter_status(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(dto.EncounterStatusEnum)
+	res := resTmp.(scalarutils.Date)
 	fc.Result = res
-	return ec.marshalOEncounterStatusEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounterStatusEnum(ctx, field.Selections, res)
+	return ec.marshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Encounter_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_onsetDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Encounter",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type EncounterStatusEnum does not have child fields")
+			return nil, errors.New("field of type Date does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Encounter_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Encounter_patientID(ctx, field)
+func (ec *executionContext) _Condition_recordedDate(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_recordedDate(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3524,7 +4221,7 @@ func (ec *executionContext) _Encounter_patientID(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PatientID, nil
+		return obj.RecordedDate, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3533,26 +4230,26 @@ func (ec *executionContext) _Encounter_patientID(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(scalarutils.Date)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Encounter_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_recordedDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Encounter",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Date does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EncounterConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EncounterConnection_totalCount(ctx, field)
+func (ec *executionContext) _Condition_note(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_note(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3565,7 +4262,7 @@ func (ec *executionContext) _EncounterConnection_totalCount(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return obj.Note, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3574,26 +4271,26 @@ func (ec *executionContext) _EncounterConnection_totalCount(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EncounterConnection_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_note(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EncounterConnection",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EncounterConnection_edges(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EncounterConnection_edges(ctx, field)
+func (ec *executionContext) _Condition_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_patientID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3606,7 +4303,7 @@ func (ec *executionContext) _EncounterConnection_edges(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Edges, nil
+		return obj.PatientID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3615,33 +4312,27 @@ func (ec *executionContext) _EncounterConnection_edges(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]dto.EncounterEdge)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOEncounterEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounterEdge(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EncounterConnection_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EncounterConnection",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "node":
-				return ec.fieldContext_EncounterEdge_node(ctx, field)
-			case "cursor":
-				return ec.fieldContext_EncounterEdge_cursor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type EncounterEdge", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EncounterConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EncounterConnection_pageInfo(ctx, field)
-	if err != nil {
+func (ec *executionContext) _Condition_encounterID(ctx context.Context, field graphql.CollectedField, obj *dto.Condition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Condition_encounterID(ctx, field)
+	if err != nil {
 		return graphql.Null
 	}
 	ctx = graphql.WithFieldContext(ctx, fc)
@@ -3653,7 +4344,7 @@ func (ec *executionContext) _EncounterConnection_pageInfo(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PageInfo, nil
+		return obj.EncounterID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3662,36 +4353,26 @@ func (ec *executionContext) _EncounterConnection_pageInfo(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(dto.PageInfo)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPageInfo(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EncounterConnection_pageInfo(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Condition_encounterID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EncounterConnection",
+		Object:     "Condition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "hasNextPage":
-				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
-			case "startCursor":
-				return ec.fieldContext_PageInfo_startCursor(ctx, field)
-			case "hasPreviousPage":
-				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
-			case "endCursor":
-				return ec.fieldContext_PageInfo_endCursor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EncounterEdge_node(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EncounterEdge_node(ctx, field)
+func (ec *executionContext) _ConditionConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionConnection_totalCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3704,7 +4385,7 @@ func (ec *executionContext) _EncounterEdge_node(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Node, nil
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3713,38 +4394,26 @@ func (ec *executionContext) _EncounterEdge_node(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(dto.Encounter)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOEncounter2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounter(ctx, field.Selections, res)
+	return ec.marshalOInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EncounterEdge_node(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionConnection_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EncounterEdge",
+		Object:     "ConditionConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Encounter_id(ctx, field)
-			case "class":
-				return ec.fieldContext_Encounter_class(ctx, field)
-			case "episodeOfCareID":
-				return ec.fieldContext_Encounter_episodeOfCareID(ctx, field)
-			case "status":
-				return ec.fieldContext_Encounter_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Encounter_patientID(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Encounter", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EncounterEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EncounterEdge_cursor(ctx, field)
+func (ec *executionContext) _ConditionConnection_edges(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionConnection_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3757,7 +4426,7 @@ func (ec *executionContext) _EncounterEdge_cursor(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Cursor, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3766,26 +4435,32 @@ func (ec *executionContext) _EncounterEdge_cursor(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]dto.ConditionEdge)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOConditionEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionEdge(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EncounterEdge_cursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionConnection_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EncounterEdge",
+		Object:     "ConditionConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_ConditionEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_ConditionEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ConditionEdge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EpisodeOfCare_id(ctx context.Context, field graphql.CollectedField, obj *dto.EpisodeOfCare) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EpisodeOfCare_id(ctx, field)
+func (ec *executionContext) _ConditionConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionConnection_pageInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3798,38 +4473,45 @@ func (ec *executionContext) _EpisodeOfCare_id(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(dto.PageInfo)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EpisodeOfCare_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionConnection_pageInfo(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EpisodeOfCare",
+		Object:     "ConditionConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EpisodeOfCare_status(ctx context.Context, field graphql.CollectedField, obj *dto.EpisodeOfCare) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EpisodeOfCare_status(ctx, field)
+func (ec *executionContext) _ConditionEdge_node(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionEdge_node(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3842,38 +4524,57 @@ func (ec *executionContext) _EpisodeOfCare_status(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(dto.EpisodeOfCareStatusEnum)
+	res := resTmp.(dto.Condition)
 	fc.Result = res
-	return ec.marshalNEpisodeOfCareStatusEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEpisodeOfCareStatusEnum(ctx, field.Selections, res)
+	return ec.marshalOCondition2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐCondition(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EpisodeOfCare_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionEdge_node(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EpisodeOfCare",
+		Object:     "ConditionEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type EpisodeOfCareStatusEnum does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Condition_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Condition_status(ctx, field)
+			case "name":
+				return ec.fieldContext_Condition_name(ctx, field)
+			case "code":
+				return ec.fieldContext_Condition_code(ctx, field)
+			case "system":
+				return ec.fieldContext_Condition_system(ctx, field)
+			case "onsetDate":
+				return ec.fieldContext_Condition_onsetDate(ctx, field)
+			case "recordedDate":
+				return ec.fieldContext_Condition_recordedDate(ctx, field)
+			case "note":
+				return ec.fieldContext_Condition_note(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Condition_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Condition_encounterID(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Condition", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EpisodeOfCare_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.EpisodeOfCare) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_EpisodeOfCare_patientID(ctx, field)
+func (ec *executionContext) _ConditionEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionEdge_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3886,26 +4587,23 @@ func (ec *executionContext) _EpisodeOfCare_patientID(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PatientID, nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_EpisodeOfCare_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionEdge_cursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EpisodeOfCare",
+		Object:     "ConditionEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3916,8 +4614,8 @@ func (ec *executionContext) fieldContext_EpisodeOfCare_patientID(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _HealthTimeline_timeline(ctx context.Context, field graphql.CollectedField, obj *dto.HealthTimeline) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_HealthTimeline_timeline(ctx, field)
+func (ec *executionContext) _ConditionHistoryConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionHistoryConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionHistoryConnection_totalCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3930,7 +4628,7 @@ func (ec *executionContext) _HealthTimeline_timeline(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Timeline, nil
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3939,40 +4637,26 @@ func (ec *executionContext) _HealthTimeline_timeline(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]dto.TimelineResource)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOTimelineResource2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐTimelineResource(ctx, field.Selections, res)
+	return ec.marshalOInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_HealthTimeline_timeline(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionHistoryConnection_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "HealthTimeline",
+		Object:     "ConditionHistoryConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_TimelineResource_id(ctx, field)
-			case "resourceType":
-				return ec.fieldContext_TimelineResource_resourceType(ctx, field)
-			case "name":
-				return ec.fieldContext_TimelineResource_name(ctx, field)
-			case "value":
-				return ec.fieldContext_TimelineResource_value(ctx, field)
-			case "status":
-				return ec.fieldContext_TimelineResource_status(ctx, field)
-			case "date":
-				return ec.fieldContext_TimelineResource_date(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TimelineResource", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _HealthTimeline_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.HealthTimeline) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_HealthTimeline_totalCount(ctx, field)
+func (ec *executionContext) _ConditionHistoryConnection_edges(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionHistoryConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionHistoryConnection_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3985,38 +4669,41 @@ func (ec *executionContext) _HealthTimeline_totalCount(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.([]dto.ConditionVersionEdge)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOConditionVersionEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionVersionEdge(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_HealthTimeline_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionHistoryConnection_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "HealthTimeline",
+		Object:     "ConditionHistoryConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_ConditionVersionEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_ConditionVersionEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ConditionVersionEdge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicalData_regimen(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicalData_regimen(ctx, field)
+func (ec *executionContext) _ConditionHistoryConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionHistoryConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionHistoryConnection_pageInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4029,7 +4716,7 @@ func (ec *executionContext) _MedicalData_regimen(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Regimen, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4038,36 +4725,36 @@ func (ec *executionContext) _MedicalData_regimen(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*dto.MedicationStatement)
+	res := resTmp.(dto.PageInfo)
 	fc.Result = res
-	return ec.marshalOMedicationStatement2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedicationStatement(ctx, field.Selections, res)
+	return ec.marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicalData_regimen(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionHistoryConnection_pageInfo(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicalData",
+		Object:     "ConditionHistoryConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_MedicationStatement_id(ctx, field)
-			case "status":
-				return ec.fieldContext_MedicationStatement_status(ctx, field)
-			case "medication":
-				return ec.fieldContext_MedicationStatement_medication(ctx, field)
-			case "patientID":
-				return ec.fieldContext_MedicationStatement_patientID(ctx, field)
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type MedicationStatement", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicalData_allergies(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicalData_allergies(ctx, field)
+func (ec *executionContext) _ConditionVersion_versionID(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionVersion_versionID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4080,49 +4767,38 @@ func (ec *executionContext) _MedicalData_allergies(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Allergies, nil
+		return obj.VersionID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*dto.Allergy)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOAllergy2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergy(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicalData_allergies(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionVersion_versionID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicalData",
+		Object:     "ConditionVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Allergy_id(ctx, field)
-			case "code":
-				return ec.fieldContext_Allergy_code(ctx, field)
-			case "system":
-				return ec.fieldContext_Allergy_system(ctx, field)
-			case "terminologySource":
-				return ec.fieldContext_Allergy_terminologySource(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Allergy_encounterID(ctx, field)
-			case "reaction":
-				return ec.fieldContext_Allergy_reaction(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Allergy", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicalData_weight(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicalData_weight(ctx, field)
+func (ec *executionContext) _ConditionVersion_timestamp(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionVersion_timestamp(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4135,49 +4811,38 @@ func (ec *executionContext) _MedicalData_weight(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Weight, nil
+		return obj.Timestamp, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*dto.Observation)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicalData_weight(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionVersion_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicalData",
+		Object:     "ConditionVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
-			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicalData_bmi(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicalData_bmi(ctx, field)
+func (ec *executionContext) _ConditionVersion_author(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionVersion_author(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4190,7 +4855,7 @@ func (ec *executionContext) _MedicalData_bmi(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BMI, nil
+		return obj.Author, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4199,40 +4864,26 @@ func (ec *executionContext) _MedicalData_bmi(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*dto.Observation)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicalData_bmi(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionVersion_author(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicalData",
+		Object:     "ConditionVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
-			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicalData_viralLoad(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicalData_viralLoad(ctx, field)
+func (ec *executionContext) _ConditionVersion_condition(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionVersion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionVersion_condition(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4245,49 +4896,60 @@ func (ec *executionContext) _MedicalData_viralLoad(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ViralLoad, nil
+		return obj.Condition, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*dto.Observation)
+	res := resTmp.(dto.Condition)
 	fc.Result = res
-	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNCondition2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐCondition(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicalData_viralLoad(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionVersion_condition(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicalData",
+		Object:     "ConditionVersion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
+				return ec.fieldContext_Condition_id(ctx, field)
 			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
+				return ec.fieldContext_Condition_status(ctx, field)
+			case "name":
+				return ec.fieldContext_Condition_name(ctx, field)
+			case "code":
+				return ec.fieldContext_Condition_code(ctx, field)
+			case "system":
+				return ec.fieldContext_Condition_system(ctx, field)
+			case "onsetDate":
+				return ec.fieldContext_Condition_onsetDate(ctx, field)
+			case "recordedDate":
+				return ec.fieldContext_Condition_recordedDate(ctx, field)
+			case "note":
+				return ec.fieldContext_Condition_note(ctx, field)
 			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
+				return ec.fieldContext_Condition_patientID(ctx, field)
 			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
-			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+				return ec.fieldContext_Condition_encounterID(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Condition", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicalData_cd4Count(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicalData_cd4Count(ctx, field)
+func (ec *executionContext) _ConditionVersionEdge_node(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionVersionEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionVersionEdge_node(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4300,7 +4962,7 @@ func (ec *executionContext) _MedicalData_cd4Count(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CD4Count, nil
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4309,40 +4971,36 @@ func (ec *executionContext) _MedicalData_cd4Count(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*dto.Observation)
+	res := resTmp.(dto.ConditionVersion)
 	fc.Result = res
-	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalOConditionVersion2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionVersion(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicalData_cd4Count(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionVersionEdge_node(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicalData",
+		Object:     "ConditionVersionEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
-			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+			case "versionID":
+				return ec.fieldContext_ConditionVersion_versionID(ctx, field)
+			case "timestamp":
+				return ec.fieldContext_ConditionVersion_timestamp(ctx, field)
+			case "author":
+				return ec.fieldContext_ConditionVersion_author(ctx, field)
+			case "condition":
+				return ec.fieldContext_ConditionVersion_condition(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ConditionVersion", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Medication_name(ctx context.Context, field graphql.CollectedField, obj *dto.Medication) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Medication_name(ctx, field)
+func (ec *executionContext) _ConditionVersionEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *dto.ConditionVersionEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConditionVersionEdge_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4355,26 +5013,23 @@ func (ec *executionContext) _Medication_name(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Medication_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConditionVersionEdge_cursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Medication",
+		Object:     "ConditionVersionEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -4385,8 +5040,8 @@ func (ec *executionContext) fieldContext_Medication_name(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Medication_code(ctx context.Context, field graphql.CollectedField, obj *dto.Medication) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Medication_code(ctx, field)
+func (ec *executionContext) _Encounter_id(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Encounter_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4399,26 +5054,23 @@ func (ec *executionContext) _Medication_code(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Code, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Medication_code(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Encounter_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Medication",
+		Object:     "Encounter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -4429,8 +5081,8 @@ func (ec *executionContext) fieldContext_Medication_code(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicationStatement_id(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicationStatement_id(ctx, field)
+func (ec *executionContext) _Encounter_class(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Encounter_class(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4443,38 +5095,35 @@ func (ec *executionContext) _MedicationStatement_id(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Class, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(dto.EncounterClass)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOEncounterClass2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounterClass(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicationStatement_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Encounter_class(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicationStatement",
+		Object:     "Encounter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type EncounterClass does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicationStatement_status(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicationStatement_status(ctx, field)
+func (ec *executionContext) _Encounter_episodeOfCareID(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Encounter_episodeOfCareID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4487,7 +5136,7 @@ func (ec *executionContext) _MedicationStatement_status(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.EpisodeOfCareID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4496,26 +5145,26 @@ func (ec *executionContext) _MedicationStatement_status(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(dto.MedicationStatementStatusEnum)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOMedicationStatementStatusEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedicationStatementStatusEnum(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicationStatement_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Encounter_episodeOfCareID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicationStatement",
+		Object:     "Encounter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type MedicationStatementStatusEnum does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicationStatement_medication(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicationStatement_medication(ctx, field)
+func (ec *executionContext) _Encounter_status(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Encounter_status(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4528,44 +5177,35 @@ func (ec *executionContext) _MedicationStatement_medication(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Medication, nil
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(dto.Medication)
+	res := resTmp.(dto.EncounterStatusEnum)
 	fc.Result = res
-	return ec.marshalNMedication2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedication(ctx, field.Selections, res)
+	return ec.marshalOEncounterStatusEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounterStatusEnum(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicationStatement_medication(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Encounter_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicationStatement",
+		Object:     "Encounter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "name":
-				return ec.fieldContext_Medication_name(ctx, field)
-			case "code":
-				return ec.fieldContext_Medication_code(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Medication", field.Name)
+			return nil, errors.New("field of type EncounterStatusEnum does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MedicationStatement_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MedicationStatement_patientID(ctx, field)
+func (ec *executionContext) _Encounter_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Encounter_patientID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4592,9 +5232,9 @@ func (ec *executionContext) _MedicationStatement_patientID(ctx context.Context,
 	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MedicationStatement_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Encounter_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MedicationStatement",
+		Object:     "Encounter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -4605,8 +5245,8 @@ func (ec *executionContext) fieldContext_MedicationStatement_patientID(ctx conte
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_createEpisodeOfCare(ctx, field)
+func (ec *executionContext) _Encounter_duration(ctx context.Context, field graphql.CollectedField, obj *dto.Encounter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Encounter_duration(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4619,7 +5259,7 @@ func (ec *executionContext) _Mutation_createEpisodeOfCare(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CreateEpisodeOfCare(rctx, fc.Args["episodeOfCare"].(dto.EpisodeOfCareInput))
+		return obj.Duration, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4628,45 +5268,26 @@ func (ec *executionContext) _Mutation_createEpisodeOfCare(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*dto.EpisodeOfCare)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOEpisodeOfCare2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEpisodeOfCare(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Encounter_duration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Encounter",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_EpisodeOfCare_id(ctx, field)
-			case "status":
-				return ec.fieldContext_EpisodeOfCare_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_EpisodeOfCare_patientID(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type EpisodeOfCare", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createEpisodeOfCare_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_endEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_endEpisodeOfCare(ctx, field)
+func (ec *executionContext) _EncounterConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EncounterConnection_totalCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4679,7 +5300,7 @@ func (ec *executionContext) _Mutation_endEpisodeOfCare(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().EndEpisodeOfCare(rctx, fc.Args["id"].(string))
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4688,27 +5309,1322 @@ func (ec *executionContext) _Mutation_endEpisodeOfCare(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*dto.EpisodeOfCare)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOEpisodeOfCare2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEpisodeOfCare(ctx, field.Selections, res)
+	return ec.marshalOInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_endEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EncounterConnection_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "EncounterConnection",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EncounterConnection_edges(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EncounterConnection_edges(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Edges, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]dto.EncounterEdge)
+	fc.Result = res
+	return ec.marshalOEncounterEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounterEdge(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EncounterConnection_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EncounterConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_EncounterEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_EncounterEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EncounterEdge", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EncounterConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EncounterConnection_pageInfo(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PageInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(dto.PageInfo)
+	fc.Result = res
+	return ec.marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPageInfo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EncounterConnection_pageInfo(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EncounterConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EncounterEdge_node(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EncounterEdge_node(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Node, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(dto.Encounter)
+	fc.Result = res
+	return ec.marshalOEncounter2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEncounter(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EncounterEdge_node(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EncounterEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Encounter_id(ctx, field)
+			case "class":
+				return ec.fieldContext_Encounter_class(ctx, field)
+			case "episodeOfCareID":
+				return ec.fieldContext_Encounter_episodeOfCareID(ctx, field)
+			case "status":
+				return ec.fieldContext_Encounter_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Encounter_patientID(ctx, field)
+			case "duration":
+				return ec.fieldContext_Encounter_duration(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Encounter", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EncounterEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *dto.EncounterEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EncounterEdge_cursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Cursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EncounterEdge_cursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EncounterEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EpisodeOfCare_id(ctx context.Context, field graphql.CollectedField, obj *dto.EpisodeOfCare) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EpisodeOfCare_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EpisodeOfCare_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EpisodeOfCare",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EpisodeOfCare_status(ctx context.Context, field graphql.CollectedField, obj *dto.EpisodeOfCare) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EpisodeOfCare_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(dto.EpisodeOfCareStatusEnum)
+	fc.Result = res
+	return ec.marshalNEpisodeOfCareStatusEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEpisodeOfCareStatusEnum(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EpisodeOfCare_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EpisodeOfCare",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type EpisodeOfCareStatusEnum does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EpisodeOfCare_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.EpisodeOfCare) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_EpisodeOfCare_patientID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PatientID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_EpisodeOfCare_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EpisodeOfCare",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _HealthTimeline_timeline(ctx context.Context, field graphql.CollectedField, obj *dto.HealthTimeline) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_HealthTimeline_timeline(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Timeline, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]dto.TimelineResource)
+	fc.Result = res
+	return ec.marshalOTimelineResource2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐTimelineResource(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_HealthTimeline_timeline(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "HealthTimeline",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_TimelineResource_id(ctx, field)
+			case "resourceType":
+				return ec.fieldContext_TimelineResource_resourceType(ctx, field)
+			case "name":
+				return ec.fieldContext_TimelineResource_name(ctx, field)
+			case "value":
+				return ec.fieldContext_TimelineResource_value(ctx, field)
+			case "status":
+				return ec.fieldContext_TimelineResource_status(ctx, field)
+			case "date":
+				return ec.fieldContext_TimelineResource_date(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TimelineResource", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _HealthTimeline_totalCount(ctx context.Context, field graphql.CollectedField, obj *dto.HealthTimeline) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_HealthTimeline_totalCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_HealthTimeline_totalCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "HealthTimeline",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicalData_regimen(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicalData_regimen(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Regimen, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.MedicationStatement)
+	fc.Result = res
+	return ec.marshalOMedicationStatement2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedicationStatement(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicalData_regimen(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicalData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_MedicationStatement_id(ctx, field)
+			case "status":
+				return ec.fieldContext_MedicationStatement_status(ctx, field)
+			case "medication":
+				return ec.fieldContext_MedicationStatement_medication(ctx, field)
+			case "patientID":
+				return ec.fieldContext_MedicationStatement_patientID(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MedicationStatement", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicalData_allergies(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicalData_allergies(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Allergies, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.Allergy)
+	fc.Result = res
+	return ec.marshalOAllergy2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergy(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicalData_allergies(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicalData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Allergy_id(ctx, field)
+			case "code":
+				return ec.fieldContext_Allergy_code(ctx, field)
+			case "system":
+				return ec.fieldContext_Allergy_system(ctx, field)
+			case "terminologySource":
+				return ec.fieldContext_Allergy_terminologySource(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Allergy_encounterID(ctx, field)
+			case "reaction":
+				return ec.fieldContext_Allergy_reaction(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Allergy", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicalData_weight(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicalData_weight(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Weight, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.Observation)
+	fc.Result = res
+	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicalData_weight(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicalData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicalData_bmi(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicalData_bmi(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BMI, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.Observation)
+	fc.Result = res
+	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicalData_bmi(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicalData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicalData_viralLoad(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicalData_viralLoad(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ViralLoad, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.Observation)
+	fc.Result = res
+	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicalData_viralLoad(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicalData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicalData_cd4Count(ctx context.Context, field graphql.CollectedField, obj *dto.MedicalData) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicalData_cd4Count(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CD4Count, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.Observation)
+	fc.Result = res
+	return ec.marshalOObservation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicalData_cd4Count(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicalData",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Medication_name(ctx context.Context, field graphql.CollectedField, obj *dto.Medication) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Medication_name(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Medication_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Medication",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Medication_code(ctx context.Context, field graphql.CollectedField, obj *dto.Medication) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Medication_code(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Code, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Medication_code(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Medication",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicationStatement_id(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicationStatement_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicationStatement_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicationStatement",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicationStatement_status(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicationStatement_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(dto.MedicationStatementStatusEnum)
+	fc.Result = res
+	return ec.marshalOMedicationStatementStatusEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedicationStatementStatusEnum(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicationStatement_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicationStatement",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type MedicationStatementStatusEnum does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicationStatement_medication(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicationStatement_medication(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Medication, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(dto.Medication)
+	fc.Result = res
+	return ec.marshalNMedication2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedication(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicationStatement_medication(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicationStatement",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_Medication_name(ctx, field)
+			case "code":
+				return ec.fieldContext_Medication_code(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Medication", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MedicationStatement_patientID(ctx context.Context, field graphql.CollectedField, obj *dto.MedicationStatement) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MedicationStatement_patientID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PatientID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_MedicationStatement_patientID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MedicationStatement",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_createEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_createEpisodeOfCare(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().CreateEpisodeOfCare(rctx, fc.Args["episodeOfCare"].(dto.EpisodeOfCareInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*dto.EpisodeOfCare)
+	fc.Result = res
+	return ec.marshalOEpisodeOfCare2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEpisodeOfCare(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_createEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_EpisodeOfCare_id(ctx, field)
+			case "status":
+				return ec.fieldContext_EpisodeOfCare_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_EpisodeOfCare_patientID(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EpisodeOfCare", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createEpisodeOfCare_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_endEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_endEpisodeOfCare(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().EndEpisodeOfCare(rctx, fc.Args["id"].(string), fc.Args["endTime"].(*time.Time))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*dto.EpisodeOfCare)
+	fc.Result = res
+	return ec.marshalOEpisodeOfCare2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐEpisodeOfCare(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_endEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
 				return ec.fieldContext_EpisodeOfCare_id(ctx, field)
 			case "status":
-				return ec.fieldContext_EpisodeOfCare_status(ctx, field)
+				return ec.fieldContext_EpisodeOfCare_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_EpisodeOfCare_patientID(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EpisodeOfCare", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_endEpisodeOfCare_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_startEncounter(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_startEncounter(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().StartEncounter(rctx, fc.Args["episodeID"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_startEncounter(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_startEncounter_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_endEncounter(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_endEncounter(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().EndEncounter(rctx, fc.Args["encounterID"].(string), fc.Args["endTime"].(*time.Time))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_endEncounter(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_endEncounter_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_recordTemperature(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordTemperature(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().RecordTemperature(rctx, fc.Args["input"].(dto.ObservationInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*dto.Observation)
+	fc.Result = res
+	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_recordTemperature(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
 			case "patientID":
-				return ec.fieldContext_EpisodeOfCare_patientID(ctx, field)
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type EpisodeOfCare", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
 		},
 	}
 	defer func() {
@@ -4718,15 +6634,153 @@ func (ec *executionContext) fieldContext_Mutation_endEpisodeOfCare(ctx context.C
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_endEpisodeOfCare_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_recordTemperature_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_startEncounter(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_startEncounter(ctx, field)
+func (ec *executionContext) _Mutation_recordHeight(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordHeight(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().RecordHeight(rctx, fc.Args["input"].(dto.ObservationInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*dto.Observation)
+	fc.Result = res
+	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_recordHeight(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_recordHeight_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_recordWeight(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordWeight(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().RecordWeight(rctx, fc.Args["input"].(dto.ObservationInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*dto.Observation)
+	fc.Result = res
+	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_recordWeight(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_recordWeight_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_recordRespiratoryRate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordRespiratoryRate(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4739,7 +6793,7 @@ func (ec *executionContext) _Mutation_startEncounter(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().StartEncounter(rctx, fc.Args["episodeID"].(string))
+		return ec.resolvers.Mutation().RecordRespiratoryRate(rctx, fc.Args["input"].(dto.ObservationInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4751,19 +6805,33 @@ func (ec *executionContext) _Mutation_startEncounter(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*dto.Observation)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_startEncounter(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_recordRespiratoryRate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
 		},
 	}
 	defer func() {
@@ -4773,15 +6841,15 @@ func (ec *executionContext) fieldContext_Mutation_startEncounter(ctx context.Con
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_startEncounter_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_recordRespiratoryRate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_endEncounter(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_endEncounter(ctx, field)
+func (ec *executionContext) _Mutation_recordPulseRate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordPulseRate(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4794,7 +6862,7 @@ func (ec *executionContext) _Mutation_endEncounter(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().EndEncounter(rctx, fc.Args["encounterID"].(string))
+		return ec.resolvers.Mutation().RecordPulseRate(rctx, fc.Args["input"].(dto.ObservationInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4806,19 +6874,33 @@ func (ec *executionContext) _Mutation_endEncounter(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*dto.Observation)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_endEncounter(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_recordPulseRate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Observation_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Observation_status(ctx, field)
+			case "patientID":
+				return ec.fieldContext_Observation_patientID(ctx, field)
+			case "encounterID":
+				return ec.fieldContext_Observation_encounterID(ctx, field)
+			case "name":
+				return ec.fieldContext_Observation_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Observation_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
 		},
 	}
 	defer func() {
@@ -4828,15 +6910,15 @@ func (ec *executionContext) fieldContext_Mutation_endEncounter(ctx context.Conte
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_endEncounter_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_recordPulseRate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordTemperature(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordTemperature(ctx, field)
+func (ec *executionContext) _Mutation_recordBloodPressure(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordBloodPressure(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4849,7 +6931,7 @@ func (ec *executionContext) _Mutation_recordTemperature(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordTemperature(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().RecordBloodPressure(rctx, fc.Args["input"].(dto.ObservationInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4866,7 +6948,7 @@ func (ec *executionContext) _Mutation_recordTemperature(ctx context.Context, fie
 	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordTemperature(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_recordBloodPressure(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -4897,15 +6979,15 @@ func (ec *executionContext) fieldContext_Mutation_recordTemperature(ctx context.
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordTemperature_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_recordBloodPressure_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordHeight(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordHeight(ctx, field)
+func (ec *executionContext) _Mutation_recordBMI(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_recordBMI(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4918,7 +7000,7 @@ func (ec *executionContext) _Mutation_recordHeight(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordHeight(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().RecordBmi(rctx, fc.Args["input"].(dto.ObservationInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4935,7 +7017,7 @@ func (ec *executionContext) _Mutation_recordHeight(ctx context.Context, field gr
 	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordHeight(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_recordBMI(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -4966,15 +7048,15 @@ func (ec *executionContext) fieldContext_Mutation_recordHeight(ctx context.Conte
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordHeight_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_recordBMI_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordWeight(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordWeight(ctx, field)
+func (ec *executionContext) _Mutation_createPatient(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_createPatient(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4987,7 +7069,7 @@ func (ec *executionContext) _Mutation_recordWeight(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordWeight(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().CreatePatient(rctx, fc.Args["input"].(dto.PatientInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4999,12 +7081,12 @@ func (ec *executionContext) _Mutation_recordWeight(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*dto.Observation)
+	res := resTmp.(*dto.Patient)
 	fc.Result = res
-	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatient(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordWeight(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_createPatient(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -5013,19 +7095,23 @@ func (ec *executionContext) fieldContext_Mutation_recordWeight(ctx context.Conte
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
+				return ec.fieldContext_Patient_id(ctx, field)
+			case "active":
+				return ec.fieldContext_Patient_active(ctx, field)
 			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+				return ec.fieldContext_Patient_name(ctx, field)
+			case "phoneNumber":
+				return ec.fieldContext_Patient_phoneNumber(ctx, field)
+			case "gender":
+				return ec.fieldContext_Patient_gender(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Patient_birthDate(ctx, field)
+			case "retirement":
+				return ec.fieldContext_Patient_retirement(ctx, field)
+			case "mergedInto":
+				return ec.fieldContext_Patient_mergedInto(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Patient", field.Name)
 		},
 	}
 	defer func() {
@@ -5035,15 +7121,15 @@ func (ec *executionContext) fieldContext_Mutation_recordWeight(ctx context.Conte
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordWeight_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_createPatient_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordRespiratoryRate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordRespiratoryRate(ctx, field)
+func (ec *executionContext) _Mutation_retirePatient(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_retirePatient(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5056,7 +7142,7 @@ func (ec *executionContext) _Mutation_recordRespiratoryRate(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordRespiratoryRate(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().RetirePatient(rctx, fc.Args["input"].(domain.RetirePatientInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5068,12 +7154,12 @@ func (ec *executionContext) _Mutation_recordRespiratoryRate(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*dto.Observation)
+	res := resTmp.(*dto.Patient)
 	fc.Result = res
-	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatient(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordRespiratoryRate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_retirePatient(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -5082,19 +7168,23 @@ func (ec *executionContext) fieldContext_Mutation_recordRespiratoryRate(ctx cont
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
+				return ec.fieldContext_Patient_id(ctx, field)
+			case "active":
+				return ec.fieldContext_Patient_active(ctx, field)
 			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+				return ec.fieldContext_Patient_name(ctx, field)
+			case "phoneNumber":
+				return ec.fieldContext_Patient_phoneNumber(ctx, field)
+			case "gender":
+				return ec.fieldContext_Patient_gender(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Patient_birthDate(ctx, field)
+			case "retirement":
+				return ec.fieldContext_Patient_retirement(ctx, field)
+			case "mergedInto":
+				return ec.fieldContext_Patient_mergedInto(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Patient", field.Name)
 		},
 	}
 	defer func() {
@@ -5104,15 +7194,15 @@ func (ec *executionContext) fieldContext_Mutation_recordRespiratoryRate(ctx cont
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordRespiratoryRate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_retirePatient_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordPulseRate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordPulseRate(ctx, field)
+func (ec *executionContext) _Mutation_reinstatePatient(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_reinstatePatient(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5125,7 +7215,7 @@ func (ec *executionContext) _Mutation_recordPulseRate(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordPulseRate(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().ReinstatePatient(rctx, fc.Args["id"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5137,12 +7227,12 @@ func (ec *executionContext) _Mutation_recordPulseRate(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*dto.Observation)
+	res := resTmp.(*dto.Patient)
 	fc.Result = res
-	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatient(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordPulseRate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_reinstatePatient(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -5151,19 +7241,23 @@ func (ec *executionContext) fieldContext_Mutation_recordPulseRate(ctx context.Co
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
+				return ec.fieldContext_Patient_id(ctx, field)
+			case "active":
+				return ec.fieldContext_Patient_active(ctx, field)
 			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+				return ec.fieldContext_Patient_name(ctx, field)
+			case "phoneNumber":
+				return ec.fieldContext_Patient_phoneNumber(ctx, field)
+			case "gender":
+				return ec.fieldContext_Patient_gender(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Patient_birthDate(ctx, field)
+			case "retirement":
+				return ec.fieldContext_Patient_retirement(ctx, field)
+			case "mergedInto":
+				return ec.fieldContext_Patient_mergedInto(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Patient", field.Name)
 		},
 	}
 	defer func() {
@@ -5173,15 +7267,15 @@ func (ec *executionContext) fieldContext_Mutation_recordPulseRate(ctx context.Co
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordPulseRate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_reinstatePatient_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordBloodPressure(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordBloodPressure(ctx, field)
+func (ec *executionContext) _Mutation_mergePatients(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_mergePatients(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5194,7 +7288,7 @@ func (ec *executionContext) _Mutation_recordBloodPressure(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordBloodPressure(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().MergePatients(rctx, fc.Args["sourceID"].(string), fc.Args["targetID"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5206,12 +7300,12 @@ func (ec *executionContext) _Mutation_recordBloodPressure(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*dto.Observation)
+	res := resTmp.(*dto.Patient)
 	fc.Result = res
-	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatient(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordBloodPressure(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_mergePatients(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -5220,19 +7314,23 @@ func (ec *executionContext) fieldContext_Mutation_recordBloodPressure(ctx contex
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
+				return ec.fieldContext_Patient_id(ctx, field)
+			case "active":
+				return ec.fieldContext_Patient_active(ctx, field)
 			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+				return ec.fieldContext_Patient_name(ctx, field)
+			case "phoneNumber":
+				return ec.fieldContext_Patient_phoneNumber(ctx, field)
+			case "gender":
+				return ec.fieldContext_Patient_gender(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Patient_birthDate(ctx, field)
+			case "retirement":
+				return ec.fieldContext_Patient_retirement(ctx, field)
+			case "mergedInto":
+				return ec.fieldContext_Patient_mergedInto(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Patient", field.Name)
 		},
 	}
 	defer func() {
@@ -5242,15 +7340,15 @@ func (ec *executionContext) fieldContext_Mutation_recordBloodPressure(ctx contex
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordBloodPressure_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_mergePatients_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_recordBMI(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_recordBMI(ctx, field)
+func (ec *executionContext) _Mutation_unmergePatient(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_unmergePatient(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5263,7 +7361,7 @@ func (ec *executionContext) _Mutation_recordBMI(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RecordBmi(rctx, fc.Args["input"].(dto.ObservationInput))
+		return ec.resolvers.Mutation().UnmergePatient(rctx, fc.Args["sourceID"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5275,12 +7373,12 @@ func (ec *executionContext) _Mutation_recordBMI(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*dto.Observation)
+	res := resTmp.(*dto.Patient)
 	fc.Result = res
-	return ec.marshalNObservation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐObservation(ctx, field.Selections, res)
+	return ec.marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatient(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_recordBMI(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_unmergePatient(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -5289,19 +7387,23 @@ func (ec *executionContext) fieldContext_Mutation_recordBMI(ctx context.Context,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Observation_id(ctx, field)
-			case "status":
-				return ec.fieldContext_Observation_status(ctx, field)
-			case "patientID":
-				return ec.fieldContext_Observation_patientID(ctx, field)
-			case "encounterID":
-				return ec.fieldContext_Observation_encounterID(ctx, field)
+				return ec.fieldContext_Patient_id(ctx, field)
+			case "active":
+				return ec.fieldContext_Patient_active(ctx, field)
 			case "name":
-				return ec.fieldContext_Observation_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Observation_value(ctx, field)
+				return ec.fieldContext_Patient_name(ctx, field)
+			case "phoneNumber":
+				return ec.fieldContext_Patient_phoneNumber(ctx, field)
+			case "gender":
+				return ec.fieldContext_Patient_gender(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Patient_birthDate(ctx, field)
+			case "retirement":
+				return ec.fieldContext_Patient_retirement(ctx, field)
+			case "mergedInto":
+				return ec.fieldContext_Patient_mergedInto(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Observation", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Patient", field.Name)
 		},
 	}
 	defer func() {
@@ -5311,15 +7413,15 @@ func (ec *executionContext) fieldContext_Mutation_recordBMI(ctx context.Context,
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_recordBMI_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_unmergePatient_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createPatient(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_createPatient(ctx, field)
+func (ec *executionContext) _Mutation_deletePatient(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_deletePatient(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5332,7 +7434,7 @@ func (ec *executionContext) _Mutation_createPatient(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CreatePatient(rctx, fc.Args["input"].(dto.PatientInput))
+		return ec.resolvers.Mutation().DeletePatient(rctx, fc.Args["id"].(string), fc.Args["dryRun"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5344,33 +7446,25 @@ func (ec *executionContext) _Mutation_createPatient(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*dto.Patient)
+	res := resTmp.(*dto.PatientDeletion)
 	fc.Result = res
-	return ec.marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatient(ctx, field.Selections, res)
+	return ec.marshalNPatientDeletion2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatientDeletion(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createPatient(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deletePatient(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: true,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Patient_id(ctx, field)
-			case "active":
-				return ec.fieldContext_Patient_active(ctx, field)
-			case "name":
-				return ec.fieldContext_Patient_name(ctx, field)
-			case "phoneNumber":
-				return ec.fieldContext_Patient_phoneNumber(ctx, field)
-			case "gender":
-				return ec.fieldContext_Patient_gender(ctx, field)
-			case "birthDate":
-				return ec.fieldContext_Patient_birthDate(ctx, field)
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "dryRun":
+				return ec.fieldContext_PatientDeletion_dryRun(ctx, field)
+			case "deleted":
+				return ec.fieldContext_PatientDeletion_deleted(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Patient", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PatientDeletion", field.Name)
 		},
 	}
 	defer func() {
@@ -5380,7 +7474,7 @@ func (ec *executionContext) fieldContext_Mutation_createPatient(ctx context.Cont
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createPatient_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_deletePatient_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
@@ -5737,21 +7831,273 @@ func (ec *executionContext) _Observation_name(ctx context.Context, field graphql
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Observation_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Observation_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Observation",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Observation_value(ctx context.Context, field graphql.CollectedField, obj *dto.Observation) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Observation_value(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Value, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Observation_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Observation",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasNextPage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_startCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_startCursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasPreviousPage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_endCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_endCursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Patient_id(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Patient_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Observation",
+		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Observation_value(ctx context.Context, field graphql.CollectedField, obj *dto.Observation) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Observation_value(ctx, field)
+func (ec *executionContext) _Patient_active(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_active(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5764,7 +8110,7 @@ func (ec *executionContext) _Observation_value(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Value, nil
+		return obj.Active, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5776,26 +8122,26 @@ func (ec *executionContext) _Observation_value(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Observation_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_active(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Observation",
+		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+func (ec *executionContext) _Patient_name(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5808,35 +8154,38 @@ func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasNextPage, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_startCursor(ctx, field)
+func (ec *executionContext) _Patient_phoneNumber(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_phoneNumber(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5849,23 +8198,26 @@ func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartCursor, nil
+		return obj.PhoneNumber, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_startCursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_phoneNumber(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5876,8 +8228,8 @@ func (ec *executionContext) fieldContext_PageInfo_startCursor(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+func (ec *executionContext) _Patient_gender(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_gender(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5890,35 +8242,38 @@ func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasPreviousPage, nil
+		return obj.Gender, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(dto.Gender)
 	fc.Result = res
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNGender2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐGender(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_gender(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Gender does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *dto.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_endCursor(ctx, field)
+func (ec *executionContext) _Patient_birthDate(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_birthDate(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5931,7 +8286,7 @@ func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EndCursor, nil
+		return obj.BirthDate, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5940,26 +8295,26 @@ func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(scalarutils.Date)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_endCursor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_birthDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Date does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Patient_id(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Patient_id(ctx, field)
+func (ec *executionContext) _Patient_retirement(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_retirement(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5972,38 +8327,41 @@ func (ec *executionContext) _Patient_id(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Retirement, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*dto.PatientRetirement)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOPatientRetirement2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatientRetirement(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Patient_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_retirement(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			switch field.Name {
+			case "reason":
+				return ec.fieldContext_PatientRetirement_reason(ctx, field)
+			case "retiredAt":
+				return ec.fieldContext_PatientRetirement_retiredAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PatientRetirement", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Patient_active(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Patient_active(ctx, field)
+func (ec *executionContext) _Patient_mergedInto(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Patient_mergedInto(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6016,38 +8374,35 @@ func (ec *executionContext) _Patient_active(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Active, nil
+		return obj.MergedInto, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Patient_active(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Patient_mergedInto(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Patient",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Patient_name(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Patient_name(ctx, field)
+func (ec *executionContext) _PatientDeletion_dryRun(ctx context.Context, field graphql.CollectedField, obj *dto.PatientDeletion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PatientDeletion_dryRun(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6060,7 +8415,7 @@ func (ec *executionContext) _Patient_name(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.DryRun, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6072,26 +8427,26 @@ func (ec *executionContext) _Patient_name(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Patient_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PatientDeletion_dryRun(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Patient",
+		Object:     "PatientDeletion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Patient_phoneNumber(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Patient_phoneNumber(ctx, field)
+func (ec *executionContext) _PatientDeletion_deleted(ctx context.Context, field graphql.CollectedField, obj *dto.PatientDeletion) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PatientDeletion_deleted(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6104,7 +8459,7 @@ func (ec *executionContext) _Patient_phoneNumber(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PhoneNumber, nil
+		return obj.Deleted, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6121,9 +8476,9 @@ func (ec *executionContext) _Patient_phoneNumber(ctx context.Context, field grap
 	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Patient_phoneNumber(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PatientDeletion_deleted(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Patient",
+		Object:     "PatientDeletion",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6134,8 +8489,8 @@ func (ec *executionContext) fieldContext_Patient_phoneNumber(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Patient_gender(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Patient_gender(ctx, field)
+func (ec *executionContext) _PatientRetirement_reason(ctx context.Context, field graphql.CollectedField, obj *dto.PatientRetirement) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PatientRetirement_reason(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6148,7 +8503,7 @@ func (ec *executionContext) _Patient_gender(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Gender, nil
+		return obj.Reason, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6160,26 +8515,26 @@ func (ec *executionContext) _Patient_gender(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(dto.Gender)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNGender2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐGender(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Patient_gender(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PatientRetirement_reason(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Patient",
+		Object:     "PatientRetirement",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Gender does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Patient_birthDate(ctx context.Context, field graphql.CollectedField, obj *dto.Patient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Patient_birthDate(ctx, field)
+func (ec *executionContext) _PatientRetirement_retiredAt(ctx context.Context, field graphql.CollectedField, obj *dto.PatientRetirement) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PatientRetirement_retiredAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6192,28 +8547,31 @@ func (ec *executionContext) _Patient_birthDate(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BirthDate, nil
+		return obj.RetiredAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(scalarutils.Date)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Patient_birthDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PatientRetirement_retiredAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Patient",
+		Object:     "PatientRetirement",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Date does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
@@ -6346,6 +8704,61 @@ func (ec *executionContext) fieldContext_Query_getMedicalData(ctx context.Contex
 	return fc, nil
 }
 
+func (ec *executionContext) _Query_exportPatientRecord(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_exportPatientRecord(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().ExportPatientRecord(rctx, fc.Args["patientID"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(map[string]interface{})
+	fc.Result = res
+	return ec.marshalNMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_exportPatientRecord(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_exportPatientRecord_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_getEpisodeOfCare(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query_getEpisodeOfCare(ctx, field)
 	if err != nil {
@@ -6445,11 +8858,71 @@ func (ec *executionContext) fieldContext_Query_listPatientConditions(ctx context
 			case "totalCount":
 				return ec.fieldContext_ConditionConnection_totalCount(ctx, field)
 			case "edges":
-				return ec.fieldContext_ConditionConnection_edges(ctx, field)
+				return ec.fieldContext_ConditionConnection_edges(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_ConditionConnection_pageInfo(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ConditionConnection", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_listPatientConditions_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_conditionHistory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_conditionHistory(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().ConditionHistory(rctx, fc.Args["id"].(string), fc.Args["pagination"].(dto.Pagination))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*dto.ConditionHistoryConnection)
+	fc.Result = res
+	return ec.marshalOConditionHistoryConnection2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionHistoryConnection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_conditionHistory(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "totalCount":
+				return ec.fieldContext_ConditionHistoryConnection_totalCount(ctx, field)
+			case "edges":
+				return ec.fieldContext_ConditionHistoryConnection_edges(ctx, field)
 			case "pageInfo":
-				return ec.fieldContext_ConditionConnection_pageInfo(ctx, field)
+				return ec.fieldContext_ConditionHistoryConnection_pageInfo(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ConditionConnection", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ConditionHistoryConnection", field.Name)
 		},
 	}
 	defer func() {
@@ -6459,7 +8932,7 @@ func (ec *executionContext) fieldContext_Query_listPatientConditions(ctx context
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_listPatientConditions_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_conditionHistory_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
@@ -7177,6 +9650,131 @@ func (ec *executionContext) fieldContext_Query_listPatientAllergies(ctx context.
 	return fc, nil
 }
 
+func (ec *executionContext) _Query_allergyHistory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_allergyHistory(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().AllergyHistory(rctx, fc.Args["id"].(string), fc.Args["pagination"].(dto.Pagination))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*dto.AllergyHistoryConnection)
+	fc.Result = res
+	return ec.marshalOAllergyHistoryConnection2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyHistoryConnection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_allergyHistory(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "totalCount":
+				return ec.fieldContext_AllergyHistoryConnection_totalCount(ctx, field)
+			case "edges":
+				return ec.fieldContext_AllergyHistoryConnection_edges(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_AllergyHistoryConnection_pageInfo(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AllergyHistoryConnection", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_allergyHistory_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_translateConcept(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_translateConcept(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().TranslateConcept(rctx, fc.Args["code"].(string), fc.Args["from"].(dto.TerminologySource), fc.Args["to"].(dto.TerminologySource))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*dto.ConceptTranslation)
+	fc.Result = res
+	return ec.marshalNConceptTranslation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptTranslationᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_translateConcept(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "code":
+				return ec.fieldContext_ConceptTranslation_code(ctx, field)
+			case "system":
+				return ec.fieldContext_ConceptTranslation_system(ctx, field)
+			case "name":
+				return ec.fieldContext_ConceptTranslation_name(ctx, field)
+			case "equivalence":
+				return ec.fieldContext_ConceptTranslation_equivalence(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ConceptTranslation", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_translateConcept_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query__service(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query__service(ctx, field)
 	if err != nil {
@@ -10160,6 +12758,42 @@ func (ec *executionContext) unmarshalInputReactionInput(ctx context.Context, obj
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputRetirePatientInput(ctx context.Context, obj interface{}) (domain.RetirePatientInput, error) {
+	var it domain.RetirePatientInput
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"id", "reason"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "id":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+			it.ID, err = ec.unmarshalNID2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "reason":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("reason"))
+			it.Reason, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 // endregion **************************** input.gotpl *****************************
 
 // region    ************************** interface.gotpl ***************************
@@ -10281,6 +12915,163 @@ func (ec *executionContext) _AllergyEdge(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
+var allergyHistoryConnectionImplementors = []string{"AllergyHistoryConnection"}
+
+func (ec *executionContext) _AllergyHistoryConnection(ctx context.Context, sel ast.SelectionSet, obj *dto.AllergyHistoryConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, allergyHistoryConnectionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AllergyHistoryConnection")
+		case "totalCount":
+
+			out.Values[i] = ec._AllergyHistoryConnection_totalCount(ctx, field, obj)
+
+		case "edges":
+
+			out.Values[i] = ec._AllergyHistoryConnection_edges(ctx, field, obj)
+
+		case "pageInfo":
+
+			out.Values[i] = ec._AllergyHistoryConnection_pageInfo(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var allergyVersionImplementors = []string{"AllergyVersion"}
+
+func (ec *executionContext) _AllergyVersion(ctx context.Context, sel ast.SelectionSet, obj *dto.AllergyVersion) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, allergyVersionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AllergyVersion")
+		case "versionID":
+
+			out.Values[i] = ec._AllergyVersion_versionID(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "timestamp":
+
+			out.Values[i] = ec._AllergyVersion_timestamp(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "author":
+
+			out.Values[i] = ec._AllergyVersion_author(ctx, field, obj)
+
+		case "allergy":
+
+			out.Values[i] = ec._AllergyVersion_allergy(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var allergyVersionEdgeImplementors = []string{"AllergyVersionEdge"}
+
+func (ec *executionContext) _AllergyVersionEdge(ctx context.Context, sel ast.SelectionSet, obj *dto.AllergyVersionEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, allergyVersionEdgeImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AllergyVersionEdge")
+		case "node":
+
+			out.Values[i] = ec._AllergyVersionEdge_node(ctx, field, obj)
+
+		case "cursor":
+
+			out.Values[i] = ec._AllergyVersionEdge_cursor(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var conceptTranslationImplementors = []string{"ConceptTranslation"}
+
+func (ec *executionContext) _ConceptTranslation(ctx context.Context, sel ast.SelectionSet, obj *dto.ConceptTranslation) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, conceptTranslationImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ConceptTranslation")
+		case "code":
+
+			out.Values[i] = ec._ConceptTranslation_code(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "system":
+
+			out.Values[i] = ec._ConceptTranslation_system(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "name":
+
+			out.Values[i] = ec._ConceptTranslation_name(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "equivalence":
+
+			out.Values[i] = ec._ConceptTranslation_equivalence(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var conditionImplementors = []string{"Condition"}
 
 func (ec *executionContext) _Condition(ctx context.Context, sel ast.SelectionSet, obj *dto.Condition) graphql.Marshaler {
@@ -10410,6 +13201,114 @@ func (ec *executionContext) _ConditionEdge(ctx context.Context, sel ast.Selectio
 	return out
 }
 
+var conditionHistoryConnectionImplementors = []string{"ConditionHistoryConnection"}
+
+func (ec *executionContext) _ConditionHistoryConnection(ctx context.Context, sel ast.SelectionSet, obj *dto.ConditionHistoryConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, conditionHistoryConnectionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ConditionHistoryConnection")
+		case "totalCount":
+
+			out.Values[i] = ec._ConditionHistoryConnection_totalCount(ctx, field, obj)
+
+		case "edges":
+
+			out.Values[i] = ec._ConditionHistoryConnection_edges(ctx, field, obj)
+
+		case "pageInfo":
+
+			out.Values[i] = ec._ConditionHistoryConnection_pageInfo(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var conditionVersionImplementors = []string{"ConditionVersion"}
+
+func (ec *executionContext) _ConditionVersion(ctx context.Context, sel ast.SelectionSet, obj *dto.ConditionVersion) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, conditionVersionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ConditionVersion")
+		case "versionID":
+
+			out.Values[i] = ec._ConditionVersion_versionID(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "timestamp":
+
+			out.Values[i] = ec._ConditionVersion_timestamp(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "author":
+
+			out.Values[i] = ec._ConditionVersion_author(ctx, field, obj)
+
+		case "condition":
+
+			out.Values[i] = ec._ConditionVersion_condition(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var conditionVersionEdgeImplementors = []string{"ConditionVersionEdge"}
+
+func (ec *executionContext) _ConditionVersionEdge(ctx context.Context, sel ast.SelectionSet, obj *dto.ConditionVersionEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, conditionVersionEdgeImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ConditionVersionEdge")
+		case "node":
+
+			out.Values[i] = ec._ConditionVersionEdge_node(ctx, field, obj)
+
+		case "cursor":
+
+			out.Values[i] = ec._ConditionVersionEdge_cursor(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var encounterImplementors = []string{"Encounter"}
 
 func (ec *executionContext) _Encounter(ctx context.Context, sel ast.SelectionSet, obj *dto.Encounter) graphql.Marshaler {
@@ -10440,6 +13339,10 @@ func (ec *executionContext) _Encounter(ctx context.Context, sel ast.SelectionSet
 
 			out.Values[i] = ec._Encounter_patientID(ctx, field, obj)
 
+		case "duration":
+
+			out.Values[i] = ec._Encounter_duration(ctx, field, obj)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -10828,6 +13731,51 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 				return ec._Mutation_createPatient(ctx, field)
 			})
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "retirePatient":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_retirePatient(ctx, field)
+			})
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "reinstatePatient":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_reinstatePatient(ctx, field)
+			})
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "mergePatients":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_mergePatients(ctx, field)
+			})
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "unmergePatient":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_unmergePatient(ctx, field)
+			})
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "deletePatient":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_deletePatient(ctx, field)
+			})
+
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -11006,6 +13954,84 @@ func (ec *executionContext) _Patient(ctx context.Context, sel ast.SelectionSet,
 
 			out.Values[i] = ec._Patient_birthDate(ctx, field, obj)
 
+		case "retirement":
+
+			out.Values[i] = ec._Patient_retirement(ctx, field, obj)
+
+		case "mergedInto":
+
+			out.Values[i] = ec._Patient_mergedInto(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var patientDeletionImplementors = []string{"PatientDeletion"}
+
+func (ec *executionContext) _PatientDeletion(ctx context.Context, sel ast.SelectionSet, obj *dto.PatientDeletion) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, patientDeletionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PatientDeletion")
+		case "dryRun":
+
+			out.Values[i] = ec._PatientDeletion_dryRun(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "deleted":
+
+			out.Values[i] = ec._PatientDeletion_deleted(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var patientRetirementImplementors = []string{"PatientRetirement"}
+
+func (ec *executionContext) _PatientRetirement(ctx context.Context, sel ast.SelectionSet, obj *dto.PatientRetirement) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, patientRetirementImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PatientRetirement")
+		case "reason":
+
+			out.Values[i] = ec._PatientRetirement_reason(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "retiredAt":
+
+			out.Values[i] = ec._PatientRetirement_retiredAt(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -11076,6 +14102,29 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "exportPatientRecord":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_exportPatientRecord(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
@@ -11116,6 +14165,26 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "conditionHistory":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_conditionHistory(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
@@ -11239,7 +14308,47 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "getPatientBMIEntries":
+		case "getPatientBMIEntries":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_getPatientBMIEntries(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "getPatientWeightEntries":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_getPatientWeightEntries(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "searchAllergy":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -11248,7 +14357,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_getPatientBMIEntries(ctx, field)
+				res = ec._Query_searchAllergy(ctx, field)
 				return res
 			}
 
@@ -11259,7 +14368,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "getPatientWeightEntries":
+		case "getAllergy":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -11268,7 +14377,10 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_getPatientWeightEntries(ctx, field)
+				res = ec._Query_getAllergy(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
 				return res
 			}
 
@@ -11279,7 +14391,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "searchAllergy":
+		case "listPatientAllergies":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -11288,7 +14400,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_searchAllergy(ctx, field)
+				res = ec._Query_listPatientAllergies(ctx, field)
 				return res
 			}
 
@@ -11299,7 +14411,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "getAllergy":
+		case "allergyHistory":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -11308,10 +14420,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_getAllergy(ctx, field)
-				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
+				res = ec._Query_allergyHistory(ctx, field)
 				return res
 			}
 
@@ -11322,7 +14431,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "listPatientAllergies":
+		case "translateConcept":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -11331,7 +14440,10 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_listPatientAllergies(ctx, field)
+				res = ec._Query_translateConcept(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
 				return res
 			}
 
@@ -11888,6 +15000,76 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 	return res
 }
 
+func (ec *executionContext) unmarshalNConceptEquivalence2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptEquivalence(ctx context.Context, v interface{}) (dto.ConceptEquivalence, error) {
+	tmp, err := graphql.UnmarshalString(v)
+	res := dto.ConceptEquivalence(tmp)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNConceptEquivalence2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptEquivalence(ctx context.Context, sel ast.SelectionSet, v dto.ConceptEquivalence) graphql.Marshaler {
+	res := graphql.MarshalString(string(v))
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) marshalNConceptTranslation2ᚕᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptTranslationᚄ(ctx context.Context, sel ast.SelectionSet, v []*dto.ConceptTranslation) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNConceptTranslation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptTranslation(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNConceptTranslation2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConceptTranslation(ctx context.Context, sel ast.SelectionSet, v *dto.ConceptTranslation) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ConceptTranslation(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNCondition2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐCondition(ctx context.Context, sel ast.SelectionSet, v dto.Condition) graphql.Marshaler {
 	return ec._Condition(ctx, sel, &v)
 }
@@ -12095,6 +15277,27 @@ func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.Selecti
 	return res
 }
 
+func (ec *executionContext) unmarshalNMap2map(ctx context.Context, v interface{}) (map[string]interface{}, error) {
+	res, err := graphql.UnmarshalMap(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]interface{}) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	res := graphql.MarshalMap(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNMedication2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐMedication(ctx context.Context, sel ast.SelectionSet, v dto.Medication) graphql.Marshaler {
 	return ec._Medication(ctx, sel, &v)
 }
@@ -12153,11 +15356,30 @@ func (ec *executionContext) marshalNPatient2ᚖgithubᚗcomᚋsavannahghiᚋclin
 	return ec._Patient(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNPatientDeletion2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatientDeletion(ctx context.Context, sel ast.SelectionSet, v dto.PatientDeletion) graphql.Marshaler {
+	return ec._PatientDeletion(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNPatientDeletion2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatientDeletion(ctx context.Context, sel ast.SelectionSet, v *dto.PatientDeletion) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PatientDeletion(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNPatientInput2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatientInput(ctx context.Context, v interface{}) (dto.PatientInput, error) {
 	res, err := ec.unmarshalInputPatientInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNRetirePatientInput2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋdomainᚐRetirePatientInput(ctx context.Context, v interface{}) (domain.RetirePatientInput, error) {
+	res, err := ec.unmarshalInputRetirePatientInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -12221,6 +15443,21 @@ func (ec *executionContext) marshalNTerminologySource2githubᚗcomᚋsavannahghi
 	return res
 }
 
+func (ec *executionContext) unmarshalNTime2timeᚐTime(ctx context.Context, v interface{}) (time.Time, error) {
+	res, err := graphql.UnmarshalTime(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
+	res := graphql.MarshalTime(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) unmarshalN_FieldSet2string(ctx context.Context, v interface{}) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -12597,6 +15834,13 @@ func (ec *executionContext) marshalOAllergyEdge2ᚕgithubᚗcomᚋsavannahghiᚋ
 	return ret
 }
 
+func (ec *executionContext) marshalOAllergyHistoryConnection2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyHistoryConnection(ctx context.Context, sel ast.SelectionSet, v *dto.AllergyHistoryConnection) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._AllergyHistoryConnection(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOAllergyIntoleranceReactionSeverityEnum2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyIntoleranceReactionSeverityEnum(ctx context.Context, v interface{}) (dto.AllergyIntoleranceReactionSeverityEnum, error) {
 	tmp, err := graphql.UnmarshalString(v)
 	res := dto.AllergyIntoleranceReactionSeverityEnum(tmp)
@@ -12608,6 +15852,55 @@ func (ec *executionContext) marshalOAllergyIntoleranceReactionSeverityEnum2githu
 	return res
 }
 
+func (ec *executionContext) marshalOAllergyVersion2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyVersion(ctx context.Context, sel ast.SelectionSet, v dto.AllergyVersion) graphql.Marshaler {
+	return ec._AllergyVersion(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalOAllergyVersionEdge2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyVersionEdge(ctx context.Context, sel ast.SelectionSet, v dto.AllergyVersionEdge) graphql.Marshaler {
+	return ec._AllergyVersionEdge(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalOAllergyVersionEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyVersionEdge(ctx context.Context, sel ast.SelectionSet, v []dto.AllergyVersionEdge) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalOAllergyVersionEdge2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐAllergyVersionEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -12690,6 +15983,13 @@ func (ec *executionContext) marshalOConditionEdge2ᚕgithubᚗcomᚋsavannahghi
 	return ret
 }
 
+func (ec *executionContext) marshalOConditionHistoryConnection2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionHistoryConnection(ctx context.Context, sel ast.SelectionSet, v *dto.ConditionHistoryConnection) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._ConditionHistoryConnection(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOConditionStatus2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionStatus(ctx context.Context, v interface{}) (dto.ConditionStatus, error) {
 	tmp, err := graphql.UnmarshalString(v)
 	res := dto.ConditionStatus(tmp)
@@ -12701,6 +16001,55 @@ func (ec *executionContext) marshalOConditionStatus2githubᚗcomᚋsavannahghi
 	return res
 }
 
+func (ec *executionContext) marshalOConditionVersion2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionVersion(ctx context.Context, sel ast.SelectionSet, v dto.ConditionVersion) graphql.Marshaler {
+	return ec._ConditionVersion(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalOConditionVersionEdge2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionVersionEdge(ctx context.Context, sel ast.SelectionSet, v dto.ConditionVersionEdge) graphql.Marshaler {
+	return ec._ConditionVersionEdge(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalOConditionVersionEdge2ᚕgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionVersionEdge(ctx context.Context, sel ast.SelectionSet, v []dto.ConditionVersionEdge) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalOConditionVersionEdge2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐConditionVersionEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalODate2githubᚗcomᚋsavannahghiᚋscalarutilsᚐDate(ctx context.Context, v interface{}) (scalarutils.Date, error) {
 	var res scalarutils.Date
 	err := res.UnmarshalGQL(v)
@@ -12822,6 +16171,22 @@ func (ec *executionContext) marshalOID2string(ctx context.Context, sel ast.Selec
 	return res
 }
 
+func (ec *executionContext) unmarshalOID2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalID(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOID2ᚖstring(ctx context.Context, sel ast.SelectionSet, v *string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalID(*v)
+	return res
+}
+
 func (ec *executionContext) unmarshalOInt2int(ctx context.Context, v interface{}) (int, error) {
 	res, err := graphql.UnmarshalInt(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -13013,6 +16378,13 @@ func (ec *executionContext) marshalOPageInfo2githubᚗcomᚋsavannahghiᚋclinic
 	return ec._PageInfo(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalOPatientRetirement2ᚖgithubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐPatientRetirement(ctx context.Context, sel ast.SelectionSet, v *dto.PatientRetirement) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._PatientRetirement(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOReaction2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐReaction(ctx context.Context, sel ast.SelectionSet, v dto.Reaction) graphql.Marshaler {
 	return ec._Reaction(ctx, sel, &v)
 }
@@ -13121,6 +16493,22 @@ func (ec *executionContext) marshalOTerminologySource2githubᚗcomᚋsavannahghi
 	return res
 }
 
+func (ec *executionContext) unmarshalOTime2ᚖtimeᚐTime(ctx context.Context, v interface{}) (*time.Time, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalTime(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOTime2ᚖtimeᚐTime(ctx context.Context, sel ast.SelectionSet, v *time.Time) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalTime(*v)
+	return res
+}
+
 func (ec *executionContext) marshalOTimelineResource2githubᚗcomᚋsavannahghiᚋclinicalᚋpkgᚋclinicalᚋapplicationᚋdtoᚐTimelineResource(ctx context.Context, sel ast.SelectionSet, v dto.TimelineResource) graphql.Marshaler {
 	return ec._TimelineResource(ctx, sel, &v)
 }
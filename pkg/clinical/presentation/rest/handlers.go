@@ -2,14 +2,19 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/savannahghi/clinical/pkg/clinical/application/common"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/clinical/pkg/clinical/usecases"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
 	"github.com/savannahghi/errorcodeutil"
 	"github.com/savannahghi/pubsubtools"
 	"github.com/savannahghi/serverutils"
@@ -272,3 +277,181 @@ func (p PresentationHandlersImpl) RegisterFacility(c *gin.Context) {
 
 	c.JSON(http.StatusOK, organization)
 }
+
+// bulkExportManifest lists the files of a completed bulk export
+type bulkExportManifest struct {
+	TransactionTime     time.Time                `json:"transactionTime"`
+	Request             string                   `json:"request"`
+	RequiresAccessToken bool                     `json:"requiresAccessToken"`
+	Output              []bulkExportManifestFile `json:"output"`
+	Error               []bulkExportManifestFile `json:"error"`
+}
+
+// bulkExportManifestFile is a NDJSON file in a bulk export manifest
+type bulkExportManifestFile struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Count int    `json:"count"`
+}
+
+// bulkExportLocation is the path of a bulk export's status
+func bulkExportLocation(id string) string {
+	return fmt.Sprintf("/api/v1/bulk-exports/%s", id)
+}
+
+// bulkExportErrorStatus is the HTTP status of a failed bulk export request
+func bulkExportErrorStatus(err error) int {
+	if errors.Is(err, clinicalUsecase.ErrBulkExportNotFound) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusBadRequest
+}
+
+// StartBulkExport kicks off an export of the tenant's resources to NDJSON
+// files. The resource types can be limited with a comma separated `_type`
+// parameter. The export's status is at the returned `Content-Location`
+func (p PresentationHandlersImpl) StartBulkExport(c *gin.Context) {
+	types := []string{}
+
+	for _, resourceType := range strings.Split(c.Query("_type"), ",") {
+		if resourceType = strings.TrimSpace(resourceType); resourceType != "" {
+			types = append(types, resourceType)
+		}
+	}
+
+	job, err := p.usecases.StartBulkExport(c.Request.Context(), types)
+	if err != nil {
+		jsonErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.Header("Content-Location", bulkExportLocation(job.ID))
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetBulkExport reports the progress of a bulk export and, once it has
+// completed, returns the manifest of its files
+func (p PresentationHandlersImpl) GetBulkExport(c *gin.Context) {
+	job, err := p.usecases.GetBulkExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		jsonErrorResponse(c, bulkExportErrorStatus(err), err)
+		return
+	}
+
+	switch job.Status {
+	case dto.BulkExportStatusInProgress:
+		c.Header("X-Progress", fmt.Sprintf("%d of %d resource types exported", len(job.Output), len(job.Types)))
+		c.Header("Retry-After", "5")
+		c.JSON(http.StatusAccepted, job)
+
+	case dto.BulkExportStatusFailed:
+		c.JSON(http.StatusInternalServerError, job)
+
+	default:
+		manifest := bulkExportManifest{
+			TransactionTime:     job.TransactionTime,
+			Request:             fmt.Sprintf("/api/v1/$export?_type=%s", strings.Join(job.Types, ",")),
+			RequiresAccessToken: true,
+			Output:              []bulkExportManifestFile{},
+			Error:               []bulkExportManifestFile{},
+		}
+
+		for _, output := range job.Output {
+			manifest.Output = append(manifest.Output, bulkExportManifestFile{
+				Type:  output.Type,
+				URL:   fmt.Sprintf("%s/%s", bulkExportLocation(job.ID), output.Type),
+				Count: output.Count,
+			})
+		}
+
+		c.JSON(http.StatusOK, manifest)
+	}
+}
+
+// DownloadBulkExportFile streams the NDJSON file of a resource type in a bulk
+// export
+func (p PresentationHandlersImpl) DownloadBulkExportFile(c *gin.Context) {
+	file, err := p.usecases.OpenBulkExportFile(c.Request.Context(), c.Param("id"), c.Param("resourceType"))
+	if err != nil {
+		jsonErrorResponse(c, bulkExportErrorStatus(err), err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "application/fhir+ndjson", file, nil)
+}
+
+// DeleteBulkExport cancels a bulk export and deletes its files
+func (p PresentationHandlersImpl) DeleteBulkExport(c *gin.Context) {
+	err := p.usecases.DeleteBulkExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		jsonErrorResponse(c, bulkExportErrorStatus(err), err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// BulkImport loads NDJSON files of resources into the tenant's facility. The
+// files are sent as the `file` fields of a multipart form, or a single file
+// as the request body. The response reports the lines that were not imported
+func (p PresentationHandlersImpl) BulkImport(c *gin.Context) {
+	files := []dto.BulkImportFile{}
+
+	if c.ContentType() == "multipart/form-data" {
+		form, err := c.MultipartForm()
+		if err != nil {
+			jsonErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+
+		for _, header := range form.File["file"] {
+			file, err := header.Open()
+			if err != nil {
+				jsonErrorResponse(c, http.StatusBadRequest, err)
+				return
+			}
+			defer file.Close()
+
+			files = append(files, dto.BulkImportFile{Name: header.Filename, Content: file})
+		}
+
+		if len(files) == 0 {
+			jsonErrorResponse(c, http.StatusBadRequest, fmt.Errorf("no files to import"))
+			return
+		}
+	} else {
+		files = append(files, dto.BulkImportFile{Name: "request", Content: c.Request.Body})
+	}
+
+	report, err := p.usecases.BulkImport(c.Request.Context(), files)
+	if err != nil {
+		jsonErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportPatientRecord downloads every resource in the patient's compartment
+// as a FHIR Bundle
+func (p PresentationHandlersImpl) ExportPatientRecord(c *gin.Context) {
+	patientID := c.Param("id")
+
+	record, err := p.usecases.ExportPatientRecord(c.Request.Context(), patientID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrFHIRResourceNotFound) {
+			status = http.StatusNotFound
+		}
+
+		jsonErrorResponse(c, status, err)
+
+		return
+	}
+
+	c.Header("Content-Type", "application/fhir+json")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="patient-%s.json"`, patientID))
+	c.JSON(http.StatusOK, record)
+}
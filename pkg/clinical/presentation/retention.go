@@ -0,0 +1,74 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	"github.com/savannahghi/clinical/pkg/clinical/usecases"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultPurgeInterval is how often expired resources are purged when
+// `PURGE_INTERVAL` is not set
+const defaultPurgeInterval = 24 * time.Hour
+
+// startRetentionPurges purges expired resources in the background, once every
+// purge interval, when `RETENTION_POLICIES_PATH` is set. The policies are read
+// when the server starts. Purging stops when the context is done, and the
+// returned channel is closed once a purge that was in progress has finished
+func startRetentionPurges(ctx context.Context, usecases usecases.Interactor) (<-chan struct{}, error) {
+	stopped := make(chan struct{})
+
+	path := os.Getenv(common.RetentionPoliciesPathEnvVar)
+	if path == "" {
+		close(stopped)
+
+		return stopped, nil
+	}
+
+	policies, err := clinicalUsecase.ReadRetentionPolicies(path)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultPurgeInterval
+
+	if value := os.Getenv(common.PurgeIntervalEnvVar); value != "" {
+		interval, err = time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid purge interval %q", value)
+		}
+	}
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			report, err := usecases.PurgeExpiredResources(ctx, policies)
+			if err != nil {
+				utils.ReportErrorToSentry(err)
+				log.Errorf("unable to purge expired resources: %v", err)
+
+				continue
+			}
+
+			log.Infof("purge %s completed, the report is in %s", report.ID, report.Directory)
+		}
+	}()
+
+	return stopped, nil
+}
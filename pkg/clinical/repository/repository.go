@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
@@ -21,81 +22,117 @@ type FHIR interface {
 	FHIRComposition
 	FHIRMedicationStatement
 	FHIRMedication
+	FHIRTransaction
+	FHIRExport
+	FHIRImport
+	FHIRRetention
 }
 
 type FHIROrganization interface {
 	CreateFHIROrganization(ctx context.Context, input domain.FHIROrganizationInput) (*domain.FHIROrganizationRelayPayload, error)
-	SearchFHIROrganization(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error)
+	SearchFHIROrganization(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIROrganizationRelayConnection, error)
 	GetFHIROrganization(ctx context.Context, id string) (*domain.FHIROrganizationRelayPayload, error)
 }
 
 type FHIRPatient interface {
 	GetFHIRPatient(ctx context.Context, id string) (*domain.FHIRPatientRelayPayload, error)
+	GetFHIRPatientTimeline(ctx context.Context, patientID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatientTimeline, error)
+	GetFHIRPatientEverything(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRBundle, error)
+	RetireFHIRPatient(ctx context.Context, id, reason string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	ReinstateFHIRPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	MergeFHIRPatients(ctx context.Context, sourceID, targetID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
+	UnmergeFHIRPatient(ctx context.Context, sourceID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error)
 	DeleteFHIRPatient(ctx context.Context, id string) (bool, error)
+	CascadeDeleteFHIRPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers, dryRun bool) (*domain.FHIRDeletion, error)
 	CreateFHIRPatient(ctx context.Context, input domain.FHIRPatientInput) (*domain.PatientPayload, error)
 	PatchFHIRPatient(ctx context.Context, id string, params []map[string]interface{}) (*domain.FHIRPatient, error)
 	SearchFHIRPatient(ctx context.Context, searchParams string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PatientConnection, error)
 }
 type FHIREpisodeOfCare interface {
-	SearchFHIREpisodeOfCare(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error)
-	SearchEpisodesByParam(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error)
+	SearchFHIREpisodeOfCare(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error)
+	SearchEpisodesByParam(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error)
 	GetFHIREpisodeOfCare(ctx context.Context, id string) (*domain.FHIREpisodeOfCareRelayPayload, error)
+	GetFHIREpisodeOfCareWithEncounters(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREpisodeOfCareRelayPayload, []domain.FHIREncounter, error)
 	CreateEpisodeOfCare(ctx context.Context, episode domain.FHIREpisodeOfCareInput) (*domain.EpisodeOfCarePayload, error)
 	UpdateFHIREpisodeOfCare(ctx context.Context, fhirResourceID string, payload map[string]interface{}) (*domain.FHIREpisodeOfCare, error)
 	HasOpenEpisode(ctx context.Context, patient domain.FHIRPatient, tenant dto.TenantIdentifiers, pagination dto.Pagination) (bool, error)
 	OpenEpisodes(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIREpisodeOfCare, error)
-	EndEpisode(ctx context.Context, episodeID string) (bool, error)
+	EndEpisode(ctx context.Context, episodeID string, endTime time.Time) (bool, error)
 	GetActiveEpisode(ctx context.Context, episodeID string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCare, error)
 }
 type FHIRObservation interface {
-	SearchFHIRObservation(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error)
+	SearchFHIRObservation(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error)
 	CreateFHIRObservation(ctx context.Context, input domain.FHIRObservationInput) (*domain.FHIRObservationRelayPayload, error)
 	DeleteFHIRObservation(ctx context.Context, id string) (bool, error)
 	SearchPatientObservations(ctx context.Context, patientReference, observationCode string, tenant dto.TenantIdentifiers, pagination dto.Pagination) ([]*domain.FHIRObservation, error)
 }
 type FHIRAllergyIntolerance interface {
-	SearchFHIRAllergyIntolerance(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
+	SearchFHIRAllergyIntolerance(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
 	CreateFHIRAllergyIntolerance(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
 	UpdateFHIRAllergyIntolerance(ctx context.Context, input domain.FHIRAllergyIntoleranceInput) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
 	GetFHIRAllergyIntolerance(ctx context.Context, id string) (*domain.FHIRAllergyIntoleranceRelayPayload, error)
 	SearchPatientAllergyIntolerance(ctx context.Context, patientReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error)
+	GetFHIRAllergyIntoleranceHistory(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergyHistory, error)
 }
 type FHIRServiceRequest interface {
-	SearchFHIRServiceRequest(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error)
+	SearchFHIRServiceRequest(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRServiceRequestRelayConnection, error)
 	CreateFHIRServiceRequest(ctx context.Context, input domain.FHIRServiceRequestInput) (*domain.FHIRServiceRequestRelayPayload, error)
 	DeleteFHIRServiceRequest(ctx context.Context, id string) (bool, error)
 }
 type FHIRMedicationRequest interface {
-	SearchFHIRMedicationRequest(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error)
+	SearchFHIRMedicationRequest(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationRequestRelayConnection, error)
 	CreateFHIRMedicationRequest(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error)
 	UpdateFHIRMedicationRequest(ctx context.Context, input domain.FHIRMedicationRequestInput) (*domain.FHIRMedicationRequestRelayPayload, error)
 	DeleteFHIRMedicationRequest(ctx context.Context, id string) (bool, error)
 }
 type FHIRCondition interface {
-	SearchFHIRCondition(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error)
+	SearchFHIRCondition(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error)
 	CreateFHIRCondition(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error)
 	UpdateFHIRCondition(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error)
+	GetFHIRConditionHistory(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRConditionHistory, error)
 }
 type FHIREncounter interface {
 	CreateFHIREncounter(ctx context.Context, input domain.FHIREncounterInput) (*domain.FHIREncounterRelayPayload, error)
 	SearchPatientEncounters(ctx context.Context, patientReference string, status *domain.EncounterStatusEnum, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
 	StartEncounter(ctx context.Context, episodeID string) (string, error)
 	SearchEpisodeEncounter(ctx context.Context, episodeReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
-	EndEncounter(ctx context.Context, encounterID string) (bool, error)
+	EndEncounter(ctx context.Context, encounterID string, endTime time.Time) (bool, error)
 	GetFHIREncounter(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error)
-	SearchFHIREncounter(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
+	GetFHIREncounterWithPatient(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error)
+	SearchFHIREncounter(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error)
 }
 type FHIRComposition interface {
-	SearchFHIRComposition(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error)
+	SearchFHIRComposition(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRCompositionRelayConnection, error)
 	CreateFHIRComposition(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error)
 	UpdateFHIRComposition(ctx context.Context, input domain.FHIRCompositionInput) (*domain.FHIRCompositionRelayPayload, error)
 	DeleteFHIRComposition(ctx context.Context, id string) (bool, error)
 }
 type FHIRMedicationStatement interface {
 	CreateFHIRMedicationStatement(ctx context.Context, input domain.FHIRMedicationStatementInput) (*domain.FHIRMedicationStatementRelayPayload, error)
-	SearchFHIRMedicationStatement(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error)
+	SearchFHIRMedicationStatement(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error)
 }
 
 type FHIRMedication interface {
 	CreateFHIRMedication(ctx context.Context, input domain.FHIRMedicationInput) (*domain.FHIRMedicationRelayPayload, error)
 }
+
+// FHIRTransaction groups FHIR writes so that they commit or fail as one
+type FHIRTransaction interface {
+	RunInTransaction(ctx context.Context, work func(fhir FHIR) error) error
+}
+
+// FHIRExport reads a tenant's resources in bulk
+type FHIRExport interface {
+	ExportFHIRResources(ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error) error
+}
+
+// FHIRImport writes resources loaded in bulk
+type FHIRImport interface {
+	ImportFHIRResource(ctx context.Context, resourceType string, payload map[string]interface{}) (string, error)
+}
+
+// FHIRRetention finds and removes the resources whose retention period has passed
+type FHIRRetention interface {
+	SearchExpiredFHIRResources(ctx context.Context, resourceType string, cutoff time.Time, tenant dto.TenantIdentifiers, fn func(resource map[string]interface{}) error) error
+	PurgeFHIRResource(ctx context.Context, resourceType, id string) error
+}
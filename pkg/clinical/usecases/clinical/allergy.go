@@ -3,7 +3,6 @@ package clinical
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
@@ -18,17 +17,17 @@ func (c *UseCasesClinicalImpl) CreateAllergyIntolerance(ctx context.Context, inp
 		return nil, err
 	}
 
-	encounter, err := c.infrastructure.FHIR.GetFHIREncounter(ctx, input.EncounterID)
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
 	}
 
-	patient, err := c.infrastructure.FHIR.GetFHIRPatient(ctx, *encounter.Resource.Subject.ID)
+	encounter, patient, err := c.infrastructure.FHIR.GetFHIREncounterWithPatient(ctx, input.EncounterID, *identifiers)
 	if err != nil {
 		return nil, err
 	}
 
-	allergyConcept, err := c.GetConcept(ctx, input.TerminologySource, input.Code)
+	allergyConcept, err := c.getConcept(ctx, input.TerminologySource, input.Code, true)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +37,8 @@ func (c *UseCasesClinicalImpl) CreateAllergyIntolerance(ctx context.Context, inp
 
 	allergyIntoleranceTypeAllergy := domain.AllergyIntoleranceTypeEnumAllergy
 
+	today := c.now(ctx)
+
 	clinicalStatusCodeActive := "active"
 	verificationDisplay := "confirmed"
 
@@ -70,9 +71,9 @@ func (c *UseCasesClinicalImpl) CreateAllergyIntolerance(ctx context.Context, inp
 			ID: encounter.Resource.ID,
 		},
 		RecordedDate: &scalarutils.Date{
-			Year:  time.Now().Year(),
-			Month: int(time.Now().Month()),
-			Day:   time.Now().Day(),
+			Year:  today.Year(),
+			Month: int(today.Month()),
+			Day:   today.Day(),
 		},
 		Type: &allergyIntoleranceTypeAllergy,
 		VerificationStatus: domain.FHIRCodeableConceptInput{
@@ -87,6 +88,8 @@ func (c *UseCasesClinicalImpl) CreateAllergyIntolerance(ctx context.Context, inp
 		},
 	}
 
+	allergyIntoleranceInput.Code.Coding = append(allergyIntoleranceInput.Code.Coding, c.equivalentCodings(ctx, allergyConcept, input.TerminologySource)...)
+
 	if input.Reaction != nil {
 		manifestationConcept, err := c.GetConcept(ctx, dto.TerminologySourceCIEL, input.Reaction.Code)
 		if err != nil {
@@ -118,6 +121,11 @@ func (c *UseCasesClinicalImpl) CreateAllergyIntolerance(ctx context.Context, inp
 		Tag: tags,
 	}
 
+	allergyIntoleranceInput.Recorder, err = c.recorder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	allergyIntolerance, err := c.infrastructure.FHIR.CreateFHIRAllergyIntolerance(ctx, allergyIntoleranceInput)
 	if err != nil {
 		return nil, err
@@ -208,3 +216,53 @@ func (c *UseCasesClinicalImpl) ListPatientAllergies(ctx context.Context, patient
 
 	return &connection, nil
 }
+
+// AllergyHistory lists the versions of an allergy intolerance, newest first, so that changes to an allergy can be audited
+func (c *UseCasesClinicalImpl) AllergyHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.AllergyHistoryConnection, error) {
+	_, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allergy intolerance id: %s", id)
+	}
+
+	err = pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	history, err := c.infrastructure.FHIR.GetFHIRAllergyIntoleranceHistory(ctx, id, *identifiers, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allergy intolerance history: %w", err)
+	}
+
+	versions := []dto.AllergyVersion{}
+
+	for _, version := range history.Versions {
+		output := dto.AllergyVersion{
+			Timestamp: version.LastUpdated,
+			Author:    authorOf(version.Resource.Recorder, version.Resource.Asserter),
+			Allergy:   *mapFHIRAllergyIntoleranceToAllergyIntoleranceDTO(version.Resource),
+		}
+
+		if version.Resource.Meta != nil {
+			output.VersionID = version.Resource.Meta.VersionID
+		}
+
+		versions = append(versions, output)
+	}
+
+	pageInfo := dto.PageInfo{
+		HasNextPage:     history.HasNextPage,
+		EndCursor:       &history.NextCursor,
+		HasPreviousPage: history.HasPreviousPage,
+		StartCursor:     &history.PreviousCursor,
+	}
+
+	connection := dto.CreateAllergyHistoryConnection(versions, pageInfo, history.TotalCount)
+
+	return &connection, nil
+}
@@ -130,9 +130,9 @@ func TestUseCasesClinicalImpl_CreateAllergyIntolerance(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Sad case: failed to get fhir patient",
+			name: "Sad case: fail to get tags",
 			args: args{
-				ctx: context.Background(),
+				ctx: nil,
 				input: dto.AllergyInput{
 					PatientID:         gofakeit.UUID(),
 					Code:              "100",
@@ -148,7 +148,7 @@ func TestUseCasesClinicalImpl_CreateAllergyIntolerance(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Sad case: fail to get tags",
+			name: "Sad case: fail to get logged in user",
 			args: args{
 				ctx: nil,
 				input: dto.AllergyInput{
@@ -256,13 +256,8 @@ func TestUseCasesClinicalImpl_CreateAllergyIntolerance(t *testing.T) {
 			}
 
 			if tt.name == "Sad case: failed to get fhir encounter" {
-				fakeFHIR.MockGetFHIREncounterFn = func(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error) {
-					return nil, fmt.Errorf("an error occurred")
-				}
-			}
-			if tt.name == "Sad case: failed to get fhir patient" {
-				fakeFHIR.MockGetFHIRPatientFn = func(ctx context.Context, id string) (*domain.FHIRPatientRelayPayload, error) {
-					return nil, fmt.Errorf("an error occurred")
+				fakeFHIR.MockGetFHIREncounterWithPatientFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
+					return nil, nil, fmt.Errorf("an error occurred")
 				}
 			}
 			if tt.name == "Sad case: fail to get tags" {
@@ -270,6 +265,11 @@ func TestUseCasesClinicalImpl_CreateAllergyIntolerance(t *testing.T) {
 					return nil, fmt.Errorf("failed to get tags")
 				}
 			}
+			if tt.name == "Sad case: fail to get logged in user" {
+				fakeExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
 			_, err := c.CreateAllergyIntolerance(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesClinicalImpl.CreateAllergyIntolerance() error = %v, wantErr %v", err, tt.wantErr)
@@ -477,3 +477,88 @@ func TestUseCasesClinicalImpl_GetPatientAllergies(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesClinicalImpl_AllergyHistory(t *testing.T) {
+
+	type args struct {
+		ctx        context.Context
+		id         string
+		pagination dto.Pagination
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: list allergy history",
+			args: args{
+				ctx:        context.Background(),
+				id:         gofakeit.UUID(),
+				pagination: dto.Pagination{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid allergy id",
+			args: args{
+				ctx:        context.Background(),
+				id:         "invalid",
+				pagination: dto.Pagination{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to get allergy history",
+			args: args{
+				ctx:        context.Background(),
+				id:         gofakeit.UUID(),
+				pagination: dto.Pagination{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to get tenant identifiers",
+			args: args{
+				ctx:        context.Background(),
+				id:         gofakeit.UUID(),
+				pagination: dto.Pagination{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "sad case: fail to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("failed to get tenant identifiers")
+				}
+			}
+
+			if tt.name == "sad case: fail to get allergy history" {
+				fakeFHIR.MockGetFHIRAllergyIntoleranceHistoryFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergyHistory, error) {
+					return nil, fmt.Errorf("failed to get history")
+				}
+			}
+
+			got, err := c.AllergyHistory(tt.args.ctx, tt.args.id, tt.args.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AllergyHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got == nil || len(got.Edges) == 0 || got.Edges[0].Node.Author == "") {
+				t.Errorf("expected versions with an author to be returned, got: %v", got)
+				return
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package clinical
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -14,14 +13,14 @@ import (
 
 // CreateCondition creates a new conditions
 func (c *UseCasesClinicalImpl) CreateCondition(ctx context.Context, input dto.ConditionInput) (*dto.Condition, error) {
-	today := time.Now()
+	today := c.now(ctx)
 
 	date, err := scalarutils.NewDate(today.Day(), int(today.Month()), today.Year())
 	if err != nil {
 		return nil, err
 	}
 
-	conditionConcept, err := c.GetConcept(ctx, dto.TerminologySourceICD10, input.Code)
+	conditionConcept, err := c.getConcept(ctx, dto.TerminologySourceICD10, input.Code, true)
 	if err != nil {
 		return nil, err
 	}
@@ -78,13 +77,17 @@ func (c *UseCasesClinicalImpl) CreateCondition(ctx context.Context, input dto.Co
 		RecordedDate: date,
 	}
 
+	// reporting uses ICD-10 while clinicians pick CIEL, so the equivalent
+	// concepts are recorded with the condition
+	conditionInput.Code.Coding = append(conditionInput.Code.Coding, c.equivalentCodings(ctx, conditionConcept, dto.TerminologySourceICD10)...)
+
 	if input.OnsetDate != nil {
 		conditionInput.OnsetDateTime = input.OnsetDate
 	}
 
 	if input.Note != "" {
 		note := scalarutils.Markdown(input.Note)
-		noteTime := scalarutils.DateTime(time.Now().Format(scalarutils.DateTimeFormatLayout))
+		noteTime := domain.FormatDateTime(c.now(ctx))
 
 		conditionInput.Note = []*domain.FHIRAnnotationInput{
 			{
@@ -94,7 +97,12 @@ func (c *UseCasesClinicalImpl) CreateCondition(ctx context.Context, input dto.Co
 		}
 	}
 
-	encounter, err := c.infrastructure.FHIR.GetFHIREncounter(ctx, input.EncounterID)
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	encounter, patient, err := c.infrastructure.FHIR.GetFHIREncounterWithPatient(ctx, input.EncounterID, *identifiers)
 	if err != nil {
 		return nil, err
 	}
@@ -113,11 +121,6 @@ func (c *UseCasesClinicalImpl) CreateCondition(ctx context.Context, input dto.Co
 		Type:      &encounterType,
 	}
 
-	patient, err := c.infrastructure.FHIR.GetFHIRPatient(ctx, *encounter.Resource.Subject.ID)
-	if err != nil {
-		return nil, err
-	}
-
 	patientRef := fmt.Sprintf("Patient/%s", *patient.Resource.ID)
 	patientType := scalarutils.URI("Patient")
 
@@ -137,6 +140,11 @@ func (c *UseCasesClinicalImpl) CreateCondition(ctx context.Context, input dto.Co
 		Tag: tags,
 	}
 
+	conditionInput.Recorder, err = c.recorder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	condition, err := c.infrastructure.FHIR.CreateFHIRCondition(ctx, conditionInput)
 	if err != nil {
 		return nil, err
@@ -169,7 +177,6 @@ func mapFHIRConditionToConditionDTO(condition domain.FHIRCondition) *dto.Conditi
 }
 
 // ListPatientConditions lists a patients conditions
-// TODO: pagination
 func (c UseCasesClinicalImpl) ListPatientConditions(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.ConditionConnection, error) {
 	_, err := uuid.Parse(patientID)
 	if err != nil {
@@ -192,10 +199,9 @@ func (c UseCasesClinicalImpl) ListPatientConditions(ctx context.Context, patient
 	}
 
 	patientRef := fmt.Sprintf("Patient/%s", *patient.Resource.ID)
-	params := map[string]interface{}{
-		"subject": patientRef,
-		"_sort":   "date",
-	}
+	params := domain.NewSearchParams().
+		Reference("subject", patientRef).
+		Sort("date")
 
 	conditionsResponse, err := c.infrastructure.FHIR.SearchFHIRCondition(ctx, params, *identifiers, pagination)
 	if err != nil {
@@ -220,3 +226,53 @@ func (c UseCasesClinicalImpl) ListPatientConditions(ctx context.Context, patient
 
 	return &connection, nil
 }
+
+// ConditionHistory lists the versions of a condition, newest first, so that changes to a diagnosis can be audited
+func (c *UseCasesClinicalImpl) ConditionHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.ConditionHistoryConnection, error) {
+	_, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition id: %s", id)
+	}
+
+	err = pagination.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	history, err := c.infrastructure.FHIR.GetFHIRConditionHistory(ctx, id, *identifiers, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []dto.ConditionVersion{}
+
+	for _, version := range history.Versions {
+		output := dto.ConditionVersion{
+			Timestamp: version.LastUpdated,
+			Author:    authorOf(version.Resource.Recorder, version.Resource.Asserter),
+			Condition: *mapFHIRConditionToConditionDTO(version.Resource),
+		}
+
+		if version.Resource.Meta != nil {
+			output.VersionID = version.Resource.Meta.VersionID
+		}
+
+		versions = append(versions, output)
+	}
+
+	pageInfo := dto.PageInfo{
+		HasNextPage:     history.HasNextPage,
+		EndCursor:       &history.NextCursor,
+		HasPreviousPage: history.HasPreviousPage,
+		StartCursor:     &history.PreviousCursor,
+	}
+
+	connection := dto.CreateConditionHistoryConnection(versions, pageInfo, history.TotalCount)
+
+	return &connection, nil
+}
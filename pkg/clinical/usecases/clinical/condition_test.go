@@ -142,6 +142,25 @@ func TestUseCasesClinicalImpl_CreateCondition(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sad case: fail to get logged in user",
+			args: args{
+				ctx: nil,
+				input: dto.ConditionInput{
+					Code:        "386661006",
+					System:      "SNOMED",
+					Status:      dto.ConditionStatusActive,
+					EncounterID: gofakeit.UUID(),
+					Note:        "Fever Fever",
+					OnsetDate: &scalarutils.Date{
+						Year:  2022,
+						Month: 12,
+						Day:   12,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "sad case: fail to  create condition",
 			args: args{
@@ -179,14 +198,14 @@ func TestUseCasesClinicalImpl_CreateCondition(t *testing.T) {
 			}
 
 			if tt.name == "sad case: fail to get patient" {
-				fakeFHIR.MockGetFHIRPatientFn = func(ctx context.Context, id string) (*domain.FHIRPatientRelayPayload, error) {
-					return nil, fmt.Errorf("fail to get patient")
+				fakeFHIR.MockGetFHIREncounterWithPatientFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
+					return nil, nil, fmt.Errorf("fail to get patient")
 				}
 			}
 
 			if tt.name == "sad case: fail to get encounter" {
-				fakeFHIR.MockGetFHIREncounterFn = func(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error) {
-					return nil, fmt.Errorf("failed to ge encounter")
+				fakeFHIR.MockGetFHIREncounterWithPatientFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
+					return nil, nil, fmt.Errorf("failed to ge encounter")
 				}
 			}
 
@@ -198,12 +217,12 @@ func TestUseCasesClinicalImpl_CreateCondition(t *testing.T) {
 
 			if tt.name == "sad case: fail in completed encounter" {
 				finished := domain.EncounterStatusEnumFinished
-				fakeFHIR.MockGetFHIREncounterFn = func(ctx context.Context, id string) (*domain.FHIREncounterRelayPayload, error) {
+				fakeFHIR.MockGetFHIREncounterWithPatientFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREncounterRelayPayload, *domain.FHIRPatientRelayPayload, error) {
 					return &domain.FHIREncounterRelayPayload{
 						Resource: &domain.FHIREncounter{
 							Status: finished,
 						},
-					}, nil
+					}, &domain.FHIRPatientRelayPayload{}, nil
 				}
 			}
 
@@ -213,6 +232,12 @@ func TestUseCasesClinicalImpl_CreateCondition(t *testing.T) {
 				}
 			}
 
+			if tt.name == "sad case: fail to get logged in user" {
+				fakeExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("failed to get logged in user")
+				}
+			}
+
 			got, err := c.CreateCondition(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateCondition() error = %v, wantErr %v", err, tt.wantErr)
@@ -308,7 +333,7 @@ func TestUseCasesClinicalImpl_ListPatientConditions(t *testing.T) {
 			}
 
 			if tt.name == "sad case: fail to search condition" {
-				fakeFHIR.MockSearchFHIRConditionFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
+				fakeFHIR.MockSearchFHIRConditionFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRCondition, error) {
 					return nil, fmt.Errorf("failed to find condition")
 				}
 			}
@@ -325,3 +350,88 @@ func TestUseCasesClinicalImpl_ListPatientConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesClinicalImpl_ConditionHistory(t *testing.T) {
+
+	type args struct {
+		ctx        context.Context
+		id         string
+		pagination dto.Pagination
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: list condition history",
+			args: args{
+				ctx:        context.Background(),
+				id:         gofakeit.UUID(),
+				pagination: dto.Pagination{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid condition id",
+			args: args{
+				ctx:        context.Background(),
+				id:         "invalid",
+				pagination: dto.Pagination{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to get condition history",
+			args: args{
+				ctx:        context.Background(),
+				id:         gofakeit.UUID(),
+				pagination: dto.Pagination{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to get tenant identifiers",
+			args: args{
+				ctx:        context.Background(),
+				id:         gofakeit.UUID(),
+				pagination: dto.Pagination{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "sad case: fail to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("failed to get tenant identifiers")
+				}
+			}
+
+			if tt.name == "sad case: fail to get condition history" {
+				fakeFHIR.MockGetFHIRConditionHistoryFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRConditionHistory, error) {
+					return nil, fmt.Errorf("failed to get history")
+				}
+			}
+
+			got, err := c.ConditionHistory(tt.args.ctx, tt.args.id, tt.args.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConditionHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got == nil || len(got.Edges) == 0 || got.Edges[0].Node.Author == "") {
+				t.Errorf("expected versions with an author to be returned, got: %v", got)
+				return
+			}
+		})
+	}
+}
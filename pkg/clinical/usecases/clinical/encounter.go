@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/scalarutils"
 )
@@ -26,8 +27,7 @@ func (c *UseCasesClinicalImpl) StartEncounter(ctx context.Context, episodeID str
 	encounterClassVersion := "2018-08-12"
 	encounterClassDisplay := string(dto.EncounterClassAmbulatory)
 	encounterClassUserSelected := false
-	now := time.Now()
-	startTime := scalarutils.DateTime(now.Format("2006-01-02T15:04:05+03:00"))
+	startTime := domain.FormatDateTime(c.now(ctx))
 
 	episodeReference := fmt.Sprintf("EpisodeOfCare/%s", *episodeOfCare.Resource.ID)
 	encounterPayload := domain.FHIREncounterInput{
@@ -78,13 +78,19 @@ func (c *UseCasesClinicalImpl) StartEncounter(ctx context.Context, episodeID str
 	return *encounter.Resource.ID, nil
 }
 
-// EndEncounter marks an encounter as finished and updates the endtime field
-func (c *UseCasesClinicalImpl) EndEncounter(ctx context.Context, encounterID string) (bool, error) {
+// EndEncounter marks an encounter as finished and updates the endtime field. The
+// encounter ends now unless an earlier end time is given
+func (c *UseCasesClinicalImpl) EndEncounter(ctx context.Context, encounterID string, endTime *time.Time) (bool, error) {
 	if encounterID == "" {
 		return false, fmt.Errorf("an encounterID is required")
 	}
 
-	ok, err := c.infrastructure.FHIR.EndEncounter(ctx, encounterID)
+	end, err := c.endTime(endTime)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := c.infrastructure.FHIR.EndEncounter(ctx, encounterID, end)
 	if err != nil {
 		return false, err
 	}
@@ -92,6 +98,25 @@ func (c *UseCasesClinicalImpl) EndEncounter(ctx context.Context, encounterID str
 	return ok, nil
 }
 
+// endTime returns the time that an encounter or episode ends, which is now
+// unless the client gives the time. Times in the future are rejected
+func (c *UseCasesClinicalImpl) endTime(endTime *time.Time) (time.Time, error) {
+	now := c.clock.Now()
+
+	if endTime == nil {
+		return now, nil
+	}
+
+	if endTime.After(now) {
+		err := fmt.Errorf("the end time %s is in the future", endTime.Format(time.RFC3339))
+		message := "please provide an end time that is not in the future"
+
+		return time.Time{}, utils.NewCustomError(err, message)
+	}
+
+	return *endTime, nil
+}
+
 // ListPatientEncounters lists all the encounters that a patient has been part of
 func (c *UseCasesClinicalImpl) ListPatientEncounters(ctx context.Context, patientID string, pagination *dto.Pagination) (*dto.EncounterConnection, error) {
 	if patientID == "" {
@@ -149,6 +174,13 @@ func mapFHIREncounterToEncounterDTO(fhirEncounters []domain.FHIREncounter) []*dt
 			encounter.PatientID = *fhirEncounter.Subject.ID
 		}
 
+		if fhirEncounter.Period != nil && fhirEncounter.Status == domain.EncounterStatusEnumFinished {
+			if duration, ok := fhirEncounter.Period.Duration(); ok {
+				seconds := int(duration.Seconds())
+				encounter.Duration = &seconds
+			}
+		}
+
 		encounters = append(encounters, encounter)
 	}
 
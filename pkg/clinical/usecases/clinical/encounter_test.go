@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	fakeExtMock "github.com/savannahghi/clinical/pkg/clinical/application/extensions/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
 	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
 	fakeMyCarehubMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub/mock"
 	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
 	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+	"github.com/savannahghi/scalarutils"
 )
 
 func TestUseCasesClinicalImpl_StartEncounter(t *testing.T) {
@@ -110,11 +114,81 @@ func TestUseCasesClinicalImpl_StartEncounter(t *testing.T) {
 	}
 }
 
+func TestUseCasesClinicalImpl_StartEncounter_TimeZone(t *testing.T) {
+	at := time.Date(2023, time.March, 1, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timeZone string
+		want     scalarutils.DateTime
+	}{
+		{
+			name:     "Happy Case - start in the facility's time zone",
+			timeZone: "Africa/Lagos",
+			want:     "2023-03-01T06:00:00+01:00",
+		},
+		{
+			name:     "Happy Case - start in the default time zone",
+			timeZone: "",
+			want:     "2023-03-01T08:00:00+03:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(common.DefaultTimeZoneEnvVar, "")
+
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+			u.SetClock(domain.FixedClock{Time: at})
+
+			fakeFHIR.MockGetFHIROrganizationFn = func(ctx context.Context, organisationID string) (*domain.FHIROrganizationRelayPayload, error) {
+				name := "Facility"
+				organization := &domain.FHIROrganization{ID: &organisationID, Name: &name}
+				if tt.timeZone != "" {
+					organization.Extension = []*domain.Extension{{URL: domain.TimeZoneExtensionURL, ValueCode: tt.timeZone}}
+				}
+
+				return &domain.FHIROrganizationRelayPayload{Resource: organization}, nil
+			}
+
+			var start scalarutils.DateTime
+
+			fakeFHIR.MockCreateFHIREncounterFn = func(ctx context.Context, input domain.FHIREncounterInput) (*domain.FHIREncounterRelayPayload, error) {
+				start = input.Period.Start
+				id := uuid.New().String()
+
+				return &domain.FHIREncounterRelayPayload{Resource: &domain.FHIREncounter{ID: &id}}, nil
+			}
+
+			ctx := utils.WithTenant(context.Background(), uuid.New().String(), uuid.New().String())
+
+			_, err := u.StartEncounter(ctx, uuid.New().String())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if start != tt.want {
+				t.Errorf("expected the encounter to start at %s, got %s", tt.want, start)
+			}
+		})
+	}
+}
+
 func TestUseCasesClinicalImpl_EndEncounter(t *testing.T) {
 	ctx := context.Background()
+	now := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Minute)
+
 	type args struct {
 		ctx         context.Context
 		encounterID string
+		endTime     *time.Time
 	}
 	tests := []struct {
 		name    string
@@ -131,6 +205,26 @@ func TestUseCasesClinicalImpl_EndEncounter(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "Happy Case - Successfully end encounter at an earlier time",
+			args: args{
+				ctx:         ctx,
+				encounterID: uuid.New().String(),
+				endTime:     &earlier,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad Case - End time in the future",
+			args: args{
+				ctx:         ctx,
+				encounterID: uuid.New().String(),
+				endTime:     &later,
+			},
+			want:    false,
+			wantErr: true,
+		},
 		{
 			name: "Sad Case - Missing encounter ID",
 			args: args{
@@ -158,14 +252,28 @@ func TestUseCasesClinicalImpl_EndEncounter(t *testing.T) {
 
 			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
 			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+			u.SetClock(domain.FixedClock{Time: now})
+
+			wantEnd := now
+			if tt.args.endTime != nil {
+				wantEnd = *tt.args.endTime
+			}
+
+			fakeFHIR.MockEndEncounterFn = func(ctx context.Context, encounterID string, endTime time.Time) (bool, error) {
+				if !endTime.Equal(wantEnd) {
+					t.Errorf("expected the encounter to end at %v, got %v", wantEnd, endTime)
+				}
+
+				return true, nil
+			}
 
 			if tt.name == "Sad Case - Fail to end encounter" {
-				fakeFHIR.MockEndEncounterFn = func(ctx context.Context, encounterID string) (bool, error) {
+				fakeFHIR.MockEndEncounterFn = func(ctx context.Context, encounterID string, endTime time.Time) (bool, error) {
 					return false, fmt.Errorf("failed to update encounter")
 				}
 			}
 
-			got, err := u.EndEncounter(tt.args.ctx, tt.args.encounterID)
+			got, err := u.EndEncounter(tt.args.ctx, tt.args.encounterID, tt.args.endTime)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesClinicalImpl.EndEncounter() error = %v, wantErr %v", err, tt.wantErr)
 				return
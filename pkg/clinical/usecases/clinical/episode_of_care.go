@@ -3,12 +3,14 @@ package clinical
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/savannahghi/clinical/pkg/clinical/application/common"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/application/extensions"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
 	"github.com/savannahghi/scalarutils"
 )
 
@@ -24,7 +26,7 @@ func (c *UseCasesClinicalImpl) CreateEpisodeOfCare(ctx context.Context, input dt
 
 	episodeOfCare := &domain.FHIREpisodeOfCareInput{
 		Status: &active,
-		Period: common.DefaultPeriodInput(),
+		Period: common.DefaultPeriodInput(c.clock),
 	}
 
 	facility, err := c.infrastructure.FHIR.GetFHIROrganization(ctx, facilityID)
@@ -63,13 +65,12 @@ func (c *UseCasesClinicalImpl) CreateEpisodeOfCare(ctx context.Context, input dt
 	}
 
 	// search for the episode of care before creating new one.
-	episodeOfCareSearchParams := map[string]interface{}{
-		"patient":      patientRef,
-		"status":       string(domain.EpisodeOfCareStatusEnumActive),
-		"organization": orgRef,
-		"_sort":        "date",
-		"_count":       "1",
-	}
+	episodeOfCareSearchParams := domain.NewSearchParams().
+		Reference("patient", patientRef).
+		Token("status", "", string(domain.EpisodeOfCareStatusEnumActive)).
+		Reference("organization", orgRef).
+		Sort("date").
+		Count(1)
 
 	episodeOfCarePayload, err := c.infrastructure.FHIR.SearchFHIREpisodeOfCare(ctx, episodeOfCareSearchParams, *identifiers, dto.Pagination{})
 	if err != nil {
@@ -98,15 +99,17 @@ func (c *UseCasesClinicalImpl) CreateEpisodeOfCare(ctx context.Context, input dt
 	return mapFHIREpisodeToEpisodeDTO(*episode.EpisodeOfCare), nil
 }
 
-func (c *UseCasesClinicalImpl) EndEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error) {
+// EndEpisodeOfCare finishes an episode of care and the encounters in it that
+// are still in progress. They end now unless an earlier end time is given
+func (c *UseCasesClinicalImpl) EndEpisodeOfCare(ctx context.Context, id string, endTime *time.Time) (*dto.EpisodeOfCare, error) {
 	_, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid episode of care id: %s", id)
 	}
 
-	episode, err := c.infrastructure.FHIR.GetFHIREpisodeOfCare(ctx, id)
+	end, err := c.endTime(endTime)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get episode of care: %w", err)
+		return nil, err
 	}
 
 	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
@@ -114,22 +117,32 @@ func (c *UseCasesClinicalImpl) EndEpisodeOfCare(ctx context.Context, id string)
 		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
 	}
 
-	// Close all encounters in this visit
-	encounterConn, err := c.infrastructure.FHIR.SearchEpisodeEncounter(ctx, id, *identifiers, dto.Pagination{})
+	// The episode is fetched together with the encounters in this visit that
+	// are still in progress, which should all be closed
+	episode, encounters, err := c.infrastructure.FHIR.GetFHIREpisodeOfCareWithEncounters(ctx, id, *identifiers)
 	if err != nil {
-		return nil, fmt.Errorf("unable to search episode encounter %w", err)
+		return nil, fmt.Errorf("unable to get episode of care: %w", err)
 	}
 
-	for _, edge := range encounterConn.Encounters {
-		_, err = c.infrastructure.FHIR.EndEncounter(ctx, *edge.ID)
+	// The encounters and the episode are ended together so that a failure does
+	// not leave an open episode with closed encounters
+	err = c.inTransaction(ctx, func(fhir repository.FHIR) error {
+		for _, edge := range encounters {
+			_, err := fhir.EndEncounter(ctx, *edge.ID, end)
+			if err != nil {
+				return fmt.Errorf("unable to end encounter %s: err: %w", *edge.ID, err)
+			}
+		}
+
+		_, err := fhir.EndEpisode(ctx, id, end)
 		if err != nil {
-			return nil, fmt.Errorf("unable to end encounter %s: err: %w", *edge.ID, err)
+			return fmt.Errorf("unable to end episode of care: %w", err)
 		}
-	}
 
-	_, err = c.infrastructure.FHIR.EndEpisode(ctx, id)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to end episode of care: %w", err)
+		return nil, err
 	}
 
 	return mapFHIREpisodeToEpisodeDTO(*episode.Resource), nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
@@ -14,6 +15,7 @@ import (
 	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
 	fakeMyCarehubMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub/mock"
 	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
 	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
 )
 
@@ -112,7 +114,7 @@ func TestUseCasesClinicalImpl_CreateEpisodeOfCare(t *testing.T) {
 			c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
 
 			if tt.name == "happy case: create an episode of care" {
-				fakeFHIR.MockSearchFHIREpisodeOfCareFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
+				fakeFHIR.MockSearchFHIREpisodeOfCareFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIREpisodeOfCareRelayConnection, error) {
 					return &domain.FHIREpisodeOfCareRelayConnection{
 						Edges: []*domain.FHIREpisodeOfCareRelayEdge{},
 					}, nil
@@ -159,9 +161,12 @@ func TestUseCasesClinicalImpl_CreateEpisodeOfCare(t *testing.T) {
 func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx     context.Context
+		id      string
+		endTime *time.Time
 	}
+	earlier := time.Now().Add(-time.Hour)
+	later := time.Now().Add(time.Hour)
 	tests := []struct {
 		name    string
 		args    args
@@ -175,6 +180,24 @@ func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "happy case: end episode of care at an earlier time",
+			args: args{
+				ctx:     context.Background(),
+				id:      gofakeit.UUID(),
+				endTime: &earlier,
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: end time in the future",
+			args: args{
+				ctx:     context.Background(),
+				id:      gofakeit.UUID(),
+				endTime: &later,
+			},
+			wantErr: true,
+		},
 		{
 			name: "sad case: invalid episode of care id",
 			args: args{
@@ -200,7 +223,7 @@ func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: fail to search encounters",
+			name: "sad case: fail to end encounter",
 			args: args{
 				ctx: context.Background(),
 				id:  gofakeit.UUID(),
@@ -208,7 +231,7 @@ func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: fail to end encounter",
+			name: "sad case: fail to end episode of care",
 			args: args{
 				ctx: context.Background(),
 				id:  gofakeit.UUID(),
@@ -216,7 +239,7 @@ func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: fail to end episode of care",
+			name: "sad case: fail to commit transaction",
 			args: args{
 				ctx: context.Background(),
 				id:  gofakeit.UUID(),
@@ -235,8 +258,8 @@ func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 			c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
 
 			if tt.name == "sad case: error retrieving episode of care" {
-				fakeFHIR.MockGetFHIREpisodeOfCareFn = func(ctx context.Context, id string) (*domain.FHIREpisodeOfCareRelayPayload, error) {
-					return nil, fmt.Errorf("failed to get episode of care")
+				fakeFHIR.MockGetFHIREpisodeOfCareWithEncountersFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIREpisodeOfCareRelayPayload, []domain.FHIREncounter, error) {
+					return nil, nil, fmt.Errorf("failed to get episode of care")
 				}
 			}
 
@@ -246,25 +269,28 @@ func TestUseCasesClinicalImpl_EndEpisodeOfCare(t *testing.T) {
 				}
 			}
 
-			if tt.name == "sad case: fail to search encounters" {
-				fakeFHIR.MockSearchEpisodeEncounterFn = func(ctx context.Context, episodeReference string, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIREncounter, error) {
-					return nil, fmt.Errorf("error searching for encounters")
-				}
-			}
-
 			if tt.name == "sad case: fail to end encounter" {
-				fakeFHIR.MockEndEncounterFn = func(ctx context.Context, encounterID string) (bool, error) {
+				fakeFHIR.MockEndEncounterFn = func(ctx context.Context, encounterID string, endTime time.Time) (bool, error) {
 					return false, fmt.Errorf("failed to end encounter")
 				}
 			}
 
 			if tt.name == "sad case: fail to end episode of care" {
-				fakeFHIR.MockEndEpisodeFn = func(ctx context.Context, episodeID string) (bool, error) {
+				fakeFHIR.MockEndEpisodeFn = func(ctx context.Context, episodeID string, endTime time.Time) (bool, error) {
 					return false, fmt.Errorf("error ending episode")
 				}
 			}
 
-			got, err := c.EndEpisodeOfCare(tt.args.ctx, tt.args.id)
+			if tt.name == "sad case: fail to commit transaction" {
+				fakeFHIR.MockRunInTransactionFn = func(ctx context.Context, work func(fhir repository.FHIR) error) error {
+					if err := work(fakeFHIR); err != nil {
+						return err
+					}
+					return fmt.Errorf("failed to commit transaction")
+				}
+			}
+
+			got, err := c.EndEpisodeOfCare(tt.args.ctx, tt.args.id, tt.args.endTime)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("EndEpisodeOfCare() error = %v, wantErr %v", err, tt.wantErr)
 				return
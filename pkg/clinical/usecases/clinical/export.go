@@ -0,0 +1,424 @@
+package clinical
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrBulkExportNotFound is returned when a bulk export does not exist or
+// belongs to another tenant
+var ErrBulkExportNotFound = errors.New("bulk export not found")
+
+// bulkExportResourceTypes are the resource types that are exported when none
+// are requested
+var bulkExportResourceTypes = []string{
+	"Patient",
+	"EpisodeOfCare",
+	"Encounter",
+	"Condition",
+	"AllergyIntolerance",
+	"Observation",
+	"MedicationStatement",
+	"Medication",
+	"MedicationRequest",
+	"ServiceRequest",
+	"Composition",
+}
+
+// isBulkExportResourceType checks whether a resource type can be exported
+func isBulkExportResourceType(resourceType string) bool {
+	for _, supported := range bulkExportResourceTypes {
+		if supported == resourceType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// defaultBulkExportTTL is how long finished exports are kept when
+// `BULK_EXPORT_TTL` is not set
+const defaultBulkExportTTL = 24 * time.Hour
+
+// BulkExportTTL returns how long the files of a finished bulk export are kept
+// before they are deleted
+func BulkExportTTL() (time.Duration, error) {
+	value := os.Getenv(common.BulkExportTTLEnvVar)
+	if value == "" {
+		return defaultBulkExportTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid bulk export TTL %q", value)
+	}
+
+	return ttl, nil
+}
+
+// bulkExports keeps track of the bulk exports started by this instance. Jobs
+// are only kept in memory, so every request for an export must reach the
+// instance that started it
+type bulkExports struct {
+	mu      sync.Mutex
+	jobs    map[string]*dto.BulkExportJob
+	cancels map[string]context.CancelFunc
+	ttl     time.Duration
+}
+
+func newBulkExports() *bulkExports {
+	// an invalid TTL is reported when the server starts
+	ttl, err := BulkExportTTL()
+	if err != nil {
+		ttl = defaultBulkExportTTL
+	}
+
+	return &bulkExports{
+		jobs:    map[string]*dto.BulkExportJob{},
+		cancels: map[string]context.CancelFunc{},
+		ttl:     ttl,
+	}
+}
+
+// get returns a copy of a job that belongs to the organisation
+func (e *bulkExports) get(id, organizationID string) (*dto.BulkExportJob, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	job, ok := e.jobs[id]
+	if !ok || job.OrganizationID != organizationID {
+		return nil, ErrBulkExportNotFound
+	}
+
+	output := *job
+	output.Types = append([]string{}, job.Types...)
+	output.Output = append([]dto.BulkExportOutput{}, job.Output...)
+
+	return &output, nil
+}
+
+// update changes a job that is still being tracked. It reports whether the
+// job was found
+func (e *bulkExports) update(id string, change func(job *dto.BulkExportJob)) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	job, ok := e.jobs[id]
+	if ok {
+		change(job)
+	}
+
+	return ok
+}
+
+// remove stops tracking a job that belongs to the organisation and cancels it
+// if it is still running. Concurrent removals of a job only succeed once
+func (e *bulkExports) remove(id, organizationID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	job, ok := e.jobs[id]
+	if !ok || job.OrganizationID != organizationID {
+		return ErrBulkExportNotFound
+	}
+
+	if cancel, ok := e.cancels[id]; ok {
+		cancel()
+	}
+
+	delete(e.jobs, id)
+	delete(e.cancels, id)
+
+	return nil
+}
+
+// expire stops tracking the jobs that finished before the TTL and returns
+// their ids
+func (e *bulkExports) expire(now time.Time) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	expired := []string{}
+
+	for id, job := range e.jobs {
+		if job.CompletedAt == nil || now.Sub(*job.CompletedAt) < e.ttl {
+			continue
+		}
+
+		delete(e.jobs, id)
+		delete(e.cancels, id)
+
+		expired = append(expired, id)
+	}
+
+	return expired
+}
+
+// tracked reports whether a job is still being tracked
+func (e *bulkExports) tracked(id string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, ok := e.jobs[id]
+
+	return ok
+}
+
+// bulkExportRoot returns the directory that holds the files of every export
+func bulkExportRoot() string {
+	root := os.Getenv(common.BulkExportDirectoryEnvVar)
+	if root == "" {
+		root = filepath.Join(os.TempDir(), "clinical-exports")
+	}
+
+	return root
+}
+
+// bulkExportDirectory returns the directory that holds the files of an export
+func bulkExportDirectory(id string) string {
+	return filepath.Join(bulkExportRoot(), id)
+}
+
+// expireBulkExports deletes the files of the exports that finished before the
+// TTL, and of the exports left behind by an instance that is no longer running
+func (c *UseCasesClinicalImpl) expireBulkExports() {
+	now := c.clock.Now()
+
+	for _, id := range c.exports.expire(now) {
+		err := os.RemoveAll(bulkExportDirectory(id))
+		if err != nil {
+			log.Errorf("unable to delete the files of bulk export %s: %v", id, err)
+		}
+	}
+
+	entries, err := os.ReadDir(bulkExportRoot())
+	if err != nil {
+		// nothing has been exported yet
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || c.exports.tracked(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < c.exports.ttl {
+			continue
+		}
+
+		err = os.RemoveAll(filepath.Join(bulkExportRoot(), entry.Name()))
+		if err != nil {
+			log.Errorf("unable to delete the files of bulk export %s: %v", entry.Name(), err)
+		}
+	}
+}
+
+// StartBulkExport starts exporting the resources of the tenant's organisation,
+// across all of its facilities, to one NDJSON file per resource type. Every
+// supported resource type is exported when no types are given.
+//
+// The export runs in the background. Its progress is polled with
+// `GetBulkExport` and the files are read with `OpenBulkExportFile`. Exports are
+// tracked in memory by the instance that started them, so the service should
+// run as a single instance, or route export requests to one instance, while
+// exports are used. Finished exports are deleted once `BULK_EXPORT_TTL` passes.
+func (c *UseCasesClinicalImpl) StartBulkExport(ctx context.Context, types []string) (*dto.BulkExportJob, error) {
+	c.expireBulkExports()
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	if len(types) == 0 {
+		types = bulkExportResourceTypes
+	}
+
+	for _, resourceType := range types {
+		if !isBulkExportResourceType(resourceType) {
+			return nil, fmt.Errorf("unsupported bulk export resource type %q", resourceType)
+		}
+	}
+
+	job := &dto.BulkExportJob{
+		ID:              uuid.New().String(),
+		OrganizationID:  identifiers.OrganizationID,
+		Status:          dto.BulkExportStatusInProgress,
+		Types:           append([]string{}, types...),
+		TransactionTime: c.clock.Now(),
+		Output:          []dto.BulkExportOutput{},
+	}
+
+	err = os.MkdirAll(bulkExportDirectory(job.ID), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the bulk export directory: %w", err)
+	}
+
+	// the export outlives the request that started it
+	exportCtx, cancel := context.WithCancel(context.Background())
+
+	c.exports.mu.Lock()
+	c.exports.jobs[job.ID] = job
+	c.exports.cancels[job.ID] = cancel
+	c.exports.mu.Unlock()
+
+	tenant := dto.TenantIdentifiers{OrganizationID: identifiers.OrganizationID}
+
+	go c.runBulkExport(exportCtx, job.ID, tenant, job.Types)
+
+	return c.exports.get(job.ID, identifiers.OrganizationID)
+}
+
+// runBulkExport exports each resource type in turn and records the outcome on
+// the job
+func (c *UseCasesClinicalImpl) runBulkExport(ctx context.Context, id string, tenant dto.TenantIdentifiers, types []string) {
+	directory := bulkExportDirectory(id)
+
+	for _, resourceType := range types {
+		count, err := c.exportResourceType(ctx, directory, resourceType, tenant)
+		if err != nil {
+			utils.ReportErrorToSentry(err)
+			log.Errorf("bulk export %s failed: %v", id, err)
+
+			found := c.exports.update(id, func(job *dto.BulkExportJob) {
+				completedAt := c.clock.Now()
+				job.Status = dto.BulkExportStatusFailed
+				job.Error = err.Error()
+				job.CompletedAt = &completedAt
+			})
+			if !found {
+				// the export was deleted while it was running
+				_ = os.RemoveAll(directory)
+			}
+
+			return
+		}
+
+		found := c.exports.update(id, func(job *dto.BulkExportJob) {
+			job.Output = append(job.Output, dto.BulkExportOutput{Type: resourceType, Count: count})
+		})
+		if !found {
+			_ = os.RemoveAll(directory)
+
+			return
+		}
+	}
+
+	c.exports.update(id, func(job *dto.BulkExportJob) {
+		completedAt := c.clock.Now()
+		job.Status = dto.BulkExportStatusCompleted
+		job.CompletedAt = &completedAt
+	})
+}
+
+// exportResourceType writes every resource of a type to its NDJSON file and
+// returns the number of resources written
+func (c *UseCasesClinicalImpl) exportResourceType(
+	ctx context.Context, directory, resourceType string, tenant dto.TenantIdentifiers,
+) (int, error) {
+	file, err := os.Create(filepath.Join(directory, resourceType+".ndjson"))
+	if err != nil {
+		return 0, fmt.Errorf("unable to create the %s export file: %w", resourceType, err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// each resource is encoded on its own line
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	count := 0
+
+	err = c.infrastructure.FHIR.ExportFHIRResources(ctx, resourceType, tenant, func(resource map[string]interface{}) error {
+		count++
+
+		return encoder.Encode(resource)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("unable to write the %s export file: %w", resourceType, err)
+	}
+
+	return count, file.Close()
+}
+
+// GetBulkExport returns the status of one of the tenant's bulk exports
+func (c *UseCasesClinicalImpl) GetBulkExport(ctx context.Context, id string) (*dto.BulkExportJob, error) {
+	c.expireBulkExports()
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	return c.exports.get(id, identifiers.OrganizationID)
+}
+
+// OpenBulkExportFile opens the NDJSON file of a resource type once it has been
+// exported. The caller should close it
+func (c *UseCasesClinicalImpl) OpenBulkExportFile(ctx context.Context, id, resourceType string) (io.ReadCloser, error) {
+	job, err := c.GetBulkExport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := false
+
+	for _, output := range job.Output {
+		if output.Type == resourceType {
+			exported = true
+		}
+	}
+
+	if !exported {
+		return nil, fmt.Errorf("%s has not been exported: %w", resourceType, ErrBulkExportNotFound)
+	}
+
+	file, err := os.Open(filepath.Join(bulkExportDirectory(id), resourceType+".ndjson"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the %s export file: %w", resourceType, err)
+	}
+
+	return file, nil
+}
+
+// DeleteBulkExport cancels a bulk export if it is still running and deletes
+// its files
+func (c *UseCasesClinicalImpl) DeleteBulkExport(ctx context.Context, id string) error {
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	err = c.exports.remove(id, identifiers.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(bulkExportDirectory(id))
+	if err != nil {
+		return fmt.Errorf("unable to delete the bulk export files: %w", err)
+	}
+
+	return nil
+}
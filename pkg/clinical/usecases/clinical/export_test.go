@@ -0,0 +1,432 @@
+package clinical_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	fakeExtMock "github.com/savannahghi/clinical/pkg/clinical/application/extensions/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
+	fakeMyCarehubMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub/mock"
+	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+)
+
+// waitForBulkExport polls an export until it is no longer in progress
+func waitForBulkExport(t *testing.T, u *clinicalUsecase.UseCasesClinicalImpl, ctx context.Context, id string) *dto.BulkExportJob {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for time.Now().Before(deadline) {
+		job, err := u.GetBulkExport(ctx, id)
+		if err != nil {
+			t.Fatalf("GetBulkExport() error = %v", err)
+		}
+
+		if job.Status != dto.BulkExportStatusInProgress {
+			return job
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("bulk export %s did not finish", id)
+
+	return nil
+}
+
+func TestUseCasesClinicalImpl_StartBulkExport(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		types []string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus dto.BulkExportStatus
+		wantErr    bool
+	}{
+		{
+			name: "Happy case: export requested types",
+			args: args{
+				ctx:   context.Background(),
+				types: []string{"Patient", "Encounter"},
+			},
+			wantStatus: dto.BulkExportStatusCompleted,
+		},
+		{
+			name: "Happy case: export all types",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantStatus: dto.BulkExportStatusCompleted,
+		},
+		{
+			name: "Sad case: unsupported resource type",
+			args: args{
+				ctx:   context.Background(),
+				types: []string{"Practitioner"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to export resources",
+			args: args{
+				ctx:   context.Background(),
+				types: []string{"Patient"},
+			},
+			wantStatus: dto.BulkExportStatusFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(common.BulkExportDirectoryEnvVar, t.TempDir())
+
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			organizationID := gofakeit.UUID()
+			fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+				return &dto.TenantIdentifiers{OrganizationID: organizationID, FacilityID: gofakeit.UUID()}, nil
+			}
+
+			fakeFHIR.MockExportFHIRResourcesFn = func(ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error) error {
+				if tenant.OrganizationID != organizationID || tenant.FacilityID != "" {
+					return fmt.Errorf("unexpected tenant %v", tenant)
+				}
+
+				for i := 0; i < 2; i++ {
+					err := write(map[string]interface{}{"resourceType": resourceType, "id": gofakeit.UUID()})
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to export resources" {
+				fakeFHIR.MockExportFHIRResourcesFn = func(ctx context.Context, resourceType string, tenant dto.TenantIdentifiers, write func(resource map[string]interface{}) error) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.StartBulkExport(tt.args.ctx, tt.args.types)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.StartBulkExport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			job := waitForBulkExport(t, u, tt.args.ctx, got.ID)
+			if job.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %s: %s", tt.wantStatus, job.Status, job.Error)
+			}
+
+			if job.Status != dto.BulkExportStatusCompleted {
+				return
+			}
+
+			if len(job.Output) != len(job.Types) {
+				t.Fatalf("expected %d files, got %d", len(job.Types), len(job.Output))
+			}
+
+			file, err := u.OpenBulkExportFile(tt.args.ctx, job.ID, job.Types[0])
+			if err != nil {
+				t.Fatalf("OpenBulkExportFile() error = %v", err)
+			}
+			defer file.Close()
+
+			lines := 0
+			scanner := bufio.NewScanner(file)
+
+			for scanner.Scan() {
+				resource := map[string]interface{}{}
+
+				err := json.Unmarshal(scanner.Bytes(), &resource)
+				if err != nil {
+					t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+				}
+
+				if resource["resourceType"] != job.Types[0] {
+					t.Errorf("expected a %s, got %v", job.Types[0], resource["resourceType"])
+				}
+
+				lines++
+			}
+
+			if lines != job.Output[0].Count {
+				t.Errorf("expected %d lines, got %d", job.Output[0].Count, lines)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_GetBulkExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{
+			name: "Happy case: get tenant's export",
+		},
+		{
+			name:    "Sad case: export belongs to another tenant",
+			wantErr: clinicalUsecase.ErrBulkExportNotFound,
+		},
+		{
+			name:    "Sad case: export not found",
+			wantErr: clinicalUsecase.ErrBulkExportNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(common.BulkExportDirectoryEnvVar, t.TempDir())
+
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			ctx := context.Background()
+
+			organizationID := gofakeit.UUID()
+			fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+				return &dto.TenantIdentifiers{OrganizationID: organizationID}, nil
+			}
+
+			job, err := u.StartBulkExport(ctx, []string{"Patient"})
+			if err != nil {
+				t.Fatalf("StartBulkExport() error = %v", err)
+			}
+
+			waitForBulkExport(t, u, ctx, job.ID)
+
+			id := job.ID
+
+			if tt.name == "Sad case: export belongs to another tenant" {
+				organizationID = gofakeit.UUID()
+			}
+
+			if tt.name == "Sad case: export not found" {
+				id = gofakeit.UUID()
+			}
+
+			_, err = u.GetBulkExport(ctx, id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UseCasesClinicalImpl.GetBulkExport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_DeleteBulkExport(t *testing.T) {
+	directory := t.TempDir()
+	t.Setenv(common.BulkExportDirectoryEnvVar, directory)
+
+	fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+	fakeFHIR := fakeFHIRMock.NewFHIRMock()
+	fakeOCL := fakeOCLMock.NewFakeOCLMock()
+	fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+	infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+	u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+	ctx := context.Background()
+
+	organizationID := gofakeit.UUID()
+	fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+		return &dto.TenantIdentifiers{OrganizationID: organizationID}, nil
+	}
+
+	job, err := u.StartBulkExport(ctx, []string{"Patient"})
+	if err != nil {
+		t.Fatalf("StartBulkExport() error = %v", err)
+	}
+
+	waitForBulkExport(t, u, ctx, job.ID)
+
+	err = u.DeleteBulkExport(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("DeleteBulkExport() error = %v", err)
+	}
+
+	_, err = os.Stat(filepath.Join(directory, job.ID))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected the export files to be deleted, got %v", err)
+	}
+
+	_, err = u.GetBulkExport(ctx, job.ID)
+	if !errors.Is(err, clinicalUsecase.ErrBulkExportNotFound) {
+		t.Errorf("expected a deleted export to be not found, got %v", err)
+	}
+
+	err = u.DeleteBulkExport(ctx, job.ID)
+	if !errors.Is(err, clinicalUsecase.ErrBulkExportNotFound) {
+		t.Errorf("expected deleting twice to fail, got %v", err)
+	}
+
+	_, err = u.OpenBulkExportFile(ctx, job.ID, "Patient")
+	if !errors.Is(err, clinicalUsecase.ErrBulkExportNotFound) {
+		t.Errorf("expected opening a deleted export to fail, got %v", err)
+	}
+
+}
+
+func TestUseCasesClinicalImpl_DeleteBulkExport_Concurrently(t *testing.T) {
+	t.Setenv(common.BulkExportDirectoryEnvVar, t.TempDir())
+
+	fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+	fakeFHIR := fakeFHIRMock.NewFHIRMock()
+	fakeOCL := fakeOCLMock.NewFakeOCLMock()
+	fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+	infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+	u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+	ctx := context.Background()
+
+	organizationID := gofakeit.UUID()
+	fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+		return &dto.TenantIdentifiers{OrganizationID: organizationID}, nil
+	}
+
+	job, err := u.StartBulkExport(ctx, []string{"Patient"})
+	if err != nil {
+		t.Fatalf("StartBulkExport() error = %v", err)
+	}
+
+	waitForBulkExport(t, u, ctx, job.ID)
+
+	deletions := 10
+	errs := make(chan error, deletions)
+
+	for i := 0; i < deletions; i++ {
+		go func() {
+			errs <- u.DeleteBulkExport(ctx, job.ID)
+		}()
+	}
+
+	deleted := 0
+
+	for i := 0; i < deletions; i++ {
+		err := <-errs
+		if err == nil {
+			deleted++
+
+			continue
+		}
+
+		if !errors.Is(err, clinicalUsecase.ErrBulkExportNotFound) {
+			t.Errorf("expected the other deletions to find nothing, got %v", err)
+		}
+	}
+
+	if deleted != 1 {
+		t.Errorf("expected the export to be deleted once, got %d deletions", deleted)
+	}
+}
+
+func TestUseCasesClinicalImpl_BulkExport_Expiry(t *testing.T) {
+	directory := t.TempDir()
+	t.Setenv(common.BulkExportDirectoryEnvVar, directory)
+	t.Setenv(common.BulkExportTTLEnvVar, "1h")
+
+	fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+	fakeFHIR := fakeFHIRMock.NewFHIRMock()
+	fakeOCL := fakeOCLMock.NewFakeOCLMock()
+	fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+	infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+	u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+	now := time.Now()
+	u.SetClock(domain.FixedClock{Time: now})
+
+	ctx := context.Background()
+
+	organizationID := gofakeit.UUID()
+	fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+		return &dto.TenantIdentifiers{OrganizationID: organizationID}, nil
+	}
+
+	// left behind by an instance that is no longer running
+	abandoned := filepath.Join(directory, gofakeit.UUID())
+
+	err := os.MkdirAll(abandoned, 0o700)
+	if err != nil {
+		t.Fatalf("unable to create an abandoned export: %v", err)
+	}
+
+	err = os.Chtimes(abandoned, now.Add(-2*time.Hour), now.Add(-2*time.Hour))
+	if err != nil {
+		t.Fatalf("unable to age the abandoned export: %v", err)
+	}
+
+	job, err := u.StartBulkExport(ctx, []string{"Patient"})
+	if err != nil {
+		t.Fatalf("StartBulkExport() error = %v", err)
+	}
+
+	waitForBulkExport(t, u, ctx, job.ID)
+
+	_, err = os.Stat(abandoned)
+	if !os.IsNotExist(err) {
+		t.Errorf("expected the abandoned export to be deleted, got %v", err)
+	}
+
+	u.SetClock(domain.FixedClock{Time: now.Add(30 * time.Minute)})
+
+	_, err = u.GetBulkExport(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("expected the export to be kept until it expires, got %v", err)
+	}
+
+	u.SetClock(domain.FixedClock{Time: now.Add(time.Hour)})
+
+	_, err = u.GetBulkExport(ctx, job.ID)
+	if !errors.Is(err, clinicalUsecase.ErrBulkExportNotFound) {
+		t.Errorf("expected an expired export to be not found, got %v", err)
+	}
+
+	_, err = os.Stat(filepath.Join(directory, job.ID))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected the files of an expired export to be deleted, got %v", err)
+	}
+}
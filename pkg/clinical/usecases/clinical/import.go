@@ -0,0 +1,527 @@
+package clinical
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/converterandformatter"
+)
+
+// bulkImportResourceTypes are the resource types that can be imported, in the
+// order that they are created so that the resources referencing them are
+// created after them
+var bulkImportResourceTypes = []string{
+	"Patient",
+	"Encounter",
+	"Observation",
+	"Condition",
+	"AllergyIntolerance",
+	"MedicationStatement",
+}
+
+// bulkImportMaxLineSize is the size of the largest resource that can be
+// imported
+const bulkImportMaxLineSize = 4 * 1024 * 1024
+
+// bulkImportResourceRank returns the position of a resource type in the
+// import order or -1 if it cannot be imported
+func bulkImportResourceRank(resourceType string) int {
+	for i, supported := range bulkImportResourceTypes {
+		if supported == resourceType {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// bulkImportLine is a resource read from a line of an imported file
+type bulkImportLine struct {
+	file         string
+	number       int
+	resourceType string
+	id           string
+	resource     map[string]interface{}
+}
+
+// key is how other resources in the import reference the resource
+func (l bulkImportLine) key() string {
+	return fmt.Sprintf("%s/%s", l.resourceType, l.id)
+}
+
+// failure reports why the line was not imported
+func (l bulkImportLine) failure(err error) dto.BulkImportLineError {
+	return dto.BulkImportLineError{
+		File:         l.file,
+		Line:         l.number,
+		ResourceType: l.resourceType,
+		ID:           l.id,
+		Error:        err.Error(),
+	}
+}
+
+// BulkImport loads NDJSON files of legacy records into the tenant's facility.
+//
+// Every line is validated before anything is created. The valid lines are
+// spooled to a temporary file per resource type, so that the files are streamed
+// rather than held in memory, and are then created in dependency order,
+// patients first. The references between them are rewritten to the IDs that
+// they are assigned. Each resource is tagged with the tenant and keeps its
+// legacy ID as a source identifier, so importing the same files again does not
+// create duplicates.
+//
+// A line that fails is reported in the returned report and does not stop the
+// import, but the resources that reference it are not imported either.
+func (c *UseCasesClinicalImpl) BulkImport(ctx context.Context, files []dto.BulkImportFile) (*dto.BulkImportReport, error) {
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	tags, err := c.CreateTenantMetaTags(ctx, identifiers.OrganizationID, identifiers.FacilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantTags := []interface{}{}
+
+	for _, tag := range tags {
+		converted, err := converterandformatter.StructToMap(tag)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert tenant tag: %w", err)
+		}
+
+		tenantTags = append(tenantTags, converted)
+	}
+
+	report := &dto.BulkImportReport{
+		Errors: []dto.BulkImportLineError{},
+	}
+
+	spool, err := newBulkImportSpool()
+	if err != nil {
+		return nil, err
+	}
+	defer spool.close()
+
+	// the resources in the import that other resources can reference
+	included := map[string]bool{}
+
+	for _, file := range files {
+		scanner := bufio.NewScanner(file.Content)
+		scanner.Buffer(make([]byte, 64*1024), bulkImportMaxLineSize)
+
+		number := 0
+
+		for scanner.Scan() {
+			number++
+
+			data := bytes.TrimSpace(scanner.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+
+			report.Total++
+
+			line, err := parseBulkImportLine(data)
+			line.file, line.number = file.Name, number
+
+			if err == nil && included[line.key()] {
+				err = fmt.Errorf("%s appears more than once", line.key())
+			}
+
+			if err != nil {
+				report.Errors = append(report.Errors, line.failure(err))
+				continue
+			}
+
+			included[line.key()] = true
+
+			err = spool.add(line, data)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("unable to read %s at line %d: %w", file.Name, number+1, err)
+		}
+	}
+
+	// the IDs assigned to the resources that have been created
+	ids := map[string]string{}
+
+	for _, resourceType := range bulkImportResourceTypes {
+		err := spool.each(resourceType, func(line bulkImportLine) {
+			err := rewriteBulkImportReferences(line.resource, included, ids)
+			if err != nil {
+				report.Errors = append(report.Errors, line.failure(err))
+				return
+			}
+
+			payload := bulkImportPayload(line, identifiers.FacilityID, tenantTags)
+
+			id, err := c.infrastructure.FHIR.ImportFHIRResource(ctx, line.resourceType, payload)
+			if err != nil {
+				report.Errors = append(report.Errors, line.failure(err))
+				return
+			}
+
+			ids[line.key()] = id
+			report.Imported++
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.Failed = len(report.Errors)
+
+	return report, nil
+}
+
+// spooledBulkImportLine is a validated line that waits for its resource type
+// to be created
+type spooledBulkImportLine struct {
+	File     string          `json:"file"`
+	Line     int             `json:"line"`
+	Resource json.RawMessage `json:"resource"`
+}
+
+// bulkImportSpool keeps the validated lines of an import in a temporary file
+// per resource type
+type bulkImportSpool struct {
+	directory string
+	files     map[string]*os.File
+	writers   map[string]*bufio.Writer
+}
+
+func newBulkImportSpool() (*bulkImportSpool, error) {
+	directory, err := os.MkdirTemp("", "clinical-import-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the bulk import directory: %w", err)
+	}
+
+	return &bulkImportSpool{
+		directory: directory,
+		files:     map[string]*os.File{},
+		writers:   map[string]*bufio.Writer{},
+	}, nil
+}
+
+// add appends a validated line to the file of its resource type
+func (s *bulkImportSpool) add(line bulkImportLine, data []byte) error {
+	writer, ok := s.writers[line.resourceType]
+	if !ok {
+		file, err := os.Create(filepath.Join(s.directory, line.resourceType+".ndjson"))
+		if err != nil {
+			return fmt.Errorf("unable to spool the %s lines: %w", line.resourceType, err)
+		}
+
+		writer = bufio.NewWriter(file)
+
+		s.files[line.resourceType] = file
+		s.writers[line.resourceType] = writer
+	}
+
+	encoded, err := json.Marshal(spooledBulkImportLine{File: line.file, Line: line.number, Resource: data})
+	if err != nil {
+		return fmt.Errorf("unable to spool %s: %w", line.key(), err)
+	}
+
+	_, err = writer.Write(append(encoded, '\n'))
+	if err != nil {
+		return fmt.Errorf("unable to spool the %s lines: %w", line.resourceType, err)
+	}
+
+	return nil
+}
+
+// each reads back the lines of a resource type in the order they were added
+func (s *bulkImportSpool) each(resourceType string, fn func(line bulkImportLine)) error {
+	writer, ok := s.writers[resourceType]
+	if !ok {
+		return nil
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return fmt.Errorf("unable to spool the %s lines: %w", resourceType, err)
+	}
+
+	file := s.files[resourceType]
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("unable to read the spooled %s lines: %w", resourceType, err)
+	}
+
+	scanner := bufio.NewScanner(file)
+	// a spooled line is a little longer than the line it was read from
+	scanner.Buffer(make([]byte, 64*1024), 2*bulkImportMaxLineSize)
+
+	for scanner.Scan() {
+		spooled := spooledBulkImportLine{}
+
+		err := json.Unmarshal(scanner.Bytes(), &spooled)
+		if err != nil {
+			return fmt.Errorf("unable to read the spooled %s lines: %w", resourceType, err)
+		}
+
+		line, err := parseBulkImportLine(spooled.Resource)
+		if err != nil {
+			return fmt.Errorf("unable to read the spooled %s lines: %w", resourceType, err)
+		}
+
+		line.file, line.number = spooled.File, spooled.Line
+
+		fn(line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read the spooled %s lines: %w", resourceType, err)
+	}
+
+	return nil
+}
+
+// close removes the spooled lines
+func (s *bulkImportSpool) close() {
+	for _, file := range s.files {
+		_ = file.Close()
+	}
+
+	_ = os.RemoveAll(s.directory)
+}
+
+// parseBulkImportLine decodes and validates the resource on a line
+func parseBulkImportLine(data []byte) (bulkImportLine, error) {
+	line := bulkImportLine{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&line.resource)
+	if err != nil {
+		return line, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	line.resourceType, _ = line.resource["resourceType"].(string)
+	line.id, _ = line.resource["id"].(string)
+
+	if bulkImportResourceRank(line.resourceType) < 0 {
+		return line, fmt.Errorf("unsupported resource type %q", line.resourceType)
+	}
+
+	if line.id == "" {
+		return line, fmt.Errorf("the %s has no ID", line.resourceType)
+	}
+
+	err = validateBulkImportResource(line.resourceType, data)
+	if err != nil {
+		return line, fmt.Errorf("invalid %s: %w", line.resourceType, err)
+	}
+
+	return line, nil
+}
+
+// validateBulkImportResource checks that a resource can be read back by this
+// service and has the fields that it relies on
+func validateBulkImportResource(resourceType string, data []byte) error {
+	switch resourceType {
+	case "Patient":
+		patient := domain.FHIRPatient{}
+
+		return json.Unmarshal(data, &patient)
+
+	case "Encounter":
+		encounter := domain.FHIREncounter{}
+
+		err := json.Unmarshal(data, &encounter)
+		if err != nil {
+			return err
+		}
+
+		if encounter.Status == "" {
+			return errors.New("status is required")
+		}
+
+		return requireBulkImportReference("subject", encounter.Subject)
+
+	case "Observation":
+		observation := domain.FHIRObservation{}
+
+		err := json.Unmarshal(data, &observation)
+		if err != nil {
+			return err
+		}
+
+		if observation.Status == nil {
+			return errors.New("status is required")
+		}
+
+		if len(observation.Code.Coding) == 0 && observation.Code.Text == "" {
+			return errors.New("code is required")
+		}
+
+		return requireBulkImportReference("subject", observation.Subject)
+
+	case "Condition":
+		condition := domain.FHIRCondition{}
+
+		err := json.Unmarshal(data, &condition)
+		if err != nil {
+			return err
+		}
+
+		if condition.Code == nil {
+			return errors.New("code is required")
+		}
+
+		return requireBulkImportReference("subject", condition.Subject)
+
+	case "AllergyIntolerance":
+		allergy := domain.FHIRAllergyIntolerance{}
+
+		err := json.Unmarshal(data, &allergy)
+		if err != nil {
+			return err
+		}
+
+		if allergy.Code == nil {
+			return errors.New("code is required")
+		}
+
+		return requireBulkImportReference("patient", allergy.Patient)
+
+	case "MedicationStatement":
+		statement := domain.FHIRMedicationStatement{}
+
+		err := json.Unmarshal(data, &statement)
+		if err != nil {
+			return err
+		}
+
+		if statement.Status == nil {
+			return errors.New("status is required")
+		}
+
+		if statement.MedicationCodeableConcept == nil && statement.MedicationReference == nil {
+			return errors.New("medication is required")
+		}
+
+		return requireBulkImportReference("subject", statement.Subject)
+	}
+
+	return nil
+}
+
+// requireBulkImportReference checks that a required reference is set
+func requireBulkImportReference(field string, reference *domain.FHIRReference) error {
+	if reference == nil || reference.Reference == nil || *reference.Reference == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+
+	return nil
+}
+
+// rewriteBulkImportReferences points the references to resources in the
+// import at the IDs that they were assigned. It fails if a referenced resource
+// is not in the import, was not created or is of a type that cannot be
+// imported, since the reference would not resolve in the facility
+func rewriteBulkImportReferences(value interface{}, included map[string]bool, ids map[string]string) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			reference, ok := item.(string)
+			if key != "reference" || !ok {
+				err := rewriteBulkImportReferences(item, included, ids)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			parts := strings.Split(reference, "/")
+			if len(parts) != 2 {
+				continue
+			}
+
+			if bulkImportResourceRank(parts[0]) < 0 {
+				return fmt.Errorf("references %s which is of a type that cannot be imported", reference)
+			}
+
+			if id, ok := ids[reference]; ok {
+				value[key] = fmt.Sprintf("%s/%s", parts[0], id)
+				continue
+			}
+
+			if included[reference] {
+				return fmt.Errorf("references %s which has not been imported", reference)
+			}
+
+			return fmt.Errorf("references %s which is not in the import", reference)
+		}
+
+	case []interface{}:
+		for _, item := range value {
+			err := rewriteBulkImportReferences(item, included, ids)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// bulkImportPayload prepares a resource to be created in the tenant's
+// facility. The legacy ID is replaced by a source identifier and the tenant
+// tags replace any that the resource already had
+func bulkImportPayload(line bulkImportLine, facilityID string, tenantTags []interface{}) map[string]interface{} {
+	payload := map[string]interface{}{}
+	for key, value := range line.resource {
+		payload[key] = value
+	}
+
+	delete(payload, "id")
+
+	tags := append([]interface{}{}, tenantTags...)
+
+	meta, _ := payload["meta"].(map[string]interface{})
+	existing, _ := meta["tag"].([]interface{})
+
+	for _, item := range existing {
+		tag, _ := item.(map[string]interface{})
+		system, _ := tag["system"].(string)
+
+		if strings.HasPrefix(system, "http://mycarehub/tenant-identification/") {
+			continue
+		}
+
+		tags = append(tags, item)
+	}
+
+	payload["meta"] = map[string]interface{}{"tag": tags}
+
+	identifiers, _ := payload["identifier"].([]interface{})
+	payload["identifier"] = append(append([]interface{}{}, identifiers...), map[string]interface{}{
+		"use":    "secondary",
+		"type":   map[string]interface{}{"text": "Source record"},
+		"system": domain.SourceIdentifierSystem,
+		"value":  fmt.Sprintf("import/%s/%s", facilityID, line.key()),
+	})
+
+	return payload
+}
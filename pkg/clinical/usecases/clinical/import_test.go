@@ -0,0 +1,210 @@
+package clinical_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	fakeExtMock "github.com/savannahghi/clinical/pkg/clinical/application/extensions/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
+	fakeMyCarehubMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub/mock"
+	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+)
+
+const (
+	importPatient     = `{"resourceType":"Patient","id":"p1","name":[{"family":"Doe"}]}`
+	importEncounter   = `{"resourceType":"Encounter","id":"e1","status":"finished","subject":{"reference":"Patient/p1"}}`
+	importObservation = `{"resourceType":"Observation","id":"o1","status":"final","code":{"text":"Weight"},"subject":{"reference":"Patient/p1"},"encounter":{"reference":"Encounter/e1"}}`
+)
+
+func TestUseCasesClinicalImpl_BulkImport(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        []dto.BulkImportFile
+		wantImported int
+		wantErrors   []string
+		wantErr      bool
+	}{
+		{
+			name: "Happy case: import related resources in any order",
+			files: []dto.BulkImportFile{
+				{Name: "observations.ndjson", Content: strings.NewReader(importObservation + "\n")},
+				{Name: "records.ndjson", Content: strings.NewReader(importEncounter + "\n\n" + importPatient + "\n")},
+			},
+			wantImported: 3,
+			wantErrors:   []string{},
+		},
+		{
+			name: "Sad case: invalid lines are reported",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(strings.Join([]string{
+					importPatient,
+					`{"resourceType":"Patient"`,
+					`{"resourceType":"Practitioner","id":"pr1"}`,
+					`{"resourceType":"Patient","name":[{"family":"Doe"}]}`,
+					`{"resourceType":"Encounter","id":"e2","subject":{"reference":"Patient/p1"}}`,
+					`{"resourceType":"Observation","id":"o2","status":"final","code":{"text":"Weight"}}`,
+					`{"resourceType":"Patient","id":"p2","gender":1}`,
+					importPatient,
+				}, "\n"))},
+			},
+			wantImported: 1,
+			wantErrors:   []string{"records.ndjson:2", "records.ndjson:3", "records.ndjson:4", "records.ndjson:5", "records.ndjson:6", "records.ndjson:7", "records.ndjson:8"},
+		},
+		{
+			name: "Sad case: unresolved reference",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(importEncounter)},
+			},
+			wantImported: 0,
+			wantErrors:   []string{"records.ndjson:1"},
+		},
+		{
+			name: "Sad case: reference to a type that cannot be imported",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(importPatient + "\n" +
+					`{"resourceType":"Encounter","id":"e1","status":"finished","subject":{"reference":"Patient/p1"},` +
+					`"participant":[{"individual":{"reference":"Practitioner/pr1"}}]}`)},
+			},
+			wantImported: 1,
+			wantErrors:   []string{"records.ndjson:2"},
+		},
+		{
+			name: "Sad case: dependent of a failed resource",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(importPatient + "\n" + importEncounter)},
+			},
+			wantImported: 0,
+			wantErrors:   []string{"records.ndjson:1", "records.ndjson:2"},
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(importPatient)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to create tenant tags",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(importPatient)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: line too long",
+			files: []dto.BulkImportFile{
+				{Name: "records.ndjson", Content: strings.NewReader(strings.Repeat("x", 5*1024*1024))},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			created := map[string]map[string]interface{}{}
+			order := []string{}
+
+			fakeFHIR.MockImportFHIRResourceFn = func(ctx context.Context, resourceType string, payload map[string]interface{}) (string, error) {
+				if tt.name == "Sad case: dependent of a failed resource" {
+					return "", fmt.Errorf("an error occurred")
+				}
+
+				id := gofakeit.UUID()
+				created[resourceType+"/"+id] = payload
+				order = append(order, resourceType)
+
+				return id, nil
+			}
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to create tenant tags" {
+				fakeFHIR.MockGetFHIROrganizationFn = func(ctx context.Context, organisationID string) (*domain.FHIROrganizationRelayPayload, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.BulkImport(context.Background(), tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.BulkImport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.Imported != tt.wantImported {
+				t.Errorf("expected %d imported, got %d: %v", tt.wantImported, got.Imported, got.Errors)
+			}
+
+			if got.Failed != len(tt.wantErrors) || got.Total != got.Imported+got.Failed {
+				t.Errorf("inconsistent report %+v", got)
+			}
+
+			failures := map[string]bool{}
+			for _, failure := range got.Errors {
+				failures[fmt.Sprintf("%s:%d", failure.File, failure.Line)] = true
+			}
+
+			for _, want := range tt.wantErrors {
+				if !failures[want] {
+					t.Errorf("expected an error for %s, got %v", want, got.Errors)
+				}
+			}
+
+			if tt.name != "Happy case: import related resources in any order" {
+				return
+			}
+
+			if strings.Join(order, ",") != "Patient,Encounter,Observation" {
+				t.Errorf("expected dependencies to be created first, got %v", order)
+			}
+
+			for key, payload := range created {
+				if _, ok := payload["id"]; ok {
+					t.Errorf("expected the legacy ID of %s to be removed", key)
+				}
+
+				meta, _ := payload["meta"].(map[string]interface{})
+				if tags, _ := meta["tag"].([]interface{}); len(tags) != 2 {
+					t.Errorf("expected %s to have the tenant tags, got %v", key, meta)
+				}
+
+				identifiers, _ := payload["identifier"].([]interface{})
+				if len(identifiers) != 1 {
+					t.Errorf("expected %s to have a source identifier, got %v", key, identifiers)
+				}
+
+				for _, field := range []string{"subject", "encounter"} {
+					reference, ok := payload[field].(map[string]interface{})
+					if !ok {
+						continue
+					}
+
+					if _, ok := created[reference["reference"].(string)]; !ok {
+						t.Errorf("expected the %s of %s to reference a created resource, got %v", field, key, reference)
+					}
+				}
+			}
+		})
+	}
+}
@@ -143,7 +143,7 @@ func (c *UseCasesClinicalImpl) RecordObservation(ctx context.Context, input dto.
 	}
 
 	system := "http://terminology.hl7.org/CodeSystem/observation-category"
-	instant := scalarutils.Instant(time.Now().Format(time.RFC3339))
+	instant := scalarutils.Instant(c.now(ctx).Format(time.RFC3339))
 	observation := domain.FHIRObservationInput{
 		Status: (*domain.ObservationStatusEnum)(&input.Status),
 		Category: []*domain.FHIRCodeableConceptInput{
@@ -32,7 +32,12 @@ func (c *UseCasesClinicalImpl) RegisterTenant(ctx context.Context, input dto.Org
 		return nil, utils.NewCustomError(err, message)
 	}
 
-	payload := mapOrganizationInputToFHIROrganizationInput(input)
+	err := validateOrganizationTimeZone(input)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := mapOrganizationInputToFHIROrganizationInput(c.clock, input)
 
 	organisationPayload, err := c.infrastructure.FHIR.CreateFHIROrganization(ctx, *payload)
 	if err != nil {
@@ -42,7 +47,7 @@ func (c *UseCasesClinicalImpl) RegisterTenant(ctx context.Context, input dto.Org
 	return mapFHIROrganizationToDTOOrganization(organisationPayload.Resource), nil
 }
 
-func mapIdentifierToFHIRIdentifierInput(idType, value string) *domain.FHIRIdentifierInput {
+func mapIdentifierToFHIRIdentifierInput(clock domain.Clock, idType, value string) *domain.FHIRIdentifierInput {
 	identificationDocumentIdentifierSystem := scalarutils.URI(idType)
 	userSelected := true
 	idSystem := scalarutils.URI(identificationDocumentIdentifierSystem)
@@ -64,13 +69,13 @@ func mapIdentifierToFHIRIdentifierInput(idType, value string) *domain.FHIRIdenti
 		},
 		System: &idSystem,
 		Value:  value,
-		Period: common.DefaultPeriodInput(),
+		Period: common.DefaultPeriodInput(clock),
 	}
 
 	return &identifier
 }
 
-func mapPhoneNumberToFHIRContactPointInput(phoneNumber string) *domain.FHIRContactPointInput {
+func mapPhoneNumberToFHIRContactPointInput(clock domain.Clock, phoneNumber string) *domain.FHIRContactPointInput {
 	use := domain.ContactPointUseEnumWork
 	rank := int64(1)
 	phoneSystem := domain.ContactPointSystemEnumPhone
@@ -80,11 +85,11 @@ func mapPhoneNumberToFHIRContactPointInput(phoneNumber string) *domain.FHIRConta
 		Value:  &phoneNumber,
 		Use:    &use,
 		Rank:   &rank,
-		Period: common.DefaultPeriodInput(),
+		Period: common.DefaultPeriodInput(clock),
 	}
 }
 
-func mapOrganizationInputToFHIROrganizationInput(organization dto.OrganizationInput) *domain.FHIROrganizationInput {
+func mapOrganizationInputToFHIROrganizationInput(clock domain.Clock, organization dto.OrganizationInput) *domain.FHIROrganizationInput {
 	active := true
 	org := domain.FHIROrganizationInput{
 		Name:       &organization.Name,
@@ -93,17 +98,41 @@ func mapOrganizationInputToFHIROrganizationInput(organization dto.OrganizationIn
 		Identifier: []*domain.FHIRIdentifierInput{},
 	}
 
-	contact := mapPhoneNumberToFHIRContactPointInput(organization.PhoneNumber)
+	contact := mapPhoneNumberToFHIRContactPointInput(clock, organization.PhoneNumber)
 	org.Telecom = append(org.Telecom, contact)
 
 	for _, id := range organization.Identifiers {
-		identifier := mapIdentifierToFHIRIdentifierInput(string(id.Type), id.Value)
+		identifier := mapIdentifierToFHIRIdentifierInput(clock, string(id.Type), id.Value)
 		org.Identifier = append(org.Identifier, identifier)
 	}
 
+	if organization.TimeZone != "" {
+		org.Extension = append(org.Extension, &domain.Extension{
+			URL:       domain.TimeZoneExtensionURL,
+			ValueCode: organization.TimeZone,
+		})
+	}
+
 	return &org
 }
 
+// validateOrganizationTimeZone checks that the time zone of an organization, if
+// given, is a known IANA time zone
+func validateOrganizationTimeZone(input dto.OrganizationInput) error {
+	if input.TimeZone == "" {
+		return nil
+	}
+
+	_, err := domain.LoadTimeZone(input.TimeZone)
+	if err != nil {
+		message := "please provide a valid IANA time zone e.g Africa/Nairobi"
+
+		return utils.NewCustomError(err, message)
+	}
+
+	return nil
+}
+
 func mapFHIROrganizationToDTOOrganization(organisation *domain.FHIROrganization) *dto.Organization {
 	org := &dto.Organization{
 		ID:           *organisation.ID,
@@ -111,6 +140,7 @@ func mapFHIROrganizationToDTOOrganization(organisation *domain.FHIROrganization)
 		Name:         *organisation.Name,
 		Identifiers:  make([]dto.OrganizationIdentifier, 0),
 		PhoneNumbers: make([]string, 0),
+		TimeZone:     organisation.TimeZone(),
 	}
 
 	for _, identifier := range organisation.Identifier {
@@ -153,7 +183,12 @@ func (c *UseCasesClinicalImpl) RegisterFacility(ctx context.Context, input dto.O
 		return nil, utils.NewCustomError(err, message)
 	}
 
-	payload := mapOrganizationInputToFHIROrganizationInput(input)
+	err := validateOrganizationTimeZone(input)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := mapOrganizationInputToFHIROrganizationInput(c.clock, input)
 
 	organisationPayload, err := c.infrastructure.FHIR.CreateFHIROrganization(ctx, *payload)
 	if err != nil {
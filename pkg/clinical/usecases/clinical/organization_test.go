@@ -197,6 +197,42 @@ func TestUseCasesClinicalImpl_RegisterFacility(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Happy case - register a facility with a time zone",
+			args: args{
+				ctx: ctx,
+				input: dto.OrganizationInput{
+					Name:        "Test facility",
+					PhoneNumber: "Number",
+					Identifiers: []dto.OrganizationIdentifier{
+						{
+							Type:  "SladeCode",
+							Value: "1234",
+						},
+					},
+					TimeZone: "Africa/Kampala",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case - Unknown time zone",
+			args: args{
+				ctx: ctx,
+				input: dto.OrganizationInput{
+					Name:        "Test facility",
+					PhoneNumber: "Number",
+					Identifiers: []dto.OrganizationIdentifier{
+						{
+							Type:  "SladeCode",
+							Value: "1234",
+						},
+					},
+					TimeZone: "Africa/Atlantis",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3,11 +3,16 @@ package clinical
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/savannahghi/clinical/pkg/clinical/application/extensions"
+	"github.com/savannahghi/converterandformatter"
 	"github.com/savannahghi/scalarutils"
 
 	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/common/helpers"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
@@ -17,26 +22,40 @@ import (
 // UseCasesClinicalImpl represents the patient usecase implementation
 type UseCasesClinicalImpl struct {
 	infrastructure infrastructure.Infrastructure
+	exports        *bulkExports
+	clock          domain.Clock
 }
 
 // NewUseCasesClinicalImpl initializes new Clinical/Patient implementation
 func NewUseCasesClinicalImpl(infra infrastructure.Infrastructure) *UseCasesClinicalImpl {
 	return &UseCasesClinicalImpl{
 		infrastructure: infra,
+		exports:        newBulkExports(),
+		clock:          domain.SystemClock{},
 	}
 }
 
+// SetClock replaces the clock that tells the times recorded by the use cases,
+// e.g with a fixed clock in tests
+func (c *UseCasesClinicalImpl) SetClock(clock domain.Clock) {
+	c.clock = clock
+}
+
+// now returns the current time in the time zone of the facility in the context
+func (c *UseCasesClinicalImpl) now(ctx context.Context) time.Time {
+	return c.clock.Now().In(helpers.FacilityLocation(ctx, c.infrastructure.FHIR))
+}
+
 // GetMedicalData returns a limited subset of specific medical data that for a specific patient
 // These include: Allergies, Viral Load, Body Mass Index, Weight, CD4 Count using their respective OCL CIEL Terminology
 // For each category the latest three records are fetched
 func (c *UseCasesClinicalImpl) GetMedicalData(ctx context.Context, patientID string) (*dto.MedicalData, error) {
 	data := &dto.MedicalData{}
 
-	filterParams := map[string]interface{}{
-		"patient": fmt.Sprintf("Patient/%v", patientID),
-		"_count":  common.MedicalDataCount,
-		"_sort":   "-date",
-	}
+	filterParams := domain.NewSearchParams().
+		Reference("patient", fmt.Sprintf("Patient/%v", patientID)).
+		SortDescending("date").
+		Count(common.MedicalDataCount)
 
 	fields := []string{
 		"Regimen",
@@ -132,9 +151,9 @@ func (c *UseCasesClinicalImpl) GetMedicalData(ctx context.Context, patientID str
 			}
 
 		case "Weight":
-			filterParams["code"] = common.WeightCIELTerminologyCode
+			params := filterParams.Token("code", "", common.WeightCIELTerminologyCode)
 
-			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, filterParams, *identifiers, dto.Pagination{Skip: true})
+			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, params, *identifiers, dto.Pagination{Skip: true})
 			if err != nil {
 				utils.ReportErrorToSentry(err)
 				return nil, fmt.Errorf("%s search error: %w", field, err)
@@ -147,9 +166,9 @@ func (c *UseCasesClinicalImpl) GetMedicalData(ctx context.Context, patientID str
 			}
 
 		case "BMI":
-			filterParams["code"] = common.BMICIELTerminologyCode
+			params := filterParams.Token("code", "", common.BMICIELTerminologyCode)
 
-			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, filterParams, *identifiers, dto.Pagination{Skip: true})
+			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, params, *identifiers, dto.Pagination{Skip: true})
 			if err != nil {
 				utils.ReportErrorToSentry(err)
 				return nil, fmt.Errorf("%s search error: %w", field, err)
@@ -162,9 +181,9 @@ func (c *UseCasesClinicalImpl) GetMedicalData(ctx context.Context, patientID str
 			}
 
 		case "ViralLoad":
-			filterParams["code"] = common.ViralLoadCIELTerminologyCode
+			params := filterParams.Token("code", "", common.ViralLoadCIELTerminologyCode)
 
-			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, filterParams, *identifiers, dto.Pagination{Skip: true})
+			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, params, *identifiers, dto.Pagination{Skip: true})
 			if err != nil {
 				utils.ReportErrorToSentry(err)
 				return nil, fmt.Errorf("%s search error: %w", field, err)
@@ -177,9 +196,9 @@ func (c *UseCasesClinicalImpl) GetMedicalData(ctx context.Context, patientID str
 			}
 
 		case "CD4Count":
-			filterParams["code"] = common.CD4CountCIELTerminologyCode
+			params := filterParams.Token("code", "", common.CD4CountCIELTerminologyCode)
 
-			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, filterParams, *identifiers, dto.Pagination{Skip: true})
+			conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, params, *identifiers, dto.Pagination{Skip: true})
 			if err != nil {
 				utils.ReportErrorToSentry(err)
 				return nil, fmt.Errorf("%s search error: %w", field, err)
@@ -427,17 +446,194 @@ func mapFHIRPatientToPatientDTO(patient *domain.FHIRPatient) *dto.Patient {
 	numbers := []string{}
 
 	for _, phone := range patient.Telecom {
-		if *phone.System == domain.ContactPointSystemEnumPhone {
+		if phone.System != nil && *phone.System == domain.ContactPointSystemEnumPhone && phone.Value != nil {
 			numbers = append(numbers, *phone.Value)
 		}
 	}
 
-	return &dto.Patient{
+	output := &dto.Patient{
 		ID:          *patient.ID,
-		Active:      *patient.Active,
-		Name:        patient.Name[0].Text,
 		PhoneNumber: numbers,
-		Gender:      dto.Gender(patient.Gender.String()),
-		BirthDate:   *patient.BirthDate,
 	}
+
+	if patient.Active != nil {
+		output.Active = *patient.Active
+	}
+
+	if len(patient.Name) > 0 && patient.Name[0] != nil {
+		output.Name = patient.Name[0].Text
+	}
+
+	if patient.Gender != nil {
+		output.Gender = dto.Gender(patient.Gender.String())
+	}
+
+	if patient.BirthDate != nil {
+		output.BirthDate = *patient.BirthDate
+	}
+
+	if reason, retiredAt, retired := patient.Retirement(); retired {
+		output.Retirement = &dto.PatientRetirement{
+			Reason:    reason,
+			RetiredAt: retiredAt,
+		}
+	}
+
+	if targetID, _, _, merged := patient.Merge(); merged {
+		output.MergedInto = &targetID
+	}
+
+	return output
+}
+
+// RetirePatient retires one of the tenant's patients instead of deleting
+// them. A retired patient is inactive and hidden from patient searches, but
+// keeps all of their data and can be reinstated with `ReinstatePatient`
+func (c *UseCasesClinicalImpl) RetirePatient(ctx context.Context, input domain.RetirePatientInput) (*dto.Patient, error) {
+	_, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patient id: %s", input.ID)
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("a reason for retiring the patient is required")
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	patient, err := c.infrastructure.FHIR.RetireFHIRPatient(ctx, input.ID, reason, *identifiers)
+	if err != nil {
+		utils.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	return mapFHIRPatientToPatientDTO(patient), nil
+}
+
+// ReinstatePatient puts a retired patient back into active use
+func (c *UseCasesClinicalImpl) ReinstatePatient(ctx context.Context, id string) (*dto.Patient, error) {
+	_, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patient id: %s", id)
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	patient, err := c.infrastructure.FHIR.ReinstateFHIRPatient(ctx, id, *identifiers)
+	if err != nil {
+		utils.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	return mapFHIRPatientToPatientDTO(patient), nil
+}
+
+// MergePatients merges a patient that was registered twice, the source, into
+// the record that is kept, the target, and returns the target. The source's
+// clinical records are moved to the target and the source is marked as
+// replaced by it. The merge can be reversed with `UnmergePatient`
+func (c *UseCasesClinicalImpl) MergePatients(ctx context.Context, sourceID, targetID string) (*dto.Patient, error) {
+	for _, id := range []string{sourceID, targetID} {
+		_, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid patient id: %s", id)
+		}
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	patient, err := c.infrastructure.FHIR.MergeFHIRPatients(ctx, sourceID, targetID, *identifiers)
+	if err != nil {
+		utils.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	return mapFHIRPatientToPatientDTO(patient), nil
+}
+
+// UnmergePatient reverses the merge of a patient into another. The records
+// that were moved by the merge are moved back and the patient is put back into
+// use
+func (c *UseCasesClinicalImpl) UnmergePatient(ctx context.Context, sourceID string) (*dto.Patient, error) {
+	_, err := uuid.Parse(sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patient id: %s", sourceID)
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	patient, err := c.infrastructure.FHIR.UnmergeFHIRPatient(ctx, sourceID, *identifiers)
+	if err != nil {
+		utils.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	return mapFHIRPatientToPatientDTO(patient), nil
+}
+
+// DeletePatient deletes a patient together with every resource in the
+// patient's compartment and reports what was deleted. With `dryRun` nothing is
+// deleted and the report lists what would be, so that it can be reviewed first
+func (c *UseCasesClinicalImpl) DeletePatient(ctx context.Context, id string, dryRun bool) (*dto.PatientDeletion, error) {
+	_, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patient id: %s", id)
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	deletion, err := c.infrastructure.FHIR.CascadeDeleteFHIRPatient(ctx, id, *identifiers, dryRun)
+	if err != nil {
+		utils.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	return &dto.PatientDeletion{
+		DryRun:  deletion.DryRun,
+		Deleted: append([]string{}, deletion.Deleted...),
+	}, nil
+}
+
+// ExportPatientRecord returns the patient's full record, every resource in the
+// patient's compartment, as a FHIR Bundle that can be handed over e.g when the
+// patient is transferred or requests access to their data
+func (c *UseCasesClinicalImpl) ExportPatientRecord(ctx context.Context, patientID string) (map[string]interface{}, error) {
+	_, err := uuid.Parse(patientID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patient id: %s", patientID)
+	}
+
+	identifiers, err := c.infrastructure.BaseExtension.GetTenantIdentifiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
+	}
+
+	bundle, err := c.infrastructure.FHIR.GetFHIRPatientEverything(ctx, patientID, *identifiers)
+	if err != nil {
+		utils.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	record, err := converterandformatter.StructToMap(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the patient's record: %w", err)
+	}
+
+	return record, nil
 }
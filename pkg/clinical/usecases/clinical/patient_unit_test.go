@@ -319,7 +319,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
 
 			if tt.name == "Happy Case - Successfully search medication statement" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					status := dto.MedicationStatementStatusEnumActive
 					code := "123"
 					return &domain.FHIRMedicationStatementRelayConnection{
@@ -353,7 +353,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search medication statement - nil node" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					return &domain.FHIRMedicationStatementRelayConnection{
 						Edges: []*domain.FHIRMedicationStatementRelayEdge{
 							{
@@ -366,7 +366,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search medication statement - nil node id" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					status := dto.MedicationStatementStatusEnumActive
 					code := "123"
 					return &domain.FHIRMedicationStatementRelayConnection{
@@ -393,7 +393,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad Case - Fail to search medication statement - nil status" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					code := "123"
 					return &domain.FHIRMedicationStatementRelayConnection{
 						Edges: []*domain.FHIRMedicationStatementRelayEdge{
@@ -419,7 +419,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad Case - Fail to search medication statement - nil coding" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					status := dto.MedicationStatementStatusEnumActive
 					return &domain.FHIRMedicationStatementRelayConnection{
 						Edges: []*domain.FHIRMedicationStatementRelayEdge{
@@ -442,7 +442,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad Case - Fail to search medication statement - empty coding" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					status := dto.MedicationStatementStatusEnumActive
 					return &domain.FHIRMedicationStatementRelayConnection{
 						Edges: []*domain.FHIRMedicationStatementRelayEdge{
@@ -466,7 +466,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad Case - Fail to search medication statement - nil subject id" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					status := dto.MedicationStatementStatusEnumActive
 					code := "123"
 					return &domain.FHIRMedicationStatementRelayConnection{
@@ -493,19 +493,19 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search medication statement" {
-				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRMedicationStatementFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRMedicationStatementRelayConnection, error) {
 					return nil, fmt.Errorf("failed to search medication statement")
 				}
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					return nil, fmt.Errorf("failed to search allergy intolerance")
 				}
 			}
 
 			if tt.name == "Happy Case - Successfully search allergy intolerance" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -543,7 +543,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil node" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					return &domain.PagedFHIRAllergy{
 						Allergies: []domain.FHIRAllergyIntolerance{
 							{
@@ -562,7 +562,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil node id" {
 				code := "123"
 				system := gofakeit.URL()
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					return &domain.PagedFHIRAllergy{
 						Allergies: []domain.FHIRAllergyIntolerance{
 							{
@@ -597,7 +597,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil patient" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -634,7 +634,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil patient id" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -671,7 +671,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil encounter" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -708,7 +708,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil encounter id" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -745,7 +745,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil code" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -782,7 +782,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search allergy intolerance - nil coding" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -818,7 +818,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad Case - Fail to search allergy intolerance - empty coding" {
-				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
+				fakeFHIR.MockSearchFHIRAllergyIntoleranceFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.PagedFHIRAllergy, error) {
 					code := "123"
 					system := gofakeit.URL()
 					return &domain.PagedFHIRAllergy{
@@ -855,7 +855,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Happy Case - Successfully search observation" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -928,7 +928,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil node" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					return &domain.FHIRObservationRelayConnection{
 						Edges: []*domain.FHIRObservationRelayEdge{
 							{
@@ -941,7 +941,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil coding" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -1011,7 +1011,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - empty coding" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -1082,7 +1082,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil status" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
 					return &domain.FHIRObservationRelayConnection{
@@ -1150,7 +1150,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil subject" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -1220,7 +1220,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil subject id" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -1291,7 +1291,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil encounter" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -1361,7 +1361,7 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search observation - nil status" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
 					status := dto.ObservationStatusFinal
 					valueConcept := "222"
 					UUID := gofakeit.UUID()
@@ -1431,8 +1431,8 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 				}
 			}
 			if tt.name == "Sad Case - Fail to search weight" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
-					if params["code"] == common.WeightCIELTerminologyCode {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+					if params.Values().Get("code") == common.WeightCIELTerminologyCode {
 						return nil, fmt.Errorf("failed to search observation")
 					}
 
@@ -1449,8 +1449,8 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search BMI" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
-					if params["code"] == common.BMICIELTerminologyCode {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+					if params.Values().Get("code") == common.BMICIELTerminologyCode {
 						return nil, fmt.Errorf("failed to search observation")
 					}
 
@@ -1467,8 +1467,8 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search viralLoad" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
-					if params["code"] == common.ViralLoadCIELTerminologyCode {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+					if params.Values().Get("code") == common.ViralLoadCIELTerminologyCode {
 						return nil, fmt.Errorf("failed to search observation")
 					}
 
@@ -1485,8 +1485,8 @@ func TestClinicalUseCaseImpl_GetMedicalData(t *testing.T) {
 			}
 
 			if tt.name == "Sad Case - Fail to search cd4Count" {
-				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params map[string]interface{}, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
-					if params["code"] == common.CD4CountCIELTerminologyCode {
+				fakeFHIR.MockSearchFHIRObservationFn = func(ctx context.Context, params domain.SearchParams, tenant dto.TenantIdentifiers, pagination dto.Pagination) (*domain.FHIRObservationRelayConnection, error) {
+					if params.Values().Get("code") == common.CD4CountCIELTerminologyCode {
 						return nil, fmt.Errorf("failed to search observation")
 					}
 
@@ -1830,3 +1830,515 @@ func TestUseCasesClinicalImpl_CreatePatient(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesClinicalImpl_ExportPatientRecord(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		patientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: export patient record",
+			args: args{
+				ctx:       context.Background(),
+				patientID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid patient ID",
+			args: args{
+				ctx:       context.Background(),
+				patientID: gofakeit.BS(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx:       context.Background(),
+				patientID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get patient everything",
+			args: args{
+				ctx:       context.Background(),
+				patientID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to get patient everything" {
+				fakeFHIR.MockGetFHIRPatientEverythingFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRBundle, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.ExportPatientRecord(tt.args.ctx, tt.args.patientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.ExportPatientRecord() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got["resourceType"] != "Bundle" || got["type"] != "collection") {
+				t.Errorf("expected a collection Bundle, got %v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_RetirePatient(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input domain.RetirePatientInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: retire patient",
+			args: args{
+				ctx:   context.Background(),
+				input: domain.RetirePatientInput{ID: gofakeit.UUID(), Reason: "Duplicate record"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid patient ID",
+			args: args{
+				ctx:   context.Background(),
+				input: domain.RetirePatientInput{ID: gofakeit.BS(), Reason: "Duplicate record"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: missing reason",
+			args: args{
+				ctx:   context.Background(),
+				input: domain.RetirePatientInput{ID: gofakeit.UUID(), Reason: " "},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx:   context.Background(),
+				input: domain.RetirePatientInput{ID: gofakeit.UUID(), Reason: "Duplicate record"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to retire patient",
+			args: args{
+				ctx:   context.Background(),
+				input: domain.RetirePatientInput{ID: gofakeit.UUID(), Reason: "Duplicate record"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to retire patient" {
+				fakeFHIR.MockRetireFHIRPatientFn = func(ctx context.Context, id, reason string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.RetirePatient(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.RetirePatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got.Active || got.Retirement == nil || got.Retirement.Reason != tt.args.input.Reason) {
+				t.Errorf("expected an inactive patient with the retirement reason, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_ReinstatePatient(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: reinstate patient",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid patient ID",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.BS(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to reinstate patient",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to reinstate patient" {
+				fakeFHIR.MockReinstateFHIRPatientFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.ReinstatePatient(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.ReinstatePatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (!got.Active || got.Retirement != nil) {
+				t.Errorf("expected an active patient, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_MergePatients(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		sourceID string
+		targetID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: merge patients",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+				targetID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid source ID",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.BS(),
+				targetID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid target ID",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+				targetID: gofakeit.BS(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+				targetID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to merge patients",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+				targetID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to merge patients" {
+				fakeFHIR.MockMergeFHIRPatientsFn = func(ctx context.Context, sourceID, targetID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.MergePatients(tt.args.ctx, tt.args.sourceID, tt.args.targetID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.MergePatients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got.ID != tt.args.targetID || got.MergedInto != nil) {
+				t.Errorf("expected the surviving patient, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_UnmergePatient(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		sourceID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: unmerge patient",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid patient ID",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.BS(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to unmerge patient",
+			args: args{
+				ctx:      context.Background(),
+				sourceID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			if tt.name == "Sad case: failed to get tenant identifiers" {
+				fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to unmerge patient" {
+				fakeFHIR.MockUnmergeFHIRPatientFn = func(ctx context.Context, sourceID string, tenant dto.TenantIdentifiers) (*domain.FHIRPatient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.UnmergePatient(tt.args.ctx, tt.args.sourceID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.UnmergePatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (!got.Active || got.MergedInto != nil) {
+				t.Errorf("expected an active patient that is not merged, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_DeletePatient(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     string
+		dryRun bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: dry run",
+			args: args{
+				ctx:    context.Background(),
+				id:     gofakeit.UUID(),
+				dryRun: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: delete patient",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid patient ID",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.BS(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get tenant identifiers",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to delete patient",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			organizationID := gofakeit.UUID()
+
+			fakeExt.MockGetTenantIdentifiersFn = func(ctx context.Context) (*dto.TenantIdentifiers, error) {
+				if tt.name == "Sad case: failed to get tenant identifiers" {
+					return nil, fmt.Errorf("an error occurred")
+				}
+
+				return &dto.TenantIdentifiers{OrganizationID: organizationID}, nil
+			}
+
+			cascadeDelete := fakeFHIR.MockCascadeDeleteFHIRPatientFn
+
+			fakeFHIR.MockCascadeDeleteFHIRPatientFn = func(ctx context.Context, id string, tenant dto.TenantIdentifiers, dryRun bool) (*domain.FHIRDeletion, error) {
+				if tt.name == "Sad case: failed to delete patient" {
+					return nil, fmt.Errorf("an error occurred")
+				}
+
+				if tenant.OrganizationID != organizationID || dryRun != tt.args.dryRun {
+					t.Errorf("expected a deletion within the tenant with dry run %v, got %v and %v", tt.args.dryRun, tenant, dryRun)
+				}
+
+				return cascadeDelete(ctx, id, tenant, dryRun)
+			}
+
+			got, err := u.DeletePatient(tt.args.ctx, tt.args.id, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.DeletePatient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got.DryRun != tt.args.dryRun || len(got.Deleted) == 0) {
+				t.Errorf("expected the deleted resources to be reported, got %#v", got)
+			}
+		})
+	}
+}
@@ -21,6 +21,10 @@ var (
 
 // CreateFHIRPatient creates a patient on FHIR store
 func (c *UseCasesClinicalImpl) CreatePubsubPatient(ctx context.Context, payload dto.PatientPubSubMessage) error {
+	// messages are not API requests, so the tenant is added to the context for
+	// the FHIR requests to reach the tenant's store
+	ctx = utils.WithTenant(ctx, payload.OrganizationID, payload.FacilityID)
+
 	year, month, day := payload.DateOfBirth.Date()
 	patientName := strings.Split(payload.Name, " ")
 	registrationInput := domain.SimplePatientRegistrationInput{
@@ -43,7 +47,7 @@ func (c *UseCasesClinicalImpl) CreatePubsubPatient(ctx context.Context, payload
 	newID := uuid.New().String()
 	patientInput.ID = &newID
 
-	patientInput.Identifier = append(patientInput.Identifier, common.DefaultIdentifier())
+	patientInput.Identifier = append(patientInput.Identifier, common.DefaultIdentifier(c.clock))
 
 	clientSystem := scalarutils.URI("mycarehub.client.id")
 	userSelected := false
@@ -63,7 +67,7 @@ func (c *UseCasesClinicalImpl) CreatePubsubPatient(ctx context.Context, payload
 			},
 		},
 		System: &clientSystem,
-		Period: common.DefaultPeriodInput(),
+		Period: common.DefaultPeriodInput(c.clock),
 	}
 
 	patientInput.Identifier = append(patientInput.Identifier, clientIdentifier)
@@ -85,7 +89,7 @@ func (c *UseCasesClinicalImpl) CreatePubsubPatient(ctx context.Context, payload
 			},
 		},
 		System: &userSystem,
-		Period: common.DefaultPeriodInput(),
+		Period: common.DefaultPeriodInput(c.clock),
 	}
 
 	patientInput.Identifier = append(patientInput.Identifier, userIdentifier)
@@ -107,7 +111,11 @@ func (c *UseCasesClinicalImpl) CreatePubsubPatient(ctx context.Context, payload
 
 	err = c.infrastructure.MyCareHub.AddFHIRIDToPatientProfile(ctx, *patient.PatientRecord.ID, payload.ClientID)
 	if err != nil {
-		return err
+		// remove the patient so that a redelivered message does not create a duplicate
+		return compensate(ctx, err, func(ctx context.Context) error {
+			_, err := c.infrastructure.FHIR.DeleteFHIRPatient(ctx, *patient.PatientRecord.ID)
+			return err
+		})
 	}
 
 	return nil
@@ -128,7 +136,7 @@ func (c *UseCasesClinicalImpl) CreatePubsubOrganization(ctx context.Context, dat
 				Value:  &data.Phone,
 				Use:    &use,
 				Rank:   &rank,
-				Period: common.DefaultPeriodInput(),
+				Period: common.DefaultPeriodInput(c.clock),
 			},
 		},
 	}
@@ -148,11 +156,20 @@ func (c *UseCasesClinicalImpl) CreatePubsubOrganization(ctx context.Context, dat
 
 // CreatePubsubVitals creates FHIR observation vitals.
 func (c *UseCasesClinicalImpl) CreatePubsubVitals(ctx context.Context, data dto.VitalSignPubSubMessage) error {
+	ctx = utils.WithTenant(ctx, data.OrganizationID, data.FacilityID)
+
 	input, err := c.ComposeVitalsInput(ctx, data)
 	if err != nil {
 		return err
 	}
 
+	identifier, err := sourceIdentifier("vitals", data.OrganizationID, data.FacilityID, data.ID, data)
+	if err != nil {
+		return err
+	}
+
+	input.Identifier = append(input.Identifier, identifier)
+
 	tags, err := c.CreateTenantMetaTags(ctx, data.OrganizationID, data.FacilityID)
 	if err != nil {
 		return err
@@ -172,11 +189,20 @@ func (c *UseCasesClinicalImpl) CreatePubsubVitals(ctx context.Context, data dto.
 
 // CreatePubsubAllergyIntolerance creates FHIR allergy intolerance
 func (c *UseCasesClinicalImpl) CreatePubsubAllergyIntolerance(ctx context.Context, data dto.PatientAllergyPubSubMessage) error {
+	ctx = utils.WithTenant(ctx, data.OrganizationID, data.FacilityID)
+
 	input, err := c.ComposeAllergyIntoleranceInput(ctx, data)
 	if err != nil {
 		return err
 	}
 
+	identifier, err := sourceIdentifier("allergy", data.OrganizationID, data.FacilityID, data.ID, data)
+	if err != nil {
+		return err
+	}
+
+	input.Identifier = append(input.Identifier, identifier)
+
 	tags, err := c.CreateTenantMetaTags(ctx, data.OrganizationID, data.FacilityID)
 	if err != nil {
 		return err
@@ -196,11 +222,20 @@ func (c *UseCasesClinicalImpl) CreatePubsubAllergyIntolerance(ctx context.Contex
 
 // CreatePubsubTestResult creates a test result as an observation
 func (c *UseCasesClinicalImpl) CreatePubsubTestResult(ctx context.Context, data dto.PatientTestResultPubSubMessage) error {
+	ctx = utils.WithTenant(ctx, data.OrganizationID, data.FacilityID)
+
 	input, err := c.ComposeTestResultInput(ctx, data)
 	if err != nil {
 		return err
 	}
 
+	identifier, err := sourceIdentifier("test-result", data.OrganizationID, data.FacilityID, data.ID, data)
+	if err != nil {
+		return err
+	}
+
+	input.Identifier = append(input.Identifier, identifier)
+
 	tags, err := c.CreateTenantMetaTags(ctx, data.OrganizationID, data.FacilityID)
 	if err != nil {
 		return err
@@ -220,11 +255,20 @@ func (c *UseCasesClinicalImpl) CreatePubsubTestResult(ctx context.Context, data
 
 // CreatePubsubMedicationStatement creates a FHIR medication statement
 func (c *UseCasesClinicalImpl) CreatePubsubMedicationStatement(ctx context.Context, data dto.MedicationPubSubMessage) error {
+	ctx = utils.WithTenant(ctx, data.OrganizationID, data.FacilityID)
+
 	input, err := c.ComposeMedicationStatementInput(ctx, data)
 	if err != nil {
 		return err
 	}
 
+	identifier, err := sourceIdentifier("medication", data.OrganizationID, data.FacilityID, data.ID, data)
+	if err != nil {
+		return err
+	}
+
+	input.Identifier = append(input.Identifier, identifier)
+
 	tags, err := c.CreateTenantMetaTags(ctx, data.OrganizationID, data.FacilityID)
 	if err != nil {
 		return err
@@ -2,11 +2,13 @@ package clinical
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/mitchellh/mapstructure"
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
 	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/scalarutils"
@@ -14,52 +16,7 @@ import (
 
 // GetConcept is a helper function that returns a concept associated the terminology source passed
 func (c *UseCasesClinicalImpl) GetConcept(ctx context.Context, terminologySource dto.TerminologySource, conceptID string) (*domain.Concept, error) {
-	var (
-		organisation string
-		source       string
-	)
-
-	switch terminologySource {
-	case dto.TerminologySourceICD10:
-		organisation = "WHO"
-		source = "ICD-10-WHO"
-
-	case dto.TerminologySourceCIEL:
-		organisation = "CIEL"
-		source = "CIEL"
-
-	case dto.TerminologySourceLOINC:
-		organisation = "Regenstrief"
-		source = "LOINC"
-
-	case dto.TerminologySourceSNOMEDCT:
-		organisation = "Sofya"
-		source = "SNOMED-CT"
-
-	default:
-		return nil, fmt.Errorf("terminology source %v not supported", source)
-	}
-
-	response, err := c.infrastructure.OpenConceptLab.GetConcept(
-		ctx,
-		organisation,
-		source,
-		conceptID,
-		false,
-		false,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	var concept *domain.Concept
-
-	err = mapstructure.Decode(response, &concept)
-	if err != nil {
-		return nil, err
-	}
-
-	return concept, nil
+	return c.getConcept(ctx, terminologySource, conceptID, false)
 }
 
 // ComposeVitalsInput composes a vitals observation from data received
@@ -398,3 +355,31 @@ func (c *UseCasesClinicalImpl) ComposeMedicationStatementInput(ctx context.Conte
 
 	return &medicationStatement, nil
 }
+
+// sourceIdentifier composes the identifier of the event that a resource is
+// ingested from so that redelivering the event does not create a duplicate.
+// The event's own ID is used when it has one, otherwise the ID is a hash of
+// the contents of the message. The ID is scoped to the tenant that sent the
+// event, because the store is searched for it across tenants.
+func sourceIdentifier(kind, organizationID, facilityID, id string, message interface{}) (*domain.FHIRIdentifierInput, error) {
+	if id == "" {
+		bs, err := json.Marshal(message)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal %s message: %w", kind, err)
+		}
+
+		sum := sha256.Sum256(bs)
+		id = hex.EncodeToString(sum[:])
+	}
+
+	system := scalarutils.URI(domain.SourceIdentifierSystem)
+
+	return &domain.FHIRIdentifierInput{
+		Use: domain.IdentifierUseEnumSecondary,
+		Type: domain.FHIRCodeableConceptInput{
+			Text: "Source event",
+		},
+		System: &system,
+		Value:  fmt.Sprintf("%s/%s/%s/%s", kind, organizationID, facilityID, id),
+	}, nil
+}
@@ -3,6 +3,7 @@ package clinical_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -83,6 +84,24 @@ func TestUseCasesClinicalImpl_CreatePubsubPatient(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - Fail to remove patient after failing to add FHIR ID to profile",
+			args: args{
+				ctx: ctx,
+				payload: dto.PatientPubSubMessage{
+					UserID:         gofakeit.UUID(),
+					ClientID:       gofakeit.UUID(),
+					Name:           gofakeit.Name(),
+					DateOfBirth:    time.Now(),
+					Gender:         "male",
+					Active:         true,
+					PhoneNumber:    gofakeit.Phone(),
+					OrganizationID: gofakeit.UUID(),
+					FacilityID:     gofakeit.UUID(),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -105,6 +124,15 @@ func TestUseCasesClinicalImpl_CreatePubsubPatient(t *testing.T) {
 					return fmt.Errorf("failed to add fhir ID to profile")
 				}
 			}
+
+			if tt.name == "Sad Case - Fail to remove patient after failing to add FHIR ID to profile" {
+				fakeMCH.MockAddFHIRIDToPatientProfileFn = func(ctx context.Context, fhirID, clientID string) error {
+					return fmt.Errorf("failed to add fhir ID to profile")
+				}
+				fakeFHIR.MockDeleteFHIRPatientFn = func(ctx context.Context, id string) (bool, error) {
+					return false, fmt.Errorf("failed to delete patient")
+				}
+			}
 			if err := u.CreatePubsubPatient(tt.args.ctx, tt.args.payload); (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesClinicalImpl.CreatePubsubPatient() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -344,6 +372,88 @@ func TestUseCasesClinicalImpl_CreatePubsubVitals(t *testing.T) {
 	}
 }
 
+func TestUseCasesClinicalImpl_CreatePubsubVitals_SourceIdentifier(t *testing.T) {
+	ctx := context.Background()
+
+	message := dto.VitalSignPubSubMessage{
+		PatientID: uuid.NewString(),
+		ConceptID: new(string),
+		Value:     "37",
+		Date:      time.Now(),
+	}
+
+	message.OrganizationID = "organization-1"
+	message.FacilityID = "facility-1"
+
+	withID := message
+	withID.ID = "event-1"
+
+	// the same event ID sent by another tenant
+	otherTenant := withID
+	otherTenant.OrganizationID = "organization-2"
+	otherTenant.FacilityID = "facility-2"
+
+	tests := []struct {
+		name string
+		data dto.VitalSignPubSubMessage
+		want func(value string) bool
+	}{
+		{
+			name: "Happy Case - use the ID of the message",
+			data: withID,
+			want: func(value string) bool { return value == "vitals/organization-1/facility-1/event-1" },
+		},
+		{
+			name: "Happy Case - scope the ID to the tenant",
+			data: otherTenant,
+			want: func(value string) bool { return value == "vitals/organization-2/facility-2/event-1" },
+		},
+		{
+			name: "Happy Case - derive the ID from the message",
+			data: message,
+			want: func(value string) bool {
+				prefix := "vitals/organization-1/facility-1/"
+				return strings.HasPrefix(value, prefix) && value != prefix
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			values := []string{}
+
+			fakeFHIR.MockCreateFHIRObservationFn = func(ctx context.Context, input domain.FHIRObservationInput) (*domain.FHIRObservationRelayPayload, error) {
+				for _, identifier := range input.Identifier {
+					if identifier.System != nil && string(*identifier.System) == domain.SourceIdentifierSystem {
+						values = append(values, identifier.Value)
+					}
+				}
+
+				return &domain.FHIRObservationRelayPayload{}, nil
+			}
+
+			// a redelivered message should carry the same source identifier
+			for i := 0; i < 2; i++ {
+				err := u.CreatePubsubVitals(ctx, tt.data)
+				if err != nil {
+					t.Fatalf("UseCasesClinicalImpl.CreatePubsubVitals() error = %v", err)
+				}
+			}
+
+			if len(values) != 2 || values[0] != values[1] || !tt.want(values[0]) {
+				t.Errorf("unexpected source identifiers %v", values)
+			}
+		})
+	}
+}
+
 func TestUseCasesClinicalImpl_CreatePubsubTestResult(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -0,0 +1,321 @@
+package clinical
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/application/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// retentionResourceTypes are the resource types that retention policies can
+// purge, in the order that they are purged so that resources are deleted
+// before the resources they reference. Patients are never purged
+var retentionResourceTypes = []string{
+	"Composition",
+	"MedicationRequest",
+	"ServiceRequest",
+	"MedicationStatement",
+	"AllergyIntolerance",
+	"Condition",
+	"Observation",
+	"Encounter",
+	"EpisodeOfCare",
+}
+
+// retentionResourceRank returns the position of a resource type in the purge
+// order or -1 if it cannot be purged
+func retentionResourceRank(resourceType string) int {
+	for i, supported := range retentionResourceTypes {
+		if supported == resourceType {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// validateRetentionPolicy checks a retention policy. A policy without an
+// action archives the expired resources
+func validateRetentionPolicy(policy *dto.RetentionPolicy) error {
+	_, err := uuid.Parse(policy.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("invalid organization ID %q: %w", policy.OrganizationID, err)
+	}
+
+	if policy.FacilityID != "" {
+		_, err := uuid.Parse(policy.FacilityID)
+		if err != nil {
+			return fmt.Errorf("invalid facility ID %q: %w", policy.FacilityID, err)
+		}
+	}
+
+	if retentionResourceRank(policy.ResourceType) < 0 {
+		return fmt.Errorf("unsupported retention resource type %q", policy.ResourceType)
+	}
+
+	if policy.RetentionDays <= 0 {
+		return fmt.Errorf("retention days must be positive, got %d", policy.RetentionDays)
+	}
+
+	switch policy.Action {
+	case "":
+		policy.Action = dto.RetentionActionArchive
+
+	case dto.RetentionActionDelete, dto.RetentionActionArchive:
+
+	default:
+		return fmt.Errorf("unknown retention action %q", policy.Action)
+	}
+
+	return nil
+}
+
+// ReadRetentionPolicies reads a JSON file that holds a list of retention
+// policies and checks each one
+func ReadRetentionPolicies(path string) ([]dto.RetentionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read retention policies: %w", err)
+	}
+
+	policies := []dto.RetentionPolicy{}
+
+	err = json.Unmarshal(data, &policies)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse retention policies: %w", err)
+	}
+
+	for i := range policies {
+		err := validateRetentionPolicy(&policies[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention policy %d: %w", i+1, err)
+		}
+	}
+
+	return policies, nil
+}
+
+// requireArchiveDirectory checks that the archives of policies that archive
+// are written to a directory that was configured. A directory in the system's
+// temporary directory may not outlast the resources that are deleted once they
+// are archived
+func requireArchiveDirectory(policies []dto.RetentionPolicy) error {
+	if os.Getenv(common.PurgeDirectoryEnvVar) != "" {
+		return nil
+	}
+
+	for _, policy := range policies {
+		if policy.Action == dto.RetentionActionArchive {
+			return fmt.Errorf(
+				"the %s retention policy of organization %s archives, which requires %s to be set to a durable directory",
+				policy.ResourceType, policy.OrganizationID, common.PurgeDirectoryEnvVar,
+			)
+		}
+	}
+
+	return nil
+}
+
+// purgeDirectory returns the directory that holds the report and archives of
+// a purge
+func purgeDirectory(id string) string {
+	root := os.Getenv(common.PurgeDirectoryEnvVar)
+	if root == "" {
+		root = filepath.Join(os.TempDir(), "clinical-purges")
+	}
+
+	return filepath.Join(root, id)
+}
+
+// PurgeExpiredResources applies each retention policy once. It finds the
+// tenant's resources that were last updated before the policy's retention
+// period and deletes them, first writing them to a NDJSON archive when the
+// policy archives.
+//
+// Policies are applied so that resources are deleted before the resources that
+// they reference. A policy that fails, or a resource that cannot be deleted,
+// is recorded in the report and the purge carries on. The report is written to
+// `report.json` in the purge directory. Nothing is purged if a policy archives
+// and `PURGE_DIRECTORY` is not set.
+func (c *UseCasesClinicalImpl) PurgeExpiredResources(ctx context.Context, policies []dto.RetentionPolicy) (*dto.PurgeReport, error) {
+	ordered := make([]dto.RetentionPolicy, len(policies))
+
+	for i, policy := range policies {
+		err := validateRetentionPolicy(&policy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention policy %d: %w", i+1, err)
+		}
+
+		ordered[i] = policy
+	}
+
+	err := requireArchiveDirectory(ordered)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return retentionResourceRank(ordered[i].ResourceType) < retentionResourceRank(ordered[j].ResourceType)
+	})
+
+	id := uuid.New().String()
+
+	report := &dto.PurgeReport{
+		ID:        id,
+		StartedAt: c.clock.Now(),
+		Directory: purgeDirectory(id),
+		Results:   []dto.PurgeResult{},
+	}
+
+	err = os.MkdirAll(report.Directory, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the purge directory: %w", err)
+	}
+
+	for _, policy := range ordered {
+		result := c.applyRetentionPolicy(ctx, report.Directory, report.StartedAt, policy)
+		if result.Error != "" {
+			log.Errorf("purge %s failed to apply the %s retention policy of organization %s: %s",
+				id, policy.ResourceType, policy.OrganizationID, result.Error)
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	report.CompletedAt = c.clock.Now()
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the purge report: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(report.Directory, "report.json"), data, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to write the purge report: %w", err)
+	}
+
+	return report, nil
+}
+
+// applyRetentionPolicy deletes the resources that expired under a policy
+func (c *UseCasesClinicalImpl) applyRetentionPolicy(
+	ctx context.Context, directory string, startedAt time.Time, policy dto.RetentionPolicy,
+) dto.PurgeResult {
+	// purges are not API requests, so the tenant is added to the context for
+	// the FHIR requests to reach the tenant's store
+	ctx = utils.WithTenant(ctx, policy.OrganizationID, policy.FacilityID)
+
+	result := dto.PurgeResult{
+		Policy:   policy,
+		Cutoff:   startedAt.AddDate(0, 0, -policy.RetentionDays),
+		Failures: []dto.PurgeFailure{},
+	}
+
+	// the expired resources are found before any is deleted so that deleting
+	// them does not shift the pages being read
+	ids, archive, err := c.findExpiredResources(ctx, directory, result.Cutoff, policy)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	result.Expired = len(ids)
+	result.Archive = archive
+
+	for _, id := range ids {
+		err := c.infrastructure.FHIR.PurgeFHIRResource(ctx, policy.ResourceType, id)
+		if err != nil {
+			result.Failures = append(result.Failures, dto.PurgeFailure{ID: id, Error: err.Error()})
+
+			continue
+		}
+
+		result.Deleted++
+	}
+
+	return result
+}
+
+// findExpiredResources returns the IDs of the resources that expired under a
+// policy. When the policy archives, the resources are appended to the
+// archive of their tenant and type, whose path is returned
+func (c *UseCasesClinicalImpl) findExpiredResources(
+	ctx context.Context, directory string, cutoff time.Time, policy dto.RetentionPolicy,
+) ([]string, string, error) {
+	tenant := dto.TenantIdentifiers{
+		OrganizationID: policy.OrganizationID,
+		FacilityID:     policy.FacilityID,
+	}
+
+	ids := []string{}
+
+	if policy.Action != dto.RetentionActionArchive {
+		err := c.infrastructure.FHIR.SearchExpiredFHIRResources(ctx, policy.ResourceType, cutoff, tenant, func(resource map[string]interface{}) error {
+			ids = append(ids, fmt.Sprint(resource["id"]))
+
+			return nil
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		return ids, "", nil
+	}
+
+	facility := policy.FacilityID
+	if facility == "" {
+		facility = "all-facilities"
+	}
+
+	path := filepath.Join(directory, policy.OrganizationID, facility, policy.ResourceType+".ndjson")
+
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create the archive directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create the %s archive: %w", policy.ResourceType, err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// each resource is encoded on its own line
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	err = c.infrastructure.FHIR.SearchExpiredFHIRResources(ctx, policy.ResourceType, cutoff, tenant, func(resource map[string]interface{}) error {
+		ids = append(ids, fmt.Sprint(resource["id"]))
+
+		return encoder.Encode(resource)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	// nothing is deleted unless it has been archived
+	err = writer.Flush()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to write the %s archive: %w", policy.ResourceType, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to write the %s archive: %w", policy.ResourceType, err)
+	}
+
+	return ids, path, nil
+}
@@ -0,0 +1,295 @@
+package clinical_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/clinical/pkg/clinical/application/common"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	fakeExtMock "github.com/savannahghi/clinical/pkg/clinical/application/extensions/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
+	fakeMyCarehubMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub/mock"
+	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+)
+
+func TestUseCasesClinicalImpl_PurgeExpiredResources(t *testing.T) {
+	organizationID := gofakeit.UUID()
+
+	tests := []struct {
+		name         string
+		policies     []dto.RetentionPolicy
+		wantExpired  int
+		wantDeleted  int
+		wantFailures int
+		wantArchive  bool
+		wantError    bool
+		wantErr      bool
+	}{
+		{
+			name: "Happy case: archive expired resources",
+			policies: []dto.RetentionPolicy{
+				{OrganizationID: organizationID, ResourceType: "Observation", RetentionDays: 30},
+			},
+			wantExpired: 2,
+			wantDeleted: 2,
+			wantArchive: true,
+		},
+		{
+			name: "Happy case: delete expired resources",
+			policies: []dto.RetentionPolicy{
+				{OrganizationID: organizationID, ResourceType: "Observation", RetentionDays: 30, Action: dto.RetentionActionDelete},
+			},
+			wantExpired: 2,
+			wantDeleted: 2,
+		},
+		{
+			name: "Sad case: failed to delete a resource",
+			policies: []dto.RetentionPolicy{
+				{OrganizationID: organizationID, ResourceType: "Observation", RetentionDays: 30, Action: dto.RetentionActionDelete},
+			},
+			wantExpired:  2,
+			wantDeleted:  1,
+			wantFailures: 1,
+		},
+		{
+			name: "Sad case: failed to search expired resources",
+			policies: []dto.RetentionPolicy{
+				{OrganizationID: organizationID, ResourceType: "Observation", RetentionDays: 30},
+			},
+			wantError: true,
+		},
+		{
+			name: "Sad case: invalid policy",
+			policies: []dto.RetentionPolicy{
+				{OrganizationID: organizationID, ResourceType: "Patient", RetentionDays: 30},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: archive without a purge directory",
+			policies: []dto.RetentionPolicy{
+				{OrganizationID: organizationID, ResourceType: "Observation", RetentionDays: 30},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(common.PurgeDirectoryEnvVar, t.TempDir())
+
+			if tt.name == "Sad case: archive without a purge directory" {
+				t.Setenv(common.PurgeDirectoryEnvVar, "")
+			}
+
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := fakeOCLMock.NewFakeOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			fakeFHIR.MockSearchExpiredFHIRResourcesFn = func(ctx context.Context, resourceType string, cutoff time.Time, tenant dto.TenantIdentifiers, fn func(resource map[string]interface{}) error) error {
+				if tt.name == "Sad case: failed to search expired resources" {
+					return fmt.Errorf("an error occurred")
+				}
+
+				if tenant.OrganizationID != organizationID {
+					return fmt.Errorf("unexpected tenant %v", tenant)
+				}
+
+				for _, id := range []string{"1", "2"} {
+					err := fn(map[string]interface{}{"resourceType": resourceType, "id": id})
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+
+			if tt.name == "Sad case: failed to delete a resource" {
+				fakeFHIR.MockPurgeFHIRResourceFn = func(ctx context.Context, resourceType, id string) error {
+					if id == "2" {
+						return fmt.Errorf("an error occurred")
+					}
+
+					return nil
+				}
+			}
+
+			report, err := u.PurgeExpiredResources(context.Background(), tt.policies)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesClinicalImpl.PurgeExpiredResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(report.Results) != 1 {
+				t.Fatalf("expected one result, got %d", len(report.Results))
+			}
+
+			result := report.Results[0]
+
+			if (result.Error != "") != tt.wantError {
+				t.Errorf("expected policy error %v, got %q", tt.wantError, result.Error)
+			}
+
+			if result.Expired != tt.wantExpired || result.Deleted != tt.wantDeleted || len(result.Failures) != tt.wantFailures {
+				t.Errorf("expected %d expired, %d deleted and %d failures, got %d, %d and %d",
+					tt.wantExpired, tt.wantDeleted, tt.wantFailures, result.Expired, result.Deleted, len(result.Failures))
+			}
+
+			if (result.Archive != "") != tt.wantArchive {
+				t.Errorf("expected archive %v, got %q", tt.wantArchive, result.Archive)
+			}
+
+			if tt.wantArchive {
+				file, err := os.Open(result.Archive)
+				if err != nil {
+					t.Fatalf("unable to open the archive: %v", err)
+				}
+				defer file.Close()
+
+				lines := 0
+				scanner := bufio.NewScanner(file)
+
+				for scanner.Scan() {
+					lines++
+				}
+
+				if lines != tt.wantExpired {
+					t.Errorf("expected %d archived resources, got %d", tt.wantExpired, lines)
+				}
+			}
+
+			data, err := os.ReadFile(filepath.Join(report.Directory, "report.json"))
+			if err != nil {
+				t.Fatalf("unable to read the purge report: %v", err)
+			}
+
+			written := dto.PurgeReport{}
+
+			err = json.Unmarshal(data, &written)
+			if err != nil || written.ID != report.ID {
+				t.Errorf("expected the purge report to be written, got %s", data)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_PurgeExpiredResources_Order(t *testing.T) {
+	t.Setenv(common.PurgeDirectoryEnvVar, t.TempDir())
+
+	fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+	fakeFHIR := fakeFHIRMock.NewFHIRMock()
+	fakeOCL := fakeOCLMock.NewFakeOCLMock()
+	fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+	infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+	u := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+	purged := []string{}
+
+	fakeFHIR.MockPurgeFHIRResourceFn = func(ctx context.Context, resourceType, id string) error {
+		purged = append(purged, resourceType)
+
+		return nil
+	}
+
+	organizationID := gofakeit.UUID()
+
+	_, err := u.PurgeExpiredResources(context.Background(), []dto.RetentionPolicy{
+		{OrganizationID: organizationID, ResourceType: "EpisodeOfCare", RetentionDays: 30, Action: dto.RetentionActionDelete},
+		{OrganizationID: organizationID, ResourceType: "Encounter", RetentionDays: 30, Action: dto.RetentionActionDelete},
+		{OrganizationID: organizationID, ResourceType: "Observation", RetentionDays: 30, Action: dto.RetentionActionDelete},
+	})
+	if err != nil {
+		t.Fatalf("UseCasesClinicalImpl.PurgeExpiredResources() error = %v", err)
+	}
+
+	want := []string{"Observation", "Encounter", "EpisodeOfCare"}
+
+	if fmt.Sprint(purged) != fmt.Sprint(want) {
+		t.Errorf("expected resources to be purged in the order %v, got %v", want, purged)
+	}
+}
+
+func TestReadRetentionPolicies(t *testing.T) {
+	organizationID := gofakeit.UUID()
+
+	tests := []struct {
+		name       string
+		content    string
+		wantAction dto.RetentionAction
+		wantErr    bool
+	}{
+		{
+			name:       "Happy case: read policies",
+			content:    fmt.Sprintf(`[{"organizationID": %q, "resourceType": "Observation", "retentionDays": 365, "action": "delete"}]`, organizationID),
+			wantAction: dto.RetentionActionDelete,
+		},
+		{
+			name:       "Happy case: policies archive by default",
+			content:    fmt.Sprintf(`[{"organizationID": %q, "resourceType": "Observation", "retentionDays": 365}]`, organizationID),
+			wantAction: dto.RetentionActionArchive,
+		},
+		{
+			name:    "Sad case: invalid JSON",
+			content: `{`,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid organization ID",
+			content: `[{"organizationID": "org", "resourceType": "Observation", "retentionDays": 365}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid facility ID",
+			content: fmt.Sprintf(`[{"organizationID": %q, "facilityID": "../facility", "resourceType": "Observation", "retentionDays": 365}]`, organizationID),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: retention days must be positive",
+			content: fmt.Sprintf(`[{"organizationID": %q, "resourceType": "Observation", "retentionDays": 0}]`, organizationID),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unknown action",
+			content: fmt.Sprintf(`[{"organizationID": %q, "resourceType": "Observation", "retentionDays": 365, "action": "hide"}]`, organizationID),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "retention.json")
+
+			err := os.WriteFile(path, []byte(tt.content), 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			policies, err := clinicalUsecase.ReadRetentionPolicies(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadRetentionPolicies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && policies[0].Action != tt.wantAction {
+				t.Errorf("expected action %q, got %q", tt.wantAction, policies[0].Action)
+			}
+		})
+	}
+}
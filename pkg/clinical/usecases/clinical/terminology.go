@@ -0,0 +1,284 @@
+package clinical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/scalarutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// oclSource is the OpenConceptLab organisation and source of a terminology source
+type oclSource struct {
+	organisation string
+	source       string
+}
+
+// terminologySources are the terminology sources that concepts are looked up
+// in, in the order that equivalent codings are added
+var terminologySources = []dto.TerminologySource{
+	dto.TerminologySourceICD10,
+	dto.TerminologySourceCIEL,
+	dto.TerminologySourceSNOMEDCT,
+	dto.TerminologySourceLOINC,
+}
+
+// oclSources are where each terminology source is found in OpenConceptLab
+var oclSources = map[dto.TerminologySource]oclSource{
+	dto.TerminologySourceICD10:    {organisation: "WHO", source: "ICD-10-WHO"},
+	dto.TerminologySourceCIEL:     {organisation: "CIEL", source: "CIEL"},
+	dto.TerminologySourceLOINC:    {organisation: "Regenstrief", source: "LOINC"},
+	dto.TerminologySourceSNOMEDCT: {organisation: "Sofya", source: "SNOMED-CT"},
+}
+
+// getConcept returns a concept of a terminology source, with the mappings to
+// and from it when they are asked for
+func (c *UseCasesClinicalImpl) getConcept(ctx context.Context, terminologySource dto.TerminologySource, conceptID string, includeMappings bool) (*domain.Concept, error) {
+	ocl, ok := oclSources[terminologySource]
+	if !ok {
+		return nil, fmt.Errorf("terminology source %v not supported", terminologySource)
+	}
+
+	response, err := c.infrastructure.OpenConceptLab.GetConcept(
+		ctx,
+		ocl.organisation,
+		ocl.source,
+		conceptID,
+		includeMappings,
+		includeMappings,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var concept *domain.Concept
+
+	err = mapstructure.Decode(response, &concept)
+	if err != nil {
+		return nil, err
+	}
+
+	return concept, nil
+}
+
+// TranslateConcept returns the concepts of a terminology source that a concept
+// of another source is mapped to in OpenConceptLab. CIEL is mapped to the other
+// sources, so a concept that is not mapped to the target source directly is
+// translated through its CIEL equivalents
+func (c *UseCasesClinicalImpl) TranslateConcept(ctx context.Context, code string, from dto.TerminologySource, to dto.TerminologySource) ([]*dto.ConceptTranslation, error) {
+	if _, ok := oclSources[to]; !ok {
+		return nil, fmt.Errorf("terminology source %v not supported", to)
+	}
+
+	if from == to {
+		return nil, fmt.Errorf("a concept cannot be translated to its own terminology source %v", from)
+	}
+
+	concept, err := c.getConcept(ctx, from, code, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.translateConcept(ctx, concept, from, to, &cielConcepts{})
+}
+
+// cielConcepts are the CIEL concepts that a concept is equivalent to. They are
+// read once, however many sources the concept is translated to
+type cielConcepts struct {
+	concepts []*domain.Concept
+	read     bool
+}
+
+// equivalentCIELConcepts reads the CIEL concepts that a concept is equivalent
+// to, with their mappings, unless they have already been read
+func (c *UseCasesClinicalImpl) equivalentCIELConcepts(ctx context.Context, concept *domain.Concept, from dto.TerminologySource, ciel *cielConcepts) ([]*domain.Concept, error) {
+	if ciel.read {
+		return ciel.concepts, nil
+	}
+
+	concepts := []*domain.Concept{}
+
+	for _, translation := range conceptTranslations(concept, from, dto.TerminologySourceCIEL) {
+		if translation.Equivalence != dto.ConceptEquivalenceEquivalent {
+			continue
+		}
+
+		cielConcept, err := c.getConcept(ctx, dto.TerminologySourceCIEL, translation.Code, true)
+		if errors.Is(err, domain.ErrConceptNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		concepts = append(concepts, cielConcept)
+	}
+
+	ciel.concepts, ciel.read = concepts, true
+
+	return concepts, nil
+}
+
+// translateConcept translates a concept that was read with its mappings
+func (c *UseCasesClinicalImpl) translateConcept(ctx context.Context, concept *domain.Concept, from dto.TerminologySource, to dto.TerminologySource, ciel *cielConcepts) ([]*dto.ConceptTranslation, error) {
+	translations := conceptTranslations(concept, from, to)
+	if len(translations) > 0 || from == dto.TerminologySourceCIEL || to == dto.TerminologySourceCIEL {
+		return translations, nil
+	}
+
+	concepts, err := c.equivalentCIELConcepts(ctx, concept, from, ciel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cielConcept := range concepts {
+		for _, translation := range conceptTranslations(cielConcept, dto.TerminologySourceCIEL, to) {
+			translations = appendTranslation(translations, translation)
+		}
+	}
+
+	return translations, nil
+}
+
+// conceptTranslations returns the concepts of the target source that a concept
+// is mapped to, or that are mapped to it. Mappings that do not relate the
+// meaning of the concepts, e.g to the answers of a question, are left out
+func conceptTranslations(concept *domain.Concept, from dto.TerminologySource, to dto.TerminologySource) []*dto.ConceptTranslation {
+	translations := []*dto.ConceptTranslation{}
+
+	if concept == nil {
+		return translations
+	}
+
+	fromSource := oclSources[from].source
+	toSource := oclSources[to].source
+
+	for _, mapping := range concept.Mappings {
+		if mapping == nil || mapping.Retired {
+			continue
+		}
+
+		var (
+			translation *dto.ConceptTranslation
+			inverse     bool
+		)
+
+		switch {
+		case mapping.FromConceptCode == concept.ID && sameSource(mapping.FromSourceName, fromSource) && sameSource(mapping.ToSourceName, toSource):
+			translation = &dto.ConceptTranslation{Code: mapping.ToConceptCode, Name: mapping.ToConceptName}
+
+		case mapping.ToConceptCode == concept.ID && sameSource(mapping.ToSourceName, fromSource) && sameSource(mapping.FromSourceName, toSource):
+			translation = &dto.ConceptTranslation{Code: mapping.FromConceptCode, Name: mapping.FromConceptName}
+			inverse = true
+
+		default:
+			continue
+		}
+
+		equivalence, ok := mappingEquivalence(mapping.MapType, inverse)
+		if !ok || translation.Code == "" {
+			continue
+		}
+
+		translation.System = to
+		translation.Equivalence = equivalence
+
+		translations = appendTranslation(translations, translation)
+	}
+
+	return translations
+}
+
+// mappingEquivalence returns how the concept at the other end of a mapping
+// relates to the concept that is translated. An inverse mapping is read from
+// the other end
+func mappingEquivalence(mapType string, inverse bool) (dto.ConceptEquivalence, bool) {
+	switch strings.ToUpper(mapType) {
+	case "SAME-AS":
+		return dto.ConceptEquivalenceEquivalent, true
+
+	// the concept is narrower than the concept it is mapped to
+	case "NARROWER-THAN":
+		if inverse {
+			return dto.ConceptEquivalenceNarrower, true
+		}
+
+		return dto.ConceptEquivalenceWider, true
+
+	case "BROADER-THAN":
+		if inverse {
+			return dto.ConceptEquivalenceWider, true
+		}
+
+		return dto.ConceptEquivalenceNarrower, true
+
+	default:
+		return "", false
+	}
+}
+
+// sameSource reports whether a mapping's source is an OpenConceptLab source
+func sameSource(mappingSource string, source string) bool {
+	return strings.EqualFold(mappingSource, source)
+}
+
+// appendTranslation adds a translation unless the concept is already translated
+func appendTranslation(translations []*dto.ConceptTranslation, translation *dto.ConceptTranslation) []*dto.ConceptTranslation {
+	for _, existing := range translations {
+		if existing.Code == translation.Code && existing.Equivalence == translation.Equivalence {
+			return translations
+		}
+	}
+
+	return append(translations, translation)
+}
+
+// equivalentCodings returns the codings of the concepts of the other
+// terminology sources that have the same meaning as a concept, translated the
+// way `TranslateConcept` translates them. They are added to the codes of the
+// records that are written so that each record can be reported in any of the
+// terminologies. A record is still written when its concept cannot be
+// translated, so the failure is logged and no codings are returned
+func (c *UseCasesClinicalImpl) equivalentCodings(ctx context.Context, concept *domain.Concept, source dto.TerminologySource) []*domain.FHIRCodingInput {
+	codings := []*domain.FHIRCodingInput{}
+	userSelected := false
+	ciel := &cielConcepts{}
+
+	for _, target := range terminologySources {
+		if target == source {
+			continue
+		}
+
+		translations, err := c.translateConcept(ctx, concept, source, target, ciel)
+		if err != nil {
+			log.Errorf("unable to translate the %s concept %s to %s: %v", source, concept.ID, target, err)
+
+			return []*domain.FHIRCodingInput{}
+		}
+
+		for _, translation := range translations {
+			if translation.Equivalence != dto.ConceptEquivalenceEquivalent {
+				continue
+			}
+
+			ocl := oclSources[target]
+			// the system of a coding is the URL of its concept in OpenConceptLab
+			system := scalarutils.URI(fmt.Sprintf("/orgs/%s/sources/%s/concepts/%s/", ocl.organisation, ocl.source, translation.Code))
+
+			codings = append(codings, &domain.FHIRCodingInput{
+				System:       &system,
+				Code:         scalarutils.Code(translation.Code),
+				Display:      translation.Name,
+				UserSelected: &userSelected,
+			})
+		}
+	}
+
+	return codings
+}
@@ -0,0 +1,289 @@
+package clinical_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	fakeExtMock "github.com/savannahghi/clinical/pkg/clinical/application/extensions/mock"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
+	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
+	fakeFHIRMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/datastore/cloudhealthcare/mock"
+	fakeMyCarehubMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/mycarehub/mock"
+	fakeOCLMock "github.com/savannahghi/clinical/pkg/clinical/infrastructure/services/openconceptlab/mock"
+	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
+)
+
+// mappedConcepts are OpenConceptLab concepts and their mappings, keyed by
+// their source and id
+var mappedConcepts = map[string]*domain.Concept{
+	"CIEL/140238": {
+		ID:          "140238",
+		DisplayName: "Fever",
+		Mappings: []*domain.ConceptMapping{
+			{
+				MapType: "SAME-AS", FromSourceName: "CIEL", FromConceptCode: "140238",
+				ToSourceName: "ICD-10-WHO", ToConceptCode: "R50.9", ToConceptName: "Fever, unspecified",
+			},
+			{
+				MapType: "NARROWER-THAN", FromSourceName: "CIEL", FromConceptCode: "140238",
+				ToSourceName: "ICD-10-WHO", ToConceptCode: "R50", ToConceptName: "Fever of other and unknown origin",
+			},
+			{
+				MapType: "SAME-AS", FromSourceName: "CIEL", FromConceptCode: "140238",
+				ToSourceName: "SNOMED-CT", ToConceptCode: "386661006", ToConceptName: "Fever",
+			},
+			{
+				MapType: "SAME-AS", FromSourceName: "CIEL", FromConceptCode: "140238",
+				ToSourceName: "ICD-10-WHO", ToConceptCode: "R50.8", Retired: true,
+			},
+		},
+	},
+	"ICD-10-WHO/R50.9": {
+		ID:          "R50.9",
+		DisplayName: "Fever, unspecified",
+		Mappings: []*domain.ConceptMapping{
+			{
+				MapType: "SAME-AS", FromSourceName: "CIEL", FromConceptCode: "140238", FromConceptName: "Fever",
+				ToSourceName: "ICD-10-WHO", ToConceptCode: "R50.9",
+			},
+			{
+				MapType: "Q-AND-A", FromSourceName: "CIEL", FromConceptCode: "1000",
+				ToSourceName: "ICD-10-WHO", ToConceptCode: "R50.9",
+			},
+		},
+	},
+	"SNOMED-CT/386661006": {
+		ID:          "386661006",
+		DisplayName: "Fever",
+	},
+}
+
+func newMappedOCLMock() *fakeOCLMock.FakeOCL {
+	fakeOCL := fakeOCLMock.NewFakeOCLMock()
+
+	fakeOCL.MockGetConceptFn = func(ctx context.Context, org, source, concept string, includeMappings, includeInverseMappings bool) (*domain.Concept, error) {
+		found, ok := mappedConcepts[source+"/"+concept]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s concept %s", domain.ErrConceptNotFound, source, concept)
+		}
+
+		result := *found
+		if !includeMappings {
+			result.Mappings = nil
+		}
+
+		return &result, nil
+	}
+
+	return fakeOCL
+}
+
+func TestUseCasesClinicalImpl_TranslateConcept(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		from    dto.TerminologySource
+		to      dto.TerminologySource
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Happy case: mappings of the concept",
+			code: "140238",
+			from: dto.TerminologySourceCIEL,
+			to:   dto.TerminologySourceICD10,
+			want: []string{"R50.9 EQUIVALENT", "R50 WIDER"},
+		},
+		{
+			name: "Happy case: mappings to the concept",
+			code: "R50.9",
+			from: dto.TerminologySourceICD10,
+			to:   dto.TerminologySourceCIEL,
+			want: []string{"140238 EQUIVALENT"},
+		},
+		{
+			name: "Happy case: translated through CIEL",
+			code: "R50.9",
+			from: dto.TerminologySourceICD10,
+			to:   dto.TerminologySourceSNOMEDCT,
+			want: []string{"386661006 EQUIVALENT"},
+		},
+		{
+			name: "Happy case: concept without mappings",
+			code: "386661006",
+			from: dto.TerminologySourceSNOMEDCT,
+			to:   dto.TerminologySourceICD10,
+			want: []string{},
+		},
+		{
+			name:    "Sad case: the same terminology source",
+			code:    "140238",
+			from:    dto.TerminologySourceCIEL,
+			to:      dto.TerminologySourceCIEL,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unsupported terminology source",
+			code:    "140238",
+			from:    dto.TerminologySourceCIEL,
+			to:      dto.TerminologySource("ICPC2"),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: concept not found",
+			code:    "0000",
+			from:    dto.TerminologySourceCIEL,
+			to:      dto.TerminologySourceICD10,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+			fakeFHIR := fakeFHIRMock.NewFHIRMock()
+			fakeOCL := newMappedOCLMock()
+			fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+			infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+			c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+			translations, err := c.TranslateConcept(context.Background(), tt.code, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UseCasesClinicalImpl.TranslateConcept() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := []string{}
+			for _, translation := range translations {
+				if translation.System != tt.to {
+					t.Errorf("expected a %s translation, got %s", tt.to, translation.System)
+				}
+
+				got = append(got, fmt.Sprintf("%s %s", translation.Code, translation.Equivalence))
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("UseCasesClinicalImpl.TranslateConcept() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesClinicalImpl_CreateCondition_EquivalentCodings(t *testing.T) {
+	fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+	fakeFHIR := fakeFHIRMock.NewFHIRMock()
+	fakeOCL := newMappedOCLMock()
+	fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+	getConcept := fakeOCL.MockGetConceptFn
+	cielLookups := 0
+
+	fakeOCL.MockGetConceptFn = func(ctx context.Context, org, source, concept string, includeMappings, includeInverseMappings bool) (*domain.Concept, error) {
+		if source == "CIEL" {
+			cielLookups++
+		}
+
+		return getConcept(ctx, org, source, concept, includeMappings, includeInverseMappings)
+	}
+
+	createCondition := fakeFHIR.MockCreateFHIRConditionFn
+
+	var codings []*domain.FHIRCodingInput
+
+	fakeFHIR.MockCreateFHIRConditionFn = func(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error) {
+		codings = input.Code.Coding
+
+		return createCondition(ctx, input)
+	}
+
+	infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+	c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+	_, err := c.CreateCondition(context.Background(), dto.ConditionInput{
+		Code:        "R50.9",
+		System:      "ICD10",
+		Status:      dto.ConditionStatusActive,
+		EncounterID: gofakeit.UUID(),
+	})
+	if err != nil {
+		t.Fatalf("UseCasesClinicalImpl.CreateCondition() error = %v", err)
+	}
+
+	// the SNOMED CT coding is translated through CIEL like TranslateConcept does
+	if len(codings) != 3 {
+		t.Fatalf("expected the selected and the CIEL and SNOMED CT equivalent codings, got %d codings", len(codings))
+	}
+
+	if cielLookups != 1 {
+		t.Errorf("expected the CIEL equivalents to be looked up once, got %d lookups", cielLookups)
+	}
+
+	if codings[0].Code != "R50.9" {
+		t.Errorf("expected the selected coding first, got %v", codings[0].Code)
+	}
+
+	equivalent := codings[1]
+	if equivalent.Code != "140238" || equivalent.Display != "Fever" || *equivalent.System != "/orgs/CIEL/sources/CIEL/concepts/140238/" {
+		t.Errorf("unexpected equivalent coding %+v", equivalent)
+	}
+
+	if equivalent.UserSelected == nil || *equivalent.UserSelected {
+		t.Errorf("expected the equivalent coding not to be selected by the user")
+	}
+
+	translated := codings[2]
+	if translated.Code != "386661006" || translated.Display != "Fever" || *translated.System != "/orgs/Sofya/sources/SNOMED-CT/concepts/386661006/" {
+		t.Errorf("unexpected translated coding %+v", translated)
+	}
+}
+
+func TestUseCasesClinicalImpl_CreateCondition_EquivalentCodingsUnavailable(t *testing.T) {
+	fakeExt := fakeExtMock.NewFakeBaseExtensionMock()
+	fakeFHIR := fakeFHIRMock.NewFHIRMock()
+	fakeOCL := newMappedOCLMock()
+	fakeMCH := fakeMyCarehubMock.NewFakeMyCareHubServiceMock()
+
+	getConcept := fakeOCL.MockGetConceptFn
+
+	fakeOCL.MockGetConceptFn = func(ctx context.Context, org, source, concept string, includeMappings, includeInverseMappings bool) (*domain.Concept, error) {
+		if source == "CIEL" {
+			return nil, fmt.Errorf("OpenConceptLab is unavailable")
+		}
+
+		return getConcept(ctx, org, source, concept, includeMappings, includeInverseMappings)
+	}
+
+	createCondition := fakeFHIR.MockCreateFHIRConditionFn
+
+	var codings []*domain.FHIRCodingInput
+
+	fakeFHIR.MockCreateFHIRConditionFn = func(ctx context.Context, input domain.FHIRConditionInput) (*domain.FHIRConditionRelayPayload, error) {
+		codings = input.Code.Coding
+
+		return createCondition(ctx, input)
+	}
+
+	infra := infrastructure.NewInfrastructureInteractor(fakeExt, fakeFHIR, fakeOCL, fakeMCH)
+	c := clinicalUsecase.NewUseCasesClinicalImpl(infra)
+
+	_, err := c.CreateCondition(context.Background(), dto.ConditionInput{
+		Code:        "R50.9",
+		System:      "ICD10",
+		Status:      dto.ConditionStatusActive,
+		EncounterID: gofakeit.UUID(),
+	})
+	if err != nil {
+		t.Fatalf("UseCasesClinicalImpl.CreateCondition() error = %v", err)
+	}
+
+	if len(codings) != 1 || codings[0].Code != "R50.9" {
+		t.Errorf("expected the condition to be written with only the selected coding, got %d codings", len(codings))
+	}
+}
@@ -3,7 +3,6 @@ package clinical
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	linq "github.com/ahmetb/go-linq/v3"
 	"github.com/google/uuid"
@@ -26,216 +25,126 @@ func (c *UseCasesClinicalImpl) PatientTimeline(ctx context.Context, patientID st
 		return nil, fmt.Errorf("failed to get tenant identifiers from context: %w", err)
 	}
 
-	timeline := []dto.TimelineResource{}
-	wg := &sync.WaitGroup{}
-	mut := &sync.Mutex{}
-
-	patientFilterParams := map[string]interface{}{
-		"patient": fmt.Sprintf("Patient/%v", patientID),
+	// the records are reverse included in a single search for the patient
+	records, err := c.infrastructure.FHIR.GetFHIRPatientTimeline(ctx, patientID, *identifiers)
+	if err != nil {
+		return nil, err
 	}
 
-	// timelineResourceFunc is a go routine that fetches particular FHIR resource and
-	// adds it to the timeline
-	type timelineResourceFunc func(wg *sync.WaitGroup, mut *sync.Mutex)
+	timeline := []dto.TimelineResource{}
 
-	allergyIntoleranceResourceFunc := func(wg *sync.WaitGroup, mut *sync.Mutex) {
-		defer wg.Done()
+	for _, allergy := range records.AllergyIntolerances {
+		if allergy.ID == nil {
+			continue
+		}
 
-		conn, err := c.infrastructure.FHIR.SearchFHIRAllergyIntolerance(ctx, patientFilterParams, *identifiers, dto.Pagination{Skip: true})
-		if err != nil {
-			utils.ReportErrorToSentry(err)
-			log.Errorf("AllergyIntolerance search error: %v", err)
+		if allergy.Code == nil {
+			continue
+		}
+
+		if len(allergy.Reaction) < 1 {
+			continue
+		}
 
-			return
+		if allergy.Reaction[0] == nil || len(allergy.Reaction[0].Manifestation) < 1 {
+			continue
 		}
 
-		for _, edge := range conn.Allergies {
-			if edge.ID == nil {
-				continue
-			}
-
-			if edge.Code == nil {
-				continue
-			}
-
-			if edge.Reaction == nil {
-				continue
-			}
-
-			if len(edge.Reaction) < 1 {
-				continue
-			}
-
-			if edge.Reaction[0].Manifestation == nil {
-				continue
-			}
-
-			if len(edge.Reaction[0].Manifestation) < 1 {
-				continue
-			}
-
-			if edge.RecordedDate == nil {
-				continue
-			}
-
-			timelineResource := dto.TimelineResource{
-				ID:           *edge.ID,
-				ResourceType: dto.ResourceTypeAllergyIntolerance,
-				Name:         edge.Code.Text,
-				Value:        edge.Reaction[0].Manifestation[0].Text,
-				Status:       edge.ClinicalStatus.Text,
-				Date:         *edge.RecordedDate,
-			}
-
-			mut.Lock()
-			timeline = append(timeline, timelineResource)
-			mut.Unlock()
+		if allergy.RecordedDate == nil {
+			continue
 		}
+
+		timeline = append(timeline, dto.TimelineResource{
+			ID:           *allergy.ID,
+			ResourceType: dto.ResourceTypeAllergyIntolerance,
+			Name:         allergy.Code.Text,
+			Value:        allergy.Reaction[0].Manifestation[0].Text,
+			Status:       allergy.ClinicalStatus.Text,
+			Date:         *allergy.RecordedDate,
+		})
 	}
 
-	observationResourceFunc := func(wg *sync.WaitGroup, mut *sync.Mutex) {
-		defer wg.Done()
+	for _, observation := range records.Observations {
+		if observation.ID == nil {
+			continue
+		}
 
-		conn, err := c.infrastructure.FHIR.SearchFHIRObservation(ctx, patientFilterParams, *identifiers, dto.Pagination{Skip: true})
-		if err != nil {
-			utils.ReportErrorToSentry(err)
-			log.Errorf("Observation search error: %v", err)
+		if len(observation.Code.Coding) < 1 {
+			continue
+		}
 
-			return
+		if observation.Status == nil {
+			continue
 		}
 
-		for _, edge := range conn.Edges {
-			if edge.Node == nil {
-				continue
-			}
-
-			if edge.Node.ID == nil {
-				continue
-			}
-
-			if edge.Node.Code.Coding == nil {
-				continue
-			}
-
-			if len(edge.Node.Code.Coding) < 1 {
-				continue
-			}
-
-			if edge.Node.Status == nil {
-				continue
-			}
-
-			if edge.Node.EffectiveDateTime == nil {
-				continue
-			}
-
-			instant := edge.Node.EffectiveDateTime.AsTime()
-			date, err := scalarutils.NewDate(instant.Day(), int(instant.Month()), instant.Year())
-
-			if err != nil {
-				utils.ReportErrorToSentry(err)
-				log.Errorf("date conversion error: %v", err)
-
-				return
-			}
-
-			timelineResource := dto.TimelineResource{
-				ID:           *edge.Node.ID,
-				ResourceType: dto.ResourceTypeObservation,
-				Name:         edge.Node.Code.Text,
-				Value:        edge.Node.Code.Coding[0].Display,
-				Status:       string(*edge.Node.Status),
-				Date:         *date,
-			}
-
-			mut.Lock()
-			timeline = append(timeline, timelineResource)
-			mut.Unlock()
+		if observation.EffectiveDateTime == nil {
+			continue
 		}
-	}
 
-	medicationStatementResourceFunc := func(wg *sync.WaitGroup, mut *sync.Mutex) {
-		defer wg.Done()
+		instant := observation.EffectiveDateTime.AsTime()
 
-		conn, err := c.infrastructure.FHIR.SearchFHIRMedicationStatement(ctx, patientFilterParams, *identifiers, dto.Pagination{Skip: true})
+		date, err := scalarutils.NewDate(instant.Day(), int(instant.Month()), instant.Year())
 		if err != nil {
 			utils.ReportErrorToSentry(err)
-			log.Errorf("MedicationStatement search error: %v", err)
+			log.Errorf("date conversion error: %v", err)
 
-			return
+			continue
 		}
 
-		for _, edge := range conn.Edges {
-			if edge.Node == nil {
-				continue
-			}
-
-			if edge.Node.ID == nil {
-				continue
-			}
-
-			if edge.Node.MedicationCodeableConcept == nil {
-				continue
-			}
-
-			if edge.Node.MedicationCodeableConcept.Coding == nil {
-				continue
-			}
-
-			if len(edge.Node.MedicationCodeableConcept.Coding) < 1 {
-				continue
-			}
-
-			if edge.Node.Status == nil {
-				continue
-			}
-
-			if edge.Node.Subject == nil {
-				continue
-			}
-
-			if edge.Node.EffectiveDateTime == nil {
-				continue
-			}
-
-			instant := edge.Node.EffectiveDateTime.AsTime()
-			date, err := scalarutils.NewDate(instant.Day(), int(instant.Month()), instant.Year())
-
-			if err != nil {
-				utils.ReportErrorToSentry(err)
-				log.Errorf("date conversion error: %v", err)
-
-				return
-			}
-
-			timelineResource := dto.TimelineResource{
-				ID:           *edge.Node.ID,
-				ResourceType: dto.ResourceTypeMedicationStatement,
-				Name:         edge.Node.Subject.Display,
-				Value:        edge.Node.MedicationCodeableConcept.Coding[0].Display,
-				Status:       string(*edge.Node.Status),
-				Date:         *date,
-			}
-
-			mut.Lock()
-			timeline = append(timeline, timelineResource)
-			mut.Unlock()
-		}
+		timeline = append(timeline, dto.TimelineResource{
+			ID:           *observation.ID,
+			ResourceType: dto.ResourceTypeObservation,
+			Name:         observation.Code.Text,
+			Value:        observation.Code.Coding[0].Display,
+			Status:       string(*observation.Status),
+			Date:         *date,
+		})
 	}
 
-	resources := []timelineResourceFunc{
-		allergyIntoleranceResourceFunc,
-		observationResourceFunc,
-		medicationStatementResourceFunc,
-	}
+	for _, statement := range records.MedicationStatements {
+		if statement.ID == nil {
+			continue
+		}
 
-	for _, resource := range resources {
-		wg.Add(1)
+		if statement.MedicationCodeableConcept == nil {
+			continue
+		}
 
-		go resource(wg, mut)
-	}
+		if len(statement.MedicationCodeableConcept.Coding) < 1 {
+			continue
+		}
 
-	wg.Wait()
+		if statement.Status == nil {
+			continue
+		}
+
+		if statement.Subject == nil {
+			continue
+		}
+
+		if statement.EffectiveDateTime == nil {
+			continue
+		}
+
+		instant := statement.EffectiveDateTime.AsTime()
+
+		date, err := scalarutils.NewDate(instant.Day(), int(instant.Month()), instant.Year())
+		if err != nil {
+			utils.ReportErrorToSentry(err)
+			log.Errorf("date conversion error: %v", err)
+
+			continue
+		}
+
+		timeline = append(timeline, dto.TimelineResource{
+			ID:           *statement.ID,
+			ResourceType: dto.ResourceTypeMedicationStatement,
+			Name:         statement.Subject.Display,
+			Value:        statement.MedicationCodeableConcept.Coding[0].Display,
+			Status:       string(*statement.Status),
+			Date:         *date,
+		})
+	}
 
 	return timeline, nil
 }
@@ -0,0 +1,27 @@
+package clinical
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/clinical/pkg/clinical/repository"
+)
+
+// inTransaction runs the FHIR writes made through the repository passed to
+// `work` as a single unit. Either all of the writes are committed or none of
+// them are.
+func (c *UseCasesClinicalImpl) inTransaction(ctx context.Context, work func(fhir repository.FHIR) error) error {
+	return c.infrastructure.FHIR.RunInTransaction(ctx, work)
+}
+
+// compensate undoes writes that were already committed when a later step that
+// cannot be part of a FHIR transaction, such as a call to another service,
+// fails with `err`. The returned error reports both failures if the
+// compensating action also fails.
+func compensate(ctx context.Context, err error, undo func(ctx context.Context) error) error {
+	if undoErr := undo(ctx); undoErr != nil {
+		return fmt.Errorf("%w (unable to undo changes: %v)", err, undoErr)
+	}
+
+	return err
+}
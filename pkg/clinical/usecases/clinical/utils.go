@@ -104,7 +104,7 @@ func (c *UseCasesClinicalImpl) SimplePatientRegistrationInputToPatientInput(ctx
 		return nil, fmt.Errorf("can't register patient with invalid contacts: %w", err)
 	}
 
-	ids, err := helpers.IDToIdentifier(input.IdentificationDocuments, input.PhoneNumbers)
+	ids, err := helpers.IDToIdentifier(c.clock, input.IdentificationDocuments, input.PhoneNumbers)
 	if err != nil {
 		utils.ReportErrorToSentry(err)
 		return nil, fmt.Errorf("can't register patient with invalid identifiers: %w", err)
@@ -119,10 +119,10 @@ func (c *UseCasesClinicalImpl) SimplePatientRegistrationInputToPatientInput(ctx
 	}
 	patientInput.Identifier = ids
 	patientInput.Telecom = contacts
-	patientInput.Name = helpers.NameToHumanName(input.Names)
+	patientInput.Name = helpers.NameToHumanName(c.clock, input.Names)
 	// patientInput.Photo = photos
 	patientInput.Address = helpers.PhysicalPostalAddressesToFHIRAddresses(
-		input.PhysicalAddresses, input.PostalAddresses)
+		c.clock, input.PhysicalAddresses, input.PostalAddresses)
 	patientInput.MaritalStatus = helpers.MaritalStatusEnumToCodeableConceptInput(
 		input.MaritalStatus)
 	patientInput.Communication = helpers.LanguagesToCommunicationInputs(input.Languages)
@@ -153,7 +153,7 @@ func (c *UseCasesClinicalImpl) ContactsToContactPointInput(_ context.Context, ph
 			System: &phoneSystem,
 			Use:    &use,
 			Rank:   &rank,
-			Period: common.DefaultPeriodInput(),
+			Period: common.DefaultPeriodInput(c.clock),
 			Value:  normalized,
 		}
 		output = append(output, phoneContact)
@@ -172,7 +172,7 @@ func (c *UseCasesClinicalImpl) ContactsToContactPointInput(_ context.Context, ph
 			System: &emailSystem,
 			Use:    &use,
 			Rank:   &rank,
-			Period: common.DefaultPeriodInput(),
+			Period: common.DefaultPeriodInput(c.clock),
 			Value:  &email.Email,
 		}
 		output = append(output, emailContact)
@@ -181,3 +181,37 @@ func (c *UseCasesClinicalImpl) ContactsToContactPointInput(_ context.Context, ph
 
 	return output, nil
 }
+
+// recorder refers to the logged in user so that each version of a clinical
+// record shows who wrote it
+func (c *UseCasesClinicalImpl) recorder(ctx context.Context) (*domain.FHIRReferenceInput, error) {
+	uid, err := c.infrastructure.BaseExtension.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the logged in user: %w", err)
+	}
+
+	return &domain.FHIRReferenceInput{
+		Display: uid,
+	}, nil
+}
+
+// authorOf describes who recorded a version of a clinical record. The
+// recorder is preferred over the asserter and a reference's display over the
+// reference itself
+func authorOf(references ...*domain.FHIRReference) string {
+	for _, reference := range references {
+		if reference == nil {
+			continue
+		}
+
+		if reference.Display != "" {
+			return reference.Display
+		}
+
+		if reference.Reference != nil && *reference.Reference != "" {
+			return *reference.Reference
+		}
+	}
+
+	return ""
+}
@@ -2,8 +2,11 @@ package usecases
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/savannahghi/clinical/pkg/clinical/application/dto"
+	"github.com/savannahghi/clinical/pkg/clinical/domain"
 	"github.com/savannahghi/clinical/pkg/clinical/infrastructure"
 	clinicalUsecase "github.com/savannahghi/clinical/pkg/clinical/usecases/clinical"
 )
@@ -15,10 +18,11 @@ type Clinical interface {
 
 	CreateEpisodeOfCare(ctx context.Context, input dto.EpisodeOfCareInput) (*dto.EpisodeOfCare, error)
 	GetEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error)
-	EndEpisodeOfCare(ctx context.Context, id string) (*dto.EpisodeOfCare, error)
+	EndEpisodeOfCare(ctx context.Context, id string, endTime *time.Time) (*dto.EpisodeOfCare, error)
 
 	CreateCondition(ctx context.Context, input dto.ConditionInput) (*dto.Condition, error)
 	ListPatientConditions(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.ConditionConnection, error)
+	ConditionHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.ConditionHistoryConnection, error)
 
 	PatientHealthTimeline(ctx context.Context, input dto.HealthTimelineInput) (*dto.HealthTimeline, error)
 	GetMedicalData(ctx context.Context, patientID string) (*dto.MedicalData, error)
@@ -32,9 +36,14 @@ type Clinical interface {
 	CreatePubsubTenant(ctx context.Context, data dto.OrganizationInput) error
 
 	CreatePatient(ctx context.Context, input dto.PatientInput) (*dto.Patient, error)
+	RetirePatient(ctx context.Context, input domain.RetirePatientInput) (*dto.Patient, error)
+	ReinstatePatient(ctx context.Context, id string) (*dto.Patient, error)
+	MergePatients(ctx context.Context, sourceID, targetID string) (*dto.Patient, error)
+	UnmergePatient(ctx context.Context, sourceID string) (*dto.Patient, error)
+	DeletePatient(ctx context.Context, id string, dryRun bool) (*dto.PatientDeletion, error)
 
 	StartEncounter(ctx context.Context, episodeID string) (string, error)
-	EndEncounter(ctx context.Context, encounterID string) (bool, error)
+	EndEncounter(ctx context.Context, encounterID string, endTime *time.Time) (bool, error)
 	ListPatientEncounters(ctx context.Context, patientID string, pagination *dto.Pagination) (*dto.EncounterConnection, error)
 
 	RecordTemperature(ctx context.Context, input dto.ObservationInput) (*dto.Observation, error)
@@ -57,8 +66,21 @@ type Clinical interface {
 
 	CreateAllergyIntolerance(ctx context.Context, input dto.AllergyInput) (*dto.Allergy, error)
 	SearchAllergy(ctx context.Context, name string) ([]*dto.Terminology, error)
+	TranslateConcept(ctx context.Context, code string, from dto.TerminologySource, to dto.TerminologySource) ([]*dto.ConceptTranslation, error)
 	GetAllergyIntolerance(ctx context.Context, id string) (*dto.Allergy, error)
 	ListPatientAllergies(ctx context.Context, patientID string, pagination dto.Pagination) (*dto.AllergyConnection, error)
+	AllergyHistory(ctx context.Context, id string, pagination dto.Pagination) (*dto.AllergyHistoryConnection, error)
+
+	StartBulkExport(ctx context.Context, types []string) (*dto.BulkExportJob, error)
+	GetBulkExport(ctx context.Context, id string) (*dto.BulkExportJob, error)
+	OpenBulkExportFile(ctx context.Context, id, resourceType string) (io.ReadCloser, error)
+	DeleteBulkExport(ctx context.Context, id string) error
+
+	BulkImport(ctx context.Context, files []dto.BulkImportFile) (*dto.BulkImportReport, error)
+
+	ExportPatientRecord(ctx context.Context, patientID string) (map[string]interface{}, error)
+
+	PurgeExpiredResources(ctx context.Context, policies []dto.RetentionPolicy) (*dto.PurgeReport, error)
 }
 
 // Interactor is an implementation of the usecases interface
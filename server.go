@@ -11,9 +11,8 @@ import (
 func init() {
 	// check if must have env variables exist
 	// expects the server to die if this not explicitly set
+	// the FHIR dataset configuration is checked when the selected backend is initialized
 	serverutils.MustGetEnvVar("CLOUD_HEALTH_PUBSUB_TOPIC")
-	serverutils.MustGetEnvVar("CLOUD_HEALTH_DATASET_ID")
-	serverutils.MustGetEnvVar("CLOUD_HEALTH_FHIRSTORE_ID")
 }
 
 func main() {